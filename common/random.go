@@ -128,3 +128,31 @@ func GetRandomBytes(rand io.Reader, length int) ([]byte, error) {
 
 	return buf, nil
 }
+
+// ForkableReader is a reader that can be split into independent streams, so that goroutines which draw randomness at
+// the same time each draw the same values however they are scheduled. It is implemented by the seeded readers that the
+// tests use to reproduce a run.
+type ForkableReader interface {
+	io.Reader
+	// Fork returns a new stream, derived from the next values of the reader
+	Fork() (io.Reader, error)
+}
+
+// ForkRandom returns count readers for goroutines that draw randomness at the same time. A ForkableReader is forked
+// count times, in order; any other reader is returned as is, so that the reader of the caller is always the one used.
+func ForkRandom(rand io.Reader, count int) ([]io.Reader, error) {
+	forks := make([]io.Reader, count)
+	forkable, ok := rand.(ForkableReader)
+	for i := range forks {
+		if !ok {
+			forks[i] = rand
+			continue
+		}
+		fork, err := forkable.Fork()
+		if err != nil {
+			return nil, errors.Wrap(err, "ForkRandom")
+		}
+		forks[i] = fork
+	}
+	return forks, nil
+}
@@ -7,7 +7,10 @@
 package common_test
 
 import (
+	"bytes"
 	"crypto/rand"
+	"errors"
+	"io"
 	"math/big"
 	"testing"
 
@@ -46,3 +49,20 @@ func TestGetRandomPrimeInt(t *testing.T) {
 	assert.NotZero(t, prime, "rand prime should not be zero")
 	assert.True(t, prime.ProbablyPrime(50), "rand prime should be prime")
 }
+
+type failingForkReader struct{ io.Reader }
+
+func (failingForkReader) Fork() (io.Reader, error) {
+	return nil, errors.New("unable to fork")
+}
+
+func TestForkRandom(t *testing.T) {
+	reader := bytes.NewReader(make([]byte, 64))
+	forks, err := common.ForkRandom(reader, 3)
+	assert.NoError(t, err)
+	for _, fork := range forks {
+		assert.Equal(t, reader, fork, "a reader that cannot be forked must be kept")
+	}
+	_, err = common.ForkRandom(failingForkReader{reader}, 3)
+	assert.Error(t, err)
+}
@@ -11,6 +11,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"math/big"
 	"sync"
 	"sync/atomic"
@@ -56,43 +57,66 @@ func probablyPrime(prime *big.Int) bool {
 
 // ----- //
 
-// The following code is a modified copy of: https://github.com/didiercrunch/paillier/blob/753322e473bf8ee20267c7824e68ae47360cc69b/safe_prime_generator.go
-// It is an implementation of the algorithm described in "Safe Prime Generation with a Combined Sieve" https://eprint.iacr.org/2003/186.pdf
-
-// The code is the original Go implementation of rand.Prime optimized for
-// generating safe (Sophie Germain) primes.
-// A safe prime is a prime number of the form 2p + 1, where p is also a prime.
-
-// Note from Author (https://github.com/pdyraga):
-// I've adapted a Go code for generating random numbers by inserting some
-// optimisations that will allow us to generate safe primes faster than
-// with the previous, naive approach.
-//
-// First of all, having q which can be prime, we first check whether q%3=1.
-// If that's true, there is no chance p=2q+1 is prime. It lets us to reject
-// candidate numbers quicker without running an expensive primality tests.
+// The search is an implementation of the combined sieve described in "Safe Prime Generation with a Combined Sieve"
+// https://eprint.iacr.org/2003/186.pdf, with an incremental search for the candidates.
 //
-// Also, before we run a primality test for q, we may check p=2q+1 against
-// the primes between 3-53 (We are limited by Go's uint64 range).
+// Rather than drawing each candidate at random, a search draws a random starting point and sieves the candidates that
+// follow it one window at a time, each window resuming where the last one ended. Only the candidates `q = 5 (mod 6)`
+// are considered: if `q = 0 (mod 3)` then `q` is no prime, and if `q = 1 (mod 3)` then `p = 2q + 1` is a multiple of
+// `3`. For each small prime `r` of the sieve, the candidates such that `r` divides `q` or `p` are struck out. These are
+// found from the residue of the first candidate of the window modulo `r`, which is carried over to the next window
+// with machine word arithmetic, so that a window costs no big.Int division at all.
 //
-// If all those conditions are met and we know p is prime, it's enough to
-// check Pocklington criterion for q instead of running an expensive
-// primality test for it.
-
-// smallPrimes is a list of small, prime numbers that allows us to rapidly
-// exclude some fraction of composite candidates when searching for a random
-// prime. This list is truncated at the point where smallPrimesProduct exceeds
-// a uint64. It does not include two because we ensure that the candidates are
-// odd by construction.
-var smallPrimes = []uint8{
-	3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37, 41, 43, 47, 53,
-}
+// Most of the candidates that survive the sieve are still composite, and they are rejected by Fermat's test to base
+// `2`, first of `q` and then of `p`, at the cost of one modular exponentiation each. If both pass, `q` gets the full
+// Miller-Rabin and Baillie-PSW tests. Knowing that `q` is prime, the Fermat test of `p` has already proved `p` prime by
+// Pocklington's criterion, so `p` is not tested again.
 
-// smallPrimesProduct is the product of the values in smallPrimes and allows us
-// to reduce a candidate prime by this number and then determine whether it's
-// coprime to all the elements of smallPrimes without further big.Int
-// operations.
-var smallPrimesProduct = new(big.Int).SetUint64(16294579238595022365)
+const (
+	// the number of candidates sieved at a time
+	sieveWindowSize = 1 << 14
+	// the bound of the small primes of the sieve
+	sievePrimesBound = 1 << 16
+)
+
+type (
+	// SafePrimeProgress reports on the progress of GetRandomSafePrimesConcurrent
+	SafePrimeProgress struct {
+		Wanted, Found int
+		// the number of candidates that were sieved, and of those that passed the sieve and were tested for primality
+		Sieved, Tested uint64
+	}
+
+	sievePrime struct {
+		r,
+		inv6, // 6^-1 (mod r)
+		half uint64 // (r - 1) / 2, the residue of `q` for which `r` divides `p = 2q + 1`
+	}
+
+	// a product of consecutive sieve primes that fits in a uint64, so that the residues of a big.Int modulo each
+	// of them are found with a single big.Int division
+	sievePrimesGroup struct {
+		product    *big.Int
+		start, end int
+	}
+
+	safePrimeSieve struct {
+		qBitLen   int
+		primes    []sievePrime
+		base      *big.Int // the first candidate of the window
+		residues  []uint64 // base (mod r) for each of the primes
+		composite []bool
+	}
+
+	safePrimeStats struct {
+		sieved, tested uint64
+	}
+)
+
+var (
+	sievePrimes       = makeSievePrimes(sievePrimesBound)
+	sievePrimesGroups = makeSievePrimesGroups(sievePrimes)
+)
 
 // ErrGeneratorCancelled is an error returned from GetRandomSafePrimesConcurrent
 // when the work of the generator has been cancelled as a result of the context
@@ -107,7 +131,7 @@ var ErrGeneratorCancelled = fmt.Errorf("generator work cancelled")
 // as well.
 //
 // How fast we generate a prime number is mostly a matter of luck and it depends
-// on how lucky we are with drawing the first bytes.
+// on how lucky we are with drawing the starting point of the search.
 // With today's multi-core processors, we can execute the process on multiple
 // cores concurrently, accept the first valid result and cancel the rest of
 // work. This way, with the same finding algorithm, we can get the result
@@ -121,25 +145,42 @@ var ErrGeneratorCancelled = fmt.Errorf("generator work cancelled")
 // `2` and for 2048-bit safe prime, `concurrencyLevel` must be set to at least
 // `4` to get the result in a reasonable time.
 //
+// The searches share `rand`, which must be safe for concurrent use with a
+// concurrency level above `1`. With a deterministic reader and a concurrency level
+// of `1`, the same primes are found every time, for tests.
+//
+// If given, `optionalProgress` is called with the progress of the search after
+// each window of the sieve and each prime found. It is called from the calling
+// goroutine and should return quickly.
+//
 // This function generates safe primes of at least 6 `bitLen`. For every
 // generated safe prime, the two most significant bits are always set to `1`
 // - we don't want the generated number to be too small.
-func GetRandomSafePrimesConcurrent(ctx context.Context, bitLen, numPrimes int, concurrency int, rand io.Reader) ([]*GermainSafePrime, error) {
+func GetRandomSafePrimesConcurrent(ctx context.Context, bitLen, numPrimes int, concurrency int, rand io.Reader, optionalProgress ...func(SafePrimeProgress)) ([]*GermainSafePrime, error) {
 	if bitLen < 6 {
 		return nil, errors.New("safe prime size must be at least 6 bits")
 	}
 	if numPrimes < 1 {
 		return nil, errors.New("numPrimes should be > 0")
 	}
+	var progress func(SafePrimeProgress)
+	if 0 < len(optionalProgress) {
+		if 1 < len(optionalProgress) {
+			return nil, errors.New("GetRandomSafePrimesConcurrent: expected 0 or 1 item in `optionalProgress`")
+		}
+		progress = optionalProgress[0]
+	}
+	if concurrency < 1 {
+		concurrency = 1
+	}
 
-	primeCh := make(chan *GermainSafePrime, concurrency*numPrimes)
+	primeCh := make(chan *GermainSafePrime, concurrency)
 	errCh := make(chan error, concurrency)
+	notifyCh := make(chan struct{}, 1)
 	primes := make([]*GermainSafePrime, 0, numPrimes)
+	stats := new(safePrimeStats)
 
 	waitGroup := &sync.WaitGroup{}
-
-	defer close(primeCh)
-	defer close(errCh)
 	defer waitGroup.Wait()
 
 	generatorCtx, cancelGeneratorCtx := context.WithCancel(ctx)
@@ -148,18 +189,30 @@ func GetRandomSafePrimesConcurrent(ctx context.Context, bitLen, numPrimes int, c
 	for i := 0; i < concurrency; i++ {
 		waitGroup.Add(1)
 		runGenPrimeRoutine(
-			generatorCtx, primeCh, errCh, waitGroup, rand, bitLen,
+			generatorCtx, primeCh, errCh, notifyCh, stats, waitGroup, rand, bitLen,
 		)
 	}
 
-	needed := int32(numPrimes)
+	report := func() {
+		if progress != nil {
+			progress(SafePrimeProgress{
+				Wanted: numPrimes,
+				Found:  len(primes),
+				Sieved: atomic.LoadUint64(&stats.sieved),
+				Tested: atomic.LoadUint64(&stats.tested),
+			})
+		}
+	}
 	for {
 		select {
 		case result := <-primeCh:
 			primes = append(primes, result)
-			if atomic.AddInt32(&needed, -1) <= 0 {
-				return primes[:numPrimes], nil
+			report()
+			if len(primes) == numPrimes {
+				return primes, nil
 			}
+		case <-notifyCh:
+			report()
 		case err := <-errCh:
 			return nil, err
 		case <-ctx.Done():
@@ -168,182 +221,230 @@ func GetRandomSafePrimesConcurrent(ctx context.Context, bitLen, numPrimes int, c
 	}
 }
 
-// Starts a Goroutine searching for a safe prime of the specified `pBitLen`.
-// If succeeds, writes prime `p` and prime `q` such that `p = 2q+1` to the
-// `primeCh`. Prime `p` has a bit length equal to `pBitLen` and prime `q` has
-// a bit length equal to `pBitLen-1`.
+// Starts a Goroutine searching for safe primes of the specified `pBitLen`.
+// For each one found, writes prime `p` and prime `q` such that `p = 2q+1` to
+// the `primeCh`. Prime `p` has a bit length equal to `pBitLen` and prime `q`
+// has a bit length equal to `pBitLen-1`.
 //
 // The algorithm is as follows:
-//  1. Generate a random odd number `q` of length `pBitLen-1` with two the most
-//     significant bits set to `1`.
-//  2. Execute preliminary primality test on `q` checking whether it is coprime
-//     to all the elements of `smallPrimes`. It allows to eliminate trivial
-//     cases quickly, when `q` is obviously no prime, without running an
-//     expensive final primality tests.
-//     If `q` is coprime to all of the `smallPrimes`, then go to the point 3.
-//     If not, add `2` and try again. Do it at most 10 times.
-//  3. Check the potentially prime `q`, whether `q = 1 (mod 3)`. This will
-//     happen for 50% of cases.
-//     If it is, then `p = 2q+1` will be a multiple of 3, so it will be obviously
-//     not a prime number. In this case, add `2` and try again. Do it at most 10
-//     times. If `q != 1 (mod 3)`, go to the point 4.
-//  4. Now we know `q` is potentially prime and `p = 2q+1` is not a multiple of
-//  3. We execute a preliminary primality test on `p`, checking whether
-//     it is coprime to all the elements of `smallPrimes` just like we did for
-//     `q` in point 2. If `p` is not coprime to at least one element of the
-//     `smallPrimes`, then go back to point 1.
-//     If `p` is coprime to all the elements of `smallPrimes`, go to point 5.
-//  5. At this point, we know `q` is potentially prime, and `p=2q+1` is also
-//     potentially prime. We need to execute a final primality test for `q`.
-//     We apply Miller-Rabin and Baillie-PSW tests. If they succeed, it means
-//     that `q` is prime with a very high probability. Knowing `q` is prime,
-//     we use Pocklington's criterion to prove the primality of `p=2q+1`, that
-//     is, we execute Fermat primality test to base 2 checking whether
-//     `2^{p-1} = 1 (mod p)`. It's significantly faster than running full
-//     Miller-Rabin and Baillie-PSW for `p`.
-//     If `q` and `p` are found to be prime, return them as a result. If not, go
-//     back to the point 1.
+//  1. Generate a random number `q` of length `pBitLen-1` with two the most
+//     significant bits set to `1`, and round it up to `q = 5 (mod 6)`. Find
+//     the residues of `q` modulo each of the small primes of the sieve.
+//  2. Sieve the window of candidates `q + 6k` from the residues, striking out
+//     those that a small prime divides, or whose `p = 2q+1` it divides.
+//  3. For each candidate left, execute Fermat's primality test to base 2 on
+//     `q` and then on `p`. If both pass, apply Miller-Rabin and Baillie-PSW
+//     tests to `q`. If they succeed, `q` is prime with a very high
+//     probability, and so is `p` by Pocklington's criterion. Send them to the
+//     `primeCh` and go back to point 1, as the next safe prime must not be
+//     found close to this one.
+//  4. When the window is exhausted, carry the residues over to the next
+//     window and go back to point 2, or to point 1 if the candidates have
+//     grown too long.
 func runGenPrimeRoutine(
 	ctx context.Context,
 	primeCh chan<- *GermainSafePrime,
 	errCh chan<- error,
+	notifyCh chan<- struct{},
+	stats *safePrimeStats,
 	waitGroup *sync.WaitGroup,
 	rand io.Reader,
 	pBitLen int,
 ) {
-	qBitLen := pBitLen - 1
-	b := uint(qBitLen % 8)
-	if b == 0 {
-		b = 8
-	}
-
-	bytes := make([]byte, (qBitLen+7)/8)
-	p := new(big.Int)
-	q := new(big.Int)
-
-	bigMod := new(big.Int)
+	sieve := newSafePrimeSieve(pBitLen - 1)
 
 	go func() {
 		defer waitGroup.Done()
 
 		for {
-			select {
-			case <-ctx.Done():
+			if err := sieve.reset(rand); err != nil {
+				select {
+				case errCh <- err:
+				case <-ctx.Done():
+				}
 				return
-			default:
-				_, err := io.ReadFull(rand, bytes)
-				if err != nil {
-					errCh <- err
+			}
+			for {
+				sgp, more := sieve.search(ctx, stats)
+				select {
+				case notifyCh <- struct{}{}:
+				default:
+				}
+				if ctx.Err() != nil {
 					return
 				}
-
-				// Clear bits in the first byte to make sure the candidate has
-				// a size <= bits.
-				bytes[0] &= uint8(int(1<<b) - 1)
-				// Don't let the value be too small, i.e, set the most
-				// significant two bits.
-				// Setting the top two bits, rather than just the top bit,
-				// means that when two of these values are multiplied together,
-				// the result isn't ever one bit short.
-				if b >= 2 {
-					bytes[0] |= 3 << (b - 2)
-				} else {
-					// Here b==1, because b cannot be zero.
-					bytes[0] |= 1
-					if len(bytes) > 1 {
-						bytes[1] |= 0x80
+				if sgp != nil {
+					select {
+					case primeCh <- sgp:
+					case <-ctx.Done():
+						return
 					}
 				}
-				// Make the value odd since an even number this large certainly
-				// isn't prime.
-				bytes[len(bytes)-1] |= 1
-
-				q.SetBytes(bytes)
-
-				// Calculate the value mod the product of smallPrimes. If it's
-				// a multiple of any of these primes we add two until it isn't.
-				// The probability of overflowing is minimal and can be ignored
-				// because we still perform Miller-Rabin tests on the result.
-				bigMod.Mod(q, smallPrimesProduct)
-				mod := bigMod.Uint64()
-
-			NextDelta:
-				for delta := uint64(0); delta < 1<<20; delta += 2 {
-					m := mod + delta
-					for _, prime := range smallPrimes {
-						if m%uint64(prime) == 0 && (qBitLen > 6 || m != uint64(prime)) {
-							continue NextDelta
-						}
-					}
-
-					if delta > 0 {
-						bigMod.SetUint64(delta)
-						q.Add(q, bigMod)
-					}
+				if !more {
+					break
+				}
+			}
+		}
+	}()
+}
 
-					// If `q = 1 (mod 3)`, then `p` is a multiple of `3` so it's
-					// obviously no prime and such `q` should be rejected.
-					// This will happen in 50% of cases and we should detect
-					// and eliminate them early.
-					//
-					// Explanation:
-					// If q = 1 (mod 3) then there exists a q' such that:
-					// q = 3q' + 1
-					//
-					// Since p = 2q + 1:
-					// p = 2q + 1 = 2(3q' + 1) + 1 = 6q' + 2 + 1 = 6q' + 3 =
-					//   = 3(2q' + 1)
-					// So `p` is a multiple of `3`.
-					qMod3 := new(big.Int).Mod(q, big.NewInt(3))
-					if qMod3.Cmp(big.NewInt(1)) == 0 {
-						continue NextDelta
-					}
+func newSafePrimeSieve(qBitLen int) *safePrimeSieve {
+	// a small prime must not strike out itself, so only those below the candidates are used
+	primes := sievePrimes
+	for i, r := range sievePrimes {
+		if qBitLen <= 64 && r.r >= uint64(1)<<uint(qBitLen-1) {
+			primes = sievePrimes[:i]
+			break
+		}
+	}
+	return &safePrimeSieve{
+		qBitLen:   qBitLen,
+		primes:    primes,
+		base:      new(big.Int),
+		residues:  make([]uint64, len(sievePrimes)),
+		composite: make([]bool, sieveWindowSize),
+	}
+}
 
-					// p = 2q+1
-					p.Mul(q, big.NewInt(2))
-					p.Add(p, big.NewInt(1))
-					if !isPrimeCandidate(p) {
-						continue NextDelta
-					}
+// reset starts the search from a new random point
+func (sieve *safePrimeSieve) reset(rand io.Reader) error {
+	b := uint(sieve.qBitLen % 8)
+	if b == 0 {
+		b = 8
+	}
+	bytes := make([]byte, (sieve.qBitLen+7)/8)
+	for {
+		if _, err := io.ReadFull(rand, bytes); err != nil {
+			return err
+		}
+		// Clear bits in the first byte to make sure the candidate has
+		// a size <= bits.
+		bytes[0] &= uint8(int(1<<b) - 1)
+		// Don't let the value be too small, i.e, set the most
+		// significant two bits.
+		// Setting the top two bits, rather than just the top bit,
+		// means that when two of these values are multiplied together,
+		// the result isn't ever one bit short.
+		if b >= 2 {
+			bytes[0] |= 3 << (b - 2)
+		} else {
+			// Here b==1, because b cannot be zero.
+			bytes[0] |= 1
+			if len(bytes) > 1 {
+				bytes[1] |= 0x80
+			}
+		}
+		sieve.base.SetBytes(bytes)
+		// round up to q = 5 (mod 6)
+		m := new(big.Int).Mod(sieve.base, big.NewInt(6)).Uint64()
+		sieve.base.Add(sieve.base, new(big.Int).SetUint64((11-m)%6))
+		if sieve.base.BitLen() == sieve.qBitLen {
+			break
+		}
+	}
+	residue := new(big.Int)
+	for _, group := range sievePrimesGroups {
+		if group.start >= len(sieve.primes) {
+			break
+		}
+		m := residue.Mod(sieve.base, group.product).Uint64()
+		for i := group.start; i < group.end; i++ {
+			sieve.residues[i] = m % sievePrimes[i].r
+		}
+	}
+	return nil
+}
 
-					break
-				}
+// search sieves the window of candidates and tests those left, then moves on to the next window. It returns the first
+// safe prime found, if any, and whether the search may go on from the next window.
+func (sieve *safePrimeSieve) search(ctx context.Context, stats *safePrimeStats) (*GermainSafePrime, bool) {
+	sieve.sieve()
+	atomic.AddUint64(&stats.sieved, sieveWindowSize)
+
+	q, p := new(big.Int), new(big.Int)
+	qMinus1, pMinus1 := new(big.Int), new(big.Int)
+	fermat := new(big.Int)
+	for k, isComposite := range sieve.composite {
+		if isComposite {
+			continue
+		}
+		if ctx.Err() != nil {
+			return nil, false
+		}
+		q.SetUint64(6 * uint64(k))
+		q.Add(q, sieve.base)
+		if q.BitLen() != sieve.qBitLen {
+			return nil, false
+		}
+		atomic.AddUint64(&stats.tested, 1)
+		if fermat.Exp(two, qMinus1.Sub(q, one), q).Cmp(one) != 0 {
+			continue
+		}
+		// p = 2q+1
+		p.Lsh(q, 1)
+		p.Add(p, one)
+		if fermat.Exp(two, pMinus1.Sub(p, one), p).Cmp(one) != 0 {
+			continue
+		}
+		if !probablyPrime(q) {
+			continue
+		}
+		return &GermainSafePrime{p: p, q: q}, false
+	}
 
-				// There is a tiny possibility that, by adding delta, we caused
-				// the number to be one bit too long. Thus we check BitLen
-				// here.
-				if q.ProbablyPrime(20) &&
-					isPocklingtonCriterionSatisfied(p) &&
-					q.BitLen() == qBitLen {
+	// carry the residues over to the next window
+	step := new(big.Int).SetUint64(6 * sieveWindowSize)
+	sieve.base.Add(sieve.base, step)
+	for i, r := range sieve.primes {
+		sieve.residues[i] = (sieve.residues[i] + 6*sieveWindowSize) % r.r
+	}
+	return nil, sieve.base.BitLen() == sieve.qBitLen
+}
 
-					if sgp := (&GermainSafePrime{p: p, q: q}); sgp.Validate() {
-						primeCh <- &GermainSafePrime{p: p, q: q}
-					}
-					p, q = new(big.Int), new(big.Int)
-				}
+// sieve strikes out the candidates of the window that a small prime divides, or whose `p = 2q+1` it divides
+func (sieve *safePrimeSieve) sieve() {
+	composite := sieve.composite
+	for k := range composite {
+		composite[k] = false
+	}
+	for i, r := range sieve.primes {
+		// base + 6k = 0 (mod r), or base + 6k = (r - 1) / 2 (mod r)
+		b := sieve.residues[i]
+		for _, target := range [2]uint64{0, r.half} {
+			for k := (target + r.r - b) % r.r * r.inv6 % r.r; k < sieveWindowSize; k += r.r {
+				composite[k] = true
 			}
 		}
-	}()
+	}
 }
 
-// Pocklington's criterion can be used to prove the primality of `p = 2q + 1`
-// once one has proven the primality of `q`.
-// With `q` prime, `p = 2q + 1`, and `p` passing Fermat's primality test to base
-// `2` that `2^{p-1} = 1 (mod p)` then `p` is prime as well.
-func isPocklingtonCriterionSatisfied(p *big.Int) bool {
-	return new(big.Int).Exp(
-		big.NewInt(2),
-		new(big.Int).Sub(p, big.NewInt(1)),
-		p,
-	).Cmp(big.NewInt(1)) == 0
+// makeSievePrimes returns the primes from 5 up to bound; 2 and 3 are ruled out by the form of the candidates
+func makeSievePrimes(bound int) []sievePrime {
+	isComposite := make([]bool, bound)
+	primes := make([]sievePrime, 0, bound/10)
+	for n := 5; n < bound; n += 2 {
+		if isComposite[n] || n%3 == 0 {
+			continue
+		}
+		for m := n * n; m < bound; m += 2 * n {
+			isComposite[m] = true
+		}
+		r := uint64(n)
+		inv6 := new(big.Int).ModInverse(big.NewInt(6), new(big.Int).SetUint64(r)).Uint64()
+		primes = append(primes, sievePrime{r: r, inv6: inv6, half: (r - 1) / 2})
+	}
+	return primes
 }
 
-func isPrimeCandidate(number *big.Int) bool {
-	m := new(big.Int).Mod(number, smallPrimesProduct).Uint64()
-	for _, prime := range smallPrimes {
-		if m%uint64(prime) == 0 && m != uint64(prime) {
-			return false
+func makeSievePrimesGroups(primes []sievePrime) []sievePrimesGroup {
+	groups := make([]sievePrimesGroup, 0)
+	for start := 0; start < len(primes); {
+		product, end := uint64(1), start
+		for ; end < len(primes) && product <= math.MaxUint64/primes[end].r; end++ {
+			product *= primes[end].r
 		}
+		groups = append(groups, sievePrimesGroup{product: new(big.Int).SetUint64(product), start: start, end: end})
+		start = end
 	}
-	return true
+	return groups
 }
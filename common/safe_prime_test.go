@@ -9,9 +9,14 @@ package common
 import (
 	"context"
 	"crypto/rand"
+	"errors"
+	"io"
 	"math/big"
+	mathrand "math/rand"
 	"runtime"
+	"sort"
 	"testing"
+	"testing/iotest"
 	"time"
 
 	"github.com/stretchr/testify/assert"
@@ -54,3 +59,171 @@ func TestGetRandomGermainPrimeConcurrent(t *testing.T) {
 		assert.True(t, sgp.Validate())
 	}
 }
+
+func TestGetRandomSafePrimesSmall(t *testing.T) {
+	for _, bitLen := range []int{6, 7, 8, 9, 16, 17, 33, 64, 65, 66, 128} {
+		sgps, err := GetRandomSafePrimesConcurrent(context.Background(), bitLen, 2, 2, rand.Reader)
+		assert.NoError(t, err)
+		for _, sgp := range sgps {
+			assert.True(t, sgp.Validate(), "%d bits: %s", bitLen, sgp.SafePrime())
+			assert.Equal(t, bitLen, sgp.SafePrime().BitLen())
+		}
+	}
+}
+
+func TestGetRandomSafePrimesSeeded(t *testing.T) {
+	var last SafePrimeProgress
+	sgps, err := GetRandomSafePrimesConcurrent(context.Background(), 512, 2, 1, mathrand.New(mathrand.NewSource(42)), func(progress SafePrimeProgress) {
+		assert.True(t, progress.Found >= last.Found && progress.Sieved >= last.Sieved && progress.Tested >= last.Tested)
+		last = progress
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, SafePrimeProgress{Wanted: 2, Found: 2, Sieved: last.Sieved, Tested: last.Tested}, last)
+	assert.True(t, 0 < last.Tested && last.Tested < last.Sieved)
+
+	again, err := GetRandomSafePrimesConcurrent(context.Background(), 512, 2, 1, mathrand.New(mathrand.NewSource(42)))
+	assert.NoError(t, err)
+	for i, sgp := range sgps {
+		assert.True(t, sgp.Validate())
+		assert.Equal(t, 0, sgp.SafePrime().Cmp(again[i].SafePrime()))
+	}
+	assert.NotEqual(t, 0, sgps[0].SafePrime().Cmp(sgps[1].SafePrime()))
+}
+
+func TestGetRandomSafePrimesReaderError(t *testing.T) {
+	failing := errors.New("the reader failed")
+	_, err := GetRandomSafePrimesConcurrent(context.Background(), 512, 1, 2, iotest.ErrReader(failing))
+	assert.Equal(t, failing, err)
+
+	nop := func(SafePrimeProgress) {}
+	_, err = GetRandomSafePrimesConcurrent(context.Background(), 512, 1, 1, rand.Reader, nop, nop)
+	assert.Error(t, err)
+}
+
+func TestGetRandomSafePrimesCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := GetRandomSafePrimesConcurrent(ctx, 2048, 2, 2, rand.Reader)
+	assert.Equal(t, ErrGeneratorCancelled, err)
+}
+
+func TestSafePrimeSieve(t *testing.T) {
+	sieve := newSafePrimeSieve(1023)
+	assert.NoError(t, sieve.reset(rand.Reader))
+	assert.Equal(t, uint64(5), new(big.Int).Mod(sieve.base, big.NewInt(6)).Uint64())
+	// check the strike outs of the second window, with residues carried over from the first
+	sieve.search(context.Background(), new(safePrimeStats))
+	sieve.sieve()
+	q, p, m := new(big.Int), new(big.Int), new(big.Int)
+	for k := 0; k < 2000; k++ {
+		q.Add(sieve.base, big.NewInt(int64(6*k)))
+		p.Lsh(q, 1)
+		p.Add(p, one)
+		divisible := false
+		for _, r := range sievePrimes {
+			bigR := new(big.Int).SetUint64(r.r)
+			if m.Mod(q, bigR).Sign() == 0 || m.Mod(p, bigR).Sign() == 0 {
+				divisible = true
+				break
+			}
+		}
+		assert.Equal(t, divisible, sieve.composite[k], "candidate %d", k)
+	}
+}
+
+// BenchmarkGetRandomSafePrimesConcurrent finds one 1024-bit safe prime, as used for NTilde, on one core. The time
+// taken varies a lot with the starting point of the search, so its median is reported as well.
+func BenchmarkGetRandomSafePrimesConcurrent(b *testing.B) {
+	benchmarkSafePrime(b, func() error {
+		_, err := GetRandomSafePrimesConcurrent(context.Background(), 1024, 1, 1, rand.Reader)
+		return err
+	})
+}
+
+// BenchmarkGetRandomSafePrimesLegacy is the baseline of BenchmarkGetRandomSafePrimesConcurrent: the same search with
+// the generator of v2.0, which draws each candidate at random and checks it against a few small primes only.
+func BenchmarkGetRandomSafePrimesLegacy(b *testing.B) {
+	benchmarkSafePrime(b, func() error {
+		_, err := legacySafePrime(rand.Reader, 1024)
+		return err
+	})
+}
+
+func benchmarkSafePrime(b *testing.B, find func() error) {
+	durations := make([]time.Duration, 0, b.N)
+	for n := 0; n < b.N; n++ {
+		start := time.Now()
+		if err := find(); err != nil {
+			b.Fatal(err)
+		}
+		durations = append(durations, time.Since(start))
+	}
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	b.ReportMetric(float64(durations[len(durations)/2].Milliseconds()), "median-ms")
+}
+
+// legacySmallPrimes are the primes that the generator of v2.0 checked the candidates against; their product fits in a
+// uint64
+var legacySmallPrimes = []uint64{3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37, 41, 43, 47, 53}
+
+// legacySafePrime finds a safe prime as the generator of v2.0 did, on the calling goroutine
+func legacySafePrime(rand io.Reader, pBitLen int) (*GermainSafePrime, error) {
+	qBitLen := pBitLen - 1
+	b := uint(qBitLen % 8)
+	if b == 0 {
+		b = 8
+	}
+	product := new(big.Int).SetUint64(16294579238595022365)
+	isCandidate := func(n *big.Int) bool {
+		m := new(big.Int).Mod(n, product).Uint64()
+		for _, prime := range legacySmallPrimes {
+			if m%prime == 0 && m != prime {
+				return false
+			}
+		}
+		return true
+	}
+	bytes := make([]byte, (qBitLen+7)/8)
+	for {
+		if _, err := io.ReadFull(rand, bytes); err != nil {
+			return nil, err
+		}
+		bytes[0] &= uint8(int(1<<b) - 1)
+		bytes[0] |= 3 << (b - 2)
+		bytes[len(bytes)-1] |= 1
+		q, p := new(big.Int).SetBytes(bytes), new(big.Int)
+		mod := new(big.Int).Mod(q, product).Uint64()
+
+	NextDelta:
+		for delta := uint64(0); delta < 1<<20; delta += 2 {
+			m := mod + delta
+			for _, prime := range legacySmallPrimes {
+				if m%prime == 0 {
+					continue NextDelta
+				}
+			}
+			if delta > 0 {
+				q.Add(q, new(big.Int).SetUint64(delta))
+			}
+			// p = 2q+1 is a multiple of 3 if q = 1 (mod 3)
+			if new(big.Int).Mod(q, big.NewInt(3)).Cmp(one) == 0 {
+				continue NextDelta
+			}
+			p.Lsh(q, 1)
+			p.Add(p, one)
+			if !isCandidate(p) {
+				continue NextDelta
+			}
+			break
+		}
+
+		pMinus1 := new(big.Int).Sub(p, one)
+		if q.ProbablyPrime(20) && new(big.Int).Exp(two, pMinus1, p).Cmp(one) == 0 && q.BitLen() == qBitLen {
+			sgp := &GermainSafePrime{p: p, q: q}
+			// v2.0 also ran the full tests on p
+			if sgp.Validate() {
+				return sgp, nil
+			}
+		}
+	}
+}
@@ -0,0 +1,250 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Zero-knowledge proof for the affine operation D = C^x * Enc_0(y; rho) of an MtA, where x < 2^l is the discrete log
+// of X = x*G and Y = Enc_1(y; rho_y) encrypts y < 2^l' under the prover's own key (CGGMP21 Fig 15, Πaff-g).
+// All secrets and masks are non-negative here, so the intervals ±2^x of the paper become [0, 2^x).
+
+package affgproof
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/paillier"
+)
+
+const (
+	ProofAffgBytesParts = 14
+)
+
+type (
+	ProofAffg struct {
+		A                                     *big.Int
+		Bx                                    *crypto.ECPoint
+		By, E, S, F, T, Z1, Z2, Z3, Z4, W, Wy *big.Int
+	}
+)
+
+// Ranges returns the bit lengths l, l' and epsilon of CGGMP21 for the curve: l is the bit length of its order,
+// l' = 5l bounds the additive share y and epsilon = 2l is the slack of the masks.
+func Ranges(ec elliptic.Curve) (l, lPrime, eps int) {
+	l = ec.Params().N.BitLen()
+	return l, 5 * l, 2 * l
+}
+
+// NewProof implements proofaffg, where pk0 is the verifier's Paillier key, pk1 the prover's own and (NCap, s, t)
+// the verifier's ring-Pedersen parameters
+func NewProof(Session []byte, ec elliptic.Curve, pk0, pk1 *paillier.PublicKey, NCap, s, t, C, D, Y *big.Int, X *crypto.ECPoint, x, y, rho, rhoY *big.Int, rand io.Reader) (*ProofAffg, error) {
+	if ec == nil || pk0 == nil || pk1 == nil || NCap == nil || s == nil || t == nil || C == nil || D == nil || Y == nil || X == nil ||
+		x == nil || y == nil || rho == nil || rhoY == nil {
+		return nil, errors.New("ProveAffg constructor received nil value(s)")
+	}
+	q := ec.Params().N
+	l, lPrime, eps := Ranges(ec)
+	twoLEps := new(big.Int).Lsh(big.NewInt(1), uint(l+eps))
+	twoLPrimeEps := new(big.Int).Lsh(big.NewInt(1), uint(lPrime+eps))
+	twoLNCap := new(big.Int).Lsh(NCap, uint(l))
+	twoLEpsNCap := new(big.Int).Lsh(NCap, uint(l+eps))
+
+	// Fig 15.1 sample
+	var alpha *big.Int
+	for alpha == nil || new(big.Int).Mod(alpha, q).Sign() == 0 {
+		alpha = common.GetRandomPositiveInt(rand, twoLEps)
+	}
+	beta := common.GetRandomPositiveInt(rand, twoLPrimeEps)
+	r := common.GetRandomPositiveRelativelyPrimeInt(rand, pk0.N)
+	rY := common.GetRandomPositiveRelativelyPrimeInt(rand, pk1.N)
+	gamma := common.GetRandomPositiveInt(rand, twoLEpsNCap)
+	m := common.GetRandomPositiveInt(rand, twoLNCap)
+	delta := common.GetRandomPositiveInt(rand, twoLEpsNCap)
+	mu := common.GetRandomPositiveInt(rand, twoLNCap)
+
+	// Fig 15.1 compute
+	modN02, modNCap := common.ModInt(pk0.NSquare()), common.ModInt(NCap)
+	encBeta, err := pk0.EncryptWithRandomness(new(big.Int).Mod(beta, pk0.N), r)
+	if err != nil {
+		return nil, err
+	}
+	A := modN02.Mul(modN02.Exp(C, alpha), encBeta)
+	Bx := crypto.ScalarBaseMult(ec, new(big.Int).Mod(alpha, q))
+	By, err := pk1.EncryptWithRandomness(new(big.Int).Mod(beta, pk1.N), rY)
+	if err != nil {
+		return nil, err
+	}
+	E := modNCap.Mul(modNCap.Exp(s, alpha), modNCap.Exp(t, gamma))
+	S := modNCap.Mul(modNCap.Exp(s, x), modNCap.Exp(t, m))
+	F := modNCap.Mul(modNCap.Exp(s, beta), modNCap.Exp(t, delta))
+	T := modNCap.Mul(modNCap.Exp(s, y), modNCap.Exp(t, mu))
+
+	// Fig 15.2 e
+	e := challenge(Session, q, pk0, pk1, NCap, s, t, C, D, Y, X, A, Bx, By, E, S, F, T)
+
+	// Fig 15.3
+	z1 := new(big.Int).Add(alpha, new(big.Int).Mul(e, x))
+	z2 := new(big.Int).Add(beta, new(big.Int).Mul(e, y))
+	z3 := new(big.Int).Add(gamma, new(big.Int).Mul(e, m))
+	z4 := new(big.Int).Add(delta, new(big.Int).Mul(e, mu))
+	w := common.ModInt(pk0.N).Mul(r, common.ModInt(pk0.N).Exp(rho, e))
+	wY := common.ModInt(pk1.N).Mul(rY, common.ModInt(pk1.N).Exp(rhoY, e))
+
+	return &ProofAffg{A: A, Bx: Bx, By: By, E: E, S: S, F: F, T: T, Z1: z1, Z2: z2, Z3: z3, Z4: z4, W: w, Wy: wY}, nil
+}
+
+func NewProofFromBytes(ec elliptic.Curve, bzs [][]byte) (*ProofAffg, error) {
+	if !common.NonEmptyMultiBytes(bzs, ProofAffgBytesParts) {
+		return nil, fmt.Errorf("expected %d byte parts to construct ProofAffg", ProofAffgBytesParts)
+	}
+	Bx, err := crypto.NewECPoint(ec, new(big.Int).SetBytes(bzs[1]), new(big.Int).SetBytes(bzs[2]))
+	if err != nil {
+		return nil, err
+	}
+	return &ProofAffg{
+		A:  new(big.Int).SetBytes(bzs[0]),
+		Bx: Bx,
+		By: new(big.Int).SetBytes(bzs[3]),
+		E:  new(big.Int).SetBytes(bzs[4]),
+		S:  new(big.Int).SetBytes(bzs[5]),
+		F:  new(big.Int).SetBytes(bzs[6]),
+		T:  new(big.Int).SetBytes(bzs[7]),
+		Z1: new(big.Int).SetBytes(bzs[8]),
+		Z2: new(big.Int).SetBytes(bzs[9]),
+		Z3: new(big.Int).SetBytes(bzs[10]),
+		Z4: new(big.Int).SetBytes(bzs[11]),
+		W:  new(big.Int).SetBytes(bzs[12]),
+		Wy: new(big.Int).SetBytes(bzs[13]),
+	}, nil
+}
+
+func (pf *ProofAffg) Verify(Session []byte, ec elliptic.Curve, pk0, pk1 *paillier.PublicKey, NCap, s, t, C, D, Y *big.Int, X *crypto.ECPoint) bool {
+	if pf == nil || !pf.ValidateBasic() || ec == nil || pk0 == nil || pk1 == nil || NCap == nil || s == nil || t == nil ||
+		C == nil || D == nil || Y == nil || X == nil {
+		return false
+	}
+	N02, N12 := pk0.NSquare(), pk1.NSquare()
+	if !common.IsNumberInMultiplicativeGroup(N02, C) || !common.IsNumberInMultiplicativeGroup(N02, D) ||
+		!common.IsNumberInMultiplicativeGroup(N02, pf.A) || !common.IsNumberInMultiplicativeGroup(N12, Y) ||
+		!common.IsNumberInMultiplicativeGroup(N12, pf.By) {
+		return false
+	}
+	for _, v := range []*big.Int{pf.E, pf.S, pf.F, pf.T} {
+		if !common.IsNumberInMultiplicativeGroup(NCap, v) {
+			return false
+		}
+	}
+	q := ec.Params().N
+	l, lPrime, eps := Ranges(ec)
+
+	// Fig 15. Range Check; the extra bit accounts for e*x and e*y
+	if !common.IsInInterval(pf.Z1, new(big.Int).Lsh(big.NewInt(1), uint(l+eps+1))) {
+		return false
+	}
+	if !common.IsInInterval(pf.Z2, new(big.Int).Lsh(big.NewInt(1), uint(lPrime+eps+1))) {
+		return false
+	}
+
+	e := challenge(Session, q, pk0, pk1, NCap, s, t, C, D, Y, X, pf.A, pf.Bx, pf.By, pf.E, pf.S, pf.F, pf.T)
+
+	// Fig 15. Equality Check
+	{
+		encZ2, err := pk0.EncryptWithRandomness(new(big.Int).Mod(pf.Z2, pk0.N), pf.W)
+		if err != nil {
+			return false
+		}
+		modN02 := common.ModInt(N02)
+		LHS := modN02.Mul(modN02.Exp(C, pf.Z1), encZ2)
+		RHS := modN02.Mul(pf.A, modN02.Exp(D, e))
+		if LHS.Cmp(RHS) != 0 {
+			return false
+		}
+	}
+
+	{
+		// computed on raw coordinates as either side may be the point at infinity
+		LX, LY := ec.ScalarBaseMult(new(big.Int).Mod(pf.Z1, q).Bytes())
+		eXX, eXY := ec.ScalarMult(X.X(), X.Y(), e.Bytes())
+		RX, RY := ec.Add(pf.Bx.X(), pf.Bx.Y(), eXX, eXY)
+		if LX.Cmp(RX) != 0 || LY.Cmp(RY) != 0 {
+			return false
+		}
+	}
+
+	{
+		LHS, err := pk1.EncryptWithRandomness(new(big.Int).Mod(pf.Z2, pk1.N), pf.Wy)
+		if err != nil {
+			return false
+		}
+		modN12 := common.ModInt(N12)
+		RHS := modN12.Mul(pf.By, modN12.Exp(Y, e))
+		if LHS.Cmp(RHS) != 0 {
+			return false
+		}
+	}
+
+	modNCap := common.ModInt(NCap)
+	{
+		LHS := modNCap.Mul(modNCap.Exp(s, pf.Z1), modNCap.Exp(t, pf.Z3))
+		RHS := modNCap.Mul(pf.E, modNCap.Exp(pf.S, e))
+		if LHS.Cmp(RHS) != 0 {
+			return false
+		}
+	}
+
+	{
+		LHS := modNCap.Mul(modNCap.Exp(s, pf.Z2), modNCap.Exp(t, pf.Z4))
+		RHS := modNCap.Mul(pf.F, modNCap.Exp(pf.T, e))
+		if LHS.Cmp(RHS) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (pf *ProofAffg) ValidateBasic() bool {
+	return pf.A != nil &&
+		pf.Bx != nil && pf.Bx.ValidateBasic() &&
+		pf.By != nil &&
+		pf.E != nil &&
+		pf.S != nil &&
+		pf.F != nil &&
+		pf.T != nil &&
+		pf.Z1 != nil &&
+		pf.Z2 != nil &&
+		pf.Z3 != nil &&
+		pf.Z4 != nil &&
+		pf.W != nil &&
+		pf.Wy != nil
+}
+
+func (pf *ProofAffg) Bytes() [ProofAffgBytesParts][]byte {
+	return [...][]byte{
+		pf.A.Bytes(),
+		pf.Bx.X().Bytes(),
+		pf.Bx.Y().Bytes(),
+		pf.By.Bytes(),
+		pf.E.Bytes(),
+		pf.S.Bytes(),
+		pf.F.Bytes(),
+		pf.T.Bytes(),
+		pf.Z1.Bytes(),
+		pf.Z2.Bytes(),
+		pf.Z3.Bytes(),
+		pf.Z4.Bytes(),
+		pf.W.Bytes(),
+		pf.Wy.Bytes(),
+	}
+}
+
+func challenge(Session []byte, q *big.Int, pk0, pk1 *paillier.PublicKey, NCap, s, t, C, D, Y *big.Int, X *crypto.ECPoint,
+	A *big.Int, Bx *crypto.ECPoint, By, E, S, F, T *big.Int) *big.Int {
+	eHash := common.SHA512_256i_TAGGED(Session, pk0.N, pk1.N, NCap, s, t, C, D, Y, X.X(), X.Y(), A, Bx.X(), Bx.Y(), By, E, S, F, T)
+	return common.RejectionSample(q, eHash)
+}
@@ -0,0 +1,84 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package affgproof_test
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	. "github.com/bnb-chain/tss-lib/v2/crypto/affgproof"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+var Session = []byte("session")
+
+func TestAffg(test *testing.T) {
+	ec := tss.EC()
+	q := ec.Params().N
+	keys, _, err := keygen.LoadKeygenTestFixtures(2)
+	assert.NoError(test, err)
+	// party 0 is the verifier (Alice) and party 1 the prover (Bob)
+	sk0, pk1 := keys[0].PaillierSK, &keys[1].PaillierSK.PublicKey
+	pk0 := &sk0.PublicKey
+	NCap, s, t := keys[0].NTildei, keys[0].H1i, keys[0].H2i
+
+	k := common.GetRandomPositiveInt(rand.Reader, q)
+	C, err := pk0.Encrypt(rand.Reader, k)
+	assert.NoError(test, err)
+
+	_, lPrime, _ := Ranges(ec)
+	x := common.GetRandomPositiveInt(rand.Reader, q)
+	y := common.GetRandomPositiveInt(rand.Reader, new(big.Int).Lsh(big.NewInt(1), uint(lPrime)))
+	X := crypto.ScalarBaseMult(ec, x)
+	encY, rho, err := pk0.EncryptAndReturnRandomness(rand.Reader, y)
+	assert.NoError(test, err)
+	Cx, err := pk0.HomoMult(x, C)
+	assert.NoError(test, err)
+	D, err := pk0.HomoAdd(Cx, encY)
+	assert.NoError(test, err)
+	Y, rhoY, err := pk1.EncryptAndReturnRandomness(rand.Reader, y)
+	assert.NoError(test, err)
+
+	proof, err := NewProof(Session, ec, pk0, pk1, NCap, s, t, C, D, Y, X, x, y, rho, rhoY, rand.Reader)
+	assert.NoError(test, err)
+
+	proofBzs := proof.Bytes()
+	proof, err = NewProofFromBytes(ec, proofBzs[:])
+	assert.NoError(test, err)
+
+	ok := proof.Verify(Session, ec, pk0, pk1, NCap, s, t, C, D, Y, X)
+	assert.True(test, ok, "proof must verify")
+
+	// Alice decrypts k*x + y
+	alpha, err := sk0.Decrypt(D)
+	assert.NoError(test, err)
+	assert.Equal(test, 0, alpha.Cmp(new(big.Int).Add(new(big.Int).Mul(k, x), y)))
+
+	ok = proof.Verify([]byte("another session"), ec, pk0, pk1, NCap, s, t, C, D, Y, X)
+	assert.False(test, ok, "proof must not verify in another session")
+
+	// X is not x*G
+	otherX := crypto.ScalarBaseMult(ec, common.GetRandomPositiveInt(rand.Reader, q))
+	proof, err = NewProof(Session, ec, pk0, pk1, NCap, s, t, C, D, Y, otherX, x, y, rho, rhoY, rand.Reader)
+	assert.NoError(test, err)
+	ok = proof.Verify(Session, ec, pk0, pk1, NCap, s, t, C, D, Y, otherX)
+	assert.False(test, ok, "proof must not verify")
+
+	// Y encrypts a different y
+	otherY, otherRhoY, err := pk1.EncryptAndReturnRandomness(rand.Reader, big.NewInt(1))
+	assert.NoError(test, err)
+	proof, err = NewProof(Session, ec, pk0, pk1, NCap, s, t, C, D, otherY, X, x, y, rho, otherRhoY, rand.Reader)
+	assert.NoError(test, err)
+	ok = proof.Verify(Session, ec, pk0, pk1, NCap, s, t, C, D, otherY, X)
+	assert.False(test, ok, "proof must not verify")
+}
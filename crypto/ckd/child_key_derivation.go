@@ -17,7 +17,9 @@ import (
 	"github.com/bnb-chain/tss-lib/v2/common"
 	"github.com/bnb-chain/tss-lib/v2/crypto"
 	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/btcsuite/btcutil/base58"
+	"github.com/decred/dcrd/dcrec/edwards/v2"
 	"golang.org/x/crypto/ripemd160"
 )
 
@@ -28,12 +30,14 @@ type ExtendedKey struct {
 	ChainCode  []byte // 32 bytes
 	ParentFP   []byte // parent fingerprint
 	Version    []byte
+	PrivateKey *big.Int // nil for extended public keys
 }
 
 // For more information about child key derivation see https://github.com/binance-chain/tss-lib/issues/104
 // https://github.com/bitcoin/bips/blob/master/bip-0032.mediawiki .
-// The functions below do not implement the full BIP-32 specification. As mentioned in the Jira ticket above,
-// we only use non-hardened derived keys.
+// Keys holding a PrivateKey follow the full BIP-32 specification, including hardened derivation.
+// Threshold keys have no single private key, so only their non-hardened children can be derived, from the extended
+// public key; hardened indices are rejected.
 
 const (
 
@@ -58,7 +62,45 @@ const (
 	MaxSeedBytes = 64 // 512 bits
 )
 
-// Extended public key serialization, defined in BIP32
+// IsPrivate returns true if the key is an extended private key
+func (k *ExtendedKey) IsPrivate() bool {
+	return k.PrivateKey != nil
+}
+
+// Neuter returns the extended public key of an extended private key, switching the version to its public counterpart
+func (k *ExtendedKey) Neuter() (*ExtendedKey, error) {
+	if !k.IsPrivate() {
+		return k, nil
+	}
+	version, err := chaincfg.HDPrivateKeyToPublicKeyID(k.Version)
+	if err != nil {
+		return nil, err
+	}
+	return &ExtendedKey{
+		PublicKey:  k.PublicKey,
+		Depth:      k.Depth,
+		ChildIndex: k.ChildIndex,
+		ChainCode:  k.ChainCode,
+		ParentFP:   k.ParentFP,
+		Version:    version,
+	}, nil
+}
+
+// HDPublicKeyToPrivateKeyID returns the extended private key version of the network whose extended public keys have the
+// version `id`, the reverse of chaincfg.HDPrivateKeyToPublicKeyID for the networks of btcd
+func HDPublicKeyToPrivateKeyID(id []byte) ([]byte, error) {
+	for _, net := range []*chaincfg.Params{&chaincfg.MainNetParams, &chaincfg.TestNet3Params, &chaincfg.RegressionNetParams, &chaincfg.SimNetParams} {
+		if bytes.Equal(net.HDPublicKeyID[:], id) {
+			return net.HDPrivateKeyID[:], nil
+		}
+		if bytes.Equal(net.HDPrivateKeyID[:], id) {
+			return id, nil
+		}
+	}
+	return nil, chaincfg.ErrUnknownHDKeyID
+}
+
+// Extended key serialization, defined in BIP32
 func (k *ExtendedKey) String() string {
 	// version(4) || depth(1) || parentFP (4) || childinde(4) || chaincode (32) || key(33) || checksum(4)
 	var childNumBytes [4]byte
@@ -70,8 +112,13 @@ func (k *ExtendedKey) String() string {
 	serializedBytes = append(serializedBytes, k.ParentFP...)
 	serializedBytes = append(serializedBytes, childNumBytes[:]...)
 	serializedBytes = append(serializedBytes, k.ChainCode...)
-	pubKeyBytes := serializeCompressed(k.PublicKey.X, k.PublicKey.Y)
-	serializedBytes = append(serializedBytes, pubKeyBytes...)
+	if k.IsPrivate() {
+		serializedBytes = append(serializedBytes, 0x00)
+		serializedBytes = paddedAppend(serializedBytes, 32, k.PrivateKey.Bytes())
+	} else {
+		pubKeyBytes := serializeCompressed(k.PublicKey.X, k.PublicKey.Y)
+		serializedBytes = append(serializedBytes, pubKeyBytes...)
+	}
 
 	checkSum := doubleHashB(serializedBytes)[:4]
 	serializedBytes = append(serializedBytes, checkSum...)
@@ -104,8 +151,15 @@ func NewExtendedKeyFromString(key string, curve elliptic.Curve) (*ExtendedKey, e
 	keyData := payload[45:78]
 
 	var pubKey ecdsa.PublicKey
+	var privKey *big.Int
 
-	if c, ok := curve.(*btcec.KoblitzCurve); ok {
+	if keyData[0] == 0x00 {
+		privKey = new(big.Int).SetBytes(keyData[1:])
+		if privKey.Sign() == 0 || privKey.Cmp(curve.Params().N) >= 0 {
+			return nil, errors.New("invalid extended key")
+		}
+		pubKey = *crypto.ScalarBaseMult(curve, privKey).ToECDSAPubKey()
+	} else if c, ok := curve.(*btcec.KoblitzCurve); ok {
 		pk, err := btcec.ParsePubKey(keyData)
 		if err != nil {
 			return nil, err
@@ -131,6 +185,7 @@ func NewExtendedKeyFromString(key string, curve elliptic.Curve) (*ExtendedKey, e
 		ChainCode:  chainCode,
 		ParentFP:   parentFP,
 		Version:    version,
+		PrivateKey: privKey,
 	}, nil
 }
 
@@ -199,10 +254,14 @@ func DeriveChildKeyFromHierarchy(indicesHierarchy []uint32, pk *ExtendedKey, mod
 }
 
 // DeriveChildKey Derive a child key from the given parent key. The function returns "IL" ("I left"), per BIP-32 spec. It also
-// returns the derived child key.
+// returns the derived child key, which is private if the parent is. Hardened indices require an extended private key.
+// Ed25519 keys are derived as in ed25519.go and only have non-hardened children.
 func DeriveChildKey(index uint32, pk *ExtendedKey, curve elliptic.Curve) (*big.Int, *ExtendedKey, error) {
-	if index >= HardenedKeyStart {
-		return nil, nil, errors.New("the index must be non-hardened")
+	if c, ok := curve.(*edwards.TwistedEdwardsCurve); ok {
+		return deriveEd25519ChildKey(index, pk, c)
+	}
+	if index >= HardenedKeyStart && !pk.IsPrivate() {
+		return nil, nil, errors.New("the index must be non-hardened for an extended public key")
 	}
 	if pk.Depth == maxDepth {
 		return nil, nil, errors.New("cannot derive key beyond max depth")
@@ -217,7 +276,12 @@ func DeriveChildKey(index uint32, pk *ExtendedKey, curve elliptic.Curve) (*big.I
 	pkPublicKeyBytes := serializeCompressed(pk.X, pk.Y)
 
 	data := make([]byte, 37)
-	copy(data, pkPublicKeyBytes)
+	if index >= HardenedKeyStart {
+		// 0x00 || ser256(k) || ser32(i)
+		copy(data[1:], paddedBytes(32, pk.PrivateKey.Bytes()))
+	} else {
+		copy(data, pkPublicKeyBytes)
+	}
 	binary.BigEndian.PutUint32(data[33:], index)
 
 	// I = HMAC-SHA512(Key = chainCode, Data=data)
@@ -255,5 +319,11 @@ func DeriveChildKey(index uint32, pk *ExtendedKey, curve elliptic.Curve) (*big.I
 		ParentFP:   hash160(pkPublicKeyBytes)[:4],
 		Version:    pk.Version,
 	}
+	if pk.IsPrivate() {
+		childPk.PrivateKey = common.ModInt(curve.Params().N).Add(pk.PrivateKey, ilNum)
+		if childPk.PrivateKey.Sign() == 0 {
+			return nil, nil, errors.New("invalid child")
+		}
+	}
 	return ilNum, childPk, nil
 }
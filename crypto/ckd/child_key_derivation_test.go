@@ -7,10 +7,17 @@
 package ckd_test
 
 import (
+	"crypto/rand"
 	"testing"
 
-	. "github.com/bnb-chain/tss-lib/v2/crypto/ckd"
 	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	. "github.com/bnb-chain/tss-lib/v2/crypto/ckd"
 )
 
 func TestPublicDerivation(t *testing.T) {
@@ -130,3 +137,125 @@ tests:
 		}
 	}
 }
+
+func TestPrivateDerivation(t *testing.T) {
+	// port from https://github.com/btcsuite/btcutil/blob/master/hdkeychain/extendedkey_test.go
+	// The private extended key for test vector 1 in [BIP32].
+	testVec1MasterPrivKey := "xprv9s21ZrQH143K3QTDL4LXw2F7HEK3wJUD2nW2nRk4stbPy6cq3jPPqjiChkVvvNKmPGJxWUtg6LnF5kejMRNNU3TGtRBeJgk33yuGBxrMPHi"
+
+	tests := []struct {
+		name     string
+		path     []uint32
+		wantPub  string
+		wantPriv string
+	}{
+		{
+			name:     "test vector 1 chain m",
+			path:     []uint32{},
+			wantPub:  "xpub661MyMwAqRbcFtXgS5sYJABqqG9YLmC4Q1Rdap9gSE8NqtwybGhePY2gZ29ESFjqJoCu1Rupje8YtGqsefD265TMg7usUDFdp6W1EGMcet8",
+			wantPriv: "xprv9s21ZrQH143K3QTDL4LXw2F7HEK3wJUD2nW2nRk4stbPy6cq3jPPqjiChkVvvNKmPGJxWUtg6LnF5kejMRNNU3TGtRBeJgk33yuGBxrMPHi",
+		},
+		{
+			name:     "test vector 1 chain m/0H",
+			path:     []uint32{HardenedKeyStart},
+			wantPub:  "xpub68Gmy5EdvgibQVfPdqkBBCHxA5htiqg55crXYuXoQRKfDBFA1WEjWgP6LHhwBZeNK1VTsfTFUHCdrfp1bgwQ9xv5ski8PX9rL2dZXvgGDnw",
+			wantPriv: "xprv9uHRZZhk6KAJC1avXpDAp4MDc3sQKNxDiPvvkX8Br5ngLNv1TxvUxt4cV1rGL5hj6KCesnDYUhd7oWgT11eZG7XnxHrnYeSvkzY7d2bhkJ7",
+		},
+		{
+			name:     "test vector 1 chain m/0H/1",
+			path:     []uint32{HardenedKeyStart, 1},
+			wantPub:  "xpub6ASuArnXKPbfEwhqN6e3mwBcDTgzisQN1wXN9BJcM47sSikHjJf3UFHKkNAWbWMiGj7Wf5uMash7SyYq527Hqck2AxYysAA7xmALppuCkwQ",
+			wantPriv: "xprv9wTYmMFdV23N2TdNG573QoEsfRrWKQgWeibmLntzniatZvR9BmLnvSxqu53Kw1UmYPxLgboyZQaXwTCg8MSY3H2EU4pWcQDnRnrVA1xe8fs",
+		},
+		{
+			name:     "test vector 1 chain m/0H/1/2H",
+			path:     []uint32{HardenedKeyStart, 1, HardenedKeyStart + 2},
+			wantPub:  "xpub6D4BDPcP2GT577Vvch3R8wDkScZWzQzMMUm3PWbmWvVJrZwQY4VUNgqFJPMM3No2dFDFGTsxxpG5uJh7n7epu4trkrX7x7DogT5Uv6fcLW5",
+			wantPriv: "xprv9z4pot5VBttmtdRTWfWQmoH1taj2axGVzFqSb8C9xaxKymcFzXBDptWmT7FwuEzG3ryjH4ktypQSAewRiNMjANTtpgP4mLTj34bhnZX7UiM",
+		},
+		{
+			name:     "test vector 1 chain m/0H/1/2H/2",
+			path:     []uint32{HardenedKeyStart, 1, HardenedKeyStart + 2, 2},
+			wantPub:  "xpub6FHa3pjLCk84BayeJxFW2SP4XRrFd1JYnxeLeU8EqN3vDfZmbqBqaGJAyiLjTAwm6ZLRQUMv1ZACTj37sR62cfN7fe5JnJ7dh8zL4fiyLHV",
+			wantPriv: "xprvA2JDeKCSNNZky6uBCviVfJSKyQ1mDYahRjijr5idH2WwLsEd4Hsb2Tyh8RfQMuPh7f7RtyzTtdrbdqqsunu5Mm3wDvUAKRHSC34sJ7in334",
+		},
+		{
+			name:     "test vector 1 chain m/0H/1/2H/2/1000000000",
+			path:     []uint32{HardenedKeyStart, 1, HardenedKeyStart + 2, 2, 1000000000},
+			wantPub:  "xpub6H1LXWLaKsWFhvm6RVpEL9P4KfRZSW7abD2ttkWP3SSQvnyA8FSVqNTEcYFgJS2UaFcxupHiYkro49S8yGasTvXEYBVPamhGW6cFJodrTHy",
+			wantPriv: "xprvA41z7zogVVwxVSgdKUHDy1SKmdb533PjDz7J6N6mV6uS3ze1ai8FHa8kmHScGpWmj4WggLyQjgPie1rFSruoUihUZREPSL39UNdE3BBDu76",
+		},
+	}
+
+	for i, test := range tests {
+		extKey, err := NewExtendedKeyFromString(testVec1MasterPrivKey, btcec.S256())
+		if !assert.NoError(t, err, "#%d (%s)", i, test.name) {
+			continue
+		}
+		for _, childNum := range test.path {
+			_, extKey, err = DeriveChildKey(childNum, extKey, btcec.S256())
+			if !assert.NoError(t, err, "#%d (%s)", i, test.name) {
+				break
+			}
+		}
+		assert.Equal(t, test.wantPriv, extKey.String(), "#%d (%s)", i, test.name)
+		pubKey, err := extKey.Neuter()
+		assert.NoError(t, err)
+		assert.Equal(t, test.wantPub, pubKey.String(), "#%d (%s)", i, test.name)
+
+		// hardened indices cannot be derived from the public key
+		_, _, err = DeriveChildKey(HardenedKeyStart, pubKey, btcec.S256())
+		assert.Error(t, err)
+	}
+}
+
+func TestEd25519Derivation(t *testing.T) {
+	ec := edwards.Edwards()
+	x := common.GetRandomPositiveInt(rand.Reader, ec.Params().N)
+	pub := crypto.ScalarBaseMult(ec, x)
+	chainCode, err := common.GetRandomBytes(rand.Reader, 32)
+	assert.NoError(t, err)
+	root := &ExtendedKey{
+		PublicKey: *pub.ToECDSAPubKey(),
+		ChainCode: chainCode,
+		ParentFP:  []byte{0x00, 0x00, 0x00, 0x00},
+		Version:   chaincfg.MainNetParams.HDPublicKeyID[:],
+	}
+	path := []uint32{0, 1, 2}
+
+	delta, child, err := DeriveChildKeyFromHierarchy(path, root, ec.Params().N, ec)
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(len(path)), child.Depth)
+	assert.Equal(t, uint32(2), child.ChildIndex)
+	assert.Len(t, child.ChainCode, 32)
+	childPub, err := crypto.NewECPoint(ec, child.X, child.Y)
+	assert.NoError(t, err)
+	childX := common.ModInt(ec.Params().N).Add(x, delta)
+	assert.True(t, crypto.ScalarBaseMult(ec, childX).Equals(childPub), "x + delta must be the child private key")
+
+	// every "IL" is a multiple of the cofactor
+	for _, index := range path {
+		il, next, err := DeriveChildKey(index, root, ec)
+		assert.NoError(t, err)
+		assert.Equal(t, uint(0), il.Bit(0)|il.Bit(1)|il.Bit(2))
+		root = next
+	}
+	assert.Equal(t, child.X, root.X)
+	assert.Equal(t, child.ChainCode, root.ChainCode)
+
+	// a private parent derives the same child along with its private key
+	privRoot := &ExtendedKey{
+		PublicKey:  *pub.ToECDSAPubKey(),
+		PrivateKey: x,
+		ChainCode:  chainCode,
+		ParentFP:   []byte{0x00, 0x00, 0x00, 0x00},
+		Version:    chaincfg.MainNetParams.HDPrivateKeyID[:],
+	}
+	_, privChild, err := DeriveChildKeyFromHierarchy(path, privRoot, ec.Params().N, ec)
+	assert.NoError(t, err)
+	assert.Equal(t, child.X, privChild.X)
+	assert.Equal(t, 0, childX.Cmp(privChild.PrivateKey))
+
+	_, _, err = DeriveChildKey(HardenedKeyStart, privRoot, ec)
+	assert.Error(t, err, "hardened Ed25519 children are not supported")
+}
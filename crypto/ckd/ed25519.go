@@ -0,0 +1,89 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package ckd
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"math/big"
+
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+)
+
+// Non-hardened derivation for Ed25519 keys.
+//
+// SLIP-10 only defines hardened children for Ed25519, so non-hardened children follow the public derivation of
+// BIP32-Ed25519 (Khovratovich and Law):
+//
+//	Z = HMAC-SHA512(Key = c_par, Data = 0x02 || A_par || ser32le(i))
+//	A_i = A_par + [8*ZL]B, where ZL is the first 28 bytes of Z read as a little-endian integer
+//	c_i = the right 32 bytes of HMAC-SHA512(Key = c_par, Data = 0x03 || A_par || ser32le(i))
+//
+// where A is the 32 byte encoding of RFC 8032. The shares of a threshold key are plain scalars rather than the
+// extended keys of BIP32-Ed25519, so the child private key is simply x + 8*ZL and "IL" is 8*ZL.
+
+const (
+	ed25519ZLBytesLen = 28
+)
+
+func deriveEd25519ChildKey(index uint32, pk *ExtendedKey, curve *edwards.TwistedEdwardsCurve) (*big.Int, *ExtendedKey, error) {
+	if index >= HardenedKeyStart {
+		return nil, nil, errors.New("the index must be non-hardened for an Ed25519 key")
+	}
+	if pk.Depth == maxDepth {
+		return nil, nil, errors.New("cannot derive key beyond max depth")
+	}
+	cryptoPk, err := crypto.NewECPoint(curve, pk.X, pk.Y)
+	if err != nil {
+		common.Logger.Error("error getting pubkey from extendedkey")
+		return nil, nil, err
+	}
+	pkPublicKeyBytes := edwards.NewPublicKey(pk.X, pk.Y).Serialize()
+
+	data := make([]byte, 1+len(pkPublicKeyBytes)+4)
+	copy(data[1:], pkPublicKeyBytes)
+	binary.LittleEndian.PutUint32(data[1+len(pkPublicKeyBytes):], index)
+
+	data[0] = 0x02
+	hmac512 := hmac.New(sha512.New, pk.ChainCode)
+	hmac512.Write(data)
+	z := hmac512.Sum(nil)
+
+	data[0] = 0x03
+	hmac512 = hmac.New(sha512.New, pk.ChainCode)
+	hmac512.Write(data)
+	childChainCode := hmac512.Sum(nil)[32:]
+
+	zl := make([]byte, ed25519ZLBytesLen)
+	for k := range zl {
+		zl[k] = z[ed25519ZLBytesLen-1-k]
+	}
+	ilNum := new(big.Int).Lsh(new(big.Int).SetBytes(zl), 3)
+
+	childCryptoPk, err := cryptoPk.Add(crypto.ScalarBaseMult(curve, ilNum))
+	if err != nil {
+		common.Logger.Error("error adding delta G to parent key")
+		return nil, nil, err
+	}
+	childPk := &ExtendedKey{
+		PublicKey:  *childCryptoPk.ToECDSAPubKey(),
+		Depth:      pk.Depth + 1,
+		ChildIndex: index,
+		ChainCode:  childChainCode,
+		ParentFP:   hash160(pkPublicKeyBytes)[:4],
+		Version:    pk.Version,
+	}
+	if pk.IsPrivate() {
+		childPk.PrivateKey = common.ModInt(curve.Params().N).Add(pk.PrivateKey, ilNum)
+	}
+	return ilNum, childPk, nil
+}
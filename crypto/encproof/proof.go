@@ -0,0 +1,151 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Zero-knowledge proof that a Paillier ciphertext K = Enc(k; rho) encrypts a plaintext k < 2^l,
+// with l the bit length of the curve order (CGGMP21 Fig 14, Πenc).
+// All secrets and masks are non-negative here, so the intervals ±2^x of the paper become [0, 2^x).
+
+package encproof
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto/paillier"
+)
+
+const (
+	ProofEncBytesParts = 6
+)
+
+type (
+	ProofEnc struct {
+		S, A, C, Z1, Z2, Z3 *big.Int
+	}
+)
+
+// NewProof implements proofenc for K = Enc_pk(k; rho), using the verifier's ring-Pedersen parameters (NCap, s, t)
+func NewProof(Session []byte, ec elliptic.Curve, pk *paillier.PublicKey, K, NCap, s, t, k, rho *big.Int, rand io.Reader) (*ProofEnc, error) {
+	if ec == nil || pk == nil || K == nil || NCap == nil || s == nil || t == nil || k == nil || rho == nil {
+		return nil, errors.New("ProveEnc constructor received nil value(s)")
+	}
+	q := ec.Params().N
+	l, eps := q.BitLen(), 2*q.BitLen()
+	twoLEps := new(big.Int).Lsh(big.NewInt(1), uint(l+eps))
+	twoLNCap := new(big.Int).Lsh(NCap, uint(l))
+	twoLEpsNCap := new(big.Int).Lsh(NCap, uint(l+eps))
+
+	// Fig 14.1 sample
+	alpha := common.GetRandomPositiveInt(rand, twoLEps)
+	mu := common.GetRandomPositiveInt(rand, twoLNCap)
+	r := common.GetRandomPositiveRelativelyPrimeInt(rand, pk.N)
+	gamma := common.GetRandomPositiveInt(rand, twoLEpsNCap)
+
+	// Fig 14.1 compute
+	modNCap := common.ModInt(NCap)
+	S := modNCap.Mul(modNCap.Exp(s, k), modNCap.Exp(t, mu))
+	A, err := pk.EncryptWithRandomness(new(big.Int).Mod(alpha, pk.N), r)
+	if err != nil {
+		return nil, err
+	}
+	C := modNCap.Mul(modNCap.Exp(s, alpha), modNCap.Exp(t, gamma))
+
+	// Fig 14.2 e
+	e := challenge(Session, q, pk, K, NCap, s, t, S, A, C)
+
+	// Fig 14.3
+	z1 := new(big.Int).Add(alpha, new(big.Int).Mul(e, k))
+	z2 := common.ModInt(pk.N).Mul(r, common.ModInt(pk.N).Exp(rho, e))
+	z3 := new(big.Int).Add(gamma, new(big.Int).Mul(e, mu))
+
+	return &ProofEnc{S: S, A: A, C: C, Z1: z1, Z2: z2, Z3: z3}, nil
+}
+
+func NewProofFromBytes(bzs [][]byte) (*ProofEnc, error) {
+	if !common.NonEmptyMultiBytes(bzs, ProofEncBytesParts) {
+		return nil, fmt.Errorf("expected %d byte parts to construct ProofEnc", ProofEncBytesParts)
+	}
+	return &ProofEnc{
+		S:  new(big.Int).SetBytes(bzs[0]),
+		A:  new(big.Int).SetBytes(bzs[1]),
+		C:  new(big.Int).SetBytes(bzs[2]),
+		Z1: new(big.Int).SetBytes(bzs[3]),
+		Z2: new(big.Int).SetBytes(bzs[4]),
+		Z3: new(big.Int).SetBytes(bzs[5]),
+	}, nil
+}
+
+func (pf *ProofEnc) Verify(Session []byte, ec elliptic.Curve, pk *paillier.PublicKey, K, NCap, s, t *big.Int) bool {
+	if pf == nil || !pf.ValidateBasic() || ec == nil || pk == nil || K == nil || NCap == nil || s == nil || t == nil {
+		return false
+	}
+	N2 := pk.NSquare()
+	if !common.IsNumberInMultiplicativeGroup(N2, K) || !common.IsNumberInMultiplicativeGroup(N2, pf.A) ||
+		!common.IsNumberInMultiplicativeGroup(NCap, pf.S) || !common.IsNumberInMultiplicativeGroup(NCap, pf.C) {
+		return false
+	}
+	q := ec.Params().N
+	l, eps := q.BitLen(), 2*q.BitLen()
+
+	// Fig 14. Range Check; the extra bit accounts for e*k
+	if !common.IsInInterval(pf.Z1, new(big.Int).Lsh(big.NewInt(1), uint(l+eps+1))) {
+		return false
+	}
+
+	e := challenge(Session, q, pk, K, NCap, s, t, pf.S, pf.A, pf.C)
+
+	// Fig 14. Equality Check
+	{
+		LHS, err := pk.EncryptWithRandomness(new(big.Int).Mod(pf.Z1, pk.N), pf.Z2)
+		if err != nil {
+			return false
+		}
+		modN2 := common.ModInt(N2)
+		RHS := modN2.Mul(pf.A, modN2.Exp(K, e))
+		if LHS.Cmp(RHS) != 0 {
+			return false
+		}
+	}
+
+	{
+		modNCap := common.ModInt(NCap)
+		LHS := modNCap.Mul(modNCap.Exp(s, pf.Z1), modNCap.Exp(t, pf.Z3))
+		RHS := modNCap.Mul(pf.C, modNCap.Exp(pf.S, e))
+		if LHS.Cmp(RHS) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (pf *ProofEnc) ValidateBasic() bool {
+	return pf.S != nil &&
+		pf.A != nil &&
+		pf.C != nil &&
+		pf.Z1 != nil &&
+		pf.Z2 != nil &&
+		pf.Z3 != nil
+}
+
+func (pf *ProofEnc) Bytes() [ProofEncBytesParts][]byte {
+	return [...][]byte{
+		pf.S.Bytes(),
+		pf.A.Bytes(),
+		pf.C.Bytes(),
+		pf.Z1.Bytes(),
+		pf.Z2.Bytes(),
+		pf.Z3.Bytes(),
+	}
+}
+
+func challenge(Session []byte, q *big.Int, pk *paillier.PublicKey, K, NCap, s, t, S, A, C *big.Int) *big.Int {
+	eHash := common.SHA512_256i_TAGGED(Session, pk.N, K, NCap, s, t, S, A, C)
+	return common.RejectionSample(q, eHash)
+}
@@ -0,0 +1,56 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package encproof_test
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	. "github.com/bnb-chain/tss-lib/v2/crypto/encproof"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+var Session = []byte("session")
+
+func TestEnc(test *testing.T) {
+	ec := tss.EC()
+	keys, _, err := keygen.LoadKeygenTestFixtures(2)
+	assert.NoError(test, err)
+	pk := &keys[0].PaillierSK.PublicKey
+	NCap, s, t := keys[1].NTildei, keys[1].H1i, keys[1].H2i
+
+	k := common.GetRandomPositiveInt(rand.Reader, ec.Params().N)
+	K, rho, err := pk.EncryptAndReturnRandomness(rand.Reader, k)
+	assert.NoError(test, err)
+
+	proof, err := NewProof(Session, ec, pk, K, NCap, s, t, k, rho, rand.Reader)
+	assert.NoError(test, err)
+
+	proofBzs := proof.Bytes()
+	proof, err = NewProofFromBytes(proofBzs[:])
+	assert.NoError(test, err)
+
+	ok := proof.Verify(Session, ec, pk, K, NCap, s, t)
+	assert.True(test, ok, "proof must verify")
+
+	ok = proof.Verify([]byte("another session"), ec, pk, K, NCap, s, t)
+	assert.False(test, ok, "proof must not verify in another session")
+
+	// the plaintext is far out of range
+	tooBig := new(big.Int).Lsh(ec.Params().N, 1024)
+	K, rho, err = pk.EncryptAndReturnRandomness(rand.Reader, tooBig)
+	assert.NoError(test, err)
+	proof, err = NewProof(Session, ec, pk, K, NCap, s, t, tooBig, rho, rand.Reader)
+	assert.NoError(test, err)
+	ok = proof.Verify(Session, ec, pk, K, NCap, s, t)
+	assert.False(test, ok, "proof must not verify")
+}
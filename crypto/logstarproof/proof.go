@@ -0,0 +1,176 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Zero-knowledge proof that the plaintext x < 2^l of a Paillier ciphertext C = Enc(x; rho) is the discrete log
+// of X = x*g for some base point g (CGGMP21 Fig 25, Πlog*).
+// All secrets and masks are non-negative here, so the intervals ±2^x of the paper become [0, 2^x).
+
+package logstarproof
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/paillier"
+)
+
+const (
+	ProofLogstarBytesParts = 8
+)
+
+type (
+	ProofLogstar struct {
+		S, A          *big.Int
+		Y             *crypto.ECPoint
+		D, Z1, Z2, Z3 *big.Int
+	}
+)
+
+// NewProof implements prooflogstar for C = Enc_pk(x; rho) and X = x*g, using the verifier's ring-Pedersen parameters (NCap, s, t)
+func NewProof(Session []byte, ec elliptic.Curve, pk *paillier.PublicKey, C *big.Int, X, g *crypto.ECPoint, NCap, s, t, x, rho *big.Int, rand io.Reader) (*ProofLogstar, error) {
+	if ec == nil || pk == nil || C == nil || X == nil || g == nil || NCap == nil || s == nil || t == nil || x == nil || rho == nil {
+		return nil, errors.New("ProveLogstar constructor received nil value(s)")
+	}
+	q := ec.Params().N
+	l, eps := q.BitLen(), 2*q.BitLen()
+	twoLEps := new(big.Int).Lsh(big.NewInt(1), uint(l+eps))
+	twoLNCap := new(big.Int).Lsh(NCap, uint(l))
+	twoLEpsNCap := new(big.Int).Lsh(NCap, uint(l+eps))
+
+	// Fig 25.1 sample
+	var alpha *big.Int
+	for alpha == nil || new(big.Int).Mod(alpha, q).Sign() == 0 {
+		alpha = common.GetRandomPositiveInt(rand, twoLEps)
+	}
+	mu := common.GetRandomPositiveInt(rand, twoLNCap)
+	r := common.GetRandomPositiveRelativelyPrimeInt(rand, pk.N)
+	gamma := common.GetRandomPositiveInt(rand, twoLEpsNCap)
+
+	// Fig 25.1 compute
+	modNCap := common.ModInt(NCap)
+	S := modNCap.Mul(modNCap.Exp(s, x), modNCap.Exp(t, mu))
+	A, err := pk.EncryptWithRandomness(new(big.Int).Mod(alpha, pk.N), r)
+	if err != nil {
+		return nil, err
+	}
+	Y := g.ScalarMult(new(big.Int).Mod(alpha, q))
+	D := modNCap.Mul(modNCap.Exp(s, alpha), modNCap.Exp(t, gamma))
+
+	// Fig 25.2 e
+	e := challenge(Session, q, pk, C, X, g, NCap, s, t, S, A, Y, D)
+
+	// Fig 25.3
+	z1 := new(big.Int).Add(alpha, new(big.Int).Mul(e, x))
+	z2 := common.ModInt(pk.N).Mul(r, common.ModInt(pk.N).Exp(rho, e))
+	z3 := new(big.Int).Add(gamma, new(big.Int).Mul(e, mu))
+
+	return &ProofLogstar{S: S, A: A, Y: Y, D: D, Z1: z1, Z2: z2, Z3: z3}, nil
+}
+
+func NewProofFromBytes(ec elliptic.Curve, bzs [][]byte) (*ProofLogstar, error) {
+	if !common.NonEmptyMultiBytes(bzs, ProofLogstarBytesParts) {
+		return nil, fmt.Errorf("expected %d byte parts to construct ProofLogstar", ProofLogstarBytesParts)
+	}
+	Y, err := crypto.NewECPoint(ec, new(big.Int).SetBytes(bzs[2]), new(big.Int).SetBytes(bzs[3]))
+	if err != nil {
+		return nil, err
+	}
+	return &ProofLogstar{
+		S:  new(big.Int).SetBytes(bzs[0]),
+		A:  new(big.Int).SetBytes(bzs[1]),
+		Y:  Y,
+		D:  new(big.Int).SetBytes(bzs[4]),
+		Z1: new(big.Int).SetBytes(bzs[5]),
+		Z2: new(big.Int).SetBytes(bzs[6]),
+		Z3: new(big.Int).SetBytes(bzs[7]),
+	}, nil
+}
+
+func (pf *ProofLogstar) Verify(Session []byte, ec elliptic.Curve, pk *paillier.PublicKey, C *big.Int, X, g *crypto.ECPoint, NCap, s, t *big.Int) bool {
+	if pf == nil || !pf.ValidateBasic() || ec == nil || pk == nil || C == nil || X == nil || g == nil || NCap == nil || s == nil || t == nil {
+		return false
+	}
+	N2 := pk.NSquare()
+	if !common.IsNumberInMultiplicativeGroup(N2, C) || !common.IsNumberInMultiplicativeGroup(N2, pf.A) ||
+		!common.IsNumberInMultiplicativeGroup(NCap, pf.S) || !common.IsNumberInMultiplicativeGroup(NCap, pf.D) {
+		return false
+	}
+	q := ec.Params().N
+	l, eps := q.BitLen(), 2*q.BitLen()
+
+	// Fig 25. Range Check; the extra bit accounts for e*x
+	if !common.IsInInterval(pf.Z1, new(big.Int).Lsh(big.NewInt(1), uint(l+eps+1))) {
+		return false
+	}
+
+	e := challenge(Session, q, pk, C, X, g, NCap, s, t, pf.S, pf.A, pf.Y, pf.D)
+
+	// Fig 25. Equality Check
+	{
+		LHS, err := pk.EncryptWithRandomness(new(big.Int).Mod(pf.Z1, pk.N), pf.Z2)
+		if err != nil {
+			return false
+		}
+		modN2 := common.ModInt(N2)
+		RHS := modN2.Mul(pf.A, modN2.Exp(C, e))
+		if LHS.Cmp(RHS) != 0 {
+			return false
+		}
+	}
+
+	{
+		// computed on raw coordinates as either side may be the point at infinity
+		LX, LY := ec.ScalarMult(g.X(), g.Y(), new(big.Int).Mod(pf.Z1, q).Bytes())
+		eXX, eXY := ec.ScalarMult(X.X(), X.Y(), e.Bytes())
+		RX, RY := ec.Add(pf.Y.X(), pf.Y.Y(), eXX, eXY)
+		if LX.Cmp(RX) != 0 || LY.Cmp(RY) != 0 {
+			return false
+		}
+	}
+
+	{
+		modNCap := common.ModInt(NCap)
+		LHS := modNCap.Mul(modNCap.Exp(s, pf.Z1), modNCap.Exp(t, pf.Z3))
+		RHS := modNCap.Mul(pf.D, modNCap.Exp(pf.S, e))
+		if LHS.Cmp(RHS) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (pf *ProofLogstar) ValidateBasic() bool {
+	return pf.S != nil &&
+		pf.A != nil &&
+		pf.Y != nil && pf.Y.ValidateBasic() &&
+		pf.D != nil &&
+		pf.Z1 != nil &&
+		pf.Z2 != nil &&
+		pf.Z3 != nil
+}
+
+func (pf *ProofLogstar) Bytes() [ProofLogstarBytesParts][]byte {
+	return [...][]byte{
+		pf.S.Bytes(),
+		pf.A.Bytes(),
+		pf.Y.X().Bytes(),
+		pf.Y.Y().Bytes(),
+		pf.D.Bytes(),
+		pf.Z1.Bytes(),
+		pf.Z2.Bytes(),
+		pf.Z3.Bytes(),
+	}
+}
+
+func challenge(Session []byte, q *big.Int, pk *paillier.PublicKey, C *big.Int, X, g *crypto.ECPoint, NCap, s, t, S, A *big.Int, Y *crypto.ECPoint, D *big.Int) *big.Int {
+	eHash := common.SHA512_256i_TAGGED(Session, pk.N, C, X.X(), X.Y(), g.X(), g.Y(), NCap, s, t, S, A, Y.X(), Y.Y(), D)
+	return common.RejectionSample(q, eHash)
+}
@@ -0,0 +1,59 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package logstarproof_test
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	. "github.com/bnb-chain/tss-lib/v2/crypto/logstarproof"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+var Session = []byte("session")
+
+func TestLogstar(test *testing.T) {
+	ec := tss.EC()
+	q := ec.Params().N
+	keys, _, err := keygen.LoadKeygenTestFixtures(2)
+	assert.NoError(test, err)
+	pk := &keys[0].PaillierSK.PublicKey
+	NCap, s, t := keys[1].NTildei, keys[1].H1i, keys[1].H2i
+
+	g := crypto.ScalarBaseMult(ec, common.GetRandomPositiveInt(rand.Reader, q))
+	x := common.GetRandomPositiveInt(rand.Reader, q)
+	X := g.ScalarMult(x)
+	C, rho, err := pk.EncryptAndReturnRandomness(rand.Reader, x)
+	assert.NoError(test, err)
+
+	proof, err := NewProof(Session, ec, pk, C, X, g, NCap, s, t, x, rho, rand.Reader)
+	assert.NoError(test, err)
+
+	proofBzs := proof.Bytes()
+	proof, err = NewProofFromBytes(ec, proofBzs[:])
+	assert.NoError(test, err)
+
+	ok := proof.Verify(Session, ec, pk, C, X, g, NCap, s, t)
+	assert.True(test, ok, "proof must verify")
+
+	ok = proof.Verify([]byte("another session"), ec, pk, C, X, g, NCap, s, t)
+	assert.False(test, ok, "proof must not verify in another session")
+
+	// X is not x*g
+	otherX := g.ScalarMult(common.GetRandomPositiveInt(rand.Reader, q))
+	ok = proof.Verify(Session, ec, pk, C, otherX, g, NCap, s, t)
+	assert.False(test, ok, "proof must not verify")
+	proof, err = NewProof(Session, ec, pk, C, otherX, g, NCap, s, t, x, rho, rand.Reader)
+	assert.NoError(test, err)
+	ok = proof.Verify(Session, ec, pk, C, otherX, g, NCap, s, t)
+	assert.False(test, ok, "proof must not verify")
+}
@@ -91,7 +91,7 @@ func ProveBobWC(Session []byte, ec elliptic.Curve, pk *paillier.PublicKey, NTild
 	// 9.
 	modNSquared := common.ModInt(NSquared)
 	v := modNSquared.Exp(c1, alpha)
-	v = modNSquared.Mul(v, modNSquared.Exp(pk.Gamma(), gamma))
+	v = modNSquared.Mul(v, pk.ExpGamma(gamma))
 	v = modNSquared.Mul(v, modNSquared.Exp(beta, pk.N))
 
 	// 10.
@@ -328,7 +328,7 @@ func (pf *ProofBobWC) Verify(Session []byte, ec elliptic.Curve, pk *paillier.Pub
 
 		c1ExpS1 := modNSquared.Exp(c1, pf.S1)
 		sExpN := modNSquared.Exp(pf.S, pk.N)
-		gammaExpT1 := modNSquared.Exp(pk.Gamma(), pf.T1)
+		gammaExpT1 := pk.ExpGamma(pf.T1)
 		left = modNSquared.Mul(c1ExpS1, sExpN)
 		left = modNSquared.Mul(left, gammaExpT1)
 		c2ExpE := modNSquared.Exp(c2, e)
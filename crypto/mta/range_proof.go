@@ -62,7 +62,7 @@ func ProveRangeAlice(ec elliptic.Curve, pk *paillier.PublicKey, c, NTilde, h1, h
 
 	// 6.
 	modNSquared := common.ModInt(pk.NSquare())
-	u := modNSquared.Exp(pk.Gamma(), alpha)
+	u := pk.ExpGamma(alpha)
 	u = modNSquared.Mul(u, modNSquared.Exp(beta, pk.N))
 
 	// 7.
@@ -162,7 +162,7 @@ func (pf *RangeProofAlice) Verify(ec elliptic.Curve, pk *paillier.PublicKey, NTi
 
 		cExpMinusE := modNSquared.Exp(c, minusE)
 		sExpN := modNSquared.Exp(pf.S, pk.N)
-		gammaExpS1 := modNSquared.Exp(pk.Gamma(), pf.S1)
+		gammaExpS1 := pk.ExpGamma(pf.S1)
 		// u != (4)
 		products = modNSquared.Mul(gammaExpS1, sExpN)
 		products = modNSquared.Mul(products, cExpMinusE)
@@ -31,7 +31,7 @@ var (
 	publicKey  *PublicKey
 )
 
-func setUp(t *testing.T) {
+func setUp(t testing.TB) {
 	if privateKey != nil && publicKey != nil {
 		return
 	}
@@ -76,6 +76,105 @@ func TestEncryptDecrypt(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestDecryptAndRecoverRandomness(t *testing.T) {
+	setUp(t)
+	exp := big.NewInt(100)
+	cypher, x, err := publicKey.EncryptAndReturnRandomness(rand.Reader, exp)
+	assert.NoError(t, err)
+	ret, retX, err := privateKey.DecryptAndRecoverRandomness(cypher)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, exp.Cmp(ret), "wrong decryption ", ret, " is not ", exp)
+	assert.Equal(t, 0, x.Cmp(retX), "wrong randomness ", retX, " is not ", x)
+
+	opened, err := publicKey.EncryptWithRandomness(ret, retX)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, cypher.Cmp(opened), "the recovered opening must re-encrypt to the same ciphertext")
+
+	_, err = publicKey.EncryptWithRandomness(ret, big.NewInt(0))
+	assert.Error(t, err)
+}
+
+func TestDecryptCRT(t *testing.T) {
+	setUp(t)
+	// keys made before v2.0 have no primes, and are decrypted modulo N2
+	noPrimes := &PrivateKey{PublicKey: privateKey.PublicKey, LambdaN: privateKey.LambdaN, PhiN: privateKey.PhiN}
+	N2 := publicKey.NSquare()
+	ms := []*big.Int{big.NewInt(0), big.NewInt(1), new(big.Int).Sub(publicKey.N, big.NewInt(1)), common.GetRandomPositiveInt(rand.Reader, publicKey.N)}
+	for _, m := range ms {
+		c, err := publicKey.Encrypt(rand.Reader, m)
+		assert.NoError(t, err)
+		ret, err := privateKey.Decrypt(c)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, m.Cmp(ret), "wrong decryption ", ret, " is not ", m)
+		ret, err = noPrimes.Decrypt(c)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, m.Cmp(ret), "wrong decryption ", ret, " is not ", m)
+	}
+	// any element of Z*_N2 is an encryption of some message
+	c := common.GetRandomPositiveRelativelyPrimeInt(rand.Reader, N2)
+	ret, err := privateKey.Decrypt(c)
+	assert.NoError(t, err)
+	exp, err := noPrimes.Decrypt(c)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, exp.Cmp(ret))
+}
+
+func TestExpGamma(t *testing.T) {
+	setUp(t)
+	N2 := publicKey.NSquare()
+	xs := []*big.Int{
+		big.NewInt(0),
+		big.NewInt(1),
+		big.NewInt(-5),
+		common.GetRandomPositiveInt(rand.Reader, publicKey.N),
+		common.MustGetRandomInt(rand.Reader, 3000),
+		new(big.Int).Neg(common.MustGetRandomInt(rand.Reader, 3000)),
+	}
+	for _, x := range xs {
+		exp := new(big.Int).Exp(publicKey.Gamma(), x, N2)
+		assert.Equal(t, 0, exp.Cmp(publicKey.ExpGamma(x)), "Gamma^%s", x)
+	}
+}
+
+func TestRandomnessPool(t *testing.T) {
+	setUp(t)
+	pk := &PublicKey{N: publicKey.N}
+	_, err := NewRandomnessPool(pk, rand.Reader, 0)
+	assert.Error(t, err)
+	pool, err := NewRandomnessPool(pk, rand.Reader, 2)
+	assert.NoError(t, err)
+	assert.NoError(t, pool.Fill(context.Background()))
+	assert.Equal(t, 2, pool.Len())
+	assert.NoError(t, pk.SetRandomnessPool(pool))
+	assert.Error(t, (&PublicKey{N: big.NewInt(35)}).SetRandomnessPool(pool))
+
+	m := big.NewInt(100)
+	c1, x1, err := pk.EncryptAndReturnRandomness(rand.Reader, m)
+	assert.NoError(t, err)
+	c2, x2, err := pk.EncryptAndReturnRandomness(rand.Reader, m)
+	assert.NoError(t, err)
+	assert.NotEqual(t, 0, x1.Cmp(x2), "the randomness must be used once")
+	for i, c := range []*big.Int{c1, c2} {
+		x := []*big.Int{x1, x2}[i]
+		opened, err := pk.EncryptWithRandomness(m, x)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, c.Cmp(opened))
+		ret, err := privateKey.Decrypt(c)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, m.Cmp(ret))
+	}
+	// the pool is empty, so the randomness is drawn from the reader
+	assert.Equal(t, 0, pool.Len())
+	_, x3, err := pk.EncryptAndReturnRandomness(rand.Reader, m)
+	assert.NoError(t, err)
+	assert.NotEqual(t, 0, x3.Cmp(x1))
+	assert.NotEqual(t, 0, x3.Cmp(x2))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Equal(t, context.Canceled, pool.Fill(ctx))
+}
+
 func TestHomoMul(t *testing.T) {
 	setUp(t)
 	three, err := privateKey.Encrypt(rand.Reader, big.NewInt(3))
@@ -155,3 +254,64 @@ func TestGenerateXs(t *testing.T) {
 		assert.True(t, common.IsNumberInMultiplicativeGroup(N, xi))
 	}
 }
+
+func BenchmarkDecrypt(b *testing.B) {
+	setUp(b)
+	benchmarkDecrypt(b, privateKey)
+}
+
+// BenchmarkDecryptWithoutPrimes decrypts modulo N2, as with keys made before v2.0
+func BenchmarkDecryptWithoutPrimes(b *testing.B) {
+	setUp(b)
+	benchmarkDecrypt(b, &PrivateKey{PublicKey: privateKey.PublicKey, LambdaN: privateKey.LambdaN, PhiN: privateKey.PhiN})
+}
+
+func BenchmarkEncrypt(b *testing.B) {
+	setUp(b)
+	benchmarkEncrypt(b, &PublicKey{N: publicKey.N}, nil)
+}
+
+// BenchmarkEncryptWithRandomnessPool measures an encryption when the pool has randomness ready; the time spent
+// filling the pool is not counted.
+func BenchmarkEncryptWithRandomnessPool(b *testing.B) {
+	setUp(b)
+	pk := &PublicKey{N: publicKey.N}
+	pool, err := NewRandomnessPool(pk, rand.Reader, 16)
+	if err != nil {
+		b.Fatal(err)
+	}
+	if err := pk.SetRandomnessPool(pool); err != nil {
+		b.Fatal(err)
+	}
+	benchmarkEncrypt(b, pk, pool)
+}
+
+func benchmarkDecrypt(b *testing.B, sk *PrivateKey) {
+	c, err := publicKey.Encrypt(rand.Reader, common.GetRandomPositiveInt(rand.Reader, publicKey.N))
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		if _, err := sk.Decrypt(c); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func benchmarkEncrypt(b *testing.B, pk *PublicKey, pool *RandomnessPool) {
+	m := common.GetRandomPositiveInt(rand.Reader, pk.N)
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		if pool != nil && pool.Len() == 0 {
+			b.StopTimer()
+			if err := pool.Fill(context.Background()); err != nil {
+				b.Fatal(err)
+			}
+			b.StartTimer()
+		}
+		if _, err := pk.Encrypt(rand.Reader, m); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package paillier
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+)
+
+type (
+	// RandomnessPool holds the randomness of encryptions under a public key, computed ahead of time. Most of the cost
+	// of an encryption is x^N mod N2 for a random x, which does not depend on the message, so a pool that is filled
+	// while the parties are idle takes it off the critical path of MtA.
+	RandomnessPool struct {
+		N *big.Int
+
+		rand  io.Reader
+		pairs chan randomness
+	}
+
+	randomness struct {
+		x, xN *big.Int // xN = x^N mod N2
+	}
+)
+
+// NewRandomnessPool returns an empty pool for up to size encryptions under publicKey, whose randomness is drawn from
+// rand when it is filled
+func NewRandomnessPool(publicKey *PublicKey, rand io.Reader, size int) (*RandomnessPool, error) {
+	if size < 1 {
+		return nil, errors.New("NewRandomnessPool: the size must be at least 1")
+	}
+	return &RandomnessPool{
+		N:     publicKey.N,
+		rand:  rand,
+		pairs: make(chan randomness, size),
+	}, nil
+}
+
+// Fill computes randomness until the pool is full, and returns the error of ctx if it is done first. It takes about
+// as long as an encryption for each missing value, so it is best run while the parties are idle, such as between
+// signings; a pool that is filled during a signing competes with it for the CPU.
+func (pool *RandomnessPool) Fill(ctx context.Context) error {
+	N2 := new(big.Int).Mul(pool.N, pool.N)
+	for len(pool.pairs) < cap(pool.pairs) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		x := common.GetRandomPositiveRelativelyPrimeInt(pool.rand, pool.N)
+		select {
+		case pool.pairs <- randomness{x: x, xN: new(big.Int).Exp(x, pool.N, N2)}:
+		default:
+			// filled at the same time by another caller
+			return nil
+		}
+	}
+	return nil
+}
+
+// Len returns the number of encryptions that the pool has randomness ready for
+func (pool *RandomnessPool) Len() int {
+	return len(pool.pairs)
+}
+
+// SetRandomnessPool makes the encryptions under the key take their randomness from pool while it has some ready,
+// instead of computing it from the reader they are given. Each randomness is used once. It must be set before the
+// key is used, and may be set to nil to stop using the pool.
+func (publicKey *PublicKey) SetRandomnessPool(pool *RandomnessPool) error {
+	if pool != nil && pool.N.Cmp(publicKey.N) != 0 {
+		return errors.New("SetRandomnessPool: the pool is for another public key")
+	}
+	publicKey.randomness = pool
+	return nil
+}
+
+func (pool *RandomnessPool) take() (randomness, bool) {
+	if pool == nil {
+		return randomness{}, false
+	}
+	select {
+	case pair := <-pool.pairs:
+		return pair, true
+	default:
+		return randomness{}, false
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Zero-knowledge proof that the ring-Pedersen parameters (N, s, t) are well-formed, i.e. that s is in the group
+// generated by t (CGGMP21 Fig 17, Πprm). It runs with binary challenges, so it is repeated Iterations times.
+
+package prmproof
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+)
+
+const (
+	Iterations         = 80
+	ProofPrmBytesParts = Iterations * 2
+)
+
+type (
+	ProofPrm struct {
+		A [Iterations]*big.Int
+		Z [Iterations]*big.Int
+	}
+)
+
+// NewProof implements proofprm for s = t^lambda mod N, where phi is the order of the group generated by t
+func NewProof(Session []byte, N, s, t, lambda, phi *big.Int, rand io.Reader) (*ProofPrm, error) {
+	if N == nil || s == nil || t == nil || lambda == nil || phi == nil {
+		return nil, errors.New("ProvePrm constructor received nil value(s)")
+	}
+	modN, modPhi := common.ModInt(N), common.ModInt(phi)
+
+	// Fig 17.1
+	a := [Iterations]*big.Int{}
+	A := [Iterations]*big.Int{}
+	for i := range a {
+		a[i] = common.GetRandomPositiveInt(rand, phi)
+		A[i] = modN.Exp(t, a[i])
+	}
+
+	// Fig 17.2
+	e := challenge(Session, N, s, t, A)
+
+	// Fig 17.3
+	Z := [Iterations]*big.Int{}
+	for i := range Z {
+		Z[i] = a[i]
+		if e.Bit(i) == 1 {
+			Z[i] = modPhi.Add(a[i], lambda)
+		}
+	}
+	return &ProofPrm{A: A, Z: Z}, nil
+}
+
+func NewProofFromBytes(bzs [][]byte) (*ProofPrm, error) {
+	if !common.NonEmptyMultiBytes(bzs, ProofPrmBytesParts) {
+		return nil, fmt.Errorf("expected %d byte parts to construct ProofPrm", ProofPrmBytesParts)
+	}
+	pf := new(ProofPrm)
+	for i := 0; i < Iterations; i++ {
+		pf.A[i] = new(big.Int).SetBytes(bzs[i])
+		pf.Z[i] = new(big.Int).SetBytes(bzs[Iterations+i])
+	}
+	return pf, nil
+}
+
+func (pf *ProofPrm) Verify(Session []byte, N, s, t *big.Int) bool {
+	if pf == nil || !pf.ValidateBasic() || N == nil || s == nil || t == nil {
+		return false
+	}
+	if N.Sign() != 1 || !common.IsNumberInMultiplicativeGroup(N, s) || !common.IsNumberInMultiplicativeGroup(N, t) {
+		return false
+	}
+	for i := range pf.A {
+		if !common.IsNumberInMultiplicativeGroup(N, pf.A[i]) || !common.IsInInterval(pf.Z[i], N) {
+			return false
+		}
+	}
+	e := challenge(Session, N, s, t, pf.A)
+
+	// Fig 17. Verification
+	modN := common.ModInt(N)
+	for i := range pf.A {
+		LHS := modN.Exp(t, pf.Z[i])
+		RHS := pf.A[i]
+		if e.Bit(i) == 1 {
+			RHS = modN.Mul(RHS, s)
+		}
+		if LHS.Cmp(RHS) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (pf *ProofPrm) ValidateBasic() bool {
+	for i := range pf.A {
+		if pf.A[i] == nil || pf.Z[i] == nil {
+			return false
+		}
+	}
+	return true
+}
+
+func (pf *ProofPrm) Bytes() [ProofPrmBytesParts][]byte {
+	bzs := [ProofPrmBytesParts][]byte{}
+	for i := 0; i < Iterations; i++ {
+		bzs[i] = pf.A[i].Bytes()
+		bzs[Iterations+i] = pf.Z[i].Bytes()
+	}
+	return bzs
+}
+
+// challenge derives the Iterations challenge bits from the hash of the statement and the first messages
+func challenge(Session []byte, N, s, t *big.Int, A [Iterations]*big.Int) *big.Int {
+	return common.SHA512_256i_TAGGED(Session, append([]*big.Int{N, s, t}, A[:]...)...)
+}
@@ -0,0 +1,50 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package prmproof_test
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	. "github.com/bnb-chain/tss-lib/v2/crypto/prmproof"
+)
+
+var Session = []byte("session")
+
+func TestPrm(test *testing.T) {
+	p, q := common.GetRandomPrimeInt(rand.Reader, 1024), common.GetRandomPrimeInt(rand.Reader, 1024)
+	N := new(big.Int).Mul(p, q)
+	phi := new(big.Int).Mul(new(big.Int).Sub(p, big.NewInt(1)), new(big.Int).Sub(q, big.NewInt(1)))
+	r := common.GetRandomPositiveRelativelyPrimeInt(rand.Reader, N)
+	t := new(big.Int).Mod(new(big.Int).Mul(r, r), N)
+	lambda := common.GetRandomPositiveInt(rand.Reader, phi)
+	s := new(big.Int).Exp(t, lambda, N)
+
+	proof, err := NewProof(Session, N, s, t, lambda, phi, rand.Reader)
+	assert.NoError(test, err)
+
+	proofBzs := proof.Bytes()
+	proof, err = NewProofFromBytes(proofBzs[:])
+	assert.NoError(test, err)
+
+	ok := proof.Verify(Session, N, s, t)
+	assert.True(test, ok, "proof must verify")
+
+	ok = proof.Verify([]byte("another session"), N, s, t)
+	assert.False(test, ok, "proof must not verify in another session")
+
+	// s is not in the group generated by t
+	badS := common.GetRandomPositiveRelativelyPrimeInt(rand.Reader, N)
+	proof, err = NewProof(Session, N, badS, t, lambda, phi, rand.Reader)
+	assert.NoError(test, err)
+	ok = proof.Verify(Session, N, badS, t)
+	assert.False(test, ok, "proof must not verify")
+}
@@ -0,0 +1,319 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Package sealed implements a versioned, authenticated and encrypted at-rest container for key share data.
+//
+// A container is laid out as
+//
+//	magic "TSSSEAL" || format version (1 byte) || header length (4 bytes, big endian) || header (JSON) || nonce || ciphertext
+//
+// The payload is encrypted with AES-256-GCM under a random data key, with the format version and header as the
+// additional data so that the header cannot be altered. The checksum of the header covers the nonce and ciphertext
+// and is left out of the additional data. The data key is itself wrapped with AES-256-GCM under a key
+// encryption key, which is either given by the caller or derived from a passphrase with Argon2id.
+package sealed
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/argon2"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	FormatVersion = 1
+
+	KDFArgon2id = "argon2id"
+
+	keyLen   = 32
+	saltLen  = 16
+	nonceLen = 12
+
+	maxHeaderLen = 1 << 16
+	// bounds on the KDF parameters read from a container, so that a forged header cannot make Open exhaust memory
+	maxArgon2Memory  = 8 * 64 * 1024 // KiB, eight times the default
+	maxArgon2Time    = 64
+	maxArgon2Threads = 64
+)
+
+var (
+	magic = []byte("TSSSEAL")
+
+	// DefaultArgon2Params are the parameters used by Passphrase, following the second recommended option of RFC 9106
+	DefaultArgon2Params = Argon2Params{Time: 3, Memory: 64 * 1024, Threads: 4}
+
+	ErrNotSealed   = errors.New("sealed: the data is not a sealed container")
+	ErrCorrupted   = errors.New("sealed: the checksum of the container does not match; the data is corrupted")
+	ErrWrongKey    = errors.New("sealed: unable to unwrap the data key; the passphrase or key is wrong or the header was altered")
+	ErrTamperedBox = errors.New("sealed: unable to decrypt the payload; the container was altered")
+)
+
+type (
+	// Header is stored in the clear at the start of a container and is authenticated with the payload
+	Header struct {
+		// Protocol names the type of the payload, e.g. "ecdsa-keygen"
+		Protocol string
+		// ProtocolVersion is the version of the payload encoding for Protocol
+		ProtocolVersion uint32
+		Curve           tss.CurveName
+		Threshold       int
+		PartyCount      int
+		// Checksum is the SHA-256 of the nonce and ciphertext; it tells corruption apart from a wrong key
+		Checksum []byte
+
+		// KDF is set when the key encryption key is derived from a passphrase
+		KDF *KDFParams `json:",omitempty"`
+		// WrappedKey is the data key encrypted under the key encryption key, prefixed with its nonce
+		WrappedKey []byte
+	}
+
+	KDFParams struct {
+		Name string
+		Salt []byte
+		Argon2Params
+	}
+
+	Argon2Params struct {
+		Time, Memory uint32
+		Threads      uint8
+	}
+
+	// Key is the key encryption key of a container, made by Passphrase or WrappingKey
+	Key interface {
+		// keyEncryptionKey returns the key encryption key for the header, filling in its KDF parameters when sealing
+		keyEncryptionKey(header *Header, sealing bool) ([]byte, error)
+	}
+
+	passphraseKey struct {
+		passphrase []byte
+		params     Argon2Params
+	}
+
+	wrappingKey []byte
+)
+
+// Passphrase derives the key encryption key from a passphrase with Argon2id and the default parameters
+func Passphrase(passphrase []byte) Key {
+	return PassphraseWithParams(passphrase, DefaultArgon2Params)
+}
+
+// PassphraseWithParams derives the key encryption key from a passphrase with Argon2id. The parameters are stored in
+// the container; they only apply when sealing.
+func PassphraseWithParams(passphrase []byte, params Argon2Params) Key {
+	return &passphraseKey{passphrase: passphrase, params: params}
+}
+
+// WrappingKey uses a 32 byte key, e.g. one held in a KMS or HSM, as the key encryption key
+func WrappingKey(key []byte) Key {
+	return wrappingKey(key)
+}
+
+func (k *passphraseKey) keyEncryptionKey(header *Header, sealing bool) ([]byte, error) {
+	if len(k.passphrase) == 0 {
+		return nil, errors.New("sealed: the passphrase is empty")
+	}
+	if sealing {
+		salt, err := common.GetRandomBytes(rand.Reader, saltLen)
+		if err != nil {
+			return nil, err
+		}
+		header.KDF = &KDFParams{Name: KDFArgon2id, Salt: salt, Argon2Params: k.params}
+	}
+	kdf := header.KDF
+	if kdf == nil {
+		return nil, errors.New("sealed: the container was sealed with a wrapping key, not a passphrase")
+	}
+	if kdf.Name != KDFArgon2id {
+		return nil, fmt.Errorf("sealed: unsupported KDF %q", kdf.Name)
+	}
+	if len(kdf.Salt) < saltLen ||
+		kdf.Time == 0 || kdf.Time > maxArgon2Time ||
+		kdf.Memory == 0 || kdf.Memory > maxArgon2Memory ||
+		kdf.Threads == 0 || kdf.Threads > maxArgon2Threads {
+		return nil, errors.New("sealed: invalid KDF parameters")
+	}
+	return argon2.IDKey(k.passphrase, kdf.Salt, kdf.Time, kdf.Memory, kdf.Threads, keyLen), nil
+}
+
+func (k wrappingKey) keyEncryptionKey(header *Header, sealing bool) ([]byte, error) {
+	if len(k) != keyLen {
+		return nil, fmt.Errorf("sealed: the wrapping key must be %d bytes", keyLen)
+	}
+	if sealing {
+		header.KDF = nil
+	}
+	if header.KDF != nil {
+		return nil, errors.New("sealed: the container was sealed with a passphrase, not a wrapping key")
+	}
+	return k, nil
+}
+
+// Seal encrypts the plaintext into a container. The Checksum, KDF and WrappedKey of the header are filled in by Seal.
+func Seal(header Header, plaintext []byte, key Key) ([]byte, error) {
+	if key == nil {
+		return nil, errors.New("sealed: nil key")
+	}
+	kek, err := key.keyEncryptionKey(&header, true)
+	if err != nil {
+		return nil, err
+	}
+	dek, err := common.GetRandomBytes(rand.Reader, keyLen)
+	if err != nil {
+		return nil, err
+	}
+	if header.WrappedKey, err = encrypt(kek, dek, magic); err != nil {
+		return nil, err
+	}
+
+	nonce, err := common.GetRandomBytes(rand.Reader, nonceLen)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(dek)
+	if err != nil {
+		return nil, err
+	}
+	header.Checksum = nil
+	ad, err := additionalData(&header)
+	if err != nil {
+		return nil, err
+	}
+	box := append(nonce, aead.Seal(nil, nonce, plaintext, ad)...)
+	checksum := sha256.Sum256(box)
+	header.Checksum = checksum[:]
+
+	headerBz, err := json.Marshal(&header)
+	if err != nil {
+		return nil, err
+	}
+	var headerLen [4]byte
+	binary.BigEndian.PutUint32(headerLen[:], uint32(len(headerBz)))
+	out := make([]byte, 0, len(magic)+1+len(headerLen)+len(headerBz)+len(box))
+	out = append(out, magic...)
+	out = append(out, FormatVersion)
+	out = append(out, headerLen[:]...)
+	out = append(out, headerBz...)
+	return append(out, box...), nil
+}
+
+// ReadHeader returns the header of a container without decrypting it. The header is only authenticated by Open.
+func ReadHeader(container []byte) (*Header, error) {
+	header, _, err := parse(container)
+	return header, err
+}
+
+// Open verifies and decrypts a container, returning its header and plaintext
+func Open(container []byte, key Key) (*Header, []byte, error) {
+	if key == nil {
+		return nil, nil, errors.New("sealed: nil key")
+	}
+	header, box, err := parse(container)
+	if err != nil {
+		return nil, nil, err
+	}
+	checksum := sha256.Sum256(box)
+	if subtle.ConstantTimeCompare(checksum[:], header.Checksum) != 1 {
+		return nil, nil, ErrCorrupted
+	}
+	if len(box) < nonceLen {
+		return nil, nil, ErrCorrupted
+	}
+	kek, err := key.keyEncryptionKey(header, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	dek, err := decrypt(kek, header.WrappedKey, magic)
+	if err != nil || len(dek) != keyLen {
+		return nil, nil, ErrWrongKey
+	}
+	aead, err := newAEAD(dek)
+	if err != nil {
+		return nil, nil, err
+	}
+	unchecked := *header
+	unchecked.Checksum = nil
+	ad, err := additionalData(&unchecked)
+	if err != nil {
+		return nil, nil, err
+	}
+	plaintext, err := aead.Open(nil, box[:nonceLen], box[nonceLen:], ad)
+	if err != nil {
+		return nil, nil, ErrTamperedBox
+	}
+	return header, plaintext, nil
+}
+
+// ----- //
+
+func parse(container []byte) (*Header, []byte, error) {
+	prefixLen := len(magic) + 1 + 4
+	if len(container) < prefixLen || !bytes.Equal(container[:len(magic)], magic) {
+		return nil, nil, ErrNotSealed
+	}
+	if version := container[len(magic)]; version != FormatVersion {
+		return nil, nil, fmt.Errorf("sealed: unsupported format version %d", version)
+	}
+	headerLen := binary.BigEndian.Uint32(container[len(magic)+1 : prefixLen])
+	if headerLen > maxHeaderLen || uint32(len(container)-prefixLen) < headerLen {
+		return nil, nil, ErrCorrupted
+	}
+	header := new(Header)
+	if err := json.Unmarshal(container[prefixLen:prefixLen+int(headerLen)], header); err != nil {
+		return nil, nil, ErrCorrupted
+	}
+	return header, container[prefixLen+int(headerLen):], nil
+}
+
+// additionalData binds the format version and every header field except the checksum to the payload
+func additionalData(header *Header) ([]byte, error) {
+	headerBz, err := json.Marshal(header)
+	if err != nil {
+		return nil, err
+	}
+	return append(append(append([]byte{}, magic...), FormatVersion), headerBz...), nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func encrypt(key, plaintext, ad []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := common.GetRandomBytes(rand.Reader, nonceLen)
+	if err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, ad), nil
+}
+
+func decrypt(key, ciphertext, ad []byte) ([]byte, error) {
+	if len(ciphertext) < nonceLen {
+		return nil, errors.New("sealed: ciphertext too short")
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	return aead.Open(nil, ciphertext[:nonceLen], ciphertext[nonceLen:], ad)
+}
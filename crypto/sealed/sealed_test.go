@@ -0,0 +1,98 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package sealed_test
+
+import (
+	"crypto/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	. "github.com/bnb-chain/tss-lib/v2/crypto/sealed"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// cheap parameters, to keep the tests fast
+var testParams = Argon2Params{Time: 1, Memory: 1024, Threads: 1}
+
+func testHeader() Header {
+	return Header{Protocol: "test", ProtocolVersion: 1, Curve: "secp256k1", Threshold: 1, PartyCount: 3}
+}
+
+func TestSealOpenPassphrase(t *testing.T) {
+	plaintext := []byte("the key share")
+	container, err := Seal(testHeader(), plaintext, PassphraseWithParams([]byte("correct horse"), testParams))
+	assert.NoError(t, err)
+
+	header, err := ReadHeader(container)
+	assert.NoError(t, err)
+	assert.Equal(t, tss.CurveName("secp256k1"), header.Curve)
+	assert.Equal(t, 1, header.Threshold)
+	assert.Equal(t, 3, header.PartyCount)
+	assert.Equal(t, KDFArgon2id, header.KDF.Name)
+	assert.NotContains(t, string(container), string(plaintext))
+
+	header, opened, err := Open(container, Passphrase([]byte("correct horse")))
+	assert.NoError(t, err)
+	assert.Equal(t, plaintext, opened)
+	assert.Equal(t, "test", header.Protocol)
+
+	_, _, err = Open(container, Passphrase([]byte("wrong horse")))
+	assert.Equal(t, ErrWrongKey, err)
+	_, _, err = Open(container, WrappingKey(make([]byte, 32)))
+	assert.Error(t, err)
+}
+
+func TestSealOpenWrappingKey(t *testing.T) {
+	kek, err := common.GetRandomBytes(rand.Reader, 32)
+	assert.NoError(t, err)
+	plaintext := []byte("the key share")
+	container, err := Seal(testHeader(), plaintext, WrappingKey(kek))
+	assert.NoError(t, err)
+
+	_, opened, err := Open(container, WrappingKey(kek))
+	assert.NoError(t, err)
+	assert.Equal(t, plaintext, opened)
+
+	otherKek, err := common.GetRandomBytes(rand.Reader, 32)
+	assert.NoError(t, err)
+	_, _, err = Open(container, WrappingKey(otherKek))
+	assert.Equal(t, ErrWrongKey, err)
+	_, err = Seal(testHeader(), plaintext, WrappingKey(kek[:16]))
+	assert.Error(t, err)
+}
+
+func TestOpenDetectsCorruptionAndTampering(t *testing.T) {
+	key := PassphraseWithParams([]byte("correct horse"), testParams)
+	container, err := Seal(testHeader(), []byte("the key share"), key)
+	assert.NoError(t, err)
+
+	_, _, err = Open([]byte("{\"Xi\": 1}"), key)
+	assert.Equal(t, ErrNotSealed, err)
+
+	corrupted := append([]byte{}, container...)
+	corrupted[len(corrupted)-1] ^= 1
+	_, _, err = Open(corrupted, key)
+	assert.Equal(t, ErrCorrupted, err)
+
+	// raising the threshold in the header keeps the checksum valid but breaks the authentication of the payload
+	tampered := []byte(strings.Replace(string(container), `"Threshold":1`, `"Threshold":2`, 1))
+	assert.NotEqual(t, container, tampered)
+	_, _, err = Open(tampered, key)
+	assert.Equal(t, ErrTamperedBox, err)
+}
+
+func TestKDFParamsAreBounded(t *testing.T) {
+	// the bounds also apply to the parameters read from a header, so a forged one cannot make Open take gigabytes
+	tooMuchMemory := Argon2Params{Time: 1, Memory: 1024 * 1024, Threads: 1} // 1 GiB
+	_, err := Seal(testHeader(), []byte("the key share"), PassphraseWithParams([]byte("correct horse"), tooMuchMemory))
+	assert.Error(t, err)
+	_, err = Seal(testHeader(), []byte("the key share"), PassphraseWithParams([]byte("correct horse"), testParams))
+	assert.NoError(t, err)
+}
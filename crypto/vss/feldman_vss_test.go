@@ -115,3 +115,28 @@ func TestReconstruct(t *testing.T) {
 	assert.NoError(t, err4)
 	assert.NotZero(t, secret4)
 }
+
+func TestZeroSharing(t *testing.T) {
+	num, threshold := 5, 3
+
+	ids := make([]*big.Int, 0)
+	for i := 0; i < num; i++ {
+		ids = append(ids, common.GetRandomPositiveInt(rand.Reader, tss.EC().Params().N))
+	}
+
+	vs, shares, err := CreateZeroSharing(tss.EC(), threshold, ids, rand.Reader)
+	assert.NoError(t, err)
+	assert.Equal(t, threshold, len(vs))
+
+	for i := 0; i < num; i++ {
+		assert.True(t, shares[i].VerifyZeroSharing(tss.EC(), threshold, vs))
+	}
+
+	secret, err := shares[:threshold+1].ReConstruct(tss.EC())
+	assert.NoError(t, err)
+	assert.Zero(t, secret.Sign())
+
+	// a tampered share must not verify
+	shares[0].Share = new(big.Int).Add(shares[0].Share, big.NewInt(1))
+	assert.False(t, shares[0].VerifyZeroSharing(tss.EC(), threshold, vs))
+}
@@ -0,0 +1,175 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package auxinfo
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	cmt "github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/crypto/prmproof"
+	ecdsakeygen "github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// Implements Party
+// Implements Stringer
+var (
+	_ tss.Party    = (*LocalParty)(nil)
+	_ fmt.Stringer = (*LocalParty)(nil)
+)
+
+type (
+	LocalParty struct {
+		*tss.BaseParty
+		params *tss.Parameters
+
+		temp        localTempData
+		input, save ecdsakeygen.LocalPartySaveData
+
+		// outbound messaging
+		out chan<- tss.Message
+		end chan<- *ecdsakeygen.LocalPartySaveData
+	}
+
+	localMessageStore struct {
+		auxRound1Messages,
+		auxRound2Messages,
+		auxRound3Messages []tss.ParsedMessage
+	}
+
+	localTempData struct {
+		localMessageStore
+
+		// temp data (thrown away after the auxiliary info protocol)
+		rho         *big.Int // this party's part of the common random string
+		deCommit    cmt.HashDeCommitment
+		prmProof    *prmproof.ProofPrm // sent with the de-commitment in round 2
+		commitments []*big.Int
+		rhos        []*big.Int
+		ssid        []byte
+		ssidNonce   *big.Int
+	}
+)
+
+// Exported, used in `tss` client
+// The auxiliary info protocol is run by the parties of a CGGMP21 key after keygen (see the `cggmp/keygen` package)
+// and whenever they wish to rotate their Paillier keys and ring-Pedersen parameters. The key data sent through `end`
+// is `key` with this party's LocalPreParams and the Paillier keys, NTilde, h1 and h2 of every other party filled in.
+// Pre-params are taken from `optionalPreParams` if given, otherwise from `key` if it already has valid ones,
+// otherwise they are generated in round 1 (which may take some time).
+func NewLocalParty(
+	params *tss.Parameters,
+	key ecdsakeygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *ecdsakeygen.LocalPartySaveData,
+	optionalPreParams ...ecdsakeygen.LocalPreParams,
+) tss.Party {
+	partyCount := params.PartyCount()
+	save := ecdsakeygen.NewLocalPartySaveData(partyCount)
+	if key.LocalPreParams.ValidateWithProof() {
+		save.LocalPreParams = key.LocalPreParams
+	}
+	if 0 < len(optionalPreParams) {
+		if 1 < len(optionalPreParams) {
+			panic(errors.New("auxinfo.NewLocalParty expected 0 or 1 item in `optionalPreParams`"))
+		}
+		if !optionalPreParams[0].ValidateWithProof() {
+			panic(errors.New("`optionalPreParams` failed to validate; it might have been generated with an older version of tss-lib"))
+		}
+		save.LocalPreParams = optionalPreParams[0]
+	}
+	save.LocalSecrets = key.LocalSecrets
+	save.Ks = make([]*big.Int, len(key.Ks))
+	copy(save.Ks, key.Ks)
+	save.BigXj = make([]*crypto.ECPoint, len(key.BigXj))
+	copy(save.BigXj, key.BigXj)
+	save.ECDSAPub = key.ECDSAPub
+	p := &LocalParty{
+		BaseParty: new(tss.BaseParty),
+		params:    params,
+		temp:      localTempData{},
+		input:     key,
+		save:      save,
+		out:       out,
+		end:       end,
+	}
+	// msgs init
+	p.temp.auxRound1Messages = make([]tss.ParsedMessage, partyCount)
+	p.temp.auxRound2Messages = make([]tss.ParsedMessage, partyCount)
+	p.temp.auxRound3Messages = make([]tss.ParsedMessage, partyCount)
+	// temp data init
+	p.temp.commitments = make([]*big.Int, partyCount)
+	p.temp.rhos = make([]*big.Int, partyCount)
+	return p
+}
+
+func (p *LocalParty) FirstRound() tss.Round {
+	return newRound1(p.params, &p.input, &p.save, &p.temp, p.out, p.end)
+}
+
+func (p *LocalParty) Start() *tss.Error {
+	return tss.BaseStart(p, TaskName)
+}
+
+func (p *LocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
+	return tss.BaseUpdate(p, msg, TaskName)
+}
+
+func (p *LocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.Update(msg)
+}
+
+func (p *LocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	if ok, err := p.BaseParty.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	// check that the message's "from index" will fit into the array
+	if maxFromIdx := p.params.PartyCount() - 1; maxFromIdx < msg.GetFrom().Index {
+		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
+			p.params.PartyCount(), msg.GetFrom().Index), msg.GetFrom())
+	}
+	return true, nil
+}
+
+func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	// ValidateBasic is cheap; double-check the message here in case the public StoreMessage was called externally
+	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	fromPIdx := msg.GetFrom().Index
+
+	// switch/case is necessary to store any messages beyond current round
+	// this does not handle message replays. we expect the caller to apply replay and spoofing protection.
+	switch msg.Content().(type) {
+	case *AuxRound1Message:
+		p.temp.auxRound1Messages[fromPIdx] = msg
+	case *AuxRound2Message:
+		p.temp.auxRound2Messages[fromPIdx] = msg
+	case *AuxRound3Message:
+		p.temp.auxRound3Messages[fromPIdx] = msg
+	default: // unrecognised message, just ignore!
+		common.Logger.Warningf("unrecognised message ignored: %v", msg)
+		return false, nil
+	}
+	return true, nil
+}
+
+func (p *LocalParty) PartyID() *tss.PartyID {
+	return p.params.PartyID()
+}
+
+func (p *LocalParty) String() string {
+	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
+}
@@ -0,0 +1,111 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package auxinfo_test
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	. "github.com/bnb-chain/tss-lib/v2/ecdsa/cggmp/auxinfo"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+func TestE2EConcurrent(t *testing.T) {
+	setUp("info")
+
+	// PHASE: load keygen fixtures
+	oldKeys, pIDs, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	p2pCtx := tss.NewPeerContext(pIDs)
+
+	// PHASE: auxiliary info
+	parties := make([]*LocalParty, 0, len(pIDs))
+	errCh := make(chan *tss.Error, len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs))
+	endCh := make(chan *keygen.LocalPartySaveData, len(pIDs))
+
+	updater := test.SharedPartyUpdater
+
+	for j, pID := range pIDs {
+		params := tss.NewParameters(tss.S256(), p2pCtx, pID, len(pIDs), testThreshold)
+		// rotate the pre-params by borrowing those of the next fixture, as generating new ones is slow
+		rotated := oldKeys[(j+1)%len(oldKeys)].LocalPreParams
+		P := NewLocalParty(params, oldKeys[j], outCh, endCh, rotated).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	newKeys := make([]keygen.LocalPartySaveData, len(pIDs))
+	var ended int32
+	for {
+		select {
+		case err := <-errCh:
+			common.Logger.Errorf("Error: %s", err)
+			assert.FailNow(t, err.Error())
+			return
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else {
+				if dest[0].Index == msg.GetFrom().Index {
+					t.Fatalf("party %d tried to send a message to itself (%d)", dest[0].Index, msg.GetFrom().Index)
+				}
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case save := <-endCh:
+			index, err := save.OriginalIndex()
+			assert.NoErrorf(t, err, "should not be an error getting a party's index from save data")
+			newKeys[index] = *save
+			if atomic.AddInt32(&ended, 1) == int32(len(pIDs)) {
+				t.Logf("Done. Received auxiliary info from %d participants", ended)
+				goto checks
+			}
+		}
+	}
+
+checks:
+	for j, key := range newKeys {
+		assert.True(t, key.ECDSAPub.Equals(oldKeys[j].ECDSAPub), "the public key must not change")
+		assert.Equal(t, 0, key.Xi.Cmp(oldKeys[j].Xi), "the share must not change")
+		assert.Equal(t, oldKeys[(j+1)%len(oldKeys)].NTildei, key.NTildei, "the pre-params must be rotated")
+		for i, other := range newKeys {
+			assert.Equal(t, 0, other.NTildej[j].Cmp(key.NTildei), "party %d must see the rotated NTilde of %d", i, j)
+			assert.Equal(t, 0, other.H1j[j].Cmp(key.H1i), "party %d must see the rotated h1 of %d", i, j)
+			assert.Equal(t, 0, other.H2j[j].Cmp(key.H2i), "party %d must see the rotated h2 of %d", i, j)
+			assert.Equal(t, 0, other.PaillierPKs[j].N.Cmp(key.PaillierSK.N), "party %d must see the rotated paillier key of %d", i, j)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package auxinfo
+
+import (
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	cmt "github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/crypto/facproof"
+	"github.com/bnb-chain/tss-lib/v2/crypto/modproof"
+	"github.com/bnb-chain/tss-lib/v2/crypto/prmproof"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// These messages were generated from Protocol Buffers definitions into ecdsa-cggmp-auxinfo.pb.go
+// The following messages are registered on the Protocol Buffers "wire"
+
+var (
+	// Ensure that auxinfo messages implement ValidateBasic
+	_ = []tss.MessageContent{
+		(*AuxRound1Message)(nil),
+		(*AuxRound2Message)(nil),
+		(*AuxRound3Message)(nil),
+	}
+)
+
+// ----- //
+
+func NewAuxRound1Message(
+	from *tss.PartyID,
+	ct cmt.HashCommitment,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &AuxRound1Message{
+		Commitment: ct.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *AuxRound1Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetCommitment())
+}
+
+func (m *AuxRound1Message) UnmarshalCommitment() *big.Int {
+	return new(big.Int).SetBytes(m.GetCommitment())
+}
+
+// ----- //
+
+func NewAuxRound2Message(
+	from *tss.PartyID,
+	deCommitment cmt.HashDeCommitment,
+	proof *prmproof.ProofPrm,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	proofBzs := proof.Bytes()
+	content := &AuxRound2Message{
+		DeCommitment: common.BigIntsToBytes(deCommitment),
+		PrmProof:     proofBzs[:],
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *AuxRound2Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyMultiBytes(m.GetDeCommitment()) &&
+		common.NonEmptyMultiBytes(m.GetPrmProof(), prmproof.ProofPrmBytesParts)
+}
+
+func (m *AuxRound2Message) UnmarshalDeCommitment() []*big.Int {
+	return cmt.NewHashDeCommitmentFromBytes(m.GetDeCommitment())
+}
+
+func (m *AuxRound2Message) UnmarshalPrmProof() (*prmproof.ProofPrm, error) {
+	return prmproof.NewProofFromBytes(m.GetPrmProof())
+}
+
+// ----- //
+
+func NewAuxRound3Message(
+	to, from *tss.PartyID,
+	modProof *modproof.ProofMod,
+	facProof *facproof.ProofFac,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	modProofBzs := modProof.Bytes()
+	facProofBzs := facProof.Bytes()
+	content := &AuxRound3Message{
+		ModProof: modProofBzs[:],
+		FacProof: facProofBzs[:],
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *AuxRound3Message) ValidateBasic() bool {
+	return m != nil
+	// This is commented for backward compatibility, which msg has no proof
+	// && common.NonEmptyMultiBytes(m.GetModProof(), modproof.ProofModBytesParts) &&
+	// common.NonEmptyMultiBytes(m.GetFacProof(), facproof.ProofFacBytesParts)
+}
+
+func (m *AuxRound3Message) UnmarshalModProof() (*modproof.ProofMod, error) {
+	return modproof.NewProofFromBytes(m.GetModProof())
+}
+
+func (m *AuxRound3Message) UnmarshalFacProof() (*facproof.ProofFac, error) {
+	return facproof.NewProofFromBytes(m.GetFacProof())
+}
@@ -0,0 +1,137 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package auxinfo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	cmts "github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/crypto/prmproof"
+	ecdsakeygen "github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	// rhoBits is the length of each party's contribution to the common random string rho
+	rhoBits = 256
+)
+
+// round 1 represents round 1 of the CGGMP21 auxiliary info protocol: every party commits to its Paillier key,
+// ring-Pedersen parameters and its part of rho, and proves the parameters are well formed
+func newRound1(params *tss.Parameters, input, save *ecdsakeygen.LocalPartySaveData, temp *localTempData, out chan<- tss.Message, end chan<- *ecdsakeygen.LocalPartySaveData) tss.Round {
+	return &round1{
+		&base{params, input, save, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1},
+	}
+}
+
+func (round *round1) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	Pi := round.PartyID()
+	i := Pi.Index
+
+	// the protocol must be run by exactly the parties that hold the key
+	if err := round.checkKeyParties(round.Parties().IDs().Keys()); err != nil {
+		return round.WrapError(err)
+	}
+
+	// 1. use the pre-params given to NewLocalParty or generate new ones
+	if !round.save.LocalPreParams.ValidateWithProof() {
+		ctx, cancel := context.WithTimeout(context.Background(), round.SafePrimeGenTimeout())
+		defer cancel()
+		preParams, err := ecdsakeygen.GeneratePreParamsWithContextAndRandom(ctx, round.Rand(), round.Concurrency())
+		if err != nil {
+			return round.WrapError(errors.New("pre-params generation failed"), Pi)
+		}
+		round.save.LocalPreParams = *preParams
+	}
+	preParams := &round.save.LocalPreParams
+	round.save.NTildej[i] = preParams.NTildei
+	round.save.H1j[i], round.save.H2j[i] = preParams.H1i, preParams.H2i
+	round.save.PaillierPKs[i] = &preParams.PaillierSK.PublicKey
+
+	round.temp.ssidNonce = new(big.Int).SetUint64(0)
+	ssid, err := round.getSSID()
+	if err != nil {
+		return round.WrapError(errors.New("failed to generate ssid"))
+	}
+	round.temp.ssid = ssid
+
+	// 2. prove that h1 = h2^beta lies in the group generated by h2
+	ContextI := common.AppendBigIntToBytesSlice(ssid, big.NewInt(int64(i)))
+	phi := new(big.Int).Mul(preParams.P, preParams.Q)
+	prmProof, err := prmproof.NewProof(ContextI, preParams.NTildei, preParams.H1i, preParams.H2i, preParams.Beta, phi, round.Rand())
+	if err != nil {
+		return round.WrapError(err, Pi)
+	}
+
+	// 3. sample rho_i and make commitment -> (C, D)
+	rho := common.MustGetRandomInt(round.Rand(), rhoBits)
+	cmt := cmts.NewHashCommitment(round.Rand(), preParams.PaillierSK.N, preParams.NTildei, preParams.H1i, preParams.H2i, rho)
+	round.temp.rho = rho
+	round.temp.deCommit = cmt.D
+	round.temp.prmProof = prmProof
+
+	// BROADCAST commitment; round 1 message
+	r1msg := NewAuxRound1Message(Pi, cmt.C)
+	round.temp.auxRound1Messages[i] = r1msg
+	round.out <- tss.WithRound(r1msg, round.number)
+	return nil
+}
+
+func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*AuxRound1Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round1) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.auxRound1Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round1) NextRound() tss.Round {
+	round.started = false
+	return &round2{round}
+}
+
+// ----- //
+
+func (round *round1) checkKeyParties(ids []*big.Int) error {
+	if round.input.Xi == nil || round.input.ECDSAPub == nil {
+		return errors.New("the key data is missing Xi or ECDSAPub")
+	}
+	if len(round.input.Ks) != len(ids) || len(round.input.BigXj) != len(ids) {
+		return fmt.Errorf("the key is shared by %d parties but %d are running the protocol", len(round.input.Ks), len(ids))
+	}
+	for j, id := range ids {
+		if round.input.Ks[j].Cmp(id) != 0 {
+			return fmt.Errorf("party %d is not one of the parties that hold the key", j)
+		}
+	}
+	return nil
+}
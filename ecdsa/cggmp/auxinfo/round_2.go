@@ -0,0 +1,64 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package auxinfo
+
+import (
+	"errors"
+
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func (round *round2) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	i := round.PartyID().Index
+
+	// 1. store the commitments of round 1
+	for j, msg := range round.temp.auxRound1Messages {
+		r1msg := msg.Content().(*AuxRound1Message)
+		round.temp.commitments[j] = r1msg.UnmarshalCommitment()
+	}
+
+	// BROADCAST de-commitment and the proof of the ring-Pedersen parameters
+	r2msg := NewAuxRound2Message(round.PartyID(), round.temp.deCommit, round.temp.prmProof)
+	round.temp.auxRound2Messages[i] = r2msg
+	round.out <- tss.WithRound(r2msg, round.number)
+	return nil
+}
+
+func (round *round2) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*AuxRound2Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round2) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.auxRound2Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		// de-commitment and proof checks are in round 3
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round2) NextRound() tss.Round {
+	round.started = false
+	return &round3{round}
+}
@@ -0,0 +1,194 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package auxinfo
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/crypto/facproof"
+	"github.com/bnb-chain/tss-lib/v2/crypto/modproof"
+	"github.com/bnb-chain/tss-lib/v2/crypto/paillier"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	paillierBitsLen = 2048
+)
+
+var zero = big.NewInt(0)
+
+func (round *round3) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 3
+	round.started = true
+	round.resetOK()
+
+	Ps := round.Parties().IDs()
+	i := round.PartyID().Index
+
+	// 1. verify the de-commitments and ring-Pedersen proofs of every Pj (concurrent)
+	errs := make([]error, len(Ps))
+	done := make(chan int, len(Ps))
+	for j := range Ps {
+		if j == i {
+			continue
+		}
+		go func(j int) {
+			errs[j] = round.verifyPj(j)
+			done <- j
+		}(j)
+	}
+	for j := range Ps {
+		if j == i {
+			continue
+		}
+		<-done
+	}
+	{
+		var multiErr error
+		culprits := make([]*tss.PartyID, 0, len(Ps)) // who caused the error(s)
+		for j, err := range errs {
+			if err != nil {
+				multiErr = multierror.Append(multiErr, err)
+				culprits = append(culprits, Ps[j])
+			}
+		}
+		if len(culprits) > 0 {
+			return round.WrapError(multiErr, culprits...)
+		}
+	}
+	round.temp.rhos[i] = round.temp.rho
+
+	// 2. ensure uniqueness of h1j, h2j
+	h1H2Map := make(map[string]struct{}, len(Ps)*2)
+	for j, Pj := range Ps {
+		h1JHex, h2JHex := hex.EncodeToString(round.save.H1j[j].Bytes()), hex.EncodeToString(round.save.H2j[j].Bytes())
+		if _, found := h1H2Map[h1JHex]; found {
+			return round.WrapError(errors.New("this h1j was already used by another party"), Pj)
+		}
+		if _, found := h1H2Map[h2JHex]; found {
+			return round.WrapError(errors.New("this h2j was already used by another party"), Pj)
+		}
+		h1H2Map[h1JHex], h1H2Map[h2JHex] = struct{}{}, struct{}{}
+	}
+
+	// 3. rho = XOR of every rho_j; the proofs below are bound to it
+	rho := new(big.Int)
+	for _, rhoj := range round.temp.rhos {
+		rho.Xor(rho, rhoj)
+	}
+	round.temp.ssid = common.AppendBigIntToBytesSlice(round.temp.ssid, rho)
+
+	// 4. p2p send the proofs that N_i is a Paillier-Blum modulus and has no small factors
+	ContextI := common.AppendBigIntToBytesSlice(round.temp.ssid, big.NewInt(int64(i)))
+	modProof := &modproof.ProofMod{W: zero, X: *new([modproof.Iterations]*big.Int), A: zero, B: zero, Z: *new([modproof.Iterations]*big.Int)}
+	if !round.Params().NoProofMod() {
+		var err error
+		modProof, err = modproof.NewProof(ContextI, round.save.PaillierSK.N,
+			round.save.PaillierSK.P, round.save.PaillierSK.Q, round.Rand())
+		if err != nil {
+			return round.WrapError(err, round.PartyID())
+		}
+	}
+	for j, Pj := range Ps {
+		if j == i {
+			continue
+		}
+		facProof := &facproof.ProofFac{
+			P: zero, Q: zero, A: zero, B: zero, T: zero, Sigma: zero,
+			Z1: zero, Z2: zero, W1: zero, W2: zero, V: zero,
+		}
+		if !round.Params().NoProofFac() {
+			var err error
+			facProof, err = facproof.NewProof(ContextI, round.EC(), round.save.PaillierSK.N, round.save.NTildej[j],
+				round.save.H1j[j], round.save.H2j[j], round.save.PaillierSK.P, round.save.PaillierSK.Q, round.Rand())
+			if err != nil {
+				return round.WrapError(err, round.PartyID())
+			}
+		}
+		enc, err := tss.EncryptMessage(round.Params(), NewAuxRound3Message(Pj, round.PartyID(), modProof, facProof))
+		if err != nil {
+			return round.WrapError(err)
+		}
+		round.out <- tss.WithRound(enc, round.number)
+	}
+	return nil
+}
+
+func (round *round3) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*AuxRound3Message); ok {
+		return !msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round3) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.auxRound3Messages {
+		if round.ok[j] {
+			continue
+		}
+		if j == round.PartyID().Index {
+			round.ok[j] = true
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		// proof checks are in round 4
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round3) NextRound() tss.Round {
+	round.started = false
+	return &round4{round}
+}
+
+// ----- //
+
+// verifyPj checks the de-commitment of Pj's Paillier key, ring-Pedersen parameters and rho_j, and Pj's proof that
+// its ring-Pedersen parameters are well formed; the values are stored on success
+func (round *round3) verifyPj(j int) error {
+	ContextJ := common.AppendBigIntToBytesSlice(round.temp.ssid, big.NewInt(int64(j)))
+	r2msg := round.temp.auxRound2Messages[j].Content().(*AuxRound2Message)
+
+	cmtDeCmt := commitments.HashCommitDecommit{C: round.temp.commitments[j], D: r2msg.UnmarshalDeCommitment()}
+	ok, values := cmtDeCmt.DeCommit()
+	if !ok || len(values) != 5 {
+		return errors.New("de-commitment verify failed")
+	}
+	paillierNj, NTildej, H1j, H2j, rhoj := values[0], values[1], values[2], values[3], values[4]
+	if paillierNj.BitLen() != paillierBitsLen {
+		return errors.New("got paillier modulus with insufficient bits for this party")
+	}
+	if NTildej.BitLen() != paillierBitsLen {
+		return errors.New("got NTildej with insufficient bits for this party")
+	}
+	if H1j.Cmp(H2j) == 0 {
+		return errors.New("h1j and h2j were equal for this party")
+	}
+	prmProof, err := r2msg.UnmarshalPrmProof()
+	if err != nil || !prmProof.Verify(ContextJ, NTildej, H1j, H2j) {
+		return errors.New("prmProof verify failed")
+	}
+	round.save.PaillierPKs[j] = &paillier.PublicKey{N: paillierNj}
+	round.save.NTildej[j] = NTildej
+	round.save.H1j[j], round.save.H2j[j] = H1j, H2j
+	round.temp.rhos[j] = rhoj
+	return nil
+}
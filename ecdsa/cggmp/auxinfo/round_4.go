@@ -0,0 +1,78 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package auxinfo
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func (round *round4) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 4
+	round.started = true
+	round.resetOK()
+
+	Ps := round.Parties().IDs()
+	i := round.PartyID().Index
+
+	// 1. verify the Paillier-Blum modulus and no small factor proofs of every Pj
+	var multiErr error
+	culprits := make([]*tss.PartyID, 0, len(Ps)) // who caused the error(s)
+	for j, msg := range round.temp.auxRound3Messages {
+		round.ok[j] = true
+		if j == i {
+			continue
+		}
+		ContextJ := common.AppendBigIntToBytesSlice(round.temp.ssid, big.NewInt(int64(j)))
+		r3msg := msg.Content().(*AuxRound3Message)
+		if !round.NoProofMod() {
+			modProof, err := r3msg.UnmarshalModProof()
+			if err != nil || !modProof.Verify(ContextJ, round.save.PaillierPKs[j].N) {
+				multiErr = multierror.Append(multiErr, errors.New("modProof verify failed"))
+				culprits = append(culprits, Ps[j])
+				continue
+			}
+		}
+		if !round.NoProofFac() {
+			facProof, err := r3msg.UnmarshalFacProof()
+			if err != nil || !facProof.Verify(ContextJ, round.EC(), round.save.PaillierPKs[j].N, round.save.NTildei,
+				round.save.H1i, round.save.H2i) {
+				multiErr = multierror.Append(multiErr, errors.New("facProof verify failed"))
+				culprits = append(culprits, Ps[j])
+			}
+		}
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(multiErr, culprits...)
+	}
+
+	round.end <- round.save
+
+	return nil
+}
+
+func (round *round4) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *round4) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *round4) NextRound() tss.Round {
+	return nil // finished!
+}
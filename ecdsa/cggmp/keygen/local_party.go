@@ -0,0 +1,162 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	cmt "github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
+	ecdsakeygen "github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// Implements Party
+// Implements Stringer
+var (
+	_ tss.Party    = (*LocalParty)(nil)
+	_ fmt.Stringer = (*LocalParty)(nil)
+)
+
+type (
+	LocalParty struct {
+		*tss.BaseParty
+		params *tss.Parameters
+
+		temp localTempData
+		data ecdsakeygen.LocalPartySaveData
+
+		// outbound messaging
+		out chan<- tss.Message
+		end chan<- *ecdsakeygen.LocalPartySaveData
+	}
+
+	localMessageStore struct {
+		kgRound1Messages,
+		kgRound2Message1s,
+		kgRound2Message2s,
+		kgRound3Messages []tss.ParsedMessage
+	}
+
+	localTempData struct {
+		localMessageStore
+
+		// temp data (thrown away after keygen)
+		ui            *big.Int // used for tests
+		tau           *big.Int // the nonce of the proof of knowledge of ui
+		rid           *big.Int
+		vs            vss.Vs
+		shares        vss.Shares
+		deCommitPolyG cmt.HashDeCommitment
+		commitments   []*big.Int
+		pjVs          []vss.Vs
+		rids          []*big.Int
+		bigAs         []*crypto.ECPoint
+		ssid          []byte
+		ssidNonce     *big.Int
+	}
+)
+
+// Exported, used in `tss` client
+// The key data sent through `end` holds the secret share, ShareID, Ks, BigXj and ECDSAPub; its Paillier keys and
+// ring-Pedersen parameters are filled in by the auxiliary info protocol (see the `auxinfo` package), which must be run
+// before the key can be used for presigning.
+func NewLocalParty(
+	params *tss.Parameters,
+	out chan<- tss.Message,
+	end chan<- *ecdsakeygen.LocalPartySaveData,
+) tss.Party {
+	partyCount := params.PartyCount()
+	data := ecdsakeygen.NewLocalPartySaveData(partyCount)
+	p := &LocalParty{
+		BaseParty: new(tss.BaseParty),
+		params:    params,
+		temp:      localTempData{},
+		data:      data,
+		out:       out,
+		end:       end,
+	}
+	// msgs init
+	p.temp.kgRound1Messages = make([]tss.ParsedMessage, partyCount)
+	p.temp.kgRound2Message1s = make([]tss.ParsedMessage, partyCount)
+	p.temp.kgRound2Message2s = make([]tss.ParsedMessage, partyCount)
+	p.temp.kgRound3Messages = make([]tss.ParsedMessage, partyCount)
+	// temp data init
+	p.temp.commitments = make([]*big.Int, partyCount)
+	p.temp.pjVs = make([]vss.Vs, partyCount)
+	p.temp.rids = make([]*big.Int, partyCount)
+	p.temp.bigAs = make([]*crypto.ECPoint, partyCount)
+	return p
+}
+
+func (p *LocalParty) FirstRound() tss.Round {
+	return newRound1(p.params, &p.data, &p.temp, p.out, p.end)
+}
+
+func (p *LocalParty) Start() *tss.Error {
+	return tss.BaseStart(p, TaskName)
+}
+
+func (p *LocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
+	return tss.BaseUpdate(p, msg, TaskName)
+}
+
+func (p *LocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.Update(msg)
+}
+
+func (p *LocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	if ok, err := p.BaseParty.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	// check that the message's "from index" will fit into the array
+	if maxFromIdx := p.params.PartyCount() - 1; maxFromIdx < msg.GetFrom().Index {
+		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
+			p.params.PartyCount(), msg.GetFrom().Index), msg.GetFrom())
+	}
+	return true, nil
+}
+
+func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	// ValidateBasic is cheap; double-check the message here in case the public StoreMessage was called externally
+	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	fromPIdx := msg.GetFrom().Index
+
+	// switch/case is necessary to store any messages beyond current round
+	// this does not handle message replays. we expect the caller to apply replay and spoofing protection.
+	switch msg.Content().(type) {
+	case *KGRound1Message:
+		p.temp.kgRound1Messages[fromPIdx] = msg
+	case *KGRound2Message1:
+		p.temp.kgRound2Message1s[fromPIdx] = msg
+	case *KGRound2Message2:
+		p.temp.kgRound2Message2s[fromPIdx] = msg
+	case *KGRound3Message:
+		p.temp.kgRound3Messages[fromPIdx] = msg
+	default: // unrecognised message, just ignore!
+		common.Logger.Warningf("unrecognised message ignored: %v", msg)
+		return false, nil
+	}
+	return true, nil
+}
+
+func (p *LocalParty) PartyID() *tss.PartyID {
+	return p.params.PartyID()
+}
+
+func (p *LocalParty) String() string {
+	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
+}
@@ -0,0 +1,119 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"math/big"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
+	ecdsakeygen "github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+func TestE2EConcurrent(t *testing.T) {
+	setUp("info")
+
+	pIDs := tss.GenerateTestPartyIDs(testParticipants)
+	p2pCtx := tss.NewPeerContext(pIDs)
+	parties := make([]*LocalParty, 0, len(pIDs))
+
+	errCh := make(chan *tss.Error, len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs))
+	endCh := make(chan *ecdsakeygen.LocalPartySaveData, len(pIDs))
+
+	updater := test.SharedPartyUpdater
+
+	// init the parties
+	for i := 0; i < len(pIDs); i++ {
+		params := tss.NewParameters(tss.S256(), p2pCtx, pIDs[i], len(pIDs), testThreshold)
+		P := NewLocalParty(params, outCh, endCh).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	saves := make([]ecdsakeygen.LocalPartySaveData, len(pIDs))
+	var ended int32
+keygen:
+	for {
+		select {
+		case err := <-errCh:
+			common.Logger.Errorf("Error: %s", err)
+			assert.FailNow(t, err.Error())
+			return
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else {
+				if dest[0].Index == msg.GetFrom().Index {
+					t.Fatalf("party %d tried to send a message to itself (%d)", dest[0].Index, msg.GetFrom().Index)
+				}
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case save := <-endCh:
+			index, err := save.OriginalIndex()
+			assert.NoErrorf(t, err, "should not be an error getting a party's index from save data")
+			saves[index] = *save
+			if atomic.AddInt32(&ended, 1) == int32(len(pIDs)) {
+				t.Logf("Done. Received save data from %d participants", ended)
+				break keygen
+			}
+		}
+	}
+
+	// the public key is the sum of every u_j*G and the shares interpolate to the sum of every u_j
+	modQ := common.ModInt(tss.S256().Params().N)
+	u := big.NewInt(0)
+	for _, P := range parties {
+		u = modQ.Add(u, P.temp.ui)
+	}
+	for j, save := range saves {
+		assert.True(t, save.ECDSAPub.Equals(crypto.ScalarBaseMult(tss.S256(), u)), "ensure ECDSAPub == g^u")
+		assert.True(t, save.BigXj[j].Equals(crypto.ScalarBaseMult(tss.S256(), save.Xi)), "ensure BigX_j == g^x_j")
+		for _, other := range saves {
+			assert.True(t, other.BigXj[j].Equals(save.BigXj[j]), "all parties must agree on BigX_j")
+			assert.True(t, other.ECDSAPub.Equals(save.ECDSAPub), "all parties must agree on ECDSAPub")
+		}
+	}
+	shares := make(vss.Shares, testThreshold+1)
+	for j := range shares {
+		shares[j] = &vss.Share{Threshold: testThreshold, ID: saves[j].ShareID, Share: saves[j].Xi}
+	}
+	secret, err := shares.ReConstruct(tss.S256())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, secret.Cmp(u), "the shares must reconstruct the secret key")
+}
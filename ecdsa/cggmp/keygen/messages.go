@@ -0,0 +1,134 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	cmt "github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// These messages were generated from Protocol Buffers definitions into ecdsa-cggmp-keygen.pb.go
+// The following messages are registered on the Protocol Buffers "wire"
+
+var (
+	// Ensure that keygen messages implement ValidateBasic
+	_ = []tss.MessageContent{
+		(*KGRound1Message)(nil),
+		(*KGRound2Message1)(nil),
+		(*KGRound2Message2)(nil),
+		(*KGRound3Message)(nil),
+	}
+)
+
+// ----- //
+
+func NewKGRound1Message(
+	from *tss.PartyID,
+	ct cmt.HashCommitment,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &KGRound1Message{
+		Commitment: ct.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *KGRound1Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetCommitment())
+}
+
+func (m *KGRound1Message) UnmarshalCommitment() *big.Int {
+	return new(big.Int).SetBytes(m.GetCommitment())
+}
+
+// ----- //
+
+func NewKGRound2Message1(
+	to, from *tss.PartyID,
+	share *vss.Share,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	content := &KGRound2Message1{
+		Share: share.Share.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *KGRound2Message1) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetShare())
+}
+
+func (m *KGRound2Message1) UnmarshalShare() *big.Int {
+	return new(big.Int).SetBytes(m.Share)
+}
+
+// ----- //
+
+func NewKGRound2Message2(
+	from *tss.PartyID,
+	deCommitment cmt.HashDeCommitment,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &KGRound2Message2{
+		DeCommitment: common.BigIntsToBytes(deCommitment),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *KGRound2Message2) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyMultiBytes(m.GetDeCommitment())
+}
+
+func (m *KGRound2Message2) UnmarshalDeCommitment() []*big.Int {
+	return cmt.NewHashDeCommitmentFromBytes(m.GetDeCommitment())
+}
+
+// ----- //
+
+func NewKGRound3Message(
+	from *tss.PartyID,
+	z *big.Int,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &KGRound3Message{
+		ProofZ: z.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *KGRound3Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetProofZ())
+}
+
+func (m *KGRound3Message) UnmarshalProofZ() *big.Int {
+	return new(big.Int).SetBytes(m.GetProofZ())
+}
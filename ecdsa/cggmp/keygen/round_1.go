@@ -0,0 +1,108 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	cmts "github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
+	ecdsakeygen "github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	// ridBits is the length of each party's contribution to the common random string rid
+	ridBits = 256
+)
+
+// round 1 represents round 1 of the CGGMP21 keygen: every party commits to its Feldman VSS polynomial, its part of rid
+// and the first message A_i of its proof of knowledge of u_i
+func newRound1(params *tss.Parameters, save *ecdsakeygen.LocalPartySaveData, temp *localTempData, out chan<- tss.Message, end chan<- *ecdsakeygen.LocalPartySaveData) tss.Round {
+	return &round1{
+		&base{params, save, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1},
+	}
+}
+
+func (round *round1) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	Pi := round.PartyID()
+	i := Pi.Index
+
+	// 1. calculate "partial" key share ui and the vss shares of it
+	ui := common.GetRandomPositiveInt(round.PartialKeyRand(), round.EC().Params().N)
+	round.temp.ui = ui
+	ids := round.Parties().IDs().Keys()
+	vs, shares, err := vss.Create(round.EC(), round.Threshold(), ui, ids, round.Rand())
+	if err != nil {
+		return round.WrapError(err, Pi)
+	}
+	round.save.Ks = ids
+
+	// 2. sample rid_i and the nonce of the proof of knowledge of ui
+	rid := common.MustGetRandomInt(round.Rand(), ridBits)
+	tau := common.GetRandomPositiveInt(round.Rand(), round.EC().Params().N)
+	bigA := crypto.ScalarBaseMult(round.EC(), tau)
+
+	// 3. make commitment -> (C, D)
+	pGFlat, err := crypto.FlattenECPoints(vs)
+	if err != nil {
+		return round.WrapError(err, Pi)
+	}
+	cmt := cmts.NewHashCommitment(round.Rand(), append(pGFlat, rid, bigA.X(), bigA.Y())...)
+
+	round.temp.ssidNonce = new(big.Int).SetUint64(0)
+	round.temp.ssid = round.getSSID()
+	round.temp.vs = vs
+	round.temp.shares = shares
+	round.temp.rid = rid
+	round.temp.tau = tau
+	round.temp.deCommitPolyG = cmt.D
+	round.save.ShareID = ids[i]
+
+	// BROADCAST commitment; round 1 message
+	r1msg := NewKGRound1Message(Pi, cmt.C)
+	round.temp.kgRound1Messages[i] = r1msg
+	round.out <- tss.WithRound(r1msg, round.number)
+	return nil
+}
+
+func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*KGRound1Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round1) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.kgRound1Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round1) NextRound() tss.Round {
+	round.started = false
+	return &round2{round}
+}
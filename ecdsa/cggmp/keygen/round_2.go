@@ -0,0 +1,87 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"errors"
+
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func (round *round2) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	i := round.PartyID().Index
+
+	// 1. store the commitments
+	for j, msg := range round.temp.kgRound1Messages {
+		r1msg := msg.Content().(*KGRound1Message)
+		round.temp.commitments[j] = r1msg.UnmarshalCommitment()
+	}
+
+	// 2. p2p send share ij to Pj
+	shares := round.temp.shares
+	for j, Pj := range round.Parties().IDs() {
+		r2msg1 := NewKGRound2Message1(Pj, round.PartyID(), shares[j])
+		// do not send to this Pj, but store for round 3
+		if j == i {
+			round.temp.kgRound2Message1s[j] = r2msg1
+			continue
+		}
+		enc, err := tss.EncryptMessage(round.Params(), r2msg1)
+		if err != nil {
+			return round.WrapError(err)
+		}
+		round.out <- tss.WithRound(enc, round.number)
+	}
+
+	// 3. BROADCAST de-commitment of the polynomial, rid_i and A_i
+	r2msg2 := NewKGRound2Message2(round.PartyID(), round.temp.deCommitPolyG)
+	round.temp.kgRound2Message2s[i] = r2msg2
+	round.out <- tss.WithRound(r2msg2, round.number)
+	return nil
+}
+
+func (round *round2) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*KGRound2Message1); ok {
+		return !msg.IsBroadcast()
+	}
+	if _, ok := msg.Content().(*KGRound2Message2); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round2) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.kgRound2Message1s {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		msg2 := round.temp.kgRound2Message2s[j]
+		if msg2 == nil || !round.CanAccept(msg2) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round2) NextRound() tss.Round {
+	round.started = false
+	return &round3{round}
+}
@@ -0,0 +1,191 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func (round *round3) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 3
+	round.started = true
+	round.resetOK()
+
+	Ps := round.Parties().IDs()
+	PIdx := round.PartyID().Index
+
+	// 1-3. verify the de-commitments and the shares of every Pj (concurrent)
+	errs := make([]error, len(Ps))
+	done := make(chan int, len(Ps))
+	for j := range Ps {
+		go func(j int) {
+			errs[j] = round.verifyPj(j)
+			done <- j
+		}(j)
+	}
+	for range Ps {
+		<-done
+	}
+	{
+		var multiErr error
+		culprits := make([]*tss.PartyID, 0, len(Ps)) // who caused the error(s)
+		for j, err := range errs {
+			if err != nil {
+				multiErr = multierror.Append(multiErr, err)
+				culprits = append(culprits, Ps[j])
+			}
+		}
+		if len(culprits) > 0 {
+			return round.WrapError(multiErr, culprits...)
+		}
+	}
+
+	// 4. rid = xor of every rid_j
+	rid := new(big.Int)
+	for _, ridj := range round.temp.rids {
+		rid.Xor(rid, ridj)
+	}
+	round.temp.rid = rid
+
+	// 5. x_i = sum_j f_j(i)
+	modQ := common.ModInt(round.EC().Params().N)
+	xi := big.NewInt(0)
+	for j := range Ps {
+		r2msg1 := round.temp.kgRound2Message1s[j].Content().(*KGRound2Message1)
+		xi = modQ.Add(xi, r2msg1.UnmarshalShare())
+	}
+	round.save.Xi = xi
+
+	// 6. X_j = sum_k f_k(j)*G and y = sum_k u_k*G
+	for j, Pj := range Ps {
+		BigXj, err := evaluatePolynomialsG(round.temp.pjVs, Pj.KeyInt())
+		if err != nil {
+			return round.WrapError(errors.New("computing BigXj resulted in a point not on the curve"))
+		}
+		round.save.BigXj[j] = BigXj
+	}
+	ecdsaPub := round.temp.pjVs[0][0]
+	for j := 1; j < len(Ps); j++ {
+		var err error
+		if ecdsaPub, err = ecdsaPub.Add(round.temp.pjVs[j][0]); err != nil {
+			return round.WrapError(errors.New("public key is not on the curve"))
+		}
+	}
+	round.save.ECDSAPub = ecdsaPub
+	if !crypto.ScalarBaseMult(round.EC(), xi).Equals(round.save.BigXj[PIdx]) {
+		return round.WrapError(errors.New("the share does not match BigXj"))
+	}
+
+	// 7. BROADCAST the response of the proof of knowledge of u_i
+	e := round.schnorrChallenge(PIdx, rid, round.temp.vs[0], round.temp.bigAs[PIdx])
+	z := modQ.Add(round.temp.tau, modQ.Mul(e, round.temp.ui))
+	r3msg := NewKGRound3Message(round.PartyID(), z)
+	round.temp.kgRound3Messages[PIdx] = r3msg
+	round.out <- tss.WithRound(r3msg, round.number)
+	return nil
+}
+
+func (round *round3) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*KGRound3Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round3) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.kgRound3Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		// proof check is in round 4
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round3) NextRound() tss.Round {
+	round.started = false
+	return &round4{round}
+}
+
+// ----- //
+
+// verifyPj opens Pj's commitment to its polynomial, rid_j and A_j and checks the share Pj sent to us
+func (round *round3) verifyPj(j int) error {
+	r2msg1 := round.temp.kgRound2Message1s[j].Content().(*KGRound2Message1)
+	r2msg2 := round.temp.kgRound2Message2s[j].Content().(*KGRound2Message2)
+
+	cmtDeCmt := commitments.HashCommitDecommit{C: round.temp.commitments[j], D: r2msg2.UnmarshalDeCommitment()}
+	ok, values := cmtDeCmt.DeCommit()
+	if !ok || len(values) != 2*(round.Threshold()+1)+3 {
+		return errors.New("de-commitment verify failed")
+	}
+	flatPolyGs, rid, bigAX, bigAY := values[:len(values)-3], values[len(values)-3], values[len(values)-2], values[len(values)-1]
+	PjVs, err := crypto.UnFlattenECPoints(round.EC(), flatPolyGs)
+	if err != nil {
+		return err
+	}
+	bigA, err := crypto.NewECPoint(round.EC(), bigAX, bigAY)
+	if err != nil {
+		return err
+	}
+	PjShare := vss.Share{
+		Threshold: round.Threshold(),
+		ID:        round.PartyID().KeyInt(),
+		Share:     r2msg1.UnmarshalShare(),
+	}
+	if ok = PjShare.Verify(round.EC(), round.Threshold(), PjVs); !ok {
+		return errors.New("vss verify failed")
+	}
+	round.temp.pjVs[j], round.temp.rids[j], round.temp.bigAs[j] = PjVs, rid, bigA
+	return nil
+}
+
+// evaluatePolynomialsG computes sum_k f_k(id)*G from the Feldman commitments of every polynomial f_k
+func evaluatePolynomialsG(pjVs []vss.Vs, id *big.Int) (*crypto.ECPoint, error) {
+	var sum *crypto.ECPoint
+	for _, vs := range pjVs {
+		ec := vs[0].Curve()
+		modQ := common.ModInt(ec.Params().N)
+		// f(id)*G = sum_c id^c * v_c
+		term := vs[0]
+		z := big.NewInt(1)
+		for c := 1; c < len(vs); c++ {
+			z = modQ.Mul(z, id)
+			var err error
+			if term, err = term.Add(vs[c].ScalarMult(z)); err != nil {
+				return nil, err
+			}
+		}
+		if sum == nil {
+			sum = term
+			continue
+		}
+		var err error
+		if sum, err = sum.Add(term); err != nil {
+			return nil, err
+		}
+	}
+	return sum, nil
+}
@@ -0,0 +1,70 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"errors"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func (round *round4) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 4
+	round.started = true
+	round.resetOK()
+
+	Ps := round.Parties().IDs()
+	PIdx := round.PartyID().Index
+
+	// 1. verify the proofs of knowledge of u_j: z_j*G = A_j + e_j*X_j
+	culprits := make([]*tss.PartyID, 0, len(Ps)) // who caused the error(s)
+	for j, msg := range round.temp.kgRound3Messages {
+		round.ok[j] = true
+		if j == PIdx {
+			continue
+		}
+		r3msg := msg.Content().(*KGRound3Message)
+		Xj, Aj := round.temp.pjVs[j][0], round.temp.bigAs[j]
+		e, z := round.schnorrChallenge(j, round.temp.rid, Xj, Aj), r3msg.UnmarshalProofZ()
+		if !common.IsInInterval(z, round.EC().Params().N) {
+			culprits = append(culprits, Ps[j])
+			continue
+		}
+		zG := crypto.ScalarBaseMult(round.EC(), z)
+		rhs, err := Aj.Add(Xj.ScalarMult(e))
+		if err != nil || !zG.Equals(rhs) {
+			common.Logger.Warningf("schnorr verify failed for party %s", Ps[j])
+			culprits = append(culprits, Ps[j])
+		}
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(errors.New("schnorr proof verify failed"), culprits...)
+	}
+
+	round.end <- round.save
+
+	return nil
+}
+
+func (round *round4) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *round4) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *round4) NextRound() tss.Round {
+	return nil // finished!
+}
@@ -0,0 +1,122 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	ecdsakeygen "github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	TaskName = "ecdsa-cggmp-keygen"
+)
+
+type (
+	base struct {
+		*tss.Parameters
+		save    *ecdsakeygen.LocalPartySaveData
+		temp    *localTempData
+		out     chan<- tss.Message
+		end     chan<- *ecdsakeygen.LocalPartySaveData
+		ok      []bool // `ok` tracks parties which have been verified by Update()
+		started bool
+		number  int
+	}
+	round1 struct {
+		*base
+	}
+	round2 struct {
+		*round1
+	}
+	round3 struct {
+		*round2
+	}
+	round4 struct {
+		*round3
+	}
+)
+
+var (
+	_ tss.Round = (*round1)(nil)
+	_ tss.Round = (*round2)(nil)
+	_ tss.Round = (*round3)(nil)
+	_ tss.Round = (*round4)(nil)
+)
+
+// ----- //
+
+func (round *base) Params() *tss.Parameters {
+	return round.Parameters
+}
+
+func (round *base) RoundNumber() int {
+	return round.number
+}
+
+// Out is used by the echo broadcast of the party
+func (round *base) Out() chan<- tss.Message {
+	return round.out
+}
+
+// CanProceed is inherited by other rounds
+func (round *base) CanProceed() bool {
+	if !round.started {
+		return false
+	}
+	for _, ok := range round.ok {
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// WaitingFor is called by a Party for reporting back to the caller
+func (round *base) WaitingFor() []*tss.PartyID {
+	Ps := round.Parties().IDs()
+	ids := make([]*tss.PartyID, 0, len(round.ok))
+	for j, ok := range round.ok {
+		if ok {
+			continue
+		}
+		ids = append(ids, Ps[j])
+	}
+	return ids
+}
+
+func (round *base) WrapError(err error, culprits ...*tss.PartyID) *tss.Error {
+	return tss.NewError(err, TaskName, round.number, round.PartyID(), culprits...)
+}
+
+// ----- //
+
+// `ok` tracks parties which have been verified by Update()
+func (round *base) resetOK() {
+	for j := range round.ok {
+		round.ok[j] = false
+	}
+}
+
+// get ssid from local params
+func (round *base) getSSID() []byte {
+	ssidList := []*big.Int{round.EC().Params().P, round.EC().Params().N, round.EC().Params().Gx, round.EC().Params().Gy} // ec curve
+	ssidList = append(ssidList, round.Parties().IDs().Keys()...)                                                         // parties
+	ssidList = append(ssidList, big.NewInt(int64(round.number)))                                                         // round number
+	ssidList = append(ssidList, round.temp.ssidNonce)
+	return common.SHA512_256i(ssidList...).Bytes()
+}
+
+// schnorrChallenge is the challenge of Pj's proof of knowledge of u_j for X_j = u_j*G with the commitment A_j,
+// bound to the session and to the common random string rid
+func (round *base) schnorrChallenge(j int, rid *big.Int, X, A *crypto.ECPoint) *big.Int {
+	eHash := common.SHA512_256i_TAGGED(round.temp.ssid, rid, big.NewInt(int64(j)), X.X(), X.Y(), A.X(), A.Y())
+	return common.RejectionSample(round.EC().Params().N, eHash)
+}
@@ -0,0 +1,785 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        v3.14.0
+// source: protob/ecdsa-cggmp-signing.proto
+
+package signing
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+//
+// Represents a BROADCAST message sent during Round 1 of the CGGMP21 ECDSA TSS presigning protocol.
+type PreSignRound1Message1 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	K []byte `protobuf:"bytes,1,opt,name=k,proto3" json:"k,omitempty"`
+	G []byte `protobuf:"bytes,2,opt,name=g,proto3" json:"g,omitempty"`
+}
+
+func (x *PreSignRound1Message1) Reset() {
+	*x = PreSignRound1Message1{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_cggmp_signing_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PreSignRound1Message1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreSignRound1Message1) ProtoMessage() {}
+
+func (x *PreSignRound1Message1) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_cggmp_signing_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreSignRound1Message1.ProtoReflect.Descriptor instead.
+func (*PreSignRound1Message1) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_cggmp_signing_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PreSignRound1Message1) GetK() []byte {
+	if x != nil {
+		return x.K
+	}
+	return nil
+}
+
+func (x *PreSignRound1Message1) GetG() []byte {
+	if x != nil {
+		return x.G
+	}
+	return nil
+}
+
+//
+// Represents a P2P message sent to each party during Round 1 of the CGGMP21 ECDSA TSS presigning protocol.
+type PreSignRound1Message2 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EncProof [][]byte `protobuf:"bytes,1,rep,name=enc_proof,json=encProof,proto3" json:"enc_proof,omitempty"`
+}
+
+func (x *PreSignRound1Message2) Reset() {
+	*x = PreSignRound1Message2{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_cggmp_signing_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PreSignRound1Message2) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreSignRound1Message2) ProtoMessage() {}
+
+func (x *PreSignRound1Message2) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_cggmp_signing_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreSignRound1Message2.ProtoReflect.Descriptor instead.
+func (*PreSignRound1Message2) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_cggmp_signing_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PreSignRound1Message2) GetEncProof() [][]byte {
+	if x != nil {
+		return x.EncProof
+	}
+	return nil
+}
+
+//
+// Represents a BROADCAST message sent during Round 2 of the CGGMP21 ECDSA TSS presigning protocol.
+// The MtA ciphertexts are indexed by the receiving party.
+type PreSignRound2Message1 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BigGammaX []byte   `protobuf:"bytes,1,opt,name=big_gamma_x,json=bigGammaX,proto3" json:"big_gamma_x,omitempty"`
+	BigGammaY []byte   `protobuf:"bytes,2,opt,name=big_gamma_y,json=bigGammaY,proto3" json:"big_gamma_y,omitempty"`
+	D         [][]byte `protobuf:"bytes,3,rep,name=d,proto3" json:"d,omitempty"`
+	F         [][]byte `protobuf:"bytes,4,rep,name=f,proto3" json:"f,omitempty"`
+	DHat      [][]byte `protobuf:"bytes,5,rep,name=d_hat,json=dHat,proto3" json:"d_hat,omitempty"`
+	FHat      [][]byte `protobuf:"bytes,6,rep,name=f_hat,json=fHat,proto3" json:"f_hat,omitempty"`
+}
+
+func (x *PreSignRound2Message1) Reset() {
+	*x = PreSignRound2Message1{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_cggmp_signing_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PreSignRound2Message1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreSignRound2Message1) ProtoMessage() {}
+
+func (x *PreSignRound2Message1) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_cggmp_signing_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreSignRound2Message1.ProtoReflect.Descriptor instead.
+func (*PreSignRound2Message1) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_cggmp_signing_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PreSignRound2Message1) GetBigGammaX() []byte {
+	if x != nil {
+		return x.BigGammaX
+	}
+	return nil
+}
+
+func (x *PreSignRound2Message1) GetBigGammaY() []byte {
+	if x != nil {
+		return x.BigGammaY
+	}
+	return nil
+}
+
+func (x *PreSignRound2Message1) GetD() [][]byte {
+	if x != nil {
+		return x.D
+	}
+	return nil
+}
+
+func (x *PreSignRound2Message1) GetF() [][]byte {
+	if x != nil {
+		return x.F
+	}
+	return nil
+}
+
+func (x *PreSignRound2Message1) GetDHat() [][]byte {
+	if x != nil {
+		return x.DHat
+	}
+	return nil
+}
+
+func (x *PreSignRound2Message1) GetFHat() [][]byte {
+	if x != nil {
+		return x.FHat
+	}
+	return nil
+}
+
+//
+// Represents a P2P message sent to each party during Round 2 of the CGGMP21 ECDSA TSS presigning protocol.
+type PreSignRound2Message2 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AffgProof    [][]byte `protobuf:"bytes,1,rep,name=affg_proof,json=affgProof,proto3" json:"affg_proof,omitempty"`
+	AffgHatProof [][]byte `protobuf:"bytes,2,rep,name=affg_hat_proof,json=affgHatProof,proto3" json:"affg_hat_proof,omitempty"`
+	LogstarProof [][]byte `protobuf:"bytes,3,rep,name=logstar_proof,json=logstarProof,proto3" json:"logstar_proof,omitempty"`
+}
+
+func (x *PreSignRound2Message2) Reset() {
+	*x = PreSignRound2Message2{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_cggmp_signing_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PreSignRound2Message2) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreSignRound2Message2) ProtoMessage() {}
+
+func (x *PreSignRound2Message2) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_cggmp_signing_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreSignRound2Message2.ProtoReflect.Descriptor instead.
+func (*PreSignRound2Message2) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_cggmp_signing_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PreSignRound2Message2) GetAffgProof() [][]byte {
+	if x != nil {
+		return x.AffgProof
+	}
+	return nil
+}
+
+func (x *PreSignRound2Message2) GetAffgHatProof() [][]byte {
+	if x != nil {
+		return x.AffgHatProof
+	}
+	return nil
+}
+
+func (x *PreSignRound2Message2) GetLogstarProof() [][]byte {
+	if x != nil {
+		return x.LogstarProof
+	}
+	return nil
+}
+
+//
+// Represents a BROADCAST message sent during Round 3 of the CGGMP21 ECDSA TSS presigning protocol.
+type PreSignRound3Message1 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Delta     []byte `protobuf:"bytes,1,opt,name=delta,proto3" json:"delta,omitempty"`
+	BigDeltaX []byte `protobuf:"bytes,2,opt,name=big_delta_x,json=bigDeltaX,proto3" json:"big_delta_x,omitempty"`
+	BigDeltaY []byte `protobuf:"bytes,3,opt,name=big_delta_y,json=bigDeltaY,proto3" json:"big_delta_y,omitempty"`
+	BigSX     []byte `protobuf:"bytes,4,opt,name=big_s_x,json=bigSX,proto3" json:"big_s_x,omitempty"`
+	BigSY     []byte `protobuf:"bytes,5,opt,name=big_s_y,json=bigSY,proto3" json:"big_s_y,omitempty"`
+}
+
+func (x *PreSignRound3Message1) Reset() {
+	*x = PreSignRound3Message1{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_cggmp_signing_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PreSignRound3Message1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreSignRound3Message1) ProtoMessage() {}
+
+func (x *PreSignRound3Message1) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_cggmp_signing_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreSignRound3Message1.ProtoReflect.Descriptor instead.
+func (*PreSignRound3Message1) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_cggmp_signing_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PreSignRound3Message1) GetDelta() []byte {
+	if x != nil {
+		return x.Delta
+	}
+	return nil
+}
+
+func (x *PreSignRound3Message1) GetBigDeltaX() []byte {
+	if x != nil {
+		return x.BigDeltaX
+	}
+	return nil
+}
+
+func (x *PreSignRound3Message1) GetBigDeltaY() []byte {
+	if x != nil {
+		return x.BigDeltaY
+	}
+	return nil
+}
+
+func (x *PreSignRound3Message1) GetBigSX() []byte {
+	if x != nil {
+		return x.BigSX
+	}
+	return nil
+}
+
+func (x *PreSignRound3Message1) GetBigSY() []byte {
+	if x != nil {
+		return x.BigSY
+	}
+	return nil
+}
+
+//
+// Represents a P2P message sent to each party during Round 3 of the CGGMP21 ECDSA TSS presigning protocol.
+type PreSignRound3Message2 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	LogstarProof [][]byte `protobuf:"bytes,1,rep,name=logstar_proof,json=logstarProof,proto3" json:"logstar_proof,omitempty"`
+}
+
+func (x *PreSignRound3Message2) Reset() {
+	*x = PreSignRound3Message2{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_cggmp_signing_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PreSignRound3Message2) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreSignRound3Message2) ProtoMessage() {}
+
+func (x *PreSignRound3Message2) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_cggmp_signing_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreSignRound3Message2.ProtoReflect.Descriptor instead.
+func (*PreSignRound3Message2) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_cggmp_signing_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *PreSignRound3Message2) GetLogstarProof() [][]byte {
+	if x != nil {
+		return x.LogstarProof
+	}
+	return nil
+}
+
+//
+// Represents a BROADCAST message sent by each party when the check of delta failed in the CGGMP21 ECDSA TSS presigning
+// protocol. It opens the ciphertexts K_i and G_i and the parts of the MtA ciphertexts D_ji chosen by party i.
+type PreSignBlameMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	K              []byte   `protobuf:"bytes,1,opt,name=k,proto3" json:"k,omitempty"`
+	Rho            []byte   `protobuf:"bytes,2,opt,name=rho,proto3" json:"rho,omitempty"`
+	Gamma          []byte   `protobuf:"bytes,3,opt,name=gamma,proto3" json:"gamma,omitempty"`
+	Nu             []byte   `protobuf:"bytes,4,opt,name=nu,proto3" json:"nu,omitempty"`
+	Beta           [][]byte `protobuf:"bytes,5,rep,name=beta,proto3" json:"beta,omitempty"`
+	BetaRandomness [][]byte `protobuf:"bytes,6,rep,name=beta_randomness,json=betaRandomness,proto3" json:"beta_randomness,omitempty"`
+}
+
+func (x *PreSignBlameMessage) Reset() {
+	*x = PreSignBlameMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_cggmp_signing_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PreSignBlameMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreSignBlameMessage) ProtoMessage() {}
+
+func (x *PreSignBlameMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_cggmp_signing_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreSignBlameMessage.ProtoReflect.Descriptor instead.
+func (*PreSignBlameMessage) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_cggmp_signing_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PreSignBlameMessage) GetK() []byte {
+	if x != nil {
+		return x.K
+	}
+	return nil
+}
+
+func (x *PreSignBlameMessage) GetRho() []byte {
+	if x != nil {
+		return x.Rho
+	}
+	return nil
+}
+
+func (x *PreSignBlameMessage) GetGamma() []byte {
+	if x != nil {
+		return x.Gamma
+	}
+	return nil
+}
+
+func (x *PreSignBlameMessage) GetNu() []byte {
+	if x != nil {
+		return x.Nu
+	}
+	return nil
+}
+
+func (x *PreSignBlameMessage) GetBeta() [][]byte {
+	if x != nil {
+		return x.Beta
+	}
+	return nil
+}
+
+func (x *PreSignBlameMessage) GetBetaRandomness() [][]byte {
+	if x != nil {
+		return x.BetaRandomness
+	}
+	return nil
+}
+
+//
+// Represents a BROADCAST message sent during the online round of the CGGMP21 ECDSA TSS signing protocol.
+type SignRound1Message struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PresignatureId []byte `protobuf:"bytes,1,opt,name=presignature_id,json=presignatureId,proto3" json:"presignature_id,omitempty"`
+	Sigma          []byte `protobuf:"bytes,2,opt,name=sigma,proto3" json:"sigma,omitempty"`
+}
+
+func (x *SignRound1Message) Reset() {
+	*x = SignRound1Message{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_cggmp_signing_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignRound1Message) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignRound1Message) ProtoMessage() {}
+
+func (x *SignRound1Message) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_cggmp_signing_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignRound1Message.ProtoReflect.Descriptor instead.
+func (*SignRound1Message) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_cggmp_signing_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SignRound1Message) GetPresignatureId() []byte {
+	if x != nil {
+		return x.PresignatureId
+	}
+	return nil
+}
+
+func (x *SignRound1Message) GetSigma() []byte {
+	if x != nil {
+		return x.Sigma
+	}
+	return nil
+}
+
+var File_protob_ecdsa_cggmp_signing_proto protoreflect.FileDescriptor
+
+var file_protob_ecdsa_cggmp_signing_proto_rawDesc = []byte{
+	0x0a, 0x20, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x2f, 0x65, 0x63, 0x64, 0x73, 0x61, 0x2d, 0x63,
+	0x67, 0x67, 0x6d, 0x70, 0x2d, 0x73, 0x69, 0x67, 0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x22, 0x62, 0x69, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x74, 0x73, 0x73, 0x6c,
+	0x69, 0x62, 0x2e, 0x65, 0x63, 0x64, 0x73, 0x61, 0x2e, 0x63, 0x67, 0x67, 0x6d, 0x70, 0x2e, 0x73,
+	0x69, 0x67, 0x6e, 0x69, 0x6e, 0x67, 0x22, 0x33, 0x0a, 0x15, 0x50, 0x72, 0x65, 0x53, 0x69, 0x67,
+	0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x31, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x31, 0x12,
+	0x0c, 0x0a, 0x01, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x6b, 0x12, 0x0c, 0x0a,
+	0x01, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x67, 0x22, 0x34, 0x0a, 0x15, 0x50,
+	0x72, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x31, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x32, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x6e, 0x63, 0x5f, 0x70, 0x72, 0x6f, 0x6f,
+	0x66, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x08, 0x65, 0x6e, 0x63, 0x50, 0x72, 0x6f, 0x6f,
+	0x66, 0x22, 0x9d, 0x01, 0x0a, 0x15, 0x50, 0x72, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75,
+	0x6e, 0x64, 0x32, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x31, 0x12, 0x1e, 0x0a, 0x0b, 0x62,
+	0x69, 0x67, 0x5f, 0x67, 0x61, 0x6d, 0x6d, 0x61, 0x5f, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x09, 0x62, 0x69, 0x67, 0x47, 0x61, 0x6d, 0x6d, 0x61, 0x58, 0x12, 0x1e, 0x0a, 0x0b, 0x62,
+	0x69, 0x67, 0x5f, 0x67, 0x61, 0x6d, 0x6d, 0x61, 0x5f, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x09, 0x62, 0x69, 0x67, 0x47, 0x61, 0x6d, 0x6d, 0x61, 0x59, 0x12, 0x0c, 0x0a, 0x01, 0x64,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x01, 0x64, 0x12, 0x0c, 0x0a, 0x01, 0x66, 0x18, 0x04,
+	0x20, 0x03, 0x28, 0x0c, 0x52, 0x01, 0x66, 0x12, 0x13, 0x0a, 0x05, 0x64, 0x5f, 0x68, 0x61, 0x74,
+	0x18, 0x05, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x48, 0x61, 0x74, 0x12, 0x13, 0x0a, 0x05,
+	0x66, 0x5f, 0x68, 0x61, 0x74, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x04, 0x66, 0x48, 0x61,
+	0x74, 0x22, 0x81, 0x01, 0x0a, 0x15, 0x50, 0x72, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75,
+	0x6e, 0x64, 0x32, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x32, 0x12, 0x1d, 0x0a, 0x0a, 0x61,
+	0x66, 0x66, 0x67, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52,
+	0x09, 0x61, 0x66, 0x66, 0x67, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x24, 0x0a, 0x0e, 0x61, 0x66,
+	0x66, 0x67, 0x5f, 0x68, 0x61, 0x74, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0c, 0x52, 0x0c, 0x61, 0x66, 0x66, 0x67, 0x48, 0x61, 0x74, 0x50, 0x72, 0x6f, 0x6f, 0x66,
+	0x12, 0x23, 0x0a, 0x0d, 0x6c, 0x6f, 0x67, 0x73, 0x74, 0x61, 0x72, 0x5f, 0x70, 0x72, 0x6f, 0x6f,
+	0x66, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0c, 0x6c, 0x6f, 0x67, 0x73, 0x74, 0x61, 0x72,
+	0x50, 0x72, 0x6f, 0x6f, 0x66, 0x22, 0x9d, 0x01, 0x0a, 0x15, 0x50, 0x72, 0x65, 0x53, 0x69, 0x67,
+	0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x33, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x31, 0x12,
+	0x14, 0x0a, 0x05, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05,
+	0x64, 0x65, 0x6c, 0x74, 0x61, 0x12, 0x1e, 0x0a, 0x0b, 0x62, 0x69, 0x67, 0x5f, 0x64, 0x65, 0x6c,
+	0x74, 0x61, 0x5f, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x62, 0x69, 0x67, 0x44,
+	0x65, 0x6c, 0x74, 0x61, 0x58, 0x12, 0x1e, 0x0a, 0x0b, 0x62, 0x69, 0x67, 0x5f, 0x64, 0x65, 0x6c,
+	0x74, 0x61, 0x5f, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x62, 0x69, 0x67, 0x44,
+	0x65, 0x6c, 0x74, 0x61, 0x59, 0x12, 0x16, 0x0a, 0x07, 0x62, 0x69, 0x67, 0x5f, 0x73, 0x5f, 0x78,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x62, 0x69, 0x67, 0x53, 0x58, 0x12, 0x16, 0x0a,
+	0x07, 0x62, 0x69, 0x67, 0x5f, 0x73, 0x5f, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05,
+	0x62, 0x69, 0x67, 0x53, 0x59, 0x22, 0x3c, 0x0a, 0x15, 0x50, 0x72, 0x65, 0x53, 0x69, 0x67, 0x6e,
+	0x52, 0x6f, 0x75, 0x6e, 0x64, 0x33, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x32, 0x12, 0x23,
+	0x0a, 0x0d, 0x6c, 0x6f, 0x67, 0x73, 0x74, 0x61, 0x72, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0c, 0x6c, 0x6f, 0x67, 0x73, 0x74, 0x61, 0x72, 0x50, 0x72,
+	0x6f, 0x6f, 0x66, 0x22, 0x98, 0x01, 0x0a, 0x13, 0x50, 0x72, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x42,
+	0x6c, 0x61, 0x6d, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0c, 0x0a, 0x01, 0x6b,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x6b, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x68, 0x6f,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x72, 0x68, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x67,
+	0x61, 0x6d, 0x6d, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x67, 0x61, 0x6d, 0x6d,
+	0x61, 0x12, 0x0e, 0x0a, 0x02, 0x6e, 0x75, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x6e,
+	0x75, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x65, 0x74, 0x61, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0c, 0x52,
+	0x04, 0x62, 0x65, 0x74, 0x61, 0x12, 0x27, 0x0a, 0x0f, 0x62, 0x65, 0x74, 0x61, 0x5f, 0x72, 0x61,
+	0x6e, 0x64, 0x6f, 0x6d, 0x6e, 0x65, 0x73, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0e,
+	0x62, 0x65, 0x74, 0x61, 0x52, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x6e, 0x65, 0x73, 0x73, 0x22, 0x52,
+	0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x31, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x70, 0x72, 0x65, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x70, 0x72,
+	0x65, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05,
+	0x73, 0x69, 0x67, 0x6d, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x73, 0x69, 0x67,
+	0x6d, 0x61, 0x42, 0x15, 0x5a, 0x13, 0x65, 0x63, 0x64, 0x73, 0x61, 0x2f, 0x63, 0x67, 0x67, 0x6d,
+	0x70, 0x2f, 0x73, 0x69, 0x67, 0x6e, 0x69, 0x6e, 0x67, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
+}
+
+var (
+	file_protob_ecdsa_cggmp_signing_proto_rawDescOnce sync.Once
+	file_protob_ecdsa_cggmp_signing_proto_rawDescData = file_protob_ecdsa_cggmp_signing_proto_rawDesc
+)
+
+func file_protob_ecdsa_cggmp_signing_proto_rawDescGZIP() []byte {
+	file_protob_ecdsa_cggmp_signing_proto_rawDescOnce.Do(func() {
+		file_protob_ecdsa_cggmp_signing_proto_rawDescData = protoimpl.X.CompressGZIP(file_protob_ecdsa_cggmp_signing_proto_rawDescData)
+	})
+	return file_protob_ecdsa_cggmp_signing_proto_rawDescData
+}
+
+var file_protob_ecdsa_cggmp_signing_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_protob_ecdsa_cggmp_signing_proto_goTypes = []interface{}{
+	(*PreSignRound1Message1)(nil), // 0: binance.tsslib.ecdsa.cggmp.signing.PreSignRound1Message1
+	(*PreSignRound1Message2)(nil), // 1: binance.tsslib.ecdsa.cggmp.signing.PreSignRound1Message2
+	(*PreSignRound2Message1)(nil), // 2: binance.tsslib.ecdsa.cggmp.signing.PreSignRound2Message1
+	(*PreSignRound2Message2)(nil), // 3: binance.tsslib.ecdsa.cggmp.signing.PreSignRound2Message2
+	(*PreSignRound3Message1)(nil), // 4: binance.tsslib.ecdsa.cggmp.signing.PreSignRound3Message1
+	(*PreSignRound3Message2)(nil), // 5: binance.tsslib.ecdsa.cggmp.signing.PreSignRound3Message2
+	(*PreSignBlameMessage)(nil),   // 6: binance.tsslib.ecdsa.cggmp.signing.PreSignBlameMessage
+	(*SignRound1Message)(nil),     // 7: binance.tsslib.ecdsa.cggmp.signing.SignRound1Message
+}
+var file_protob_ecdsa_cggmp_signing_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_protob_ecdsa_cggmp_signing_proto_init() }
+func file_protob_ecdsa_cggmp_signing_proto_init() {
+	if File_protob_ecdsa_cggmp_signing_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_protob_ecdsa_cggmp_signing_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PreSignRound1Message1); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_cggmp_signing_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PreSignRound1Message2); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_cggmp_signing_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PreSignRound2Message1); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_cggmp_signing_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PreSignRound2Message2); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_cggmp_signing_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PreSignRound3Message1); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_cggmp_signing_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PreSignRound3Message2); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_cggmp_signing_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PreSignBlameMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_cggmp_signing_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignRound1Message); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_protob_ecdsa_cggmp_signing_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_protob_ecdsa_cggmp_signing_proto_goTypes,
+		DependencyIndexes: file_protob_ecdsa_cggmp_signing_proto_depIdxs,
+		MessageInfos:      file_protob_ecdsa_cggmp_signing_proto_msgTypes,
+	}.Build()
+	File_protob_ecdsa_cggmp_signing_proto = out.File
+	file_protob_ecdsa_cggmp_signing_proto_rawDesc = nil
+	file_protob_ecdsa_cggmp_signing_proto_goTypes = nil
+	file_protob_ecdsa_cggmp_signing_proto_depIdxs = nil
+}
@@ -0,0 +1,62 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func TestE2EPreSignWithEchoBroadcast(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	p2pCtx := tss.NewPeerContext(signPIDs)
+
+	parties := make([]*LocalParty, 0, len(signPIDs))
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *PreSignatureData, len(signPIDs))
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		params.SetEchoBroadcast(true)
+		P := NewPreSignLocalParty(params, keys[i], outCh, endCh).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	echoes := 0
+	preSigs := make([]*PreSignatureData, 0, len(signPIDs))
+	for len(preSigs) < len(signPIDs) {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+		case msg := <-outCh:
+			if _, ok := msg.(tss.ParsedMessage).Content().(*tss.EchoMessage); ok {
+				echoes++
+			}
+			routeTestMessage(t, parties, msg, errCh, test.SharedPartyUpdater)
+		case preSig := <-endCh:
+			preSigs = append(preSigs, preSig)
+		}
+	}
+	assert.NotZero(t, echoes, "the broadcasts must be echoed")
+	for _, preSig := range preSigs {
+		assert.Equal(t, preSigs[0].ID, preSig.ID, "all parties should agree on the presignature id")
+		assert.True(t, preSigs[0].BigR.Equals(preSig.BigR), "all parties should agree on R")
+	}
+}
@@ -0,0 +1,261 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	ecdsakeygen "github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// Implements Party
+// Implements Stringer
+var (
+	_ tss.Party    = (*LocalParty)(nil)
+	_ fmt.Stringer = (*LocalParty)(nil)
+)
+
+type (
+	LocalParty struct {
+		*tss.BaseParty
+		params *tss.Parameters
+
+		keys ecdsakeygen.LocalPartySaveData
+		temp localTempData
+		data *common.SignatureData
+
+		// outbound messaging
+		out    chan<- tss.Message
+		end    chan<- *common.SignatureData
+		preEnd chan<- *PreSignatureData
+	}
+
+	localMessageStore struct {
+		preSignRound1Message1s,
+		preSignRound1Message2s,
+		preSignRound2Message1s,
+		preSignRound2Message2s,
+		preSignRound3Message1s,
+		preSignRound3Message2s,
+		preSignBlameMessages,
+		signRound1Messages []tss.ParsedMessage
+	}
+
+	localTempData struct {
+		localMessageStore
+
+		// temp data (thrown away after presigning) / round 1
+		w,
+		k,
+		rho,
+		gamma,
+		nu *big.Int
+		bigWs []*crypto.ECPoint
+		Ks,
+		Gs []*big.Int
+
+		// round 2
+		bigGammas []*crypto.ECPoint
+		betas,
+		betaRandomness,
+		betaHats []*big.Int
+
+		// round 3
+		delta,
+		chi *big.Int
+		bigGamma,
+		bigDelta *crypto.ECPoint
+
+		ssidNonce *big.Int
+		ssid      []byte
+
+		// online signing with a presignature
+		m           *big.Int
+		preSig      *PreSignatureData
+		nonceLedger tss.NonceLedger
+		rx,
+		ry,
+		sigma *big.Int
+		fullBytesLen int
+
+		// set once the check of delta failed and the parties reveal their blame messages
+		abort bool
+	}
+)
+
+// NewPreSignLocalParty returns a party that runs the CGGMP21 presigning protocol with the other parties in `params`,
+// who must all hold a share of `key` with its auxiliary info (see the `auxinfo` package). A PreSignatureData is sent
+// through `end` that can later be used to sign one message in a single round with NewLocalParty.
+func NewPreSignLocalParty(
+	params *tss.Parameters,
+	key ecdsakeygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *PreSignatureData,
+) tss.Party {
+	p := newLocalParty(nil, params, key, nil, out, nil)
+	p.preEnd = end
+	return p
+}
+
+// NewLocalParty returns a party that signs msg in one round using a presignature made earlier by the same set of
+// parties. The ID of the presignature is recorded in the ledger as the party starts, before its signature share is
+// sent, and the party fails with tss.ErrNonceUsed if it was recorded before; the ledger must be durable and must
+// outlive every copy of the presignature. The presignature is wiped as well.
+func NewLocalParty(
+	msg *big.Int,
+	params *tss.Parameters,
+	key ecdsakeygen.LocalPartySaveData,
+	preSig *PreSignatureData,
+	ledger tss.NonceLedger,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+	fullBytesLen ...int,
+) tss.Party {
+	if preSig == nil {
+		panic(errors.New("signing.NewLocalParty requires a presignature"))
+	}
+	p := newLocalParty(msg, params, key, preSig, out, end, fullBytesLen...)
+	p.temp.nonceLedger = ledger
+	return p
+}
+
+func newLocalParty(
+	msg *big.Int,
+	params *tss.Parameters,
+	key ecdsakeygen.LocalPartySaveData,
+	preSig *PreSignatureData,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+	fullBytesLen ...int,
+) *LocalParty {
+	partyCount := len(params.Parties().IDs())
+	p := &LocalParty{
+		BaseParty: new(tss.BaseParty),
+		params:    params,
+		keys:      ecdsakeygen.BuildLocalSaveDataSubset(key, params.Parties().IDs()),
+		temp:      localTempData{},
+		data:      &common.SignatureData{},
+		out:       out,
+		end:       end,
+	}
+	// msgs init
+	p.temp.preSignRound1Message1s = make([]tss.ParsedMessage, partyCount)
+	p.temp.preSignRound1Message2s = make([]tss.ParsedMessage, partyCount)
+	p.temp.preSignRound2Message1s = make([]tss.ParsedMessage, partyCount)
+	p.temp.preSignRound2Message2s = make([]tss.ParsedMessage, partyCount)
+	p.temp.preSignRound3Message1s = make([]tss.ParsedMessage, partyCount)
+	p.temp.preSignRound3Message2s = make([]tss.ParsedMessage, partyCount)
+	p.temp.preSignBlameMessages = make([]tss.ParsedMessage, partyCount)
+	p.temp.signRound1Messages = make([]tss.ParsedMessage, partyCount)
+	// temp data init
+	p.temp.m = msg
+	p.temp.preSig = preSig
+	if len(fullBytesLen) > 0 {
+		p.temp.fullBytesLen = fullBytesLen[0]
+	}
+	p.temp.Ks = make([]*big.Int, partyCount)
+	p.temp.Gs = make([]*big.Int, partyCount)
+	p.temp.bigGammas = make([]*crypto.ECPoint, partyCount)
+	p.temp.betas = make([]*big.Int, partyCount)
+	p.temp.betaRandomness = make([]*big.Int, partyCount)
+	p.temp.betaHats = make([]*big.Int, partyCount)
+	return p
+}
+
+func (p *LocalParty) FirstRound() tss.Round {
+	if p.temp.preSig != nil {
+		return newSignRound(p.params, &p.keys, p.data, &p.temp, p.out, p.end)
+	}
+	return newRound1(p.params, &p.keys, &p.temp, p.out, p.preEnd)
+}
+
+func (p *LocalParty) Start() *tss.Error {
+	return tss.BaseStart(p, TaskName, func(round tss.Round) *tss.Error {
+		if _, ok := round.(*signRound); ok {
+			// the key share was already used during presigning
+			return nil
+		}
+		round1, ok := round.(*round1)
+		if !ok {
+			return round.WrapError(errors.New("unable to Start(). party is in an unexpected round"))
+		}
+		if err := round1.prepare(); err != nil {
+			return round.WrapError(err)
+		}
+		return nil
+	})
+}
+
+func (p *LocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
+	return tss.BaseUpdate(p, msg, TaskName)
+}
+
+func (p *LocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.Update(msg)
+}
+
+func (p *LocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	if ok, err := p.BaseParty.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	// check that the message's "from index" will fit into the array
+	if maxFromIdx := len(p.params.Parties().IDs()) - 1; maxFromIdx < msg.GetFrom().Index {
+		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
+			maxFromIdx, msg.GetFrom().Index), msg.GetFrom())
+	}
+	return true, nil
+}
+
+func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	// ValidateBasic is cheap; double-check the message here in case the public StoreMessage was called externally
+	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	fromPIdx := msg.GetFrom().Index
+
+	// switch/case is necessary to store any messages beyond current round
+	// this does not handle message replays. we expect the caller to apply replay and spoofing protection.
+	switch msg.Content().(type) {
+	case *PreSignRound1Message1:
+		p.temp.preSignRound1Message1s[fromPIdx] = msg
+	case *PreSignRound1Message2:
+		p.temp.preSignRound1Message2s[fromPIdx] = msg
+	case *PreSignRound2Message1:
+		p.temp.preSignRound2Message1s[fromPIdx] = msg
+	case *PreSignRound2Message2:
+		p.temp.preSignRound2Message2s[fromPIdx] = msg
+	case *PreSignRound3Message1:
+		p.temp.preSignRound3Message1s[fromPIdx] = msg
+	case *PreSignRound3Message2:
+		p.temp.preSignRound3Message2s[fromPIdx] = msg
+	case *PreSignBlameMessage:
+		p.temp.preSignBlameMessages[fromPIdx] = msg
+	case *SignRound1Message:
+		p.temp.signRound1Messages[fromPIdx] = msg
+	default: // unrecognised message, just ignore!
+		common.Logger.Warningf("unrecognised message ignored: %v", msg)
+		return false, nil
+	}
+	return true, nil
+}
+
+func (p *LocalParty) PartyID() *tss.PartyID {
+	return p.params.PartyID()
+}
+
+func (p *LocalParty) String() string {
+	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
+}
@@ -0,0 +1,302 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+func TestE2EPreSignAndSign(t *testing.T) {
+	setUp("info")
+
+	// PHASE: load keygen fixtures
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	updater := test.SharedPartyUpdater
+
+	// PHASE: presigning
+	preParties := make([]*LocalParty, 0, len(signPIDs))
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	preEndChs := make([]chan *PreSignatureData, len(signPIDs))
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		preEndChs[i] = make(chan *PreSignatureData, 1)
+		P := NewPreSignLocalParty(params, keys[i], outCh, preEndChs[i]).(*LocalParty)
+		preParties = append(preParties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	// the presignatures are collected per party as they hold different shares
+	endedCh := make(chan int, len(signPIDs))
+	preSigs := make([]*PreSignatureData, len(signPIDs))
+	for i, ch := range preEndChs {
+		go func(i int, ch <-chan *PreSignatureData) {
+			preSigs[i] = <-ch
+			endedCh <- i
+		}(i, ch)
+	}
+	for ended := 0; ended < len(signPIDs); {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+		case msg := <-outCh:
+			routeTestMessage(t, preParties, msg, errCh, updater)
+		case <-endedCh:
+			ended++
+		}
+	}
+	for _, preSig := range preSigs {
+		assert.True(t, preSig.ValidateBasic())
+		assert.Equal(t, preSigs[0].ID, preSig.ID, "all parties should agree on the presignature id")
+		assert.True(t, preSigs[0].BigR.Equals(preSig.BigR), "all parties should agree on R")
+		for j := range signPIDs {
+			assert.True(t, preSigs[0].BigRj[j].Equals(preSig.BigRj[j]), "all parties should agree on R_j")
+			assert.True(t, preSigs[0].BigSj[j].Equals(preSig.BigSj[j]), "all parties should agree on S_j")
+		}
+	}
+
+	// presignatures are serializable; sign with the decoded copies
+	backups := make([][]byte, len(signPIDs))
+	for i, preSig := range preSigs {
+		bz, err := json.Marshal(preSig)
+		assert.NoError(t, err)
+		decoded := new(PreSignatureData)
+		assert.NoError(t, json.Unmarshal(bz, decoded))
+		preSigs[i], backups[i] = decoded, bz
+	}
+
+	// PHASE: signing
+	msg := big.NewInt(42)
+	parties := make([]*LocalParty, 0, len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+	ledgers := make([]tss.NonceLedger, len(signPIDs))
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		ledgers[i] = newNonceLedger(t)
+		P := NewLocalParty(msg, params, keys[i], preSigs[i], ledgers[i], outCh, endCh).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	var sigData *common.SignatureData
+	for ended := 0; ended < len(signPIDs); {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+		case msg := <-outCh:
+			routeTestMessage(t, parties, msg, errCh, updater)
+		case sigData = <-endCh:
+			ended++
+		}
+	}
+
+	pk := ecdsa.PublicKey{
+		Curve: tss.EC(),
+		X:     keys[0].ECDSAPub.X(),
+		Y:     keys[0].ECDSAPub.Y(),
+	}
+	ok := ecdsa.Verify(&pk, msg.Bytes(), new(big.Int).SetBytes(sigData.R), new(big.Int).SetBytes(sigData.S))
+	assert.True(t, ok, "ecdsa verify must pass")
+
+	// PHASE: a presignature cannot be used twice
+	for _, preSig := range preSigs {
+		assert.True(t, preSig.Used())
+		assert.Nil(t, preSig.K)
+		assert.Nil(t, preSig.Chi)
+	}
+	params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[0], len(signPIDs), testThreshold)
+	P := NewLocalParty(big.NewInt(43), params, keys[0], preSigs[0], ledgers[0], outCh, endCh)
+	tssErr := P.Start()
+	if assert.NotNil(t, tssErr) {
+		assert.ErrorIs(t, tssErr.Cause(), ErrPreSignatureUsed)
+	}
+
+	// nor can a copy of it restored from a backup, which the ledger refuses
+	restored := new(PreSignatureData)
+	assert.NoError(t, json.Unmarshal(backups[0], restored))
+	assert.False(t, restored.Used())
+	P = NewLocalParty(big.NewInt(43), params, keys[0], restored, ledgers[0], outCh, endCh)
+	tssErr = P.Start()
+	if assert.NotNil(t, tssErr) {
+		assert.ErrorIs(t, tssErr.Cause(), tss.ErrNonceUsed)
+	}
+	assert.Equal(t, 0, len(outCh), "no signature share should be sent with a used presignature")
+
+	// and none is used without a ledger
+	restored = new(PreSignatureData)
+	assert.NoError(t, json.Unmarshal(backups[1], restored))
+	P = NewLocalParty(big.NewInt(43), params, keys[0], restored, nil, outCh, endCh)
+	assert.NotNil(t, P.Start())
+	assert.False(t, restored.Used(), "the presignature should not be consumed without a ledger")
+}
+
+func TestIdentifyAbortWrongBeta(t *testing.T) {
+	setUp("info")
+	const cheater = 1
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixtures(testThreshold + 1)
+	assert.NoError(t, err, "should load keygen fixtures")
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]*LocalParty, 0, len(signPIDs))
+	preEndCh := make(chan *PreSignatureData, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs)*len(signPIDs)*10)
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		parties = append(parties, NewPreSignLocalParty(params, keys[i], outCh, preEndCh).(*LocalParty))
+	}
+
+	// once its MtA ciphertexts are made the cheater uses another beta_ij for delta_i, so the check of delta fails
+	tampered := false
+	errs := runWithCheater(t, parties, outCh, func(tss.Message) {
+		if betas := parties[cheater].temp.betas; !tampered && betas[0] != nil {
+			betas[0] = new(big.Int).Add(betas[0], big.NewInt(1))
+			tampered = true
+		}
+	})
+	assert.Equal(t, 0, len(preEndCh), "no party should output a presignature")
+	assertCulprit(t, errs, signPIDs, cheater, 5)
+}
+
+func TestIdentifyWrongSigma(t *testing.T) {
+	setUp("info")
+	const cheater = 2
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixtures(testThreshold + 1)
+	assert.NoError(t, err, "should load keygen fixtures")
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	n := len(signPIDs)
+	outCh := make(chan tss.Message, n*n*10)
+
+	preParties := make([]*LocalParty, 0, n)
+	preEndChs := make([]chan *PreSignatureData, n)
+	for i := 0; i < n; i++ {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], n, testThreshold)
+		preEndChs[i] = make(chan *PreSignatureData, 1)
+		preParties = append(preParties, NewPreSignLocalParty(params, keys[i], outCh, preEndChs[i]).(*LocalParty))
+	}
+	errs := runWithCheater(t, preParties, outCh, func(tss.Message) {})
+	preSigs := make([]*PreSignatureData, n)
+	for i := 0; i < n; i++ {
+		if !assert.Nil(t, errs[i]) || !assert.Equal(t, 1, len(preEndChs[i])) {
+			return
+		}
+		preSigs[i] = <-preEndChs[i]
+	}
+
+	// the cheater signs with a k_i that does not match its R_i
+	preSigs[cheater].K = new(big.Int).Add(preSigs[cheater].K, big.NewInt(1))
+	parties := make([]*LocalParty, 0, n)
+	endCh := make(chan *common.SignatureData, n)
+	for i := 0; i < n; i++ {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], n, testThreshold)
+		parties = append(parties, NewLocalParty(big.NewInt(42), params, keys[i], preSigs[i], newNonceLedger(t), outCh, endCh).(*LocalParty))
+	}
+	errs = runWithCheater(t, parties, outCh, func(tss.Message) {})
+	assert.Equal(t, 0, len(endCh), "no party should output a signature")
+	assertCulprit(t, errs, signPIDs, cheater, 2)
+}
+
+// ----- //
+
+// runWithCheater delivers the messages of the parties one at a time so that `tamper` can change the state of a party
+// right after it sent a message, and returns the error of each party
+func runWithCheater(t *testing.T, parties []*LocalParty, outCh chan tss.Message, tamper func(msg tss.Message)) []*tss.Error {
+	n := len(parties)
+	errs := make([]*tss.Error, n)
+	errCh := make(chan *tss.Error, n*n*10)
+	for _, P := range parties {
+		if err := P.Start(); err != nil {
+			errs[P.PartyID().Index] = err
+		}
+	}
+	for 0 < len(outCh) {
+		msg := <-outCh
+		tamper(msg)
+		for _, P := range parties {
+			if P.PartyID().Index == msg.GetFrom().Index {
+				continue
+			}
+			if (msg.IsBroadcast() || msg.GetTo()[0].Index == P.PartyID().Index) && errs[P.PartyID().Index] == nil {
+				test.SharedPartyUpdater(P, msg, errCh)
+			}
+			select {
+			case err := <-errCh:
+				errs[P.PartyID().Index] = err
+			default:
+			}
+		}
+	}
+	return errs
+}
+
+func assertCulprit(t *testing.T, errs []*tss.Error, signPIDs tss.SortedPartyIDs, cheater, round int) {
+	for j, err := range errs {
+		if !assert.NotNilf(t, err, "party %d should abort", j) {
+			continue
+		}
+		assert.Equal(t, round, err.Round(), err.Error())
+		if assert.Equal(t, 1, len(err.Culprits()), err.Error()) {
+			assert.Equal(t, signPIDs[cheater], err.Culprits()[0])
+		}
+	}
+}
+
+func routeTestMessage(t *testing.T, parties []*LocalParty, msg tss.Message, errCh chan<- *tss.Error, updater func(tss.Party, tss.Message, chan<- *tss.Error)) {
+	dest := msg.GetTo()
+	if dest == nil {
+		for _, P := range parties {
+			if P.PartyID().Index == msg.GetFrom().Index {
+				continue
+			}
+			go updater(P, msg, errCh)
+		}
+		return
+	}
+	if dest[0].Index == msg.GetFrom().Index {
+		t.Fatalf("party %d tried to send a message to itself (%d)", dest[0].Index, msg.GetFrom().Index)
+	}
+	go updater(parties[dest[0].Index], msg, errCh)
+}
+
+// newNonceLedger returns a ledger of its own for a party
+func newNonceLedger(t *testing.T) tss.NonceLedger {
+	ledger, err := tss.NewFileNonceLedger(t.TempDir())
+	assert.NoError(t, err)
+	return ledger
+}
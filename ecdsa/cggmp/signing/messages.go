@@ -0,0 +1,362 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/elliptic"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/affgproof"
+	"github.com/bnb-chain/tss-lib/v2/crypto/encproof"
+	"github.com/bnb-chain/tss-lib/v2/crypto/logstarproof"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// These messages were generated from Protocol Buffers definitions into ecdsa-cggmp-signing.pb.go
+// The following messages are registered on the Protocol Buffers "wire"
+
+var (
+	// Ensure that signing messages implement ValidateBasic
+	_ = []tss.MessageContent{
+		(*PreSignRound1Message1)(nil),
+		(*PreSignRound1Message2)(nil),
+		(*PreSignRound2Message1)(nil),
+		(*PreSignRound2Message2)(nil),
+		(*PreSignRound3Message1)(nil),
+		(*PreSignRound3Message2)(nil),
+		(*PreSignBlameMessage)(nil),
+		(*SignRound1Message)(nil),
+	}
+)
+
+// ----- //
+
+func NewPreSignRound1Message1(
+	from *tss.PartyID,
+	K, G *big.Int,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &PreSignRound1Message1{
+		K: K.Bytes(),
+		G: G.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *PreSignRound1Message1) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetK()) &&
+		common.NonEmptyBytes(m.GetG())
+}
+
+func (m *PreSignRound1Message1) UnmarshalK() *big.Int {
+	return new(big.Int).SetBytes(m.GetK())
+}
+
+func (m *PreSignRound1Message1) UnmarshalG() *big.Int {
+	return new(big.Int).SetBytes(m.GetG())
+}
+
+// ----- //
+
+func NewPreSignRound1Message2(
+	to, from *tss.PartyID,
+	proof *encproof.ProofEnc,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	proofBzs := proof.Bytes()
+	content := &PreSignRound1Message2{
+		EncProof: proofBzs[:],
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *PreSignRound1Message2) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyMultiBytes(m.GetEncProof(), encproof.ProofEncBytesParts)
+}
+
+func (m *PreSignRound1Message2) UnmarshalEncProof() (*encproof.ProofEnc, error) {
+	return encproof.NewProofFromBytes(m.GetEncProof())
+}
+
+// ----- //
+
+// NewPreSignRound2Message1 broadcasts Gamma_i and the MtA ciphertexts of this party, indexed by the receiving party.
+// The entries at the sender's own index are empty.
+func NewPreSignRound2Message1(
+	from *tss.PartyID,
+	bigGamma *crypto.ECPoint,
+	Ds, Fs, DHats, FHats []*big.Int,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &PreSignRound2Message1{
+		BigGammaX: bigGamma.X().Bytes(),
+		BigGammaY: bigGamma.Y().Bytes(),
+		D:         common.BigIntsToBytes(Ds),
+		F:         common.BigIntsToBytes(Fs),
+		DHat:      common.BigIntsToBytes(DHats),
+		FHat:      common.BigIntsToBytes(FHats),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *PreSignRound2Message1) ValidateBasic() bool {
+	if m == nil ||
+		!common.NonEmptyBytes(m.GetBigGammaX()) ||
+		!common.NonEmptyBytes(m.GetBigGammaY()) {
+		return false
+	}
+	n := len(m.GetD())
+	return 0 < n && len(m.GetF()) == n && len(m.GetDHat()) == n && len(m.GetFHat()) == n
+}
+
+func (m *PreSignRound2Message1) UnmarshalBigGamma(ec elliptic.Curve) (*crypto.ECPoint, error) {
+	return crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(m.GetBigGammaX()),
+		new(big.Int).SetBytes(m.GetBigGammaY()))
+}
+
+func (m *PreSignRound2Message1) UnmarshalD(j int) *big.Int {
+	return new(big.Int).SetBytes(m.GetD()[j])
+}
+
+func (m *PreSignRound2Message1) UnmarshalF(j int) *big.Int {
+	return new(big.Int).SetBytes(m.GetF()[j])
+}
+
+func (m *PreSignRound2Message1) UnmarshalDHat(j int) *big.Int {
+	return new(big.Int).SetBytes(m.GetDHat()[j])
+}
+
+func (m *PreSignRound2Message1) UnmarshalFHat(j int) *big.Int {
+	return new(big.Int).SetBytes(m.GetFHat()[j])
+}
+
+// ----- //
+
+func NewPreSignRound2Message2(
+	to, from *tss.PartyID,
+	affgProof, affgHatProof *affgproof.ProofAffg,
+	logstarProof *logstarproof.ProofLogstar,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	affgBzs := affgProof.Bytes()
+	affgHatBzs := affgHatProof.Bytes()
+	logstarBzs := logstarProof.Bytes()
+	content := &PreSignRound2Message2{
+		AffgProof:    affgBzs[:],
+		AffgHatProof: affgHatBzs[:],
+		LogstarProof: logstarBzs[:],
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *PreSignRound2Message2) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyMultiBytes(m.GetAffgProof(), affgproof.ProofAffgBytesParts) &&
+		common.NonEmptyMultiBytes(m.GetAffgHatProof(), affgproof.ProofAffgBytesParts) &&
+		common.NonEmptyMultiBytes(m.GetLogstarProof(), logstarproof.ProofLogstarBytesParts)
+}
+
+func (m *PreSignRound2Message2) UnmarshalAffgProof(ec elliptic.Curve) (*affgproof.ProofAffg, error) {
+	return affgproof.NewProofFromBytes(ec, m.GetAffgProof())
+}
+
+func (m *PreSignRound2Message2) UnmarshalAffgHatProof(ec elliptic.Curve) (*affgproof.ProofAffg, error) {
+	return affgproof.NewProofFromBytes(ec, m.GetAffgHatProof())
+}
+
+func (m *PreSignRound2Message2) UnmarshalLogstarProof(ec elliptic.Curve) (*logstarproof.ProofLogstar, error) {
+	return logstarproof.NewProofFromBytes(ec, m.GetLogstarProof())
+}
+
+// ----- //
+
+func NewPreSignRound3Message1(
+	from *tss.PartyID,
+	delta *big.Int,
+	bigDelta, bigS *crypto.ECPoint,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &PreSignRound3Message1{
+		Delta:     delta.Bytes(),
+		BigDeltaX: bigDelta.X().Bytes(),
+		BigDeltaY: bigDelta.Y().Bytes(),
+		BigSX:     bigS.X().Bytes(),
+		BigSY:     bigS.Y().Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *PreSignRound3Message1) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetDelta()) &&
+		common.NonEmptyBytes(m.GetBigDeltaX()) &&
+		common.NonEmptyBytes(m.GetBigDeltaY()) &&
+		common.NonEmptyBytes(m.GetBigSX()) &&
+		common.NonEmptyBytes(m.GetBigSY())
+}
+
+func (m *PreSignRound3Message1) UnmarshalDelta() *big.Int {
+	return new(big.Int).SetBytes(m.GetDelta())
+}
+
+func (m *PreSignRound3Message1) UnmarshalBigDelta(ec elliptic.Curve) (*crypto.ECPoint, error) {
+	return crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(m.GetBigDeltaX()),
+		new(big.Int).SetBytes(m.GetBigDeltaY()))
+}
+
+func (m *PreSignRound3Message1) UnmarshalBigS(ec elliptic.Curve) (*crypto.ECPoint, error) {
+	return crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(m.GetBigSX()),
+		new(big.Int).SetBytes(m.GetBigSY()))
+}
+
+// ----- //
+
+func NewPreSignRound3Message2(
+	to, from *tss.PartyID,
+	proof *logstarproof.ProofLogstar,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	proofBzs := proof.Bytes()
+	content := &PreSignRound3Message2{
+		LogstarProof: proofBzs[:],
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *PreSignRound3Message2) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyMultiBytes(m.GetLogstarProof(), logstarproof.ProofLogstarBytesParts)
+}
+
+func (m *PreSignRound3Message2) UnmarshalLogstarProof(ec elliptic.Curve) (*logstarproof.ProofLogstar, error) {
+	return logstarproof.NewProofFromBytes(ec, m.GetLogstarProof())
+}
+
+// ----- //
+
+// NewPreSignBlameMessage opens K_i, G_i and the additive shares beta_ij this party chose for the MtA ciphertexts
+// D_ji, together with their randomness s_ij. The entries at the sender's own index are empty.
+func NewPreSignBlameMessage(
+	from *tss.PartyID,
+	k, rho, gamma, nu *big.Int,
+	betas, betaRandomness []*big.Int,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &PreSignBlameMessage{
+		K:              k.Bytes(),
+		Rho:            rho.Bytes(),
+		Gamma:          gamma.Bytes(),
+		Nu:             nu.Bytes(),
+		Beta:           common.BigIntsToBytes(betas),
+		BetaRandomness: common.BigIntsToBytes(betaRandomness),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *PreSignBlameMessage) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetK()) &&
+		common.NonEmptyBytes(m.GetRho()) &&
+		common.NonEmptyBytes(m.GetGamma()) &&
+		common.NonEmptyBytes(m.GetNu()) &&
+		0 < len(m.GetBeta()) &&
+		len(m.GetBeta()) == len(m.GetBetaRandomness())
+}
+
+func (m *PreSignBlameMessage) UnmarshalK() *big.Int {
+	return new(big.Int).SetBytes(m.GetK())
+}
+
+func (m *PreSignBlameMessage) UnmarshalRho() *big.Int {
+	return new(big.Int).SetBytes(m.GetRho())
+}
+
+func (m *PreSignBlameMessage) UnmarshalGamma() *big.Int {
+	return new(big.Int).SetBytes(m.GetGamma())
+}
+
+func (m *PreSignBlameMessage) UnmarshalNu() *big.Int {
+	return new(big.Int).SetBytes(m.GetNu())
+}
+
+func (m *PreSignBlameMessage) UnmarshalBeta(j int) *big.Int {
+	return new(big.Int).SetBytes(m.GetBeta()[j])
+}
+
+func (m *PreSignBlameMessage) UnmarshalBetaRandomness(j int) *big.Int {
+	return new(big.Int).SetBytes(m.GetBetaRandomness()[j])
+}
+
+// ----- //
+
+func NewSignRound1Message(
+	from *tss.PartyID,
+	preSignatureID []byte,
+	sigma *big.Int,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &SignRound1Message{
+		PresignatureId: preSignatureID,
+		Sigma:          sigma.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *SignRound1Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetPresignatureId()) &&
+		common.NonEmptyBytes(m.GetSigma())
+}
+
+func (m *SignRound1Message) UnmarshalSigma() *big.Int {
+	return new(big.Int).SetBytes(m.GetSigma())
+}
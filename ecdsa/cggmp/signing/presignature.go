@@ -0,0 +1,78 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+)
+
+var ErrPreSignatureUsed = errors.New("presignature has already been used")
+
+type (
+	// PreSignatureData is the output of a CGGMP21 presigning session (see NewPreSignLocalParty).
+	// K and Chi are secret and must be stored as carefully as the key share itself, and a presignature must only
+	// ever be used to sign ONE message; NewLocalParty records the ID in a tss.NonceLedger before it signs, which
+	// refuses any copy of a used presignature, and wipes K and Chi.
+	PreSignatureData struct {
+		// ID is shared by all of the parties that took part in the same presigning session
+		ID       []byte
+		Ks       []*big.Int
+		ECDSAPub *crypto.ECPoint
+		BigR     *crypto.ECPoint
+		// BigRj[j] = k_j*R and BigSj[j] = chi_j*R let the parties check each other's signature shares
+		BigRj []*crypto.ECPoint
+		BigSj []*crypto.ECPoint
+		K     *big.Int
+		Chi   *big.Int
+
+		mtx sync.Mutex
+	}
+)
+
+// ValidateBasic checks that the presignature is complete
+func (pre *PreSignatureData) ValidateBasic() bool {
+	if pre == nil ||
+		len(pre.ID) == 0 ||
+		len(pre.Ks) == 0 ||
+		pre.ECDSAPub == nil ||
+		pre.BigR == nil || !pre.BigR.ValidateBasic() ||
+		len(pre.BigRj) != len(pre.Ks) ||
+		len(pre.BigSj) != len(pre.Ks) ||
+		pre.K == nil || pre.K.Sign() <= 0 ||
+		pre.Chi == nil {
+		return false
+	}
+	for j := range pre.Ks {
+		if pre.BigRj[j] == nil || !pre.BigRj[j].ValidateBasic() || pre.BigSj[j] == nil || !pre.BigSj[j].ValidateBasic() {
+			return false
+		}
+	}
+	return true
+}
+
+// Used returns true once the presignature has been handed to an online signing round
+func (pre *PreSignatureData) Used() bool {
+	pre.mtx.Lock()
+	defer pre.mtx.Unlock()
+	return pre.K == nil
+}
+
+// consume returns the secret nonce shares and wipes them from the record, so that this copy cannot be used again
+func (pre *PreSignatureData) consume() (k, chi *big.Int, err error) {
+	pre.mtx.Lock()
+	defer pre.mtx.Unlock()
+	if pre.K == nil || pre.Chi == nil {
+		return nil, nil, ErrPreSignatureUsed
+	}
+	k, chi = pre.K, pre.Chi
+	pre.K, pre.Chi = nil, nil
+	return k, chi, nil
+}
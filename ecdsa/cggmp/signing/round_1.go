@@ -0,0 +1,157 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto/encproof"
+	ecdsakeygen "github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	ecdsasigning "github.com/bnb-chain/tss-lib/v2/ecdsa/signing"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+var zero = big.NewInt(0)
+
+// round 1 represents round 1 of the CGGMP21 presigning protocol: every party encrypts its nonce share k_i and its
+// mask gamma_i under its own Paillier key and proves to every other party that k_i is in range
+func newRound1(params *tss.Parameters, key *ecdsakeygen.LocalPartySaveData, temp *localTempData, out chan<- tss.Message, preEnd chan<- *PreSignatureData) tss.Round {
+	return &round1{
+		&base{params, key, nil, temp, out, nil, preEnd, make([]bool, len(params.Parties().IDs())), false, 1},
+	}
+}
+
+func (round *round1) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	Pi := round.PartyID()
+	i := Pi.Index
+
+	round.temp.ssidNonce = new(big.Int).SetUint64(0)
+	ssid, err := round.getSSID()
+	if err != nil {
+		return round.WrapError(err)
+	}
+	round.temp.ssid = ssid
+
+	// 1. sample k_i, gamma_i and encrypt them: K_i = enc_i(k_i; rho_i), G_i = enc_i(gamma_i; nu_i)
+	q := round.EC().Params().N
+	k := common.GetRandomPositiveInt(round.Rand(), q)
+	gamma := common.GetRandomPositiveInt(round.Rand(), q)
+	paillierPK := &round.key.PaillierSK.PublicKey
+	K, rho, err := paillierPK.EncryptAndReturnRandomness(round.Rand(), k)
+	if err != nil {
+		return round.WrapError(err, Pi)
+	}
+	G, nu, err := paillierPK.EncryptAndReturnRandomness(round.Rand(), gamma)
+	if err != nil {
+		return round.WrapError(err, Pi)
+	}
+	round.temp.k, round.temp.rho = k, rho
+	round.temp.gamma, round.temp.nu = gamma, nu
+	round.temp.Ks[i], round.temp.Gs[i] = K, G
+
+	// 2. p2p send the proofs that K_i encrypts a value in range, made with Pj's ring-Pedersen parameters
+	ContextI := round.contextJ(i)
+	for j, Pj := range round.Parties().IDs() {
+		if j == i {
+			continue
+		}
+		proof, err := encproof.NewProof(ContextI, round.EC(), paillierPK, K, round.key.NTildej[j], round.key.H1j[j], round.key.H2j[j], k, rho, round.Rand())
+		if err != nil {
+			return round.WrapError(err, Pi)
+		}
+		enc, err := tss.EncryptMessage(round.Params(), NewPreSignRound1Message2(Pj, Pi, proof))
+		if err != nil {
+			return round.WrapError(err)
+		}
+		round.out <- tss.WithRound(enc, round.number)
+	}
+
+	// BROADCAST K_i, G_i
+	r1msg1 := NewPreSignRound1Message1(Pi, K, G)
+	round.temp.preSignRound1Message1s[i] = r1msg1
+	round.out <- tss.WithRound(r1msg1, round.number)
+	return nil
+}
+
+func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*PreSignRound1Message1); ok {
+		return msg.IsBroadcast()
+	}
+	if _, ok := msg.Content().(*PreSignRound1Message2); ok {
+		return !msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round1) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg1 := range round.temp.preSignRound1Message1s {
+		if round.ok[j] {
+			continue
+		}
+		if j == round.PartyID().Index {
+			round.ok[j] = true
+			continue
+		}
+		if msg1 == nil || !round.CanAccept(msg1) {
+			ret = false
+			continue
+		}
+		msg2 := round.temp.preSignRound1Message2s[j]
+		if msg2 == nil || !round.CanAccept(msg2) {
+			ret = false
+			continue
+		}
+		// proof checks are in round 2
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round1) NextRound() tss.Round {
+	round.started = false
+	return &round2{round}
+}
+
+// ----- //
+
+// prepare checks the key data and computes the additive share w_i of the key held by this set of signers
+func (round *round1) prepare() error {
+	i := round.PartyID().Index
+	ks := round.key.Ks
+
+	if round.key.Xi == nil || round.key.PaillierSK == nil {
+		return errors.New("the key data is missing the secret share or the Paillier key")
+	}
+	if len(round.key.PaillierPKs) != len(ks) || len(round.key.NTildej) != len(ks) ||
+		len(round.key.H1j) != len(ks) || len(round.key.H2j) != len(ks) {
+		return errors.New("the key data is missing the auxiliary info of the other parties")
+	}
+	for j := range ks {
+		if round.key.PaillierPKs[j] == nil || round.key.NTildej[j] == nil || round.key.H1j[j] == nil || round.key.H2j[j] == nil {
+			return fmt.Errorf("the key data is missing the auxiliary info of party %d", j)
+		}
+	}
+	if round.Threshold()+1 > len(ks) {
+		return fmt.Errorf("t+1=%d is not satisfied by the key count of %d", round.Threshold()+1, len(ks))
+	}
+	wi, bigWs := ecdsasigning.PrepareForSigning(round.EC(), i, len(ks), round.key.Xi, ks, round.key.BigXj)
+
+	round.temp.w = wi
+	round.temp.bigWs = bigWs
+	return nil
+}
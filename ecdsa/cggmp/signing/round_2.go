@@ -0,0 +1,236 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/affgproof"
+	"github.com/bnb-chain/tss-lib/v2/crypto/logstarproof"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func (round *round2) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	Ps := round.Parties().IDs()
+	Pi := round.PartyID()
+	i := Pi.Index
+
+	// 1. verify the range proofs of K_j (concurrent)
+	errs := make([]error, len(Ps))
+	done := make(chan int, len(Ps))
+	for j := range Ps {
+		if j == i {
+			continue
+		}
+		go func(j int) {
+			errs[j] = round.verifyRound1(j)
+			done <- j
+		}(j)
+	}
+	for j := range Ps {
+		if j == i {
+			continue
+		}
+		<-done
+	}
+	if err := culpritsError(errs, Ps); err != nil {
+		return round.WrapError(err.err, err.culprits...)
+	}
+
+	// 2. Gamma_i = gamma_i*G
+	bigGamma := crypto.ScalarBaseMult(round.EC(), round.temp.gamma)
+	round.temp.bigGammas[i] = bigGamma
+
+	// 3. the MtA with every Pj for k_j*gamma_i and k_j*w_i, with the proofs made with Pj's ring-Pedersen parameters
+	Ds, Fs := make([]*big.Int, len(Ps)), make([]*big.Int, len(Ps))
+	DHats, FHats := make([]*big.Int, len(Ps)), make([]*big.Int, len(Ps))
+	r2msg2s := make([]tss.ParsedMessage, len(Ps))
+	for j := range Ps {
+		if j == i {
+			Ds[j], Fs[j], DHats[j], FHats[j] = zero, zero, zero, zero
+			continue
+		}
+		go func(j int) {
+			errs[j] = round.mta(j, bigGamma, Ds, Fs, DHats, FHats, r2msg2s)
+			done <- j
+		}(j)
+	}
+	for j := range Ps {
+		if j == i {
+			continue
+		}
+		<-done
+	}
+	for _, err := range errs {
+		if err != nil {
+			return round.WrapError(err, Pi)
+		}
+	}
+
+	// p2p send the proofs
+	for j, msg := range r2msg2s {
+		if j == i {
+			continue
+		}
+		enc, err := tss.EncryptMessage(round.Params(), msg)
+		if err != nil {
+			return round.WrapError(err)
+		}
+		round.out <- tss.WithRound(enc, round.number)
+	}
+
+	// BROADCAST Gamma_i and the MtA ciphertexts
+	r2msg1 := NewPreSignRound2Message1(Pi, bigGamma, Ds, Fs, DHats, FHats)
+	round.temp.preSignRound2Message1s[i] = r2msg1
+	round.out <- tss.WithRound(r2msg1, round.number)
+	return nil
+}
+
+func (round *round2) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*PreSignRound2Message1); ok {
+		return msg.IsBroadcast()
+	}
+	if _, ok := msg.Content().(*PreSignRound2Message2); ok {
+		return !msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round2) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg1 := range round.temp.preSignRound2Message1s {
+		if round.ok[j] {
+			continue
+		}
+		if j == round.PartyID().Index {
+			round.ok[j] = true
+			continue
+		}
+		if msg1 == nil || !round.CanAccept(msg1) {
+			ret = false
+			continue
+		}
+		msg2 := round.temp.preSignRound2Message2s[j]
+		if msg2 == nil || !round.CanAccept(msg2) {
+			ret = false
+			continue
+		}
+		// proof checks are in round 3
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round2) NextRound() tss.Round {
+	round.started = false
+	return &round3{round}
+}
+
+// ----- //
+
+// verifyRound1 checks that K_j and G_j are well formed and verifies Pj's proof that K_j encrypts a value in range;
+// the ciphertexts are stored on success
+func (round *round2) verifyRound1(j int) error {
+	r1msg1 := round.temp.preSignRound1Message1s[j].Content().(*PreSignRound1Message1)
+	r1msg2 := round.temp.preSignRound1Message2s[j].Content().(*PreSignRound1Message2)
+	pkj := round.key.PaillierPKs[j]
+	Kj, Gj := r1msg1.UnmarshalK(), r1msg1.UnmarshalG()
+	if !common.IsNumberInMultiplicativeGroup(pkj.NSquare(), Gj) {
+		return errors.New("G_j is not a valid ciphertext")
+	}
+	proof, err := r1msg2.UnmarshalEncProof()
+	if err != nil || !proof.Verify(round.contextJ(j), round.EC(), pkj, Kj, round.key.NTildei, round.key.H1i, round.key.H2i) {
+		return errors.New("encProof verify failed")
+	}
+	round.temp.Ks[j], round.temp.Gs[j] = Kj, Gj
+	return nil
+}
+
+// mta computes D_ji = gamma_i*K_j + enc_j(beta_ij) and F_ji = enc_i(beta_ij), the same for w_i with beta_hat_ij,
+// and the proofs for Pj. Pj decrypts alpha_ji = k_j*gamma_i + beta_ij, so the share of party i is -beta_ij.
+func (round *round2) mta(j int, bigGamma *crypto.ECPoint, Ds, Fs, DHats, FHats []*big.Int, r2msg2s []tss.ParsedMessage) error {
+	_, lPrime, _ := affgproof.Ranges(round.EC())
+	betaBound := new(big.Int).Lsh(big.NewInt(1), uint(lPrime))
+	pki, pkj := &round.key.PaillierSK.PublicKey, round.key.PaillierPKs[j]
+	NCap, s, t := round.key.NTildej[j], round.key.H1j[j], round.key.H2j[j]
+	ContextI := round.contextJ(round.PartyID().Index)
+
+	affine := func(x *big.Int, X *crypto.ECPoint) (beta, betaRandomness, D, F *big.Int, proof *affgproof.ProofAffg, err error) {
+		beta = common.GetRandomPositiveInt(round.Rand(), betaBound)
+		encBeta, betaRandomness, err := pkj.EncryptAndReturnRandomness(round.Rand(), beta)
+		if err != nil {
+			return
+		}
+		xK, err := pkj.HomoMult(x, round.temp.Ks[j])
+		if err != nil {
+			return
+		}
+		if D, err = pkj.HomoAdd(xK, encBeta); err != nil {
+			return
+		}
+		F, r, err := pki.EncryptAndReturnRandomness(round.Rand(), beta)
+		if err != nil {
+			return
+		}
+		proof, err = affgproof.NewProof(ContextI, round.EC(), pkj, pki, NCap, s, t, round.temp.Ks[j], D, F, X, x, beta, betaRandomness, r, round.Rand())
+		return
+	}
+
+	beta, betaRandomness, D, F, affgProof, err := affine(round.temp.gamma, bigGamma)
+	if err != nil {
+		return err
+	}
+	betaHat, _, DHat, FHat, affgHatProof, err := affine(round.temp.w, round.temp.bigWs[round.PartyID().Index])
+	if err != nil {
+		return err
+	}
+	logstarProof, err := logstarproof.NewProof(ContextI, round.EC(), pki, round.temp.Gs[round.PartyID().Index], bigGamma,
+		crypto.ScalarBaseMult(round.EC(), big.NewInt(1)), NCap, s, t, round.temp.gamma, round.temp.nu, round.Rand())
+	if err != nil {
+		return err
+	}
+
+	round.temp.betas[j], round.temp.betaRandomness[j], round.temp.betaHats[j] = beta, betaRandomness, betaHat
+	Ds[j], Fs[j], DHats[j], FHats[j] = D, F, DHat, FHat
+	r2msg2s[j] = NewPreSignRound2Message2(round.Parties().IDs()[j], round.PartyID(), affgProof, affgHatProof, logstarProof)
+	return nil
+}
+
+// ----- //
+
+type culprits struct {
+	err      error
+	culprits []*tss.PartyID
+}
+
+// culpritsError collects the errors of the parties that failed a check, or returns nil if all passed
+func culpritsError(errs []error, Ps tss.SortedPartyIDs) *culprits {
+	var multiErr error
+	ids := make([]*tss.PartyID, 0, len(Ps)) // who caused the error(s)
+	for j, err := range errs {
+		if err != nil {
+			multiErr = multierror.Append(multiErr, err)
+			ids = append(ids, Ps[j])
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	return &culprits{multiErr, ids}
+}
@@ -0,0 +1,191 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/logstarproof"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func (round *round3) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 3
+	round.started = true
+	round.resetOK()
+
+	Ps := round.Parties().IDs()
+	Pi := round.PartyID()
+	i := Pi.Index
+
+	// 1. verify the MtA proofs and the proof that Gamma_j is the plaintext of G_j times G (concurrent)
+	errs := make([]error, len(Ps))
+	done := make(chan int, len(Ps))
+	for j := range Ps {
+		if j == i {
+			continue
+		}
+		go func(j int) {
+			errs[j] = round.verifyRound2(j)
+			done <- j
+		}(j)
+	}
+	for j := range Ps {
+		if j == i {
+			continue
+		}
+		<-done
+	}
+	if err := culpritsError(errs, Ps); err != nil {
+		return round.WrapError(err.err, err.culprits...)
+	}
+
+	// 2. Gamma = sum_j Gamma_j, Delta_i = k_i*Gamma
+	bigGamma := round.temp.bigGammas[0]
+	for j := 1; j < len(Ps); j++ {
+		var err error
+		if bigGamma, err = bigGamma.Add(round.temp.bigGammas[j]); err != nil {
+			return round.WrapError(errors.New("the sum of Gamma_j is not a valid point"))
+		}
+	}
+	bigDelta := bigGamma.ScalarMult(round.temp.k)
+
+	// 3. delta_i = k_i*gamma_i + sum_j (alpha_ij - beta_ij), chi_i = k_i*w_i + sum_j (alpha_hat_ij - beta_hat_ij)
+	modQ := common.ModInt(round.EC().Params().N)
+	delta := modQ.Mul(round.temp.k, round.temp.gamma)
+	chi := modQ.Mul(round.temp.k, round.temp.w)
+	for j := range Ps {
+		if j == i {
+			continue
+		}
+		r2msg1 := round.temp.preSignRound2Message1s[j].Content().(*PreSignRound2Message1)
+		alpha, err := round.key.PaillierSK.Decrypt(r2msg1.UnmarshalD(i))
+		if err != nil {
+			return round.WrapError(err, Ps[j])
+		}
+		alphaHat, err := round.key.PaillierSK.Decrypt(r2msg1.UnmarshalDHat(i))
+		if err != nil {
+			return round.WrapError(err, Ps[j])
+		}
+		delta = modQ.Add(delta, modQ.Sub(alpha, round.temp.betas[j]))
+		chi = modQ.Add(chi, modQ.Sub(alphaHat, round.temp.betaHats[j]))
+	}
+	bigS := bigGamma.ScalarMult(chi)
+
+	round.temp.bigGamma = bigGamma
+	round.temp.bigDelta = bigDelta
+	round.temp.delta = delta
+	round.temp.chi = chi
+
+	// 4. p2p send the proofs that Delta_i = k_i*Gamma for the k_i in K_i
+	ContextI := round.contextJ(i)
+	for j, Pj := range Ps {
+		if j == i {
+			continue
+		}
+		proof, err := logstarproof.NewProof(ContextI, round.EC(), &round.key.PaillierSK.PublicKey, round.temp.Ks[i], bigDelta, bigGamma,
+			round.key.NTildej[j], round.key.H1j[j], round.key.H2j[j], round.temp.k, round.temp.rho, round.Rand())
+		if err != nil {
+			return round.WrapError(err, Pi)
+		}
+		enc, err := tss.EncryptMessage(round.Params(), NewPreSignRound3Message2(Pj, Pi, proof))
+		if err != nil {
+			return round.WrapError(err)
+		}
+		round.out <- tss.WithRound(enc, round.number)
+	}
+
+	// BROADCAST delta_i, Delta_i, S_i
+	r3msg1 := NewPreSignRound3Message1(Pi, delta, bigDelta, bigS)
+	round.temp.preSignRound3Message1s[i] = r3msg1
+	round.out <- tss.WithRound(r3msg1, round.number)
+	return nil
+}
+
+func (round *round3) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*PreSignRound3Message1); ok {
+		return msg.IsBroadcast()
+	}
+	if _, ok := msg.Content().(*PreSignRound3Message2); ok {
+		return !msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round3) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg1 := range round.temp.preSignRound3Message1s {
+		if round.ok[j] {
+			continue
+		}
+		if j == round.PartyID().Index {
+			round.ok[j] = true
+			continue
+		}
+		if msg1 == nil || !round.CanAccept(msg1) {
+			ret = false
+			continue
+		}
+		msg2 := round.temp.preSignRound3Message2s[j]
+		if msg2 == nil || !round.CanAccept(msg2) {
+			ret = false
+			continue
+		}
+		// proof checks are in the output round
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round3) NextRound() tss.Round {
+	round.started = false
+	return &preSignOutput{round}
+}
+
+// ----- //
+
+// verifyRound2 verifies Pj's proofs for the MtA ciphertexts addressed to this party and for Gamma_j;
+// Gamma_j is stored on success
+func (round *round3) verifyRound2(j int) error {
+	i := round.PartyID().Index
+	r2msg1 := round.temp.preSignRound2Message1s[j].Content().(*PreSignRound2Message1)
+	r2msg2 := round.temp.preSignRound2Message2s[j].Content().(*PreSignRound2Message2)
+	if len(r2msg1.GetD()) != len(round.Parties().IDs()) {
+		return errors.New("got the wrong number of MtA ciphertexts")
+	}
+	bigGammaj, err := r2msg1.UnmarshalBigGamma(round.EC())
+	if err != nil {
+		return err
+	}
+	pki, pkj := &round.key.PaillierSK.PublicKey, round.key.PaillierPKs[j]
+	NCap, s, t := round.key.NTildei, round.key.H1i, round.key.H2i
+	ContextJ := round.contextJ(j)
+
+	affgProof, err := r2msg2.UnmarshalAffgProof(round.EC())
+	if err != nil || !affgProof.Verify(ContextJ, round.EC(), pki, pkj, NCap, s, t, round.temp.Ks[i],
+		r2msg1.UnmarshalD(i), r2msg1.UnmarshalF(i), bigGammaj) {
+		return errors.New("affgProof verify failed")
+	}
+	affgHatProof, err := r2msg2.UnmarshalAffgHatProof(round.EC())
+	if err != nil || !affgHatProof.Verify(ContextJ, round.EC(), pki, pkj, NCap, s, t, round.temp.Ks[i],
+		r2msg1.UnmarshalDHat(i), r2msg1.UnmarshalFHat(i), round.temp.bigWs[j]) {
+		return errors.New("affgHatProof verify failed")
+	}
+	logstarProof, err := r2msg2.UnmarshalLogstarProof(round.EC())
+	if err != nil || !logstarProof.Verify(ContextJ, round.EC(), pkj, round.temp.Gs[j], bigGammaj,
+		crypto.ScalarBaseMult(round.EC(), big.NewInt(1)), NCap, s, t) {
+		return errors.New("logstarProof verify failed")
+	}
+	round.temp.bigGammas[j] = bigGammaj
+	return nil
+}
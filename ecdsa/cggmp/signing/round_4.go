@@ -0,0 +1,202 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// preSignOutput checks delta and outputs the presignature. If delta*G != sum_j Delta_j the parties open their
+// round 1 ciphertexts and MtA shares to identify who deviated (see identifyAbort).
+func (round *preSignOutput) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 4
+	round.started = true
+	round.resetOK()
+
+	Ps := round.Parties().IDs()
+	ec := round.EC()
+
+	// 1. verify the proofs that Delta_j = k_j*Gamma for the k_j in K_j
+	deltas := make([]*big.Int, len(Ps))
+	bigDeltas := make([]*crypto.ECPoint, len(Ps))
+	bigSs := make([]*crypto.ECPoint, len(Ps))
+	errs := make([]error, len(Ps))
+	done := make(chan int, len(Ps))
+	for j := range Ps {
+		go func(j int) {
+			deltas[j], bigDeltas[j], bigSs[j], errs[j] = round.verifyRound3(j)
+			done <- j
+		}(j)
+	}
+	for range Ps {
+		<-done
+	}
+	if err := culpritsError(errs, Ps); err != nil {
+		return round.WrapError(err.err, err.culprits...)
+	}
+
+	// 2. delta = sum_j delta_j must satisfy delta*G = sum_j Delta_j
+	modQ := common.ModInt(ec.Params().N)
+	delta := new(big.Int)
+	for _, deltaj := range deltas {
+		delta = modQ.Add(delta, deltaj)
+	}
+	if !round.deltaIsConsistent(delta, bigDeltas) {
+		common.Logger.Warnf("party %s: the check of delta failed, revealing k_i and gamma_i to identify the culprits", round.PartyID())
+		return round.startBlame()
+	}
+
+	// 3. sum_j S_j = chi*Gamma must be delta*X as sum_j chi_j = k*x
+	sumS := bigSs[0]
+	for j := 1; j < len(Ps); j++ {
+		var err error
+		if sumS, err = sumS.Add(bigSs[j]); err != nil {
+			break
+		}
+	}
+	if sumS == nil || !sumS.Equals(round.key.ECDSAPub.ScalarMult(delta)) {
+		// attributing this failure would need proofs of the multiplications behind S_j, which are not implemented
+		return round.WrapError(errors.New("the presignature is inconsistent with the public key"))
+	}
+
+	// 4. R = delta^-1*Gamma, R_j = delta^-1*Delta_j, S_j = delta^-1*S_j
+	deltaInverse := modQ.ModInverse(delta)
+	bigR := round.temp.bigGamma.ScalarMult(deltaInverse)
+	bigRj := make([]*crypto.ECPoint, len(Ps))
+	bigSj := make([]*crypto.ECPoint, len(Ps))
+	for j := range Ps {
+		bigRj[j] = bigDeltas[j].ScalarMult(deltaInverse)
+		bigSj[j] = bigSs[j].ScalarMult(deltaInverse)
+	}
+
+	ks := make([]*big.Int, len(round.key.Ks))
+	copy(ks, round.key.Ks)
+	preSig := &PreSignatureData{
+		ID:       common.SHA512_256(round.temp.ssid, bigR.X().Bytes(), bigR.Y().Bytes()),
+		Ks:       ks,
+		ECDSAPub: round.key.ECDSAPub,
+		BigR:     bigR,
+		BigRj:    bigRj,
+		BigSj:    bigSj,
+		K:        round.temp.k,
+		Chi:      round.temp.chi,
+	}
+
+	// clear temp.w, temp.k, temp.gamma and temp.chi from memory, lint ignore
+	round.temp.w = zero
+	round.temp.k = zero
+	round.temp.gamma = zero
+	round.temp.chi = zero
+
+	for j := range round.ok {
+		round.ok[j] = true
+	}
+	round.preEnd <- preSig
+
+	return nil
+}
+
+func (round *preSignOutput) CanAccept(msg tss.ParsedMessage) bool {
+	if round.temp.abort {
+		if _, ok := msg.Content().(*PreSignBlameMessage); ok {
+			return msg.IsBroadcast()
+		}
+	}
+	// not expecting any other incoming messages in this round
+	return false
+}
+
+func (round *preSignOutput) Update() (bool, *tss.Error) {
+	if !round.temp.abort {
+		// not expecting any incoming messages in this round
+		return false, nil
+	}
+	ret := true
+	for j, msg := range round.temp.preSignBlameMessages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *preSignOutput) NextRound() tss.Round {
+	if round.temp.abort {
+		round.started = false
+		return &identifyAbort{round.base}
+	}
+	return nil // finished!
+}
+
+// ----- //
+
+// verifyRound3 parses Pj's round 3 broadcast and verifies its proof that Delta_j = k_j*Gamma
+func (round *preSignOutput) verifyRound3(j int) (delta *big.Int, bigDelta, bigS *crypto.ECPoint, err error) {
+	r3msg1 := round.temp.preSignRound3Message1s[j].Content().(*PreSignRound3Message1)
+	if bigDelta, err = r3msg1.UnmarshalBigDelta(round.EC()); err != nil {
+		return
+	}
+	if bigS, err = r3msg1.UnmarshalBigS(round.EC()); err != nil {
+		return
+	}
+	delta = r3msg1.UnmarshalDelta()
+	if j == round.PartyID().Index {
+		return
+	}
+	r3msg2 := round.temp.preSignRound3Message2s[j].Content().(*PreSignRound3Message2)
+	proof, err := r3msg2.UnmarshalLogstarProof(round.EC())
+	if err != nil || !proof.Verify(round.contextJ(j), round.EC(), round.key.PaillierPKs[j], round.temp.Ks[j], bigDelta,
+		round.temp.bigGamma, round.key.NTildei, round.key.H1i, round.key.H2i) {
+		err = errors.New("logstarProof verify failed")
+	}
+	return
+}
+
+// deltaIsConsistent checks that delta is not zero and that delta*G = sum_j Delta_j
+func (round *preSignOutput) deltaIsConsistent(delta *big.Int, bigDeltas []*crypto.ECPoint) bool {
+	if delta.Sign() == 0 {
+		return false
+	}
+	sum := bigDeltas[0]
+	for j := 1; j < len(bigDeltas); j++ {
+		var err error
+		if sum, err = sum.Add(bigDeltas[j]); err != nil {
+			return false
+		}
+	}
+	return sum.Equals(crypto.ScalarBaseMult(round.EC(), delta))
+}
+
+// startBlame broadcasts the openings of K_i, G_i and of the MtA shares beta_ij and waits for those of the other parties
+func (round *preSignOutput) startBlame() *tss.Error {
+	round.temp.abort = true
+	round.resetOK()
+	for j := range round.temp.betas {
+		if round.temp.betas[j] == nil {
+			round.temp.betas[j], round.temp.betaRandomness[j] = zero, zero
+		}
+	}
+	msg := NewPreSignBlameMessage(round.PartyID(), round.temp.k, round.temp.rho, round.temp.gamma, round.temp.nu,
+		round.temp.betas, round.temp.betaRandomness)
+	round.temp.preSignBlameMessages[round.PartyID().Index] = msg
+	round.ok[round.PartyID().Index] = true
+	round.out <- tss.WithRound(msg, round.number)
+	return nil
+}
@@ -0,0 +1,147 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// identifyAbort follows a failed check of delta: with k_j, gamma_j and the MtA shares beta_jl of every party opened,
+// each party recomputes the delta_j every other party should have broadcast and reports those that deviated.
+// Revealing these values is safe as the presignature is discarded; w_j and the shares of chi are never opened.
+func (round *identifyAbort) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 5
+	round.started = true
+	round.resetOK()
+
+	Ps := round.Parties().IDs()
+	ks, gammas, betas, err := round.openings()
+	if err != nil {
+		return round.WrapError(err.err, err.culprits...)
+	}
+
+	errs := make([]error, len(Ps))
+	for l := range Ps {
+		round.ok[l] = true
+		errs[l] = round.checkDelta(l, ks, gammas, betas)
+	}
+	if err := culpritsError(errs, Ps); err != nil {
+		return round.WrapError(err.err, err.culprits...)
+	}
+	return round.WrapError(errors.New("the check of delta failed but no party could be identified as the culprit"))
+}
+
+func (round *identifyAbort) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *identifyAbort) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *identifyAbort) NextRound() tss.Round {
+	return nil // finished!
+}
+
+// ----- //
+
+// openings checks every blame message against the ciphertexts of rounds 1 and 2 and returns the opened k_j, gamma_j
+// and beta_jl; a party whose openings do not match its ciphertexts is a culprit
+func (round *identifyAbort) openings() (ks, gammas []*big.Int, betas [][]*big.Int, err *culprits) {
+	Ps := round.Parties().IDs()
+	ks, gammas = make([]*big.Int, len(Ps)), make([]*big.Int, len(Ps))
+	betas = make([][]*big.Int, len(Ps))
+	errs := make([]error, len(Ps))
+	for l := range Ps {
+		ks[l], gammas[l], betas[l], errs[l] = round.openingsOf(l)
+	}
+	return ks, gammas, betas, culpritsError(errs, Ps)
+}
+
+func (round *identifyAbort) openingsOf(l int) (k, gamma *big.Int, betas []*big.Int, err error) {
+	Ps := round.Parties().IDs()
+	ec := round.EC()
+	blame := round.temp.preSignBlameMessages[l].Content().(*PreSignBlameMessage)
+	r2msg1 := round.temp.preSignRound2Message1s[l].Content().(*PreSignRound2Message1)
+	if len(blame.GetBeta()) != len(Ps) {
+		return nil, nil, nil, errors.New("got the wrong number of MtA shares")
+	}
+	pkl := round.key.PaillierPKs[l]
+	k, gamma = blame.UnmarshalK(), blame.UnmarshalGamma()
+
+	// K_l = enc_l(k_l; rho_l), G_l = enc_l(gamma_l; nu_l), Gamma_l = gamma_l*G
+	if K, e := pkl.EncryptWithRandomness(k, blame.UnmarshalRho()); e != nil || K.Cmp(round.temp.Ks[l]) != 0 {
+		return nil, nil, nil, errors.New("the opening of K_j does not match")
+	}
+	if G, e := pkl.EncryptWithRandomness(gamma, blame.UnmarshalNu()); e != nil || G.Cmp(round.temp.Gs[l]) != 0 {
+		return nil, nil, nil, errors.New("the opening of G_j does not match")
+	}
+	gx, gy := ec.ScalarBaseMult(new(big.Int).Mod(gamma, ec.Params().N).Bytes())
+	if gx.Cmp(round.temp.bigGammas[l].X()) != 0 || gy.Cmp(round.temp.bigGammas[l].Y()) != 0 {
+		return nil, nil, nil, errors.New("the opening of Gamma_j does not match")
+	}
+
+	// D_jl = gamma_l*K_j + enc_j(beta_lj; s_lj) for every j
+	betas = make([]*big.Int, len(Ps))
+	for j := range Ps {
+		if j == l {
+			continue
+		}
+		pkj := round.key.PaillierPKs[j]
+		beta := blame.UnmarshalBeta(j)
+		encBeta, e := pkj.EncryptWithRandomness(beta, blame.UnmarshalBetaRandomness(j))
+		if e != nil {
+			return nil, nil, nil, fmt.Errorf("the opening of the MtA share for party %d is invalid", j)
+		}
+		modNj2 := common.ModInt(pkj.NSquare())
+		D := modNj2.Mul(modNj2.Exp(round.temp.Ks[j], gamma), encBeta)
+		if D.Cmp(r2msg1.UnmarshalD(j)) != 0 {
+			return nil, nil, nil, fmt.Errorf("the opening of the MtA share for party %d does not match", j)
+		}
+		betas[j] = beta
+	}
+	return k, gamma, betas, nil
+}
+
+// checkDelta recomputes delta_l = k_l*gamma_l + sum_j (alpha_lj - beta_lj) with alpha_lj = k_l*gamma_j + beta_jl
+// decrypted under N_l, and checks it and Delta_l = k_l*Gamma against what party l broadcast
+func (round *identifyAbort) checkDelta(l int, ks, gammas []*big.Int, betas [][]*big.Int) error {
+	ec := round.EC()
+	modQ := common.ModInt(ec.Params().N)
+	Nl := round.key.PaillierPKs[l].N
+	delta := modQ.Mul(ks[l], gammas[l])
+	for j := range ks {
+		if j == l {
+			continue
+		}
+		alpha := new(big.Int).Mod(new(big.Int).Add(new(big.Int).Mul(ks[l], gammas[j]), betas[j][l]), Nl)
+		delta = modQ.Add(delta, modQ.Sub(alpha, betas[l][j]))
+	}
+	r3msg1 := round.temp.preSignRound3Message1s[l].Content().(*PreSignRound3Message1)
+	if delta.Cmp(new(big.Int).Mod(r3msg1.UnmarshalDelta(), ec.Params().N)) != 0 {
+		return errors.New("the party broadcast a wrong delta_j")
+	}
+	bigDelta, err := r3msg1.UnmarshalBigDelta(ec)
+	if err != nil {
+		return err
+	}
+	dx, dy := ec.ScalarMult(round.temp.bigGamma.X(), round.temp.bigGamma.Y(), new(big.Int).Mod(ks[l], ec.Params().N).Bytes())
+	if dx.Cmp(bigDelta.X()) != 0 || dy.Cmp(bigDelta.Y()) != 0 {
+		return errors.New("the party broadcast a wrong Delta_j")
+	}
+	return nil
+}
@@ -0,0 +1,238 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	ecdsakeygen "github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// the sign round signs the message with a presignature: sigma_i = m*k_i + r*chi_i is broadcast and the shares are summed
+func newSignRound(params *tss.Parameters, key *ecdsakeygen.LocalPartySaveData, data *common.SignatureData, temp *localTempData, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Round {
+	return &signRound{
+		&base{params, key, data, temp, out, end, nil, make([]bool, len(params.Parties().IDs())), false, 1},
+	}
+}
+
+func (round *signRound) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	q := round.EC().Params().N
+	if round.temp.m == nil || round.temp.m.Cmp(q) >= 0 {
+		return round.WrapError(errors.New("hashed message is not valid"))
+	}
+	pre := round.temp.preSig
+	if pre.Used() {
+		return round.WrapError(ErrPreSignatureUsed)
+	}
+	if !pre.ValidateBasic() {
+		return round.WrapError(errors.New("presignature is invalid"))
+	}
+	if len(pre.Ks) != len(round.key.Ks) {
+		return round.WrapError(fmt.Errorf("presignature was made by %d parties but %d are signing", len(pre.Ks), len(round.key.Ks)))
+	}
+	for j, kj := range round.key.Ks {
+		if kj.Cmp(pre.Ks[j]) != 0 {
+			return round.WrapError(errors.New("presignature was made by a different set of parties"))
+		}
+	}
+	if !pre.ECDSAPub.Equals(round.key.ECDSAPub) {
+		return round.WrapError(errors.New("presignature was made for a different key"))
+	}
+	// the ID is recorded before sigma_i is computed, so that no copy of the presignature can sign another message
+	if round.temp.nonceLedger == nil {
+		return round.WrapError(errors.New("a nonce ledger is required to sign with a presignature"))
+	}
+	if err := round.temp.nonceLedger.Use(pre.ID); err != nil {
+		return round.WrapError(err)
+	}
+	k, chi, err := pre.consume()
+	if err != nil {
+		return round.WrapError(err)
+	}
+
+	modQ := common.ModInt(q)
+	rx, ry := pre.BigR.X(), pre.BigR.Y()
+	r := new(big.Int).Mod(rx, q)
+	sigma := modQ.Add(modQ.Mul(round.temp.m, k), modQ.Mul(r, chi))
+
+	round.temp.rx = rx
+	round.temp.ry = ry
+	round.temp.sigma = sigma
+
+	i := round.PartyID().Index
+	round.ok[i] = true
+
+	msg := NewSignRound1Message(round.PartyID(), pre.ID, sigma)
+	round.temp.signRound1Messages[i] = msg
+	round.out <- tss.WithRound(msg, round.number)
+	return nil
+}
+
+func (round *signRound) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.signRound1Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *signRound) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignRound1Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *signRound) NextRound() tss.Round {
+	round.started = false
+	return &signFinalization{round}
+}
+
+// ----- //
+
+func (round *signFinalization) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	Ps := round.Parties().IDs()
+	q := round.EC().Params().N
+	modQ := common.ModInt(q)
+	sigmas := make([]*big.Int, len(Ps))
+	sumSigma := new(big.Int)
+	for j, Pj := range Ps {
+		round.ok[j] = true
+		r1msg := round.temp.signRound1Messages[j].Content().(*SignRound1Message)
+		if !bytes.Equal(r1msg.GetPresignatureId(), round.temp.preSig.ID) {
+			return round.WrapError(errors.New("party signed with a different presignature"), Pj)
+		}
+		sigmas[j] = r1msg.UnmarshalSigma()
+		sumSigma = modQ.Add(sumSigma, sigmas[j])
+	}
+
+	if err := round.finishSignature(sumSigma); err != nil {
+		// sigma_j*R = m*R_j + r*S_j holds for the share of every honest party
+		r := new(big.Int).Mod(round.temp.rx, q)
+		culprits := make([]*tss.PartyID, 0, len(Ps))
+		for j, Pj := range Ps {
+			if !round.shareIsValid(j, sigmas[j], r) {
+				culprits = append(culprits, Pj)
+			}
+		}
+		return round.WrapError(err, culprits...)
+	}
+	return nil
+}
+
+func (round *signFinalization) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *signFinalization) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *signFinalization) NextRound() tss.Round {
+	return nil // finished!
+}
+
+// shareIsValid checks sigma_j*R = m*R_j + r*S_j; it is computed on raw coordinates as sigma_j is chosen by party j
+func (round *signFinalization) shareIsValid(j int, sigmaj, r *big.Int) bool {
+	ec, pre := round.EC(), round.temp.preSig
+	q := ec.Params().N
+	lx, ly := ec.ScalarMult(pre.BigR.X(), pre.BigR.Y(), new(big.Int).Mod(sigmaj, q).Bytes())
+	mx, my := ec.ScalarMult(pre.BigRj[j].X(), pre.BigRj[j].Y(), round.temp.m.Bytes())
+	sx, sy := ec.ScalarMult(pre.BigSj[j].X(), pre.BigSj[j].Y(), r.Bytes())
+	rx, ry := ec.Add(mx, my, sx, sy)
+	return lx.Cmp(rx) == 0 && ly.Cmp(ry) == 0
+}
+
+// finishSignature normalises the summed sigma, assembles the signature data, verifies it against the public key and sends it out
+func (round *signFinalization) finishSignature(sumSigma *big.Int) error {
+	q := round.EC().Params().N
+	recid := 0
+	// byte v = if(R.X >= curve.N) then 2 else 0) | (if R.Y.IsEven then 0 else 1);
+	if round.temp.rx.Cmp(q) >= 0 {
+		recid = 2
+	}
+	if round.temp.ry.Bit(0) != 0 {
+		recid |= 1
+	}
+
+	// on secp256k1 the signature is normalised to the lower half of the order, as in the GG18 signing
+	s := new(big.Int).Set(sumSigma)
+	if tss.SameCurve(round.EC(), tss.S256()) {
+		halfQ := new(big.Int).Rsh(q, 1)
+		if s.Cmp(halfQ) > 0 {
+			s.Sub(q, s)
+			recid ^= 1
+		}
+	}
+	r := new(big.Int).Mod(round.temp.rx, q)
+
+	// save the signature for final output
+	bitSizeInBytes := round.EC().Params().BitSize / 8
+	round.data.R = padToLengthBytesInPlace(r.Bytes(), bitSizeInBytes)
+	round.data.S = padToLengthBytesInPlace(s.Bytes(), bitSizeInBytes)
+	round.data.Signature = append(round.data.R, round.data.S...)
+	round.data.SignatureRecovery = []byte{byte(recid)}
+	if round.temp.fullBytesLen == 0 {
+		round.data.M = round.temp.m.Bytes()
+	} else {
+		var mBytes = make([]byte, round.temp.fullBytesLen)
+		round.temp.m.FillBytes(mBytes)
+		round.data.M = mBytes
+	}
+
+	pk := ecdsa.PublicKey{
+		Curve: round.EC(),
+		X:     round.key.ECDSAPub.X(),
+		Y:     round.key.ECDSAPub.Y(),
+	}
+	if ok := ecdsa.Verify(&pk, round.data.M, r, s); !ok {
+		return errors.New("signature verification failed")
+	}
+
+	round.end <- round.data
+
+	return nil
+}
+
+func padToLengthBytesInPlace(src []byte, length int) []byte {
+	oriLen := len(src)
+	if oriLen < length {
+		for i := 0; i < length-oriLen; i++ {
+			src = append([]byte{0}, src...)
+		}
+	}
+	return src
+}
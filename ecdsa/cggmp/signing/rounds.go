@@ -0,0 +1,152 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	ecdsakeygen "github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	TaskName = "ecdsa-cggmp-signing"
+)
+
+type (
+	base struct {
+		*tss.Parameters
+		key     *ecdsakeygen.LocalPartySaveData
+		data    *common.SignatureData
+		temp    *localTempData
+		out     chan<- tss.Message
+		end     chan<- *common.SignatureData
+		preEnd  chan<- *PreSignatureData
+		ok      []bool // `ok` tracks parties which have been verified by Update()
+		started bool
+		number  int
+	}
+
+	// presigning
+	round1 struct {
+		*base
+	}
+	round2 struct {
+		*round1
+	}
+	round3 struct {
+		*round2
+	}
+	preSignOutput struct {
+		*round3
+	}
+	identifyAbort struct {
+		*base
+	}
+
+	// online signing with a presignature
+	signRound struct {
+		*base
+	}
+	signFinalization struct {
+		*signRound
+	}
+)
+
+var (
+	_ tss.Round = (*round1)(nil)
+	_ tss.Round = (*round2)(nil)
+	_ tss.Round = (*round3)(nil)
+	_ tss.Round = (*preSignOutput)(nil)
+	_ tss.Round = (*identifyAbort)(nil)
+	_ tss.Round = (*signRound)(nil)
+	_ tss.Round = (*signFinalization)(nil)
+)
+
+// ----- //
+
+func (round *base) Params() *tss.Parameters {
+	return round.Parameters
+}
+
+func (round *base) RoundNumber() int {
+	return round.number
+}
+
+// Out is used by the echo broadcast of the party
+func (round *base) Out() chan<- tss.Message {
+	return round.out
+}
+
+// CanProceed is inherited by other rounds
+func (round *base) CanProceed() bool {
+	if !round.started {
+		return false
+	}
+	for _, ok := range round.ok {
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// WaitingFor is called by a Party for reporting back to the caller
+func (round *base) WaitingFor() []*tss.PartyID {
+	Ps := round.Parties().IDs()
+	ids := make([]*tss.PartyID, 0, len(round.ok))
+	for j, ok := range round.ok {
+		if ok {
+			continue
+		}
+		ids = append(ids, Ps[j])
+	}
+	return ids
+}
+
+func (round *base) WrapError(err error, culprits ...*tss.PartyID) *tss.Error {
+	return tss.NewError(err, TaskName, round.number, round.PartyID(), culprits...)
+}
+
+// ----- //
+
+// `ok` tracks parties which have been verified by Update()
+func (round *base) resetOK() {
+	for j := range round.ok {
+		round.ok[j] = false
+	}
+}
+
+// get ssid from local params
+func (round *base) getSSID() ([]byte, error) {
+	ssidList := []*big.Int{round.EC().Params().P, round.EC().Params().N, round.EC().Params().B, round.EC().Params().Gx, round.EC().Params().Gy} // ec curve
+	ssidList = append(ssidList, round.Parties().IDs().Keys()...)                                                                                // parties
+	BigXjList, err := crypto.FlattenECPoints(round.key.BigXj)
+	if err != nil {
+		return nil, errors.New("read BigXj failed")
+	}
+	ssidList = append(ssidList, BigXjList...) // BigXj
+	for _, pk := range round.key.PaillierPKs {
+		ssidList = append(ssidList, pk.N) // paillier pk
+	}
+	ssidList = append(ssidList, round.key.NTildej...)            // NTilde
+	ssidList = append(ssidList, round.key.H1j...)                // h1
+	ssidList = append(ssidList, round.key.H2j...)                // h2
+	ssidList = append(ssidList, big.NewInt(int64(round.number))) // round number
+	ssidList = append(ssidList, round.temp.ssidNonce)
+	ssid := common.SHA512_256i(ssidList...).Bytes()
+
+	return ssid, nil
+}
+
+// contextJ binds a proof made by party j to this session
+func (round *base) contextJ(j int) []byte {
+	return common.AppendBigIntToBytesSlice(round.temp.ssid, big.NewInt(int64(j)))
+}
@@ -0,0 +1,579 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        v3.14.0
+// source: protob/ecdsa-savedata.proto
+
+package keygen
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+//
+// A point on an elliptic curve, named as in the tss curve registry (e.g. "secp256k1"). The coordinates are
+// unsigned big-endian integers.
+type ECPoint struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Curve string `protobuf:"bytes,1,opt,name=curve,proto3" json:"curve,omitempty"`
+	X     []byte `protobuf:"bytes,2,opt,name=x,proto3" json:"x,omitempty"`
+	Y     []byte `protobuf:"bytes,3,opt,name=y,proto3" json:"y,omitempty"`
+}
+
+func (x *ECPoint) Reset() {
+	*x = ECPoint{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_savedata_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ECPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ECPoint) ProtoMessage() {}
+
+func (x *ECPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_savedata_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ECPoint.ProtoReflect.Descriptor instead.
+func (*ECPoint) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_savedata_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ECPoint) GetCurve() string {
+	if x != nil {
+		return x.Curve
+	}
+	return ""
+}
+
+func (x *ECPoint) GetX() []byte {
+	if x != nil {
+		return x.X
+	}
+	return nil
+}
+
+func (x *ECPoint) GetY() []byte {
+	if x != nil {
+		return x.Y
+	}
+	return nil
+}
+
+//
+// A Paillier private key. The public key is the modulus n.
+type PaillierPrivateKey struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	N       []byte `protobuf:"bytes,1,opt,name=n,proto3" json:"n,omitempty"`
+	LambdaN []byte `protobuf:"bytes,2,opt,name=lambda_n,json=lambdaN,proto3" json:"lambda_n,omitempty"`
+	PhiN    []byte `protobuf:"bytes,3,opt,name=phi_n,json=phiN,proto3" json:"phi_n,omitempty"`
+	P       []byte `protobuf:"bytes,4,opt,name=p,proto3" json:"p,omitempty"`
+	Q       []byte `protobuf:"bytes,5,opt,name=q,proto3" json:"q,omitempty"`
+}
+
+func (x *PaillierPrivateKey) Reset() {
+	*x = PaillierPrivateKey{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_savedata_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PaillierPrivateKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaillierPrivateKey) ProtoMessage() {}
+
+func (x *PaillierPrivateKey) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_savedata_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaillierPrivateKey.ProtoReflect.Descriptor instead.
+func (*PaillierPrivateKey) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_savedata_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PaillierPrivateKey) GetN() []byte {
+	if x != nil {
+		return x.N
+	}
+	return nil
+}
+
+func (x *PaillierPrivateKey) GetLambdaN() []byte {
+	if x != nil {
+		return x.LambdaN
+	}
+	return nil
+}
+
+func (x *PaillierPrivateKey) GetPhiN() []byte {
+	if x != nil {
+		return x.PhiN
+	}
+	return nil
+}
+
+func (x *PaillierPrivateKey) GetP() []byte {
+	if x != nil {
+		return x.P
+	}
+	return nil
+}
+
+func (x *PaillierPrivateKey) GetQ() []byte {
+	if x != nil {
+		return x.Q
+	}
+	return nil
+}
+
+//
+// The public part of an ECDSA key share, common to all parties. Integers are unsigned big-endian and the lists are
+// indexed by party, in the order of ks.
+type SaveDataPublic struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ks          [][]byte   `protobuf:"bytes,1,rep,name=ks,proto3" json:"ks,omitempty"`
+	BigXj       []*ECPoint `protobuf:"bytes,2,rep,name=big_xj,json=bigXj,proto3" json:"big_xj,omitempty"`
+	EcdsaPub    *ECPoint   `protobuf:"bytes,3,opt,name=ecdsa_pub,json=ecdsaPub,proto3" json:"ecdsa_pub,omitempty"`
+	NTildeJ     [][]byte   `protobuf:"bytes,4,rep,name=n_tilde_j,json=nTildeJ,proto3" json:"n_tilde_j,omitempty"`
+	H1J         [][]byte   `protobuf:"bytes,5,rep,name=h1j,proto3" json:"h1j,omitempty"`
+	H2J         [][]byte   `protobuf:"bytes,6,rep,name=h2j,proto3" json:"h2j,omitempty"`
+	PaillierPks [][]byte   `protobuf:"bytes,7,rep,name=paillier_pks,json=paillierPks,proto3" json:"paillier_pks,omitempty"`
+}
+
+func (x *SaveDataPublic) Reset() {
+	*x = SaveDataPublic{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_savedata_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SaveDataPublic) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveDataPublic) ProtoMessage() {}
+
+func (x *SaveDataPublic) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_savedata_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveDataPublic.ProtoReflect.Descriptor instead.
+func (*SaveDataPublic) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_savedata_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SaveDataPublic) GetKs() [][]byte {
+	if x != nil {
+		return x.Ks
+	}
+	return nil
+}
+
+func (x *SaveDataPublic) GetBigXj() []*ECPoint {
+	if x != nil {
+		return x.BigXj
+	}
+	return nil
+}
+
+func (x *SaveDataPublic) GetEcdsaPub() *ECPoint {
+	if x != nil {
+		return x.EcdsaPub
+	}
+	return nil
+}
+
+func (x *SaveDataPublic) GetNTildeJ() [][]byte {
+	if x != nil {
+		return x.NTildeJ
+	}
+	return nil
+}
+
+func (x *SaveDataPublic) GetH1J() [][]byte {
+	if x != nil {
+		return x.H1J
+	}
+	return nil
+}
+
+func (x *SaveDataPublic) GetH2J() [][]byte {
+	if x != nil {
+		return x.H2J
+	}
+	return nil
+}
+
+func (x *SaveDataPublic) GetPaillierPks() [][]byte {
+	if x != nil {
+		return x.PaillierPks
+	}
+	return nil
+}
+
+//
+// The canonical encoding of the ECDSA keygen save data (keygen.LocalPartySaveData). Integers are unsigned
+// big-endian; an empty value stands for an absent one.
+type SaveData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version uint32 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	// pre-params
+	PaillierSk *PaillierPrivateKey `protobuf:"bytes,2,opt,name=paillier_sk,json=paillierSk,proto3" json:"paillier_sk,omitempty"`
+	NTildeI    []byte              `protobuf:"bytes,3,opt,name=n_tilde_i,json=nTildeI,proto3" json:"n_tilde_i,omitempty"`
+	H1I        []byte              `protobuf:"bytes,4,opt,name=h1i,proto3" json:"h1i,omitempty"`
+	H2I        []byte              `protobuf:"bytes,5,opt,name=h2i,proto3" json:"h2i,omitempty"`
+	Alpha      []byte              `protobuf:"bytes,6,opt,name=alpha,proto3" json:"alpha,omitempty"`
+	Beta       []byte              `protobuf:"bytes,7,opt,name=beta,proto3" json:"beta,omitempty"`
+	P          []byte              `protobuf:"bytes,8,opt,name=p,proto3" json:"p,omitempty"`
+	Q          []byte              `protobuf:"bytes,9,opt,name=q,proto3" json:"q,omitempty"`
+	// secrets
+	Xi      []byte          `protobuf:"bytes,10,opt,name=xi,proto3" json:"xi,omitempty"`
+	ShareId []byte          `protobuf:"bytes,11,opt,name=share_id,json=shareId,proto3" json:"share_id,omitempty"`
+	Public  *SaveDataPublic `protobuf:"bytes,12,opt,name=public,proto3" json:"public,omitempty"`
+}
+
+func (x *SaveData) Reset() {
+	*x = SaveData{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_savedata_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SaveData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveData) ProtoMessage() {}
+
+func (x *SaveData) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_savedata_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveData.ProtoReflect.Descriptor instead.
+func (*SaveData) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_savedata_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SaveData) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *SaveData) GetPaillierSk() *PaillierPrivateKey {
+	if x != nil {
+		return x.PaillierSk
+	}
+	return nil
+}
+
+func (x *SaveData) GetNTildeI() []byte {
+	if x != nil {
+		return x.NTildeI
+	}
+	return nil
+}
+
+func (x *SaveData) GetH1I() []byte {
+	if x != nil {
+		return x.H1I
+	}
+	return nil
+}
+
+func (x *SaveData) GetH2I() []byte {
+	if x != nil {
+		return x.H2I
+	}
+	return nil
+}
+
+func (x *SaveData) GetAlpha() []byte {
+	if x != nil {
+		return x.Alpha
+	}
+	return nil
+}
+
+func (x *SaveData) GetBeta() []byte {
+	if x != nil {
+		return x.Beta
+	}
+	return nil
+}
+
+func (x *SaveData) GetP() []byte {
+	if x != nil {
+		return x.P
+	}
+	return nil
+}
+
+func (x *SaveData) GetQ() []byte {
+	if x != nil {
+		return x.Q
+	}
+	return nil
+}
+
+func (x *SaveData) GetXi() []byte {
+	if x != nil {
+		return x.Xi
+	}
+	return nil
+}
+
+func (x *SaveData) GetShareId() []byte {
+	if x != nil {
+		return x.ShareId
+	}
+	return nil
+}
+
+func (x *SaveData) GetPublic() *SaveDataPublic {
+	if x != nil {
+		return x.Public
+	}
+	return nil
+}
+
+var File_protob_ecdsa_savedata_proto protoreflect.FileDescriptor
+
+var file_protob_ecdsa_savedata_proto_rawDesc = []byte{
+	0x0a, 0x1b, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x2f, 0x65, 0x63, 0x64, 0x73, 0x61, 0x2d, 0x73,
+	0x61, 0x76, 0x65, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x1b, 0x62,
+	0x69, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x74, 0x73, 0x73, 0x6c, 0x69, 0x62, 0x2e, 0x65, 0x63,
+	0x64, 0x73, 0x61, 0x2e, 0x6b, 0x65, 0x79, 0x67, 0x65, 0x6e, 0x22, 0x3b, 0x0a, 0x07, 0x45, 0x43,
+	0x50, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x75, 0x72, 0x76, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x63, 0x75, 0x72, 0x76, 0x65, 0x12, 0x0c, 0x0a, 0x01, 0x78,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x78, 0x12, 0x0c, 0x0a, 0x01, 0x79, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x79, 0x22, 0x6e, 0x0a, 0x12, 0x50, 0x61, 0x69, 0x6c, 0x6c,
+	0x69, 0x65, 0x72, 0x50, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x12, 0x0c, 0x0a,
+	0x01, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x6e, 0x12, 0x19, 0x0a, 0x08, 0x6c,
+	0x61, 0x6d, 0x62, 0x64, 0x61, 0x5f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x6c,
+	0x61, 0x6d, 0x62, 0x64, 0x61, 0x4e, 0x12, 0x13, 0x0a, 0x05, 0x70, 0x68, 0x69, 0x5f, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x70, 0x68, 0x69, 0x4e, 0x12, 0x0c, 0x0a, 0x01, 0x70,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x70, 0x12, 0x0c, 0x0a, 0x01, 0x71, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x71, 0x22, 0x83, 0x02, 0x0a, 0x0e, 0x53, 0x61, 0x76, 0x65,
+	0x44, 0x61, 0x74, 0x61, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x12, 0x0e, 0x0a, 0x02, 0x6b, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x02, 0x6b, 0x73, 0x12, 0x3b, 0x0a, 0x06, 0x62, 0x69,
+	0x67, 0x5f, 0x78, 0x6a, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x62, 0x69, 0x6e,
+	0x61, 0x6e, 0x63, 0x65, 0x2e, 0x74, 0x73, 0x73, 0x6c, 0x69, 0x62, 0x2e, 0x65, 0x63, 0x64, 0x73,
+	0x61, 0x2e, 0x6b, 0x65, 0x79, 0x67, 0x65, 0x6e, 0x2e, 0x45, 0x43, 0x50, 0x6f, 0x69, 0x6e, 0x74,
+	0x52, 0x05, 0x62, 0x69, 0x67, 0x58, 0x6a, 0x12, 0x41, 0x0a, 0x09, 0x65, 0x63, 0x64, 0x73, 0x61,
+	0x5f, 0x70, 0x75, 0x62, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x62, 0x69, 0x6e,
+	0x61, 0x6e, 0x63, 0x65, 0x2e, 0x74, 0x73, 0x73, 0x6c, 0x69, 0x62, 0x2e, 0x65, 0x63, 0x64, 0x73,
+	0x61, 0x2e, 0x6b, 0x65, 0x79, 0x67, 0x65, 0x6e, 0x2e, 0x45, 0x43, 0x50, 0x6f, 0x69, 0x6e, 0x74,
+	0x52, 0x08, 0x65, 0x63, 0x64, 0x73, 0x61, 0x50, 0x75, 0x62, 0x12, 0x1a, 0x0a, 0x09, 0x6e, 0x5f,
+	0x74, 0x69, 0x6c, 0x64, 0x65, 0x5f, 0x6a, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x07, 0x6e,
+	0x54, 0x69, 0x6c, 0x64, 0x65, 0x4a, 0x12, 0x10, 0x0a, 0x03, 0x68, 0x31, 0x6a, 0x18, 0x05, 0x20,
+	0x03, 0x28, 0x0c, 0x52, 0x03, 0x68, 0x31, 0x6a, 0x12, 0x10, 0x0a, 0x03, 0x68, 0x32, 0x6a, 0x18,
+	0x06, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x03, 0x68, 0x32, 0x6a, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x61,
+	0x69, 0x6c, 0x6c, 0x69, 0x65, 0x72, 0x5f, 0x70, 0x6b, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0c,
+	0x52, 0x0b, 0x70, 0x61, 0x69, 0x6c, 0x6c, 0x69, 0x65, 0x72, 0x50, 0x6b, 0x73, 0x22, 0xec, 0x02,
+	0x0a, 0x08, 0x53, 0x61, 0x76, 0x65, 0x44, 0x61, 0x74, 0x61, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x50, 0x0a, 0x0b, 0x70, 0x61, 0x69, 0x6c, 0x6c, 0x69, 0x65, 0x72,
+	0x5f, 0x73, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x62, 0x69, 0x6e, 0x61,
+	0x6e, 0x63, 0x65, 0x2e, 0x74, 0x73, 0x73, 0x6c, 0x69, 0x62, 0x2e, 0x65, 0x63, 0x64, 0x73, 0x61,
+	0x2e, 0x6b, 0x65, 0x79, 0x67, 0x65, 0x6e, 0x2e, 0x50, 0x61, 0x69, 0x6c, 0x6c, 0x69, 0x65, 0x72,
+	0x50, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x52, 0x0a, 0x70, 0x61, 0x69, 0x6c,
+	0x6c, 0x69, 0x65, 0x72, 0x53, 0x6b, 0x12, 0x1a, 0x0a, 0x09, 0x6e, 0x5f, 0x74, 0x69, 0x6c, 0x64,
+	0x65, 0x5f, 0x69, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x6e, 0x54, 0x69, 0x6c, 0x64,
+	0x65, 0x49, 0x12, 0x10, 0x0a, 0x03, 0x68, 0x31, 0x69, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x03, 0x68, 0x31, 0x69, 0x12, 0x10, 0x0a, 0x03, 0x68, 0x32, 0x69, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x03, 0x68, 0x32, 0x69, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x12, 0x12, 0x0a, 0x04,
+	0x62, 0x65, 0x74, 0x61, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x62, 0x65, 0x74, 0x61,
+	0x12, 0x0c, 0x0a, 0x01, 0x70, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x70, 0x12, 0x0c,
+	0x0a, 0x01, 0x71, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x71, 0x12, 0x0e, 0x0a, 0x02,
+	0x78, 0x69, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x78, 0x69, 0x12, 0x19, 0x0a, 0x08,
+	0x73, 0x68, 0x61, 0x72, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07,
+	0x73, 0x68, 0x61, 0x72, 0x65, 0x49, 0x64, 0x12, 0x43, 0x0a, 0x06, 0x70, 0x75, 0x62, 0x6c, 0x69,
+	0x63, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x62, 0x69, 0x6e, 0x61, 0x6e, 0x63,
+	0x65, 0x2e, 0x74, 0x73, 0x73, 0x6c, 0x69, 0x62, 0x2e, 0x65, 0x63, 0x64, 0x73, 0x61, 0x2e, 0x6b,
+	0x65, 0x79, 0x67, 0x65, 0x6e, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x44, 0x61, 0x74, 0x61, 0x50, 0x75,
+	0x62, 0x6c, 0x69, 0x63, 0x52, 0x06, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x42, 0x0e, 0x5a, 0x0c,
+	0x65, 0x63, 0x64, 0x73, 0x61, 0x2f, 0x6b, 0x65, 0x79, 0x67, 0x65, 0x6e, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_protob_ecdsa_savedata_proto_rawDescOnce sync.Once
+	file_protob_ecdsa_savedata_proto_rawDescData = file_protob_ecdsa_savedata_proto_rawDesc
+)
+
+func file_protob_ecdsa_savedata_proto_rawDescGZIP() []byte {
+	file_protob_ecdsa_savedata_proto_rawDescOnce.Do(func() {
+		file_protob_ecdsa_savedata_proto_rawDescData = protoimpl.X.CompressGZIP(file_protob_ecdsa_savedata_proto_rawDescData)
+	})
+	return file_protob_ecdsa_savedata_proto_rawDescData
+}
+
+var file_protob_ecdsa_savedata_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_protob_ecdsa_savedata_proto_goTypes = []interface{}{
+	(*ECPoint)(nil),            // 0: binance.tsslib.ecdsa.keygen.ECPoint
+	(*PaillierPrivateKey)(nil), // 1: binance.tsslib.ecdsa.keygen.PaillierPrivateKey
+	(*SaveDataPublic)(nil),     // 2: binance.tsslib.ecdsa.keygen.SaveDataPublic
+	(*SaveData)(nil),           // 3: binance.tsslib.ecdsa.keygen.SaveData
+}
+var file_protob_ecdsa_savedata_proto_depIdxs = []int32{
+	0, // 0: binance.tsslib.ecdsa.keygen.SaveDataPublic.big_xj:type_name -> binance.tsslib.ecdsa.keygen.ECPoint
+	0, // 1: binance.tsslib.ecdsa.keygen.SaveDataPublic.ecdsa_pub:type_name -> binance.tsslib.ecdsa.keygen.ECPoint
+	1, // 2: binance.tsslib.ecdsa.keygen.SaveData.paillier_sk:type_name -> binance.tsslib.ecdsa.keygen.PaillierPrivateKey
+	2, // 3: binance.tsslib.ecdsa.keygen.SaveData.public:type_name -> binance.tsslib.ecdsa.keygen.SaveDataPublic
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_protob_ecdsa_savedata_proto_init() }
+func file_protob_ecdsa_savedata_proto_init() {
+	if File_protob_ecdsa_savedata_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_protob_ecdsa_savedata_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ECPoint); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_savedata_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PaillierPrivateKey); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_savedata_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SaveDataPublic); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_savedata_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SaveData); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_protob_ecdsa_savedata_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_protob_ecdsa_savedata_proto_goTypes,
+		DependencyIndexes: file_protob_ecdsa_savedata_proto_depIdxs,
+		MessageInfos:      file_protob_ecdsa_savedata_proto_msgTypes,
+	}.Build()
+	File_protob_ecdsa_savedata_proto = out.File
+	file_protob_ecdsa_savedata_proto_rawDesc = nil
+	file_protob_ecdsa_savedata_proto_goTypes = nil
+	file_protob_ecdsa_savedata_proto_depIdxs = nil
+}
@@ -8,6 +8,7 @@ package keygen
 
 import (
 	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/rand"
 	"encoding/json"
 	"fmt"
@@ -169,8 +170,6 @@ func TestBadMessageCulprits(t *testing.T) {
 func TestE2EConcurrentAndSaveFixtures(t *testing.T) {
 	setUp("info")
 
-	// tss.SetCurve(elliptic.P256())
-
 	threshold := testThreshold
 	fixtures, pIDs, err := LoadKeygenTestFixtures(testParticipants)
 	if err != nil {
@@ -243,7 +242,7 @@ keygen:
 			// .. here comes a workaround to recover this party's index (it was removed from save data)
 			index, err := save.OriginalIndex()
 			assert.NoErrorf(t, err, "should not be an error getting a party's index from save data")
-			tryWriteTestFixtureFile(t, index, *save)
+			tryWriteTestFixtureFile(t, tss.S256(), index, *save)
 
 			atomic.AddInt32(&ended, 1)
 			if atomic.LoadInt32(&ended) == int32(len(pIDs)) {
@@ -338,8 +337,101 @@ keygen:
 	}
 }
 
-func tryWriteTestFixtureFile(t *testing.T, index int, data LocalPartySaveData) {
-	fixtureFileName := makeTestFixtureFilePath(index)
+func TestE2EConcurrentP256AndSaveFixtures(t *testing.T) {
+	setUp("info")
+
+	ec := tss.P256()
+	threshold := testThreshold
+	// the Paillier keys and safe primes do not depend on the curve, so the secp256k1 pre-params are reused here
+	preParams, pIDs, err := LoadKeygenTestFixtures(testParticipants)
+	if err != nil {
+		t.Skip("run TestE2EConcurrentAndSaveFixtures first to generate the pre-params")
+	}
+
+	p2pCtx := tss.NewPeerContext(pIDs)
+	parties := make([]*LocalParty, 0, len(pIDs))
+
+	errCh := make(chan *tss.Error, len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs))
+	endCh := make(chan *LocalPartySaveData, len(pIDs))
+
+	updater := test.SharedPartyUpdater
+
+	for i := 0; i < len(pIDs); i++ {
+		params := tss.NewParameters(ec, p2pCtx, pIDs[i], len(pIDs), threshold)
+		// do not use in untrusted setting
+		params.SetNoProofMod()
+		// do not use in untrusted setting
+		params.SetNoProofFac()
+		P := NewLocalParty(params, outCh, endCh, preParams[i].LocalPreParams).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	saves := make([]*LocalPartySaveData, 0, len(pIDs))
+keygen:
+	for {
+		select {
+		case err := <-errCh:
+			common.Logger.Errorf("Error: %s", err)
+			assert.FailNow(t, err.Error())
+			break keygen
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else {
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case save := <-endCh:
+			index, err := save.OriginalIndex()
+			assert.NoErrorf(t, err, "should not be an error getting a party's index from save data")
+			tryWriteTestFixtureFile(t, ec, index, *save)
+			saves = append(saves, save)
+			if len(saves) == len(pIDs) {
+				break keygen
+			}
+		}
+	}
+
+	// the shares must interpolate to the discrete log of the P-256 public key
+	shares := make(vss.Shares, 0, threshold+1)
+	for _, save := range saves[:threshold+1] {
+		shares = append(shares, &vss.Share{Threshold: threshold, ID: save.ShareID, Share: save.Xi})
+	}
+	u, err := shares.ReConstruct(ec)
+	assert.NoError(t, err)
+	pub := saves[0].ECDSAPub
+	assert.True(t, tss.SameCurve(ec, pub.Curve()), "public key must be on P-256")
+	assert.True(t, crypto.ScalarBaseMult(ec, u).Equals(pub), "public key must match the interpolated secret")
+	for _, save := range saves {
+		assert.True(t, pub.Equals(save.ECDSAPub))
+		for j, BigXj := range save.BigXj {
+			assert.True(t, BigXj.Equals(saves[0].BigXj[j]))
+		}
+	}
+
+	// the key must be usable with the standard library
+	sk := &ecdsa.PrivateKey{PublicKey: ecdsa.PublicKey{Curve: ec, X: pub.X(), Y: pub.Y()}, D: u}
+	data := make([]byte, 32)
+	r, s, err := ecdsa.Sign(rand.Reader, sk, data)
+	assert.NoError(t, err)
+	assert.True(t, ecdsa.Verify(&sk.PublicKey, data, r, s), "signature should be ok")
+}
+
+func tryWriteTestFixtureFile(t *testing.T, ec elliptic.Curve, index int, data LocalPartySaveData) {
+	fixtureFileName := makeTestFixtureFilePathForCurve(ec, index)
 
 	// fixture file does not already exist?
 	// if it does, we won't re-create it here
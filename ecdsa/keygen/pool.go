@@ -0,0 +1,360 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto/sealed"
+)
+
+const (
+	// PreParamsSealedProtocol identifies pre-parameters in a sealed container
+	PreParamsSealedProtocol = "ecdsa-preparams"
+	// PreParamsSealedProtocolVersion is the version of the JSON encoding of LocalPreParams in a sealed container
+	PreParamsSealedProtocolVersion = 1
+
+	preParamsFileExt = ".preparams"
+	// the wait before generating again after a generation failed
+	preParamsRetryInterval = 10 * time.Second
+)
+
+// ErrInvalidPreParams is returned by OpenPreParams for a container that opens but holds pre-parameters that are
+// malformed or fail VerifyPreParams
+var ErrInvalidPreParams = errors.New("the pre-params are invalid")
+
+// PreParamsPool keeps a stock of pre-parameters, generated in the background and sealed on disk, so that keygen and
+// re-sharing parties do not wait minutes for them. Each entry is handed out once: its file is removed before it is
+// returned, so that the same Paillier key is never used in two runs, even across restarts.
+type PreParamsPool struct {
+	dir      string
+	key      sealed.Key
+	stock    int
+	generate func(ctx context.Context) (*LocalPreParams, error)
+
+	mtx     sync.Mutex
+	entries []string // the files of the entries that are ready
+	ready   chan struct{}
+	refill  chan struct{}
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewPreParamsPool opens the pool kept in dir, which it creates if needed, and starts generating pre-parameters in the
+// background until stock of them are ready. The entries already in dir are checked with ValidateWithProof and
+// primality tests, and those that fail are removed. If not specified, the generation uses a concurrency of a quarter of
+// the available CPU cores, so that it does not starve the parties that are running.
+func NewPreParamsPool(dir string, key sealed.Key, stock int, optionalConcurrency ...int) (*PreParamsPool, error) {
+	if stock < 1 {
+		return nil, errors.New("NewPreParamsPool: the stock must be at least 1")
+	}
+	concurrency := runtime.NumCPU() / 4
+	if 0 < len(optionalConcurrency) {
+		if 1 < len(optionalConcurrency) {
+			panic(errors.New("NewPreParamsPool: expected 0 or 1 item in `optionalConcurrency`"))
+		}
+		concurrency = optionalConcurrency[0]
+	}
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	return newPreParamsPool(dir, key, stock, func(ctx context.Context) (*LocalPreParams, error) {
+		return GeneratePreParamsWithContext(ctx, concurrency)
+	})
+}
+
+func newPreParamsPool(dir string, key sealed.Key, stock int, generate func(ctx context.Context) (*LocalPreParams, error)) (*PreParamsPool, error) {
+	pool := &PreParamsPool{
+		dir:      dir,
+		key:      key,
+		stock:    stock,
+		generate: generate,
+		ready:    make(chan struct{}),
+		refill:   make(chan struct{}, 1),
+		done:     make(chan struct{}),
+	}
+	if err := pool.load(); err != nil {
+		return nil, err
+	}
+	pool.start()
+	return pool, nil
+}
+
+// Get hands out pre-parameters from the pool, waiting for some to be generated if none are ready, and returns an
+// error if ctx is done or the pool is closed first
+func (pool *PreParamsPool) Get(ctx context.Context) (*LocalPreParams, error) {
+	for {
+		pool.mtx.Lock()
+		if len(pool.entries) == 0 {
+			ready := pool.ready
+			pool.mtx.Unlock()
+			select {
+			case <-ready:
+				continue
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-pool.done:
+				return nil, errors.New("PreParamsPool: the pool is closed")
+			}
+		}
+		path := pool.entries[0]
+		pool.entries = pool.entries[1:]
+		pool.mtx.Unlock()
+		pool.signalRefill()
+
+		preParams, err := pool.read(path)
+		if err != nil && !errors.Is(err, ErrInvalidPreParams) {
+			// left on disk for the next pool, as the error may not last
+			common.Logger.Warningf("PreParamsPool: skipped the entry %s: %v", path, err)
+			continue
+		}
+		// the entry is removed before it is handed out; one that cannot be removed is not handed out at all
+		if rmErr := os.Remove(path); rmErr != nil {
+			return nil, rmErr
+		}
+		// and the removal made durable, so that the entry does not come back after a crash
+		if syncErr := common.SyncDir(pool.dir); syncErr != nil {
+			return nil, syncErr
+		}
+		if err != nil {
+			common.Logger.Warningf("PreParamsPool: dropped the entry %s: %v", path, err)
+			continue
+		}
+		return preParams, nil
+	}
+}
+
+// Len returns the number of pre-parameters that are ready
+func (pool *PreParamsPool) Len() int {
+	pool.mtx.Lock()
+	defer pool.mtx.Unlock()
+	return len(pool.entries)
+}
+
+// Close stops the generation and waits for it to end. The entries that are ready stay on disk for the next pool.
+func (pool *PreParamsPool) Close() {
+	pool.cancel()
+	<-pool.done
+}
+
+// ----- //
+
+// VerifyPreParams checks that pre-parameters are complete and consistent, and that their primes are prime
+func VerifyPreParams(preParams *LocalPreParams) error {
+	if preParams == nil || !preParams.ValidateWithProof() {
+		return errors.New("the pre-params are incomplete")
+	}
+	sk := preParams.PaillierSK
+	if sk.N == nil || sk.N.BitLen() != paillierBitsLen || new(big.Int).Mul(sk.P, sk.Q).Cmp(sk.N) != 0 {
+		return errors.New("the Paillier modulus is not the product of its primes")
+	}
+	if !sk.P.ProbablyPrime(30) || !sk.Q.ProbablyPrime(30) {
+		return errors.New("a Paillier prime is not prime")
+	}
+	NTilde := big.NewInt(1)
+	for _, p := range []*big.Int{preParams.P, preParams.Q} {
+		// NTilde = (2p + 1)(2q + 1)
+		safe := new(big.Int).Lsh(p, 1)
+		safe.Add(safe, big.NewInt(1))
+		if !p.ProbablyPrime(30) || !safe.ProbablyPrime(30) {
+			return errors.New("a prime of NTilde is not a safe prime")
+		}
+		NTilde.Mul(NTilde, safe)
+	}
+	if NTilde.Cmp(preParams.NTildei) != 0 || NTilde.BitLen() != paillierBitsLen {
+		return errors.New("NTilde is not the product of its safe primes")
+	}
+	modPQ := common.ModInt(new(big.Int).Mul(preParams.P, preParams.Q))
+	if modPQ.Mul(preParams.Alpha, preParams.Beta).Cmp(big.NewInt(1)) != 0 {
+		return errors.New("beta is not the inverse of alpha")
+	}
+	if common.ModInt(NTilde).Exp(preParams.H1i, preParams.Alpha).Cmp(preParams.H2i) != 0 || preParams.H1i.Cmp(preParams.H2i) == 0 {
+		return errors.New("h2 is not h1 to the power of alpha")
+	}
+	return nil
+}
+
+// SealPreParams encrypts pre-parameters for storage at rest with a passphrase or wrapping key (see crypto/sealed)
+func SealPreParams(preParams *LocalPreParams, key sealed.Key) ([]byte, error) {
+	if preParams == nil || !preParams.ValidateWithProof() {
+		return nil, errors.New("SealPreParams: the pre-params are incomplete")
+	}
+	plaintext, err := json.Marshal(preParams)
+	if err != nil {
+		return nil, err
+	}
+	header := sealed.Header{
+		Protocol:        PreParamsSealedProtocol,
+		ProtocolVersion: PreParamsSealedProtocolVersion,
+	}
+	return sealed.Seal(header, plaintext, key)
+}
+
+// OpenPreParams decrypts pre-parameters sealed by SealPreParams and verifies them with VerifyPreParams
+func OpenPreParams(container []byte, key sealed.Key) (*LocalPreParams, error) {
+	header, plaintext, err := sealed.Open(container, key)
+	if err != nil {
+		return nil, err
+	}
+	if header.Protocol != PreParamsSealedProtocol {
+		return nil, fmt.Errorf("OpenPreParams: the container holds %q, not %q", header.Protocol, PreParamsSealedProtocol)
+	}
+	if header.ProtocolVersion != PreParamsSealedProtocolVersion {
+		return nil, fmt.Errorf("OpenPreParams: unsupported version %d", header.ProtocolVersion)
+	}
+	preParams := new(LocalPreParams)
+	if err = json.Unmarshal(plaintext, preParams); err != nil {
+		return nil, fmt.Errorf("OpenPreParams: %w: %v", ErrInvalidPreParams, err)
+	}
+	if err = VerifyPreParams(preParams); err != nil {
+		return nil, fmt.Errorf("OpenPreParams: %w: %v", ErrInvalidPreParams, err)
+	}
+	return preParams, nil
+}
+
+// ----- //
+
+// load finds the entries in the directory of the pool, removing those that open but fail verification. The entries
+// that cannot be read or opened for another reason are skipped but kept, as they may hold hours of work and the error
+// may not last. It fails if an entry cannot be opened with the key of the pool, as the key is likely wrong.
+func (pool *PreParamsPool) load() error {
+	if err := os.MkdirAll(pool.dir, 0o700); err != nil {
+		return err
+	}
+	files, err := ioutil.ReadDir(pool.dir)
+	if err != nil {
+		return err
+	}
+	for _, fi := range files {
+		if fi.IsDir() || !strings.HasSuffix(fi.Name(), preParamsFileExt) {
+			continue
+		}
+		path := filepath.Join(pool.dir, fi.Name())
+		if _, err := pool.read(path); err != nil {
+			if errors.Is(err, sealed.ErrWrongKey) {
+				return fmt.Errorf("NewPreParamsPool: unable to open %s: %w", path, err)
+			}
+			if !errors.Is(err, ErrInvalidPreParams) {
+				common.Logger.Warningf("PreParamsPool: skipped the entry %s: %v", path, err)
+				continue
+			}
+			common.Logger.Warningf("PreParamsPool: removing the entry %s: %v", path, err)
+			if err := os.Remove(path); err != nil {
+				return err
+			}
+			if err := common.SyncDir(pool.dir); err != nil {
+				return err
+			}
+			continue
+		}
+		pool.entries = append(pool.entries, path)
+	}
+	return nil
+}
+
+func (pool *PreParamsPool) start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	pool.cancel = cancel
+	go func() {
+		defer close(pool.done)
+		for {
+			if pool.Len() >= pool.stock {
+				select {
+				case <-pool.refill:
+					continue
+				case <-ctx.Done():
+					return
+				}
+			}
+			preParams, err := pool.generate(ctx)
+			if err == nil {
+				err = pool.add(preParams)
+			}
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				common.Logger.Errorf("PreParamsPool: failed to generate pre-params: %v", err)
+				select {
+				case <-time.After(preParamsRetryInterval):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+}
+
+// add seals new pre-parameters to a file of their own, written atomically, and makes them ready
+func (pool *PreParamsPool) add(preParams *LocalPreParams) error {
+	container, err := SealPreParams(preParams, pool.key)
+	if err != nil {
+		return err
+	}
+	name, err := common.GetRandomBytes(rand.Reader, 16)
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(pool.dir, hex.EncodeToString(name)+preParamsFileExt)
+	f, err := ioutil.TempFile(pool.dir, ".preparams-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err = f.Write(container); err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	if err = os.Rename(f.Name(), path); err != nil {
+		return err
+	}
+	if err = common.SyncDir(pool.dir); err != nil {
+		return err
+	}
+
+	pool.mtx.Lock()
+	defer pool.mtx.Unlock()
+	pool.entries = append(pool.entries, path)
+	close(pool.ready)
+	pool.ready = make(chan struct{})
+	return nil
+}
+
+func (pool *PreParamsPool) read(path string) (*LocalPreParams, error) {
+	container, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return OpenPreParams(container, pool.key)
+}
+
+func (pool *PreParamsPool) signalRefill() {
+	select {
+	case pool.refill <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto/sealed"
+)
+
+const testPoolTimeout = 30 * time.Second
+
+func testPoolKey() sealed.Key {
+	return sealed.PassphraseWithParams([]byte("correct horse"), sealed.Argon2Params{Time: 1, Memory: 1024, Threads: 1})
+}
+
+// fixturePreParams returns a generator that hands out the pre-params of the keygen fixtures, then blocks
+func fixturePreParams(t *testing.T, count int) func(ctx context.Context) (*LocalPreParams, error) {
+	keys, _, err := LoadKeygenTestFixtures(count)
+	assert.NoError(t, err, "should load keygen fixtures")
+	next := make(chan *LocalPreParams, count)
+	for _, key := range keys {
+		preParams := key.LocalPreParams
+		next <- &preParams
+	}
+	return func(ctx context.Context) (*LocalPreParams, error) {
+		select {
+		case preParams := <-next:
+			return preParams, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func TestPreParamsPoolHandsOutOnce(t *testing.T) {
+	dir := t.TempDir()
+	pool, err := newPreParamsPool(dir, testPoolKey(), 2, fixturePreParams(t, 4))
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testPoolTimeout)
+	defer cancel()
+	first, err := pool.Get(ctx)
+	assert.NoError(t, err)
+	second, err := pool.Get(ctx)
+	assert.NoError(t, err)
+	assert.NotEqual(t, 0, first.PaillierSK.N.Cmp(second.PaillierSK.N))
+	// the stock is made up again with the last fixtures
+	for pool.Len() < 2 {
+		select {
+		case <-ctx.Done():
+			assert.FailNow(t, "the pool was not refilled")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	pool.Close()
+	files, err := filepath.Glob(filepath.Join(dir, "*"+preParamsFileExt))
+	assert.NoError(t, err)
+	assert.Len(t, files, 2)
+
+	// the entries that were handed out are not handed out again by a new pool
+	pool, err = newPreParamsPool(dir, testPoolKey(), 2, fixturePreParams(t, 0))
+	assert.NoError(t, err)
+	defer pool.Close()
+	assert.Equal(t, 2, pool.Len())
+	for i := 0; i < 2; i++ {
+		preParams, err := pool.Get(ctx)
+		assert.NoError(t, err)
+		assert.NotEqual(t, 0, first.PaillierSK.N.Cmp(preParams.PaillierSK.N))
+		assert.NotEqual(t, 0, second.PaillierSK.N.Cmp(preParams.PaillierSK.N))
+	}
+	short, cancelShort := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancelShort()
+	_, err = pool.Get(short)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestPreParamsPoolRemovesInvalidEntries(t *testing.T) {
+	keys, _, err := LoadKeygenTestFixtures(1)
+	assert.NoError(t, err, "should load keygen fixtures")
+	preParams := keys[0].LocalPreParams
+	preParams.Alpha = new(big.Int).Add(preParams.Alpha, big.NewInt(1))
+	container, err := SealPreParams(&preParams, testPoolKey())
+	assert.NoError(t, err)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "invalid"+preParamsFileExt)
+	assert.NoError(t, ioutil.WriteFile(path, container, 0o600))
+
+	pool, err := newPreParamsPool(dir, testPoolKey(), 1, fixturePreParams(t, 0))
+	assert.NoError(t, err)
+	defer pool.Close()
+	assert.Equal(t, 0, pool.Len())
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestPreParamsPoolKeepsUnreadableEntries(t *testing.T) {
+	keys, _, err := LoadKeygenTestFixtures(1)
+	assert.NoError(t, err, "should load keygen fixtures")
+	container, err := SealPreParams(&keys[0].LocalPreParams, testPoolKey())
+	assert.NoError(t, err)
+	container[len(container)-1] ^= 1
+	dir := t.TempDir()
+	path := filepath.Join(dir, "corrupted"+preParamsFileExt)
+	assert.NoError(t, ioutil.WriteFile(path, container, 0o600))
+
+	pool, err := newPreParamsPool(dir, testPoolKey(), 1, fixturePreParams(t, 0))
+	assert.NoError(t, err)
+	defer pool.Close()
+	assert.Equal(t, 0, pool.Len())
+	_, err = os.Stat(path)
+	assert.NoError(t, err, "an entry that does not open must be kept")
+}
+
+func TestPreParamsPoolWrongKey(t *testing.T) {
+	dir := t.TempDir()
+	pool, err := newPreParamsPool(dir, testPoolKey(), 1, fixturePreParams(t, 1))
+	assert.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), testPoolTimeout)
+	defer cancel()
+	for pool.Len() < 1 {
+		select {
+		case <-ctx.Done():
+			assert.FailNow(t, "the pool was not filled")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	pool.Close()
+
+	wrong := sealed.PassphraseWithParams([]byte("wrong horse"), sealed.Argon2Params{Time: 1, Memory: 1024, Threads: 1})
+	_, err = newPreParamsPool(dir, wrong, 1, fixturePreParams(t, 0))
+	assert.True(t, errors.Is(err, sealed.ErrWrongKey), "%v", err)
+	files, err := filepath.Glob(filepath.Join(dir, "*"+preParamsFileExt))
+	assert.NoError(t, err)
+	assert.Len(t, files, 1, "the entries must be kept")
+}
+
+func TestVerifyPreParams(t *testing.T) {
+	keys, _, err := LoadKeygenTestFixtures(1)
+	assert.NoError(t, err, "should load keygen fixtures")
+	preParams := keys[0].LocalPreParams
+	assert.NoError(t, VerifyPreParams(&preParams))
+
+	container, err := SealPreParams(&preParams, testPoolKey())
+	assert.NoError(t, err)
+	opened, err := OpenPreParams(container, testPoolKey())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, preParams.NTildei.Cmp(opened.NTildei))
+
+	bad := preParams
+	bad.P = new(big.Int).Add(preParams.P, big.NewInt(2))
+	assert.Error(t, VerifyPreParams(&bad))
+	bad = preParams
+	bad.H2i = new(big.Int).Set(preParams.H1i)
+	assert.Error(t, VerifyPreParams(&bad))
+	assert.Error(t, VerifyPreParams(nil))
+}
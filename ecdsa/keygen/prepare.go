@@ -24,8 +24,6 @@ const (
 	paillierModulusLen = 2048
 	// Two 1024-bit safe primes to produce NTilde
 	safePrimeBitLen = 1024
-	// Ticker for printing log statements while generating primes/modulus
-	logProgressTickInterval = 8 * time.Second
 	// Safe big len using random for ssid
 	SafeBitLen = 1024
 )
@@ -89,7 +87,7 @@ func GeneratePreParamsWithContextAndRandom(ctx context.Context, rand io.Reader,
 		var err error
 		common.Logger.Info("generating the safe primes for the signing proofs, please wait...")
 		start := time.Now()
-		sgps, err := common.GetRandomSafePrimesConcurrent(ctx, safePrimeBitLen, 2, concurrency, rand)
+		sgps, err := common.GetRandomSafePrimesConcurrent(ctx, safePrimeBitLen, 2, concurrency, rand, logSafePrimeProgress(start))
 		if err != nil {
 			ch <- nil
 			return
@@ -98,17 +96,12 @@ func GeneratePreParamsWithContextAndRandom(ctx context.Context, rand io.Reader,
 		ch <- sgps
 	}(sgpCh)
 
-	// this ticker will print a log statement while the generating is still in progress
-	logProgressTicker := time.NewTicker(logProgressTickInterval)
-
 	// errors can be thrown in the following code; consume chans to end goroutines here
 	var sgps []*common.GermainSafePrime
 	var paiSK *paillier.PrivateKey
 consumer:
 	for {
 		select {
-		case <-logProgressTicker.C:
-			common.Logger.Info("still generating primes...")
 		case sgps = <-sgpCh:
 			if sgps == nil ||
 				sgps[0] == nil || sgps[1] == nil ||
@@ -128,7 +121,6 @@ consumer:
 			}
 		}
 	}
-	logProgressTicker.Stop()
 
 	P, Q := sgps[0].SafePrime(), sgps[1].SafePrime()
 	NTildei := new(big.Int).Mul(P, Q)
@@ -154,3 +146,16 @@ consumer:
 	}
 	return preParams, nil
 }
+
+// logSafePrimeProgress logs each safe prime as it is found
+func logSafePrimeProgress(start time.Time) func(common.SafePrimeProgress) {
+	found := 0
+	return func(progress common.SafePrimeProgress) {
+		if progress.Found == found {
+			return
+		}
+		found = progress.Found
+		common.Logger.Infof("safe prime %d of %d found after testing %d of %d candidates. took %s",
+			progress.Found, progress.Wanted, progress.Tested, progress.Sieved, time.Since(start))
+	}
+}
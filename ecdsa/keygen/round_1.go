@@ -126,7 +126,7 @@ func (round *round1) Start() *tss.Error {
 			return round.WrapError(err, Pi)
 		}
 		round.temp.kgRound1Messages[i] = msg
-		round.out <- msg
+		round.out <- tss.WithRound(msg, round.number)
 	}
 	return nil
 }
@@ -133,7 +133,11 @@ func (round *round2) Start() *tss.Error {
 			round.temp.kgRound2Message1s[j] = r2msg1
 			continue
 		}
-		round.out <- r2msg1
+		enc, err := tss.EncryptMessage(round.Params(), r2msg1)
+		if err != nil {
+			return round.WrapError(err)
+		}
+		round.out <- tss.WithRound(enc, round.number)
 	}
 
 	// 7. BROADCAST de-commitments of Shamir poly*G
@@ -148,7 +152,7 @@ func (round *round2) Start() *tss.Error {
 	}
 	r2msg2 := NewKGRound2Message2(round.PartyID(), round.temp.deCommitPolyG, modProof)
 	round.temp.kgRound2Message2s[i] = r2msg2
-	round.out <- r2msg2
+	round.out <- tss.WithRound(r2msg2, round.number)
 
 	return nil
 }
@@ -216,7 +216,7 @@ func (round *round3) Start() *tss.Error {
 	proof := round.save.PaillierSK.Proof(ki, ecdsaPubKey)
 	r3msg := NewKGRound3Message(round.PartyID(), proof)
 	round.temp.kgRound3Messages[PIdx] = r3msg
-	round.out <- r3msg
+	round.out <- tss.WithRound(r3msg, round.number)
 	return nil
 }
 
@@ -0,0 +1,283 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"crypto/elliptic"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/paillier"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// SaveDataProtoVersion is the version written to SaveData.Version
+const SaveDataProtoVersion = 1
+
+type (
+	// the layout of LocalPartySaveData in the legacy JSON encoding, with the points left unchecked until their curve is known
+	legacyECPoint struct {
+		Curve  string
+		Coords [2]*big.Int
+	}
+
+	legacyLocalPartySaveData struct {
+		PaillierSK *paillier.PrivateKey
+		NTildei,
+		H1i, H2i,
+		Alpha, Beta,
+		P, Q *big.Int
+
+		Xi, ShareID *big.Int
+
+		Ks                []*big.Int
+		NTildej, H1j, H2j []*big.Int
+		BigXj             []*legacyECPoint
+		PaillierPKs       []*paillier.PublicKey
+		ECDSAPub          *legacyECPoint
+	}
+)
+
+// ToProto converts the save data to its canonical protobuf encoding
+func (save LocalPartySaveData) ToProto() (*SaveData, error) {
+	public, err := save.ToPublicProto()
+	if err != nil {
+		return nil, err
+	}
+	pb := &SaveData{
+		Version: SaveDataProtoVersion,
+		NTildeI: bigIntToBytes(save.NTildei),
+		H1I:     bigIntToBytes(save.H1i),
+		H2I:     bigIntToBytes(save.H2i),
+		Alpha:   bigIntToBytes(save.Alpha),
+		Beta:    bigIntToBytes(save.Beta),
+		P:       bigIntToBytes(save.P),
+		Q:       bigIntToBytes(save.Q),
+		Xi:      bigIntToBytes(save.Xi),
+		ShareId: bigIntToBytes(save.ShareID),
+		Public:  public,
+	}
+	if sk := save.PaillierSK; sk != nil {
+		pb.PaillierSk = &PaillierPrivateKey{
+			N:       bigIntToBytes(sk.N),
+			LambdaN: bigIntToBytes(sk.LambdaN),
+			PhiN:    bigIntToBytes(sk.PhiN),
+			P:       bigIntToBytes(sk.P),
+			Q:       bigIntToBytes(sk.Q),
+		}
+	}
+	return pb, nil
+}
+
+// ToPublicProto converts the parts of the save data that are common to all parties, which may be handed to services
+// that must not see the secrets
+func (save LocalPartySaveData) ToPublicProto() (*SaveDataPublic, error) {
+	pb := &SaveDataPublic{
+		Ks:          bigIntsToBytes(save.Ks),
+		NTildeJ:     bigIntsToBytes(save.NTildej),
+		H1J:         bigIntsToBytes(save.H1j),
+		H2J:         bigIntsToBytes(save.H2j),
+		PaillierPks: make([][]byte, len(save.PaillierPKs)),
+		BigXj:       make([]*ECPoint, len(save.BigXj)),
+	}
+	for j, pk := range save.PaillierPKs {
+		if pk != nil {
+			pb.PaillierPks[j] = bigIntToBytes(pk.N)
+		}
+	}
+	var err error
+	for j, bigXj := range save.BigXj {
+		if pb.BigXj[j], err = ecPointToProto(bigXj); err != nil {
+			return nil, err
+		}
+	}
+	if pb.EcdsaPub, err = ecPointToProto(save.ECDSAPub); err != nil {
+		return nil, err
+	}
+	return pb, nil
+}
+
+// LocalPartySaveDataFromProto converts the canonical protobuf encoding back to save data
+func LocalPartySaveDataFromProto(pb *SaveData) (LocalPartySaveData, error) {
+	var save LocalPartySaveData
+	if pb == nil || pb.GetPublic() == nil {
+		return save, errors.New("LocalPartySaveDataFromProto: the save data is empty")
+	}
+	if pb.GetVersion() != SaveDataProtoVersion {
+		return save, fmt.Errorf("LocalPartySaveDataFromProto: unsupported version %d", pb.GetVersion())
+	}
+	public := pb.GetPublic()
+	partyCount := len(public.GetKs())
+	if len(public.GetBigXj()) != partyCount ||
+		len(public.GetNTildeJ()) != partyCount || len(public.GetH1J()) != partyCount || len(public.GetH2J()) != partyCount ||
+		len(public.GetPaillierPks()) != partyCount {
+		return save, errors.New("LocalPartySaveDataFromProto: the lists of the public data must have one entry per party")
+	}
+	if sk := pb.GetPaillierSk(); sk != nil {
+		save.PaillierSK = &paillier.PrivateKey{
+			PublicKey: paillier.PublicKey{N: bytesToBigInt(sk.GetN())},
+			LambdaN:   bytesToBigInt(sk.GetLambdaN()),
+			PhiN:      bytesToBigInt(sk.GetPhiN()),
+			P:         bytesToBigInt(sk.GetP()),
+			Q:         bytesToBigInt(sk.GetQ()),
+		}
+	}
+	save.NTildei = bytesToBigInt(pb.GetNTildeI())
+	save.H1i, save.H2i = bytesToBigInt(pb.GetH1I()), bytesToBigInt(pb.GetH2I())
+	save.Alpha, save.Beta = bytesToBigInt(pb.GetAlpha()), bytesToBigInt(pb.GetBeta())
+	save.P, save.Q = bytesToBigInt(pb.GetP()), bytesToBigInt(pb.GetQ())
+	save.Xi, save.ShareID = bytesToBigInt(pb.GetXi()), bytesToBigInt(pb.GetShareId())
+
+	save.Ks = bytesToBigInts(public.GetKs())
+	save.NTildej = bytesToBigInts(public.GetNTildeJ())
+	save.H1j, save.H2j = bytesToBigInts(public.GetH1J()), bytesToBigInts(public.GetH2J())
+	save.PaillierPKs = make([]*paillier.PublicKey, partyCount)
+	for j, n := range public.GetPaillierPks() {
+		if len(n) > 0 {
+			save.PaillierPKs[j] = &paillier.PublicKey{N: bytesToBigInt(n)}
+		}
+	}
+	save.BigXj = make([]*crypto.ECPoint, partyCount)
+	var err error
+	for j, bigXj := range public.GetBigXj() {
+		if save.BigXj[j], err = ecPointFromProto(bigXj); err != nil {
+			return save, err
+		}
+	}
+	if save.ECDSAPub, err = ecPointFromProto(public.GetEcdsaPub()); err != nil {
+		return save, err
+	}
+	return save, nil
+}
+
+// MarshalProto returns the canonical protobuf encoding of the save data
+func (save LocalPartySaveData) MarshalProto() ([]byte, error) {
+	pb, err := save.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(pb)
+}
+
+// UnmarshalLocalPartySaveData parses save data in the canonical protobuf encoding
+func UnmarshalLocalPartySaveData(bz []byte) (LocalPartySaveData, error) {
+	pb := new(SaveData)
+	if err := proto.Unmarshal(bz, pb); err != nil {
+		return LocalPartySaveData{}, err
+	}
+	return LocalPartySaveDataFromProto(pb)
+}
+
+// ParseLegacyJSONSaveData reads save data in the legacy JSON encoding, i.e. the default encoding of
+// LocalPartySaveData. Points that were written without a curve name, as they were before the curve registry, are
+// taken to be on ec.
+func ParseLegacyJSONSaveData(bz []byte, ec elliptic.Curve) (LocalPartySaveData, error) {
+	var save LocalPartySaveData
+	legacy := new(legacyLocalPartySaveData)
+	if err := json.Unmarshal(bz, legacy); err != nil {
+		return save, err
+	}
+	save.PaillierSK = legacy.PaillierSK
+	save.NTildei, save.H1i, save.H2i = legacy.NTildei, legacy.H1i, legacy.H2i
+	save.Alpha, save.Beta, save.P, save.Q = legacy.Alpha, legacy.Beta, legacy.P, legacy.Q
+	save.Xi, save.ShareID = legacy.Xi, legacy.ShareID
+	save.Ks = legacy.Ks
+	save.NTildej, save.H1j, save.H2j = legacy.NTildej, legacy.H1j, legacy.H2j
+	save.PaillierPKs = legacy.PaillierPKs
+	save.BigXj = make([]*crypto.ECPoint, len(legacy.BigXj))
+	var err error
+	for j, bigXj := range legacy.BigXj {
+		if save.BigXj[j], err = legacyECPointToECPoint(bigXj, ec); err != nil {
+			return save, err
+		}
+	}
+	if save.ECDSAPub, err = legacyECPointToECPoint(legacy.ECDSAPub, ec); err != nil {
+		return save, err
+	}
+	return save, nil
+}
+
+// MigrateJSONSaveData converts save data from the legacy JSON encoding to the canonical protobuf encoding
+func MigrateJSONSaveData(bz []byte, ec elliptic.Curve) ([]byte, error) {
+	save, err := ParseLegacyJSONSaveData(bz, ec)
+	if err != nil {
+		return nil, err
+	}
+	return save.MarshalProto()
+}
+
+// ----- //
+
+func legacyECPointToECPoint(p *legacyECPoint, ec elliptic.Curve) (*crypto.ECPoint, error) {
+	if p == nil {
+		return nil, nil
+	}
+	if len(p.Curve) > 0 {
+		var ok bool
+		if ec, ok = tss.GetCurveByName(tss.CurveName(p.Curve)); !ok {
+			return nil, fmt.Errorf("ParseLegacyJSONSaveData: unknown curve %s", p.Curve)
+		}
+	}
+	return crypto.NewECPoint(ec, p.Coords[0], p.Coords[1])
+}
+
+func ecPointToProto(p *crypto.ECPoint) (*ECPoint, error) {
+	if p == nil {
+		return nil, nil
+	}
+	name, ok := tss.GetCurveName(p.Curve())
+	if !ok {
+		return nil, fmt.Errorf("cannot find %T name in curve registry", p.Curve())
+	}
+	return &ECPoint{Curve: string(name), X: p.X().Bytes(), Y: p.Y().Bytes()}, nil
+}
+
+func ecPointFromProto(pb *ECPoint) (*crypto.ECPoint, error) {
+	if pb == nil {
+		return nil, nil
+	}
+	ec, ok := tss.GetCurveByName(tss.CurveName(pb.GetCurve()))
+	if !ok {
+		return nil, fmt.Errorf("cannot find curve named with %s in curve registry", pb.GetCurve())
+	}
+	return crypto.NewECPoint(ec, new(big.Int).SetBytes(pb.GetX()), new(big.Int).SetBytes(pb.GetY()))
+}
+
+func bigIntToBytes(i *big.Int) []byte {
+	if i == nil {
+		return nil
+	}
+	return i.Bytes()
+}
+
+func bytesToBigInt(bz []byte) *big.Int {
+	if len(bz) == 0 {
+		return nil
+	}
+	return new(big.Int).SetBytes(bz)
+}
+
+func bigIntsToBytes(is []*big.Int) [][]byte {
+	out := make([][]byte, len(is))
+	for j, i := range is {
+		out[j] = bigIntToBytes(i)
+	}
+	return out
+}
+
+func bytesToBigInts(bzs [][]byte) []*big.Int {
+	out := make([]*big.Int, len(bzs))
+	for j, bz := range bzs {
+		out[j] = bytesToBigInt(bz)
+	}
+	return out
+}
@@ -0,0 +1,79 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"crypto/elliptic"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func TestMigrateJSONSaveData(t *testing.T) {
+	for _, ec := range []struct {
+		name  tss.CurveName
+		curve func() elliptic.Curve
+	}{{tss.Secp256k1, tss.S256}, {tss.Secp256r1, tss.P256}} {
+		keys, _, err := LoadKeygenTestFixturesForCurve(ec.curve(), 1)
+		assert.NoError(t, err, "should load keygen fixtures")
+		legacy, err := ioutil.ReadFile(makeTestFixtureFilePathForCurve(ec.curve(), 0))
+		assert.NoError(t, err)
+
+		bz, err := MigrateJSONSaveData(legacy, ec.curve())
+		assert.NoError(t, err)
+		migrated, err := UnmarshalLocalPartySaveData(bz)
+		assert.NoError(t, err)
+		assertSaveDataEqual(t, keys[0], migrated)
+
+		// the public part can be read on its own from the full encoding
+		pb := new(SaveData)
+		assert.NoError(t, proto.Unmarshal(bz, pb))
+		assert.Equal(t, string(ec.name), pb.GetPublic().GetEcdsaPub().GetCurve())
+		assert.Len(t, pb.GetPublic().GetBigXj(), len(keys[0].Ks))
+		public, err := keys[0].ToPublicProto()
+		assert.NoError(t, err)
+		assert.True(t, proto.Equal(public, pb.GetPublic()))
+	}
+}
+
+func TestSaveDataProtoRejectsMismatchedLists(t *testing.T) {
+	keys, _, err := LoadKeygenTestFixtures(1)
+	assert.NoError(t, err, "should load keygen fixtures")
+	pb, err := keys[0].ToProto()
+	assert.NoError(t, err)
+	pb.Public.BigXj = pb.Public.BigXj[1:]
+	_, err = LocalPartySaveDataFromProto(pb)
+	assert.Error(t, err)
+
+	pb.Version = SaveDataProtoVersion + 1
+	_, err = LocalPartySaveDataFromProto(pb)
+	assert.Error(t, err)
+}
+
+func assertSaveDataEqual(t *testing.T, expected, actual LocalPartySaveData) {
+	assert.Equal(t, 0, expected.Xi.Cmp(actual.Xi))
+	assert.Equal(t, 0, expected.ShareID.Cmp(actual.ShareID))
+	assert.Equal(t, 0, expected.PaillierSK.N.Cmp(actual.PaillierSK.N))
+	assert.Equal(t, 0, expected.PaillierSK.LambdaN.Cmp(actual.PaillierSK.LambdaN))
+	assert.Equal(t, 0, expected.PaillierSK.PhiN.Cmp(actual.PaillierSK.PhiN))
+	assert.Equal(t, 0, expected.NTildei.Cmp(actual.NTildei))
+	assert.Equal(t, 0, expected.Alpha.Cmp(actual.Alpha))
+	assert.Equal(t, 0, expected.Q.Cmp(actual.Q))
+	assert.True(t, expected.ECDSAPub.Equals(actual.ECDSAPub))
+	assert.True(t, tss.SameCurve(expected.ECDSAPub.Curve(), actual.ECDSAPub.Curve()))
+	for j := range expected.Ks {
+		assert.Equal(t, 0, expected.Ks[j].Cmp(actual.Ks[j]))
+		assert.Equal(t, 0, expected.NTildej[j].Cmp(actual.NTildej[j]))
+		assert.Equal(t, 0, expected.H2j[j].Cmp(actual.H2j[j]))
+		assert.Equal(t, 0, expected.PaillierPKs[j].N.Cmp(actual.PaillierPKs[j].N))
+		assert.True(t, expected.BigXj[j].Equals(actual.BigXj[j]))
+	}
+}
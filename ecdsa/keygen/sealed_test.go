@@ -0,0 +1,39 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto/sealed"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func TestSealOpenSaveData(t *testing.T) {
+	keys, _, err := LoadKeygenTestFixtures(1)
+	assert.NoError(t, err, "should load keygen fixtures")
+	key := sealed.PassphraseWithParams([]byte("correct horse"), sealed.Argon2Params{Time: 1, Memory: 1024, Threads: 1})
+
+	container, err := keys[0].Seal(testThreshold, key)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(container), keys[0].Xi.String())
+
+	opened, header, err := OpenLocalPartySaveData(container, key)
+	assert.NoError(t, err)
+	assert.Equal(t, SealedProtocol, header.Protocol)
+	assert.Equal(t, tss.Secp256k1, header.Curve)
+	assert.Equal(t, testThreshold, header.Threshold)
+	assert.Equal(t, testParticipants, header.PartyCount)
+	assert.Equal(t, 0, keys[0].Xi.Cmp(opened.Xi))
+	assert.Equal(t, 0, keys[0].PaillierSK.LambdaN.Cmp(opened.PaillierSK.LambdaN))
+	assert.True(t, keys[0].ECDSAPub.Equals(opened.ECDSAPub))
+
+	_, _, err = OpenLocalPartySaveData(container, sealed.Passphrase([]byte("wrong horse")))
+	assert.Equal(t, sealed.ErrWrongKey, err)
+}
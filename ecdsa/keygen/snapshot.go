@@ -0,0 +1,189 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/sealed"
+	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	// SnapshotProtocol identifies a snapshot of a keygen party in a sealed container
+	SnapshotProtocol = "ecdsa-keygen-snapshot"
+	// SnapshotProtocolVersion is the version of the encoding of a snapshot in a sealed container
+	SnapshotProtocolVersion = 1
+)
+
+// snapshotState is the state of a party in a snapshot: the progress of its round, its save data so far, including
+// the pre-params, and its temp data
+type snapshotState struct {
+	OK            []bool
+	Data          LocalPartySaveData
+	UI            *big.Int
+	KGCs          []*big.Int
+	Vs            []*crypto.ECPoint
+	SSID          []byte
+	SSIDNonce     *big.Int
+	Shares        vss.Shares
+	DeCommitPolyG []*big.Int
+}
+
+// Snapshot seals the state of a running party with a passphrase or wrapping key (see crypto/sealed), so that it may
+// be resumed with ResumeLocalParty if the process dies. Each snapshot is recorded in the ledger, which lets only the
+// latest snapshot be resumed, and only once.
+func (p *LocalParty) Snapshot(ledger tss.SnapshotLedger, key sealed.Key) ([]byte, error) {
+	snap, err := tss.BaseSnapshot(p, TaskName, ledger, func(rnd tss.Round) (interface{}, []tss.ParsedMessage, error) {
+		base, err := baseOf(rnd)
+		if err != nil {
+			return nil, nil, err
+		}
+		state := &snapshotState{
+			OK:            base.ok,
+			Data:          p.data,
+			UI:            p.temp.ui,
+			KGCs:          p.temp.KGCs,
+			Vs:            p.temp.vs,
+			SSID:          p.temp.ssid,
+			SSIDNonce:     p.temp.ssidNonce,
+			Shares:        p.temp.shares,
+			DeCommitPolyG: p.temp.deCommitPolyG,
+		}
+		return state, p.temp.localMessageStore.stored(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	plaintext, jsonErr := json.Marshal(snap)
+	if jsonErr != nil {
+		return nil, jsonErr
+	}
+	curveName, ok := tss.GetCurveName(p.params.EC())
+	if !ok {
+		return nil, errors.New("Snapshot: the curve of the party is not registered")
+	}
+	header := sealed.Header{
+		Protocol:        SnapshotProtocol,
+		ProtocolVersion: SnapshotProtocolVersion,
+		Curve:           curveName,
+		Threshold:       p.params.Threshold(),
+		PartyCount:      p.params.PartyCount(),
+	}
+	return sealed.Seal(header, plaintext, key)
+}
+
+// ResumeLocalParty rebuilds a party from a snapshot sealed by LocalParty.Snapshot, with the same parameters that the
+// party was created with. The snapshot is consumed in the ledger. The party continues in the round of the snapshot
+// with the messages it had received; it must not be started, and the messages sent to it while it was down must be
+// sent to it again.
+func ResumeLocalParty(
+	container []byte,
+	key sealed.Key,
+	ledger tss.SnapshotLedger,
+	params *tss.Parameters,
+	out chan<- tss.Message,
+	end chan<- *LocalPartySaveData,
+) (tss.Party, error) {
+	header, plaintext, err := sealed.Open(container, key)
+	if err != nil {
+		return nil, err
+	}
+	if header.Protocol != SnapshotProtocol {
+		return nil, fmt.Errorf("ResumeLocalParty: the container holds %q, not %q", header.Protocol, SnapshotProtocol)
+	}
+	if header.ProtocolVersion != SnapshotProtocolVersion {
+		return nil, fmt.Errorf("ResumeLocalParty: unsupported version %d", header.ProtocolVersion)
+	}
+	if curveName, ok := tss.GetCurveName(params.EC()); !ok || curveName != header.Curve ||
+		header.Threshold != params.Threshold() || header.PartyCount != params.PartyCount() {
+		return nil, errors.New("ResumeLocalParty: the snapshot does not match the parameters")
+	}
+	snap := new(tss.Snapshot)
+	if err = json.Unmarshal(plaintext, snap); err != nil {
+		return nil, err
+	}
+	state := new(snapshotState)
+	if err = json.Unmarshal(snap.State, state); err != nil {
+		return nil, err
+	}
+	if len(state.OK) != params.PartyCount() || len(state.KGCs) != params.PartyCount() {
+		return nil, errors.New("ResumeLocalParty: the state in the snapshot does not match the parameters")
+	}
+
+	p := NewLocalParty(params, out, end).(*LocalParty)
+	p.data = state.Data
+	p.temp.ui = state.UI
+	p.temp.KGCs = state.KGCs
+	p.temp.vs = state.Vs
+	p.temp.ssid = state.SSID
+	p.temp.ssidNonce = state.SSIDNonce
+	p.temp.shares = state.Shares
+	p.temp.deCommitPolyG = state.DeCommitPolyG
+
+	round, err := p.roundAt(snap.Round, state.OK)
+	if err != nil {
+		return nil, err
+	}
+	if err := tss.BaseResume(p, snap, ledger, params.Parties().IDs(), round, TaskName); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// ----- //
+
+// roundAt returns the started round with the given number and progress
+func (p *LocalParty) roundAt(number int, ok []bool) (tss.Round, error) {
+	r1 := newRound1(p.params, &p.data, &p.temp, p.out, p.end).(*round1)
+	r1.number, r1.started, r1.ok = number, true, ok
+	switch number {
+	case 1:
+		return r1, nil
+	case 2:
+		return &round2{r1}, nil
+	case 3:
+		return &round3{&round2{r1}}, nil
+	}
+	return nil, fmt.Errorf("ResumeLocalParty: a snapshot cannot be taken in round %d", number)
+}
+
+// baseOf returns the base of a round that can be resumed by roundAt. The last round is not one: it takes no messages,
+// so the party is finishing and a snapshot of it could never be resumed.
+func baseOf(rnd tss.Round) (*base, error) {
+	switch r := rnd.(type) {
+	case *round1:
+		return r.base, nil
+	case *round2:
+		return r.base, nil
+	case *round3:
+		return r.base, nil
+	}
+	return nil, fmt.Errorf("unexpected round %T", rnd)
+}
+
+func (store *localMessageStore) stored() []tss.ParsedMessage {
+	msgs := make([]tss.ParsedMessage, 0)
+	for _, round := range [][]tss.ParsedMessage{
+		store.kgRound1Messages,
+		store.kgRound2Message1s,
+		store.kgRound2Message2s,
+		store.kgRound3Messages,
+	} {
+		for _, msg := range round {
+			if msg != nil {
+				msgs = append(msgs, msg)
+			}
+		}
+	}
+	return msgs
+}
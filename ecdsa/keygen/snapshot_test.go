@@ -0,0 +1,182 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto/sealed"
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func TestSnapshotAndResumeAfterCrash(t *testing.T) {
+	setUp("info")
+
+	fixtures, pIDs, err := LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	ledger, err := tss.NewFileSnapshotLedger(t.TempDir())
+	assert.NoError(t, err)
+	key := sealed.PassphraseWithParams([]byte("correct horse"), sealed.Argon2Params{Time: 1, Memory: 1024, Threads: 1})
+
+	p2pCtx := tss.NewPeerContext(pIDs)
+	errCh := make(chan *tss.Error, len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs))
+	endCh := make(chan *LocalPartySaveData, len(pIDs))
+	newParams := func(pID *tss.PartyID) *tss.Parameters {
+		params := tss.NewParameters(tss.S256(), p2pCtx, pID, len(pIDs), testThreshold)
+		// do not use in untrusted setting
+		params.SetNoProofMod()
+		// do not use in untrusted setting
+		params.SetNoProofFac()
+		return params
+	}
+	parties := make([]tss.Party, len(pIDs))
+	for i, pID := range pIDs {
+		parties[i] = NewLocalParty(newParams(pID), outCh, endCh, fixtures[i].LocalPreParams)
+		go func(P tss.Party) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(parties[i])
+	}
+
+	// the first party crashes in round 2; the messages to it are held until it is resumed
+	var held []tss.Message
+	resumed := false
+	deliver := func(P tss.Party, msg tss.Message) {
+		_, isRound1 := msg.(tss.ParsedMessage).Content().(*KGRound1Message)
+		if P.PartyID().Index == 0 && !resumed && !isRound1 {
+			held = append(held, msg)
+			return
+		}
+		go test.SharedPartyUpdater(P, msg, errCh)
+	}
+
+	saves := make([]*LocalPartySaveData, 0, len(pIDs))
+	for len(saves) < len(pIDs) {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+		case msg := <-outCh:
+			if _, ok := msg.(tss.ParsedMessage).Content().(*KGRound2Message2); ok && msg.GetFrom().Index == 0 && !resumed {
+				container, err := parties[0].(*LocalParty).Snapshot(ledger, key)
+				assert.NoError(t, err)
+				P, err := ResumeLocalParty(container, key, ledger, newParams(pIDs[0]), outCh, endCh)
+				assert.NoError(t, err)
+				assert.Contains(t, P.String(), "round: 2")
+				_, err = ResumeLocalParty(container, key, ledger, newParams(pIDs[0]), outCh, endCh)
+				assert.True(t, errors.Is(err, tss.ErrSnapshotResumed), "a snapshot must not be resumed twice")
+
+				parties[0], resumed = P, true
+				for _, m := range held {
+					deliver(P, m)
+				}
+			}
+			if dest := msg.GetTo(); dest != nil {
+				deliver(parties[dest[0].Index], msg)
+				continue
+			}
+			for _, P := range parties {
+				if P.PartyID().Index != msg.GetFrom().Index {
+					deliver(P, msg)
+				}
+			}
+		case save := <-endCh:
+			saves = append(saves, save)
+		}
+	}
+	assert.True(t, resumed)
+	for _, save := range saves {
+		assert.True(t, saves[0].ECDSAPub.Equals(save.ECDSAPub), "all parties must agree on the public key")
+	}
+}
+
+func TestResumeStaleSnapshot(t *testing.T) {
+	setUp("info")
+
+	fixtures, pIDs, err := LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	ledger, err := tss.NewFileSnapshotLedger(t.TempDir())
+	assert.NoError(t, err)
+	key := sealed.PassphraseWithParams([]byte("correct horse"), sealed.Argon2Params{Time: 1, Memory: 1024, Threads: 1})
+
+	params := tss.NewParameters(tss.S256(), tss.NewPeerContext(pIDs), pIDs[0], len(pIDs), testThreshold)
+	P := NewLocalParty(params, make(chan tss.Message, len(pIDs)), nil, fixtures[0].LocalPreParams).(*LocalParty)
+	_, err = P.Snapshot(ledger, key)
+	assert.Error(t, err, "a party that is not running cannot be snapshotted")
+	assert.Nil(t, P.Start())
+
+	first, err := P.Snapshot(ledger, key)
+	assert.NoError(t, err)
+	second, err := P.Snapshot(ledger, key)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(second), fixtures[0].PaillierSK.LambdaN.String())
+
+	_, err = ResumeLocalParty(first, key, ledger, params, nil, nil)
+	assert.True(t, errors.Is(err, tss.ErrSnapshotStale), "an earlier snapshot must not be resumed")
+	_, err = ResumeLocalParty(second, sealed.Passphrase([]byte("wrong horse")), ledger, params, nil, nil)
+	assert.Equal(t, sealed.ErrWrongKey, err)
+	resumed, err := ResumeLocalParty(second, key, ledger, params, nil, nil)
+	assert.NoError(t, err)
+	assert.True(t, resumed.Running())
+}
+
+func TestResumeSnapshotOfEarlierRound(t *testing.T) {
+	setUp("info")
+
+	fixtures, pIDs, err := LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	ledger, err := tss.NewFileSnapshotLedger(t.TempDir())
+	assert.NoError(t, err)
+	key := sealed.PassphraseWithParams([]byte("correct horse"), sealed.Argon2Params{Time: 1, Memory: 1024, Threads: 1})
+
+	p2pCtx := tss.NewPeerContext(pIDs)
+	outCh := make(chan tss.Message, len(pIDs)*len(pIDs))
+	newParams := func(pID *tss.PartyID) *tss.Parameters {
+		params := tss.NewParameters(tss.S256(), p2pCtx, pID, len(pIDs), testThreshold)
+		// do not use in untrusted setting
+		params.SetNoProofMod()
+		// do not use in untrusted setting
+		params.SetNoProofFac()
+		return params
+	}
+	parties := make([]*LocalParty, len(pIDs))
+	for i, pID := range pIDs {
+		parties[i] = NewLocalParty(newParams(pID), outCh, nil, fixtures[i].LocalPreParams).(*LocalParty)
+		assert.Nil(t, parties[i].Start())
+	}
+	container, err := parties[0].Snapshot(ledger, key)
+	assert.NoError(t, err)
+
+	// the first party starts round 2 after the snapshot in round 1, and crashes before it takes another
+	for len(outCh) > 0 {
+		msg := <-outCh
+		if msg.GetFrom().Index != 0 {
+			bz, routing, err := msg.WireBytes()
+			assert.NoError(t, err)
+			_, tssErr := parties[0].UpdateFromBytes(bz, routing.From, routing.IsBroadcast)
+			assert.Nil(t, tssErr)
+		}
+	}
+	assert.Contains(t, parties[0].String(), "round: 2")
+
+	_, err = ResumeLocalParty(container, key, ledger, newParams(pIDs[0]), outCh, nil)
+	assert.True(t, errors.Is(err, tss.ErrSnapshotStale), "a snapshot must not be resumed after the party started a later round")
+}
+
+func TestSnapshotRefusedInLastRound(t *testing.T) {
+	// round 4 cannot be rebuilt by roundAt, so a snapshot of it must fail before it is recorded in the ledger
+	r1 := &round1{&base{}}
+	_, err := baseOf(&round3{&round2{r1}})
+	assert.NoError(t, err)
+	_, err = baseOf(&round4{&round3{&round2{r1}}})
+	assert.Error(t, err)
+}
@@ -7,6 +7,7 @@
 package keygen
 
 import (
+	"crypto/elliptic"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -29,18 +30,24 @@ const (
 	TestThreshold    = test.TestParticipants / 2
 )
 const (
-	testFixtureDirFormat  = "%s/../../test/_ecdsa_fixtures"
-	testFixtureFileFormat = "keygen_data_%d.json"
+	testFixtureDirFormat     = "%s/../../test/_ecdsa_fixtures"
+	testP256FixtureDirFormat = "%s/../../test/_ecdsa_p256_fixtures"
+	testFixtureFileFormat    = "keygen_data_%d.json"
 )
 
 func LoadKeygenTestFixtures(qty int, optionalStart ...int) ([]LocalPartySaveData, tss.SortedPartyIDs, error) {
+	return LoadKeygenTestFixturesForCurve(tss.S256(), qty, optionalStart...)
+}
+
+// LoadKeygenTestFixturesForCurve loads the fixtures of the keys generated on `ec`, which is secp256k1 or P-256
+func LoadKeygenTestFixturesForCurve(ec elliptic.Curve, qty int, optionalStart ...int) ([]LocalPartySaveData, tss.SortedPartyIDs, error) {
 	keys := make([]LocalPartySaveData, 0, qty)
 	start := 0
 	if 0 < len(optionalStart) {
 		start = optionalStart[0]
 	}
 	for i := start; i < qty; i++ {
-		fixtureFilePath := makeTestFixtureFilePath(i)
+		fixtureFilePath := makeTestFixtureFilePathForCurve(ec, i)
 		bz, err := ioutil.ReadFile(fixtureFilePath)
 		if err != nil {
 			return nil, nil, errors.Wrapf(err,
@@ -54,9 +61,9 @@ func LoadKeygenTestFixtures(qty int, optionalStart ...int) ([]LocalPartySaveData
 				i, fixtureFilePath)
 		}
 		for _, kbxj := range key.BigXj {
-			kbxj.SetCurve(tss.S256())
+			kbxj.SetCurve(ec)
 		}
-		key.ECDSAPub.SetCurve(tss.S256())
+		key.ECDSAPub.SetCurve(ec)
 		keys = append(keys, key)
 	}
 	partyIDs := make(tss.UnSortedPartyIDs, len(keys))
@@ -69,6 +76,10 @@ func LoadKeygenTestFixtures(qty int, optionalStart ...int) ([]LocalPartySaveData
 }
 
 func LoadKeygenTestFixturesRandomSet(qty, fixtureCount int) ([]LocalPartySaveData, tss.SortedPartyIDs, error) {
+	return LoadKeygenTestFixturesRandomSetForCurve(tss.S256(), qty, fixtureCount)
+}
+
+func LoadKeygenTestFixturesRandomSetForCurve(ec elliptic.Curve, qty, fixtureCount int) ([]LocalPartySaveData, tss.SortedPartyIDs, error) {
 	keys := make([]LocalPartySaveData, 0, qty)
 	plucked := make(map[int]interface{}, qty)
 	for i := 0; len(plucked) < qty; i = (i + 1) % fixtureCount {
@@ -78,7 +89,7 @@ func LoadKeygenTestFixturesRandomSet(qty, fixtureCount int) ([]LocalPartySaveDat
 		}
 	}
 	for i := range plucked {
-		fixtureFilePath := makeTestFixtureFilePath(i)
+		fixtureFilePath := makeTestFixtureFilePathForCurve(ec, i)
 		bz, err := ioutil.ReadFile(fixtureFilePath)
 		if err != nil {
 			return nil, nil, errors.Wrapf(err,
@@ -92,9 +103,9 @@ func LoadKeygenTestFixturesRandomSet(qty, fixtureCount int) ([]LocalPartySaveDat
 				i, fixtureFilePath)
 		}
 		for _, kbxj := range key.BigXj {
-			kbxj.SetCurve(tss.S256())
+			kbxj.SetCurve(ec)
 		}
-		key.ECDSAPub.SetCurve(tss.S256())
+		key.ECDSAPub.SetCurve(ec)
 		keys = append(keys, key)
 	}
 	partyIDs := make(tss.UnSortedPartyIDs, len(keys))
@@ -120,9 +131,13 @@ func LoadNTildeH1H2FromTestFixture(idx int) (NTildei, h1i, h2i *big.Int, err err
 	return
 }
 
-func makeTestFixtureFilePath(partyIndex int) string {
+func makeTestFixtureFilePathForCurve(ec elliptic.Curve, partyIndex int) string {
 	_, callerFileName, _, _ := runtime.Caller(0)
 	srcDirName := filepath.Dir(callerFileName)
-	fixtureDirName := fmt.Sprintf(testFixtureDirFormat, srcDirName)
+	dirFormat := testFixtureDirFormat
+	if tss.SameCurve(ec, tss.P256()) {
+		dirFormat = testP256FixtureDirFormat
+	}
+	fixtureDirName := fmt.Sprintf(dirFormat, srcDirName)
 	return fmt.Sprintf("%s/"+testFixtureFileFormat, fixtureDirName, partyIndex)
 }
@@ -0,0 +1,172 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package refresh
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	cmt "github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// Implements Party
+// Implements Stringer
+var (
+	_ tss.Party    = (*LocalParty)(nil)
+	_ fmt.Stringer = (*LocalParty)(nil)
+)
+
+type (
+	LocalParty struct {
+		*tss.BaseParty
+		params *tss.Parameters
+
+		temp        localTempData
+		input, save keygen.LocalPartySaveData
+
+		// outbound messaging
+		out chan<- tss.Message
+		end chan<- *keygen.LocalPartySaveData
+	}
+
+	localMessageStore struct {
+		rfRound1Messages,
+		rfRound2Message1s,
+		rfRound2Message2s,
+		rfRound3Messages []tss.ParsedMessage
+	}
+
+	localTempData struct {
+		localMessageStore
+
+		// temp data (thrown away after refresh)
+		commitments   []cmt.HashCommitment
+		vs            vss.Vs
+		shares        vss.Shares
+		deCommitPolyG cmt.HashDeCommitment
+		pjVs          []vss.Vs
+		ssid          []byte
+		ssidNonce     *big.Int
+	}
+)
+
+// Exported, used in `tss` client
+// The refresh is run by every party that holds a share of the key; the `params` must contain the same parties as keygen.
+// The new key data is sent through `end` and has the same ECDSAPub as `key`, but new Xi and BigXj.
+// You may optionally provide new LocalPreParams to rotate this party's Paillier key and NTilde, h1, h2 at the same time.
+func NewLocalParty(
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *keygen.LocalPartySaveData,
+	optionalPreParams ...keygen.LocalPreParams,
+) tss.Party {
+	partyCount := params.PartyCount()
+	save := keygen.NewLocalPartySaveData(partyCount)
+	save.LocalPreParams = key.LocalPreParams
+	if 0 < len(optionalPreParams) {
+		if 1 < len(optionalPreParams) {
+			panic(errors.New("refresh.NewLocalParty expected 0 or 1 item in `optionalPreParams`"))
+		}
+		if !optionalPreParams[0].ValidateWithProof() {
+			panic(errors.New("`optionalPreParams` failed to validate; it might have been generated with an older version of tss-lib"))
+		}
+		save.LocalPreParams = optionalPreParams[0]
+	}
+	save.ShareID = key.ShareID
+	save.Ks = make([]*big.Int, len(key.Ks))
+	copy(save.Ks, key.Ks)
+	save.ECDSAPub = key.ECDSAPub
+	p := &LocalParty{
+		BaseParty: new(tss.BaseParty),
+		params:    params,
+		temp:      localTempData{},
+		input:     key,
+		save:      save,
+		out:       out,
+		end:       end,
+	}
+	// msgs init
+	p.temp.rfRound1Messages = make([]tss.ParsedMessage, partyCount)
+	p.temp.rfRound2Message1s = make([]tss.ParsedMessage, partyCount)
+	p.temp.rfRound2Message2s = make([]tss.ParsedMessage, partyCount)
+	p.temp.rfRound3Messages = make([]tss.ParsedMessage, partyCount)
+	// temp data init
+	p.temp.commitments = make([]cmt.HashCommitment, partyCount)
+	p.temp.pjVs = make([]vss.Vs, partyCount)
+	return p
+}
+
+func (p *LocalParty) FirstRound() tss.Round {
+	return newRound1(p.params, &p.input, &p.save, &p.temp, p.out, p.end)
+}
+
+func (p *LocalParty) Start() *tss.Error {
+	return tss.BaseStart(p, TaskName)
+}
+
+func (p *LocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
+	return tss.BaseUpdate(p, msg, TaskName)
+}
+
+func (p *LocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.Update(msg)
+}
+
+func (p *LocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	if ok, err := p.BaseParty.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	// check that the message's "from index" will fit into the array
+	if maxFromIdx := p.params.PartyCount() - 1; maxFromIdx < msg.GetFrom().Index {
+		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
+			p.params.PartyCount(), msg.GetFrom().Index), msg.GetFrom())
+	}
+	return true, nil
+}
+
+func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	// ValidateBasic is cheap; double-check the message here in case the public StoreMessage was called externally
+	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	fromPIdx := msg.GetFrom().Index
+
+	// switch/case is necessary to store any messages beyond current round
+	// this does not handle message replays. we expect the caller to apply replay and spoofing protection.
+	switch msg.Content().(type) {
+	case *RefreshRound1Message:
+		p.temp.rfRound1Messages[fromPIdx] = msg
+	case *RefreshRound2Message1:
+		p.temp.rfRound2Message1s[fromPIdx] = msg
+	case *RefreshRound2Message2:
+		p.temp.rfRound2Message2s[fromPIdx] = msg
+	case *RefreshRound3Message:
+		p.temp.rfRound3Messages[fromPIdx] = msg
+	default: // unrecognised message, just ignore!
+		common.Logger.Warningf("unrecognised message ignored: %v", msg)
+		return false, nil
+	}
+	return true, nil
+}
+
+func (p *LocalParty) PartyID() *tss.PartyID {
+	return p.params.PartyID()
+}
+
+func (p *LocalParty) String() string {
+	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
+}
@@ -0,0 +1,219 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package refresh
+
+import (
+	"crypto/elliptic"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	cmt "github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/crypto/dlnproof"
+	"github.com/bnb-chain/tss-lib/v2/crypto/facproof"
+	"github.com/bnb-chain/tss-lib/v2/crypto/modproof"
+	"github.com/bnb-chain/tss-lib/v2/crypto/paillier"
+	"github.com/bnb-chain/tss-lib/v2/crypto/schnorr"
+	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// These messages were generated from Protocol Buffers definitions into ecdsa-refresh.pb.go
+// The following messages are registered on the Protocol Buffers "wire"
+
+var (
+	// Ensure that refresh messages implement ValidateBasic
+	_ = []tss.MessageContent{
+		(*RefreshRound1Message)(nil),
+		(*RefreshRound2Message1)(nil),
+		(*RefreshRound2Message2)(nil),
+		(*RefreshRound3Message)(nil),
+	}
+)
+
+// ----- //
+
+func NewRefreshRound1Message(
+	from *tss.PartyID,
+	ct cmt.HashCommitment,
+	paillierPK *paillier.PublicKey,
+	nTildeI, h1I, h2I *big.Int,
+	dlnProof1, dlnProof2 *dlnproof.Proof,
+) (tss.ParsedMessage, error) {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	dlnProof1Bz, err := dlnProof1.Serialize()
+	if err != nil {
+		return nil, err
+	}
+	dlnProof2Bz, err := dlnProof2.Serialize()
+	if err != nil {
+		return nil, err
+	}
+	content := &RefreshRound1Message{
+		Commitment: ct.Bytes(),
+		PaillierN:  paillierPK.N.Bytes(),
+		NTilde:     nTildeI.Bytes(),
+		H1:         h1I.Bytes(),
+		H2:         h2I.Bytes(),
+		Dlnproof_1: dlnProof1Bz,
+		Dlnproof_2: dlnProof2Bz,
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg), nil
+}
+
+func (m *RefreshRound1Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetCommitment()) &&
+		common.NonEmptyBytes(m.GetPaillierN()) &&
+		common.NonEmptyBytes(m.GetNTilde()) &&
+		common.NonEmptyBytes(m.GetH1()) &&
+		common.NonEmptyBytes(m.GetH2()) &&
+		// expected len of dln proof = sizeof(int64) + len(alpha) + len(t)
+		common.NonEmptyMultiBytes(m.GetDlnproof_1(), 2+(dlnproof.Iterations*2)) &&
+		common.NonEmptyMultiBytes(m.GetDlnproof_2(), 2+(dlnproof.Iterations*2))
+}
+
+func (m *RefreshRound1Message) UnmarshalCommitment() *big.Int {
+	return new(big.Int).SetBytes(m.GetCommitment())
+}
+
+func (m *RefreshRound1Message) UnmarshalPaillierPK() *paillier.PublicKey {
+	return &paillier.PublicKey{N: new(big.Int).SetBytes(m.GetPaillierN())}
+}
+
+func (m *RefreshRound1Message) UnmarshalNTilde() *big.Int {
+	return new(big.Int).SetBytes(m.GetNTilde())
+}
+
+func (m *RefreshRound1Message) UnmarshalH1() *big.Int {
+	return new(big.Int).SetBytes(m.GetH1())
+}
+
+func (m *RefreshRound1Message) UnmarshalH2() *big.Int {
+	return new(big.Int).SetBytes(m.GetH2())
+}
+
+func (m *RefreshRound1Message) UnmarshalDLNProof1() (*dlnproof.Proof, error) {
+	return dlnproof.UnmarshalDLNProof(m.GetDlnproof_1())
+}
+
+func (m *RefreshRound1Message) UnmarshalDLNProof2() (*dlnproof.Proof, error) {
+	return dlnproof.UnmarshalDLNProof(m.GetDlnproof_2())
+}
+
+// ----- //
+
+func NewRefreshRound2Message1(
+	to, from *tss.PartyID,
+	share *vss.Share,
+	proof *facproof.ProofFac,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	proofBzs := proof.Bytes()
+	content := &RefreshRound2Message1{
+		Share:    share.Share.Bytes(),
+		FacProof: proofBzs[:],
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *RefreshRound2Message1) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetShare())
+}
+
+func (m *RefreshRound2Message1) UnmarshalShare() *big.Int {
+	return new(big.Int).SetBytes(m.Share)
+}
+
+func (m *RefreshRound2Message1) UnmarshalFacProof() (*facproof.ProofFac, error) {
+	return facproof.NewProofFromBytes(m.GetFacProof())
+}
+
+// ----- //
+
+func NewRefreshRound2Message2(
+	from *tss.PartyID,
+	deCommitment cmt.HashDeCommitment,
+	proof *modproof.ProofMod,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	dcBzs := common.BigIntsToBytes(deCommitment)
+	proofBzs := proof.Bytes()
+	content := &RefreshRound2Message2{
+		DeCommitment: dcBzs,
+		ModProof:     proofBzs[:],
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *RefreshRound2Message2) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyMultiBytes(m.GetDeCommitment())
+}
+
+func (m *RefreshRound2Message2) UnmarshalDeCommitment() []*big.Int {
+	deComBzs := m.GetDeCommitment()
+	return cmt.NewHashDeCommitmentFromBytes(deComBzs)
+}
+
+func (m *RefreshRound2Message2) UnmarshalModProof() (*modproof.ProofMod, error) {
+	return modproof.NewProofFromBytes(m.GetModProof())
+}
+
+// ----- //
+
+func NewRefreshRound3Message(
+	from *tss.PartyID,
+	proof *schnorr.ZKProof,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &RefreshRound3Message{
+		ProofAlphaX: proof.Alpha.X().Bytes(),
+		ProofAlphaY: proof.Alpha.Y().Bytes(),
+		ProofT:      proof.T.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *RefreshRound3Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetProofAlphaX()) &&
+		common.NonEmptyBytes(m.GetProofAlphaY()) &&
+		common.NonEmptyBytes(m.GetProofT())
+}
+
+func (m *RefreshRound3Message) UnmarshalZKProof(ec elliptic.Curve) (*schnorr.ZKProof, error) {
+	point, err := crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(m.GetProofAlphaX()),
+		new(big.Int).SetBytes(m.GetProofAlphaY()))
+	if err != nil {
+		return nil, err
+	}
+	return &schnorr.ZKProof{
+		Alpha: point,
+		T:     new(big.Int).SetBytes(m.GetProofT()),
+	}, nil
+}
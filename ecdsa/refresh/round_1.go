@@ -0,0 +1,146 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package refresh
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	cmts "github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/crypto/dlnproof"
+	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+var zero = big.NewInt(0)
+
+// round 1 represents round 1 of the proactive key refresh: every party deals a sharing of zero
+func newRound1(params *tss.Parameters, input, save *keygen.LocalPartySaveData, temp *localTempData, out chan<- tss.Message, end chan<- *keygen.LocalPartySaveData) tss.Round {
+	return &round1{
+		&base{params, input, save, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1},
+	}
+}
+
+func (round *round1) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	Pi := round.PartyID()
+	i := Pi.Index
+
+	// the refresh must be run by exactly the parties that hold the key
+	ids := round.Parties().IDs().Keys()
+	if err := round.checkKeyParties(ids); err != nil {
+		return round.WrapError(err)
+	}
+	if !round.save.LocalPreParams.ValidateWithProof() {
+		return round.WrapError(
+			errors.New("the pre-params failed to validate; it might have been generated with an older version of tss-lib"))
+	}
+
+	// 1. compute the vss shares of zero
+	vs, shares, err := vss.CreateZeroSharing(round.EC(), round.Threshold(), ids, round.Rand())
+	if err != nil {
+		return round.WrapError(err, Pi)
+	}
+
+	// make commitment -> (C, D)
+	pGFlat, err := crypto.FlattenECPoints(vs)
+	if err != nil {
+		return round.WrapError(err, Pi)
+	}
+	cmt := cmts.NewHashCommitment(round.Rand(), pGFlat...)
+
+	// 2. prove the (possibly rotated) NTilde, h1, h2
+	preParams := &round.save.LocalPreParams
+	round.save.NTildej[i] = preParams.NTildei
+	round.save.H1j[i], round.save.H2j[i] = preParams.H1i, preParams.H2i
+	round.save.PaillierPKs[i] = &preParams.PaillierSK.PublicKey
+	h1i, h2i, alpha, beta, p, q, NTildei := preParams.H1i,
+		preParams.H2i,
+		preParams.Alpha,
+		preParams.Beta,
+		preParams.P,
+		preParams.Q,
+		preParams.NTildei
+	dlnProof1 := dlnproof.NewDLNProof(h1i, h2i, alpha, p, q, NTildei, round.Rand())
+	dlnProof2 := dlnproof.NewDLNProof(h2i, h1i, beta, p, q, NTildei, round.Rand())
+
+	round.temp.ssidNonce = new(big.Int).SetUint64(0)
+	ssid, err := round.getSSID()
+	if err != nil {
+		return round.WrapError(errors.New("failed to generate ssid"))
+	}
+	round.temp.ssid = ssid
+	round.temp.vs = vs
+	round.temp.shares = shares
+	round.temp.deCommitPolyG = cmt.D
+
+	// BROADCAST commitments, paillier pk, NTilde, h1, h2 + proofs; round 1 message
+	{
+		msg, err := NewRefreshRound1Message(
+			round.PartyID(), cmt.C, &preParams.PaillierSK.PublicKey, preParams.NTildei, preParams.H1i, preParams.H2i, dlnProof1, dlnProof2)
+		if err != nil {
+			return round.WrapError(err, Pi)
+		}
+		round.temp.rfRound1Messages[i] = msg
+		round.out <- tss.WithRound(msg, round.number)
+	}
+	return nil
+}
+
+func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*RefreshRound1Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round1) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.rfRound1Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		// proof checks are in round 2
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round1) NextRound() tss.Round {
+	round.started = false
+	return &round2{round}
+}
+
+// ----- //
+
+func (round *round1) checkKeyParties(ids []*big.Int) error {
+	if round.input.Xi == nil || round.input.ECDSAPub == nil {
+		return errors.New("the key data is missing Xi or ECDSAPub")
+	}
+	if len(round.input.Ks) != len(ids) || len(round.input.BigXj) != len(ids) {
+		return fmt.Errorf("the key is shared by %d parties but %d are refreshing it", len(round.input.Ks), len(ids))
+	}
+	for j, id := range ids {
+		if round.input.Ks[j].Cmp(id) != 0 {
+			return fmt.Errorf("party %d is not one of the parties that hold the key", j)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package refresh
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"sync"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto/facproof"
+	"github.com/bnb-chain/tss-lib/v2/crypto/modproof"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	paillierBitsLen = 2048
+)
+
+func (round *round2) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	dlnVerifier := keygen.NewDlnProofVerifier(round.Concurrency())
+
+	i := round.PartyID().Index
+
+	// 1. verify dln proofs, store r1 message pieces, ensure uniqueness of h1j, h2j
+	h1H2Map := make(map[string]struct{}, len(round.temp.rfRound1Messages)*2)
+	dlnProof1FailCulprits := make([]*tss.PartyID, len(round.temp.rfRound1Messages))
+	dlnProof2FailCulprits := make([]*tss.PartyID, len(round.temp.rfRound1Messages))
+	wg := new(sync.WaitGroup)
+	for j, msg := range round.temp.rfRound1Messages {
+		r1msg := msg.Content().(*RefreshRound1Message)
+		H1j, H2j, NTildej, paillierPKj := r1msg.UnmarshalH1(),
+			r1msg.UnmarshalH2(),
+			r1msg.UnmarshalNTilde(),
+			r1msg.UnmarshalPaillierPK()
+		if paillierPKj.N.BitLen() != paillierBitsLen {
+			return round.WrapError(errors.New("got paillier modulus with insufficient bits for this party"), msg.GetFrom())
+		}
+		if H1j.Cmp(H2j) == 0 {
+			return round.WrapError(errors.New("h1j and h2j were equal for this party"), msg.GetFrom())
+		}
+		if NTildej.BitLen() != paillierBitsLen {
+			return round.WrapError(errors.New("got NTildej with insufficient bits for this party"), msg.GetFrom())
+		}
+		h1JHex, h2JHex := hex.EncodeToString(H1j.Bytes()), hex.EncodeToString(H2j.Bytes())
+		if _, found := h1H2Map[h1JHex]; found {
+			return round.WrapError(errors.New("this h1j was already used by another party"), msg.GetFrom())
+		}
+		if _, found := h1H2Map[h2JHex]; found {
+			return round.WrapError(errors.New("this h2j was already used by another party"), msg.GetFrom())
+		}
+		h1H2Map[h1JHex], h1H2Map[h2JHex] = struct{}{}, struct{}{}
+
+		wg.Add(2)
+		_j := j
+		_msg := msg
+
+		dlnVerifier.VerifyDLNProof1(r1msg, H1j, H2j, NTildej, func(isValid bool) {
+			if !isValid {
+				dlnProof1FailCulprits[_j] = _msg.GetFrom()
+			}
+			wg.Done()
+		})
+		dlnVerifier.VerifyDLNProof2(r1msg, H2j, H1j, NTildej, func(isValid bool) {
+			if !isValid {
+				dlnProof2FailCulprits[_j] = _msg.GetFrom()
+			}
+			wg.Done()
+		})
+	}
+	wg.Wait()
+	for _, culprit := range append(dlnProof1FailCulprits, dlnProof2FailCulprits...) {
+		if culprit != nil {
+			return round.WrapError(errors.New("dln proof verification failed"), culprit)
+		}
+	}
+	// save NTilde_j, h1_j, h2_j, paillier pk_j
+	for j, msg := range round.temp.rfRound1Messages {
+		if j == i {
+			continue
+		}
+		r1msg := msg.Content().(*RefreshRound1Message)
+		round.save.PaillierPKs[j] = r1msg.UnmarshalPaillierPK()
+		round.save.NTildej[j] = r1msg.UnmarshalNTilde()
+		round.save.H1j[j], round.save.H2j[j] = r1msg.UnmarshalH1(), r1msg.UnmarshalH2()
+		round.temp.commitments[j] = r1msg.UnmarshalCommitment()
+	}
+
+	// 2. p2p send share ij to Pj
+	shares := round.temp.shares
+	ContextI := append(round.temp.ssid, big.NewInt(int64(i)).Bytes()...)
+	for j, Pj := range round.Parties().IDs() {
+		facProof := &facproof.ProofFac{
+			P: zero, Q: zero, A: zero, B: zero, T: zero, Sigma: zero,
+			Z1: zero, Z2: zero, W1: zero, W2: zero, V: zero,
+		}
+		if !round.Params().NoProofFac() {
+			var err error
+			facProof, err = facproof.NewProof(ContextI, round.EC(), round.save.PaillierSK.N, round.save.NTildej[j],
+				round.save.H1j[j], round.save.H2j[j], round.save.PaillierSK.P, round.save.PaillierSK.Q, round.Rand())
+			if err != nil {
+				return round.WrapError(err, round.PartyID())
+			}
+		}
+		r2msg1 := NewRefreshRound2Message1(Pj, round.PartyID(), shares[j], facProof)
+		// do not send to this Pj, but store for round 3
+		if j == i {
+			round.temp.rfRound2Message1s[j] = r2msg1
+			continue
+		}
+		enc, err := tss.EncryptMessage(round.Params(), r2msg1)
+		if err != nil {
+			return round.WrapError(err)
+		}
+		round.out <- tss.WithRound(enc, round.number)
+	}
+
+	// 3. BROADCAST de-commitments of the zero-sharing poly*G
+	modProof := &modproof.ProofMod{W: zero, X: *new([modproof.Iterations]*big.Int), A: zero, B: zero, Z: *new([modproof.Iterations]*big.Int)}
+	if !round.Params().NoProofMod() {
+		var err error
+		modProof, err = modproof.NewProof(ContextI, round.save.PaillierSK.N,
+			round.save.PaillierSK.P, round.save.PaillierSK.Q, round.Rand())
+		if err != nil {
+			return round.WrapError(err, round.PartyID())
+		}
+	}
+	r2msg2 := NewRefreshRound2Message2(round.PartyID(), round.temp.deCommitPolyG, modProof)
+	round.temp.rfRound2Message2s[i] = r2msg2
+	round.out <- tss.WithRound(r2msg2, round.number)
+
+	return nil
+}
+
+func (round *round2) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*RefreshRound2Message1); ok {
+		return !msg.IsBroadcast()
+	}
+	if _, ok := msg.Content().(*RefreshRound2Message2); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round2) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.rfRound2Message1s {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		msg2 := round.temp.rfRound2Message2s[j]
+		if msg2 == nil || !round.CanAccept(msg2) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round2) NextRound() tss.Round {
+	round.started = false
+	return &round3{round}
+}
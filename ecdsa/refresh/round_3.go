@@ -0,0 +1,180 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package refresh
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/crypto/schnorr"
+	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func (round *round3) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 3
+	round.started = true
+	round.resetOK()
+
+	Ps := round.Parties().IDs()
+	PIdx := round.PartyID().Index
+
+	// 1-5. verify the de-commitments, proofs and shares of every Pj (concurrent)
+	errs := make([]error, len(Ps))
+	done := make(chan int, len(Ps))
+	for j := range Ps {
+		if j == PIdx {
+			continue
+		}
+		go func(j int) {
+			errs[j] = round.verifyPj(j)
+			done <- j
+		}(j)
+	}
+	for j := range Ps {
+		if j == PIdx {
+			continue
+		}
+		<-done
+	}
+	{
+		var multiErr error
+		culprits := make([]*tss.PartyID, 0, len(Ps)) // who caused the error(s)
+		for j, err := range errs {
+			if err != nil {
+				multiErr = multierror.Append(multiErr, err)
+				culprits = append(culprits, Ps[j])
+			}
+		}
+		if len(culprits) > 0 {
+			return round.WrapError(multiErr, culprits...)
+		}
+	}
+	round.temp.pjVs[PIdx] = round.temp.vs
+
+	// 6. x_i' = x_i + sum_j f_j(i)
+	modQ := common.ModInt(round.EC().Params().N)
+	xi := new(big.Int).Set(round.input.Xi)
+	for j := range Ps {
+		r2msg1 := round.temp.rfRound2Message1s[j].Content().(*RefreshRound2Message1)
+		xi = modQ.Add(xi, r2msg1.UnmarshalShare())
+	}
+	round.save.Xi = xi
+
+	// 7. X_j' = X_j + sum_k f_k(j)*G
+	{
+		culprits := make([]*tss.PartyID, 0, len(Ps)) // who caused the error(s)
+		for j, Pj := range Ps {
+			BigXj := round.input.BigXj[j]
+			for k, Pk := range Ps {
+				fkj, err := vss.EvaluateZeroSharing(round.EC(), round.temp.pjVs[k], Pj.KeyInt())
+				if err == nil {
+					BigXj, err = BigXj.Add(fkj)
+				}
+				if err != nil {
+					culprits = append(culprits, Pk)
+				}
+			}
+			round.save.BigXj[j] = BigXj
+		}
+		if len(culprits) > 0 {
+			return round.WrapError(errors.New("refreshing BigXj resulted in a point not on the curve"), culprits...)
+		}
+	}
+	if !crypto.ScalarBaseMult(round.EC(), xi).Equals(round.save.BigXj[PIdx]) {
+		return round.WrapError(errors.New("the refreshed share does not match the refreshed BigXj"))
+	}
+
+	// BROADCAST proof of knowledge of x_i'
+	ContextI := common.AppendBigIntToBytesSlice(round.temp.ssid, big.NewInt(int64(PIdx)))
+	proof, err := schnorr.NewZKProof(ContextI, xi, round.save.BigXj[PIdx], round.Rand())
+	if err != nil {
+		return round.WrapError(err)
+	}
+	r3msg := NewRefreshRound3Message(round.PartyID(), proof)
+	round.temp.rfRound3Messages[PIdx] = r3msg
+	round.out <- tss.WithRound(r3msg, round.number)
+	return nil
+}
+
+func (round *round3) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*RefreshRound3Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round3) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.rfRound3Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		// proof check is in round 4
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round3) NextRound() tss.Round {
+	round.started = false
+	return &round4{round}
+}
+
+// ----- //
+
+// verifyPj checks the de-commitment of Pj's zero-sharing polynomial, the share Pj sent to us and Pj's Paillier proofs
+func (round *round3) verifyPj(j int) error {
+	ContextJ := common.AppendBigIntToBytesSlice(round.temp.ssid, big.NewInt(int64(j)))
+	r2msg1 := round.temp.rfRound2Message1s[j].Content().(*RefreshRound2Message1)
+	r2msg2 := round.temp.rfRound2Message2s[j].Content().(*RefreshRound2Message2)
+
+	cmtDeCmt := commitments.HashCommitDecommit{C: round.temp.commitments[j], D: r2msg2.UnmarshalDeCommitment()}
+	ok, flatPolyGs := cmtDeCmt.DeCommit()
+	if !ok || flatPolyGs == nil {
+		return errors.New("de-commitment verify failed")
+	}
+	PjVs, err := crypto.UnFlattenECPoints(round.EC(), flatPolyGs)
+	if err != nil {
+		return err
+	}
+	PjShare := vss.Share{
+		Threshold: round.Threshold(),
+		ID:        round.PartyID().KeyInt(),
+		Share:     r2msg1.UnmarshalShare(),
+	}
+	if ok = PjShare.VerifyZeroSharing(round.EC(), round.Threshold(), PjVs); !ok {
+		return errors.New("vss verify failed")
+	}
+	if !round.NoProofMod() {
+		modProof, err := r2msg2.UnmarshalModProof()
+		if err != nil || !modProof.Verify(ContextJ, round.save.PaillierPKs[j].N) {
+			return errors.New("modProof verify failed")
+		}
+	}
+	if !round.NoProofFac() {
+		facProof, err := r2msg1.UnmarshalFacProof()
+		if err != nil || !facProof.Verify(ContextJ, round.EC(), round.save.PaillierPKs[j].N, round.save.NTildei,
+			round.save.H1i, round.save.H2i) {
+			return errors.New("facProof verify failed")
+		}
+	}
+	round.temp.pjVs[j] = PjVs
+	return nil
+}
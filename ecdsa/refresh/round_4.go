@@ -0,0 +1,99 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package refresh
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func (round *round4) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 4
+	round.started = true
+	round.resetOK()
+
+	Ps := round.Parties().IDs()
+	PIdx := round.PartyID().Index
+
+	// 1. verify the proofs of knowledge of x_j' for every X_j'
+	culprits := make([]*tss.PartyID, 0, len(Ps)) // who caused the error(s)
+	for j, msg := range round.temp.rfRound3Messages {
+		round.ok[j] = true
+		if j == PIdx {
+			continue
+		}
+		ContextJ := common.AppendBigIntToBytesSlice(round.temp.ssid, big.NewInt(int64(j)))
+		r3msg := msg.Content().(*RefreshRound3Message)
+		proof, err := r3msg.UnmarshalZKProof(round.EC())
+		if err != nil || !proof.Verify(ContextJ, round.save.BigXj[j]) {
+			common.Logger.Warningf("schnorr verify failed for party %s", Ps[j])
+			culprits = append(culprits, Ps[j])
+		}
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(errors.New("schnorr proof verify failed"), culprits...)
+	}
+
+	// 2. the refreshed shares must still interpolate to the same public key
+	pub, err := interpolateAtZero(round.EC().Params().N, Ps.Keys()[:round.Threshold()+1], round.save.BigXj[:round.Threshold()+1])
+	if err != nil || !pub.Equals(round.input.ECDSAPub) {
+		return round.WrapError(errors.New("the refreshed shares do not match the public key"))
+	}
+
+	round.end <- round.save
+
+	return nil
+}
+
+func (round *round4) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *round4) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *round4) NextRound() tss.Round {
+	return nil // finished!
+}
+
+// ----- //
+
+// interpolateAtZero computes sum_j lambda_j * X_j with the Lagrange coefficients of the ids at 0
+func interpolateAtZero(q *big.Int, ks []*big.Int, bigXs []*crypto.ECPoint) (*crypto.ECPoint, error) {
+	modQ := common.ModInt(q)
+	var sum *crypto.ECPoint
+	for j, kj := range ks {
+		lambda := big.NewInt(1)
+		for c, kc := range ks {
+			if c == j {
+				continue
+			}
+			// kc / (kc - kj)
+			lambda = modQ.Mul(lambda, modQ.Mul(kc, modQ.ModInverse(modQ.Sub(kc, kj))))
+		}
+		term := bigXs[j].ScalarMult(lambda)
+		if sum == nil {
+			sum = term
+			continue
+		}
+		var err error
+		if sum, err = sum.Add(term); err != nil {
+			return nil, err
+		}
+	}
+	return sum, nil
+}
@@ -79,7 +79,7 @@ func (round *round1) Start() *tss.Error {
 		round.NewParties().IDs().Exclude(round.PartyID()), round.PartyID(),
 		round.input.ECDSAPub, vCmt.C, ssid)
 	round.temp.dgRound1Messages[i] = r1msg
-	round.out <- r1msg
+	round.out <- tss.WithRound(r1msg, round.number)
 
 	return nil
 }
@@ -34,7 +34,11 @@ func (round *round3) Start() *tss.Error {
 		share := round.temp.NewShares[j]
 		r3msg1 := NewDGRound3Message1(Pj, round.PartyID(), share)
 		round.temp.dgRound3Message1s[i] = r3msg1
-		round.out <- r3msg1
+		enc, err := tss.EncryptMessage(round.Params(), r3msg1)
+		if err != nil {
+			return round.WrapError(err)
+		}
+		round.out <- tss.WithRound(enc, round.number)
 	}
 
 	vDeCmt := round.temp.VD
@@ -42,7 +46,7 @@ func (round *round3) Start() *tss.Error {
 		round.NewParties().IDs().Exclude(round.PartyID()), round.PartyID(),
 		vDeCmt)
 	round.temp.dgRound3Message2s[i] = r3msg2
-	round.out <- r3msg2
+	round.out <- tss.WithRound(r3msg2, round.number)
 
 	return nil
 }
@@ -63,20 +67,23 @@ func (round *round3) Update() (bool, *tss.Error) {
 		return true, nil
 	}
 	// accept messages from old -> new committee
+	ret := true
 	for j, msg1 := range round.temp.dgRound3Message1s {
 		if round.oldOK[j] {
 			continue
 		}
 		if msg1 == nil || !round.CanAccept(msg1) {
-			return false, nil
+			ret = false
+			continue
 		}
 		msg2 := round.temp.dgRound3Message2s[j]
 		if msg2 == nil || !round.CanAccept(msg2) {
-			return false, nil
+			ret = false
+			continue
 		}
 		round.oldOK[j] = true
 	}
-	return true, nil
+	return ret, nil
 }
 
 func (round *round3) NextRound() tss.Round {
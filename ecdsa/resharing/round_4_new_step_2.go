@@ -229,13 +229,17 @@ func (round *round4) Start() *tss.Error {
 			}
 		}
 		r4msg1 := NewDGRound4Message1(Pj, Pi, facProof)
-		round.out <- r4msg1
+		enc, err := tss.EncryptMessage(round.Params(), r4msg1)
+		if err != nil {
+			return round.WrapError(err)
+		}
+		round.out <- tss.WithRound(enc, round.number)
 	}
 
 	// Send an "ACK" message to both committees to signal that we're ready to save our data
 	r4msg2 := NewDGRound4Message2(round.OldAndNewParties(), Pi)
 	round.temp.dgRound4Message2s[i] = r4msg2
-	round.out <- r4msg2
+	round.out <- tss.WithRound(r4msg2, round.number)
 
 	return nil
 }
@@ -252,22 +256,25 @@ func (round *round4) CanAccept(msg tss.ParsedMessage) bool {
 
 func (round *round4) Update() (bool, *tss.Error) {
 	// accept messages from new -> old&new committees
+	ret := true
 	for j, msg2 := range round.temp.dgRound4Message2s {
 		if round.newOK[j] {
 			continue
 		}
 		if msg2 == nil || !round.CanAccept(msg2) {
-			return false, nil
+			ret = false
+			continue
 		}
 		if round.ReSharingParams().IsNewCommittee() {
 			msg1 := round.temp.dgRound4Message1s[j]
 			if msg1 == nil || !round.CanAccept(msg1) {
-				return false, nil
+				ret = false
+				continue
 			}
 		}
 		round.newOK[j] = true
 	}
-	return true, nil
+	return ret, nil
 }
 
 func (round *round4) NextRound() tss.Round {
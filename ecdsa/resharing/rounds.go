@@ -71,6 +71,11 @@ func (round *base) RoundNumber() int {
 	return round.number
 }
 
+// Out is used by the echo broadcast of the party
+func (round *base) Out() chan<- tss.Message {
+	return round.out
+}
+
 // CanProceed is inherited by other rounds
 func (round *base) CanProceed() bool {
 	if !round.started {
@@ -0,0 +1,204 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package resharing
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/sealed"
+	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	// SnapshotProtocol identifies a snapshot of a re-sharing party in a sealed container
+	SnapshotProtocol = "ecdsa-resharing-snapshot"
+	// SnapshotProtocolVersion is the version of the encoding of a snapshot in a sealed container
+	SnapshotProtocolVersion = 1
+)
+
+// snapshotState is the state of a party in a snapshot: the progress of its round, the key it was given, the save data
+// of the new committee so far and its temp data
+type snapshotState struct {
+	OldOK, NewOK []bool
+	Input, Save  keygen.LocalPartySaveData
+	NewVs        []*crypto.ECPoint
+	NewShares    vss.Shares
+	VD           []*big.Int
+	NewXi        *big.Int
+	NewKs        []*big.Int
+	NewBigXjs    []*crypto.ECPoint
+	SSID         []byte
+	SSIDNonce    *big.Int
+}
+
+// Snapshot seals the state of a running party with a passphrase or wrapping key (see crypto/sealed), so that it may
+// be resumed with ResumeLocalParty if the process dies. Each snapshot is recorded in the ledger, which lets only the
+// latest snapshot be resumed, and only once.
+func (p *LocalParty) Snapshot(ledger tss.SnapshotLedger, key sealed.Key) ([]byte, error) {
+	snap, err := tss.BaseSnapshot(p, TaskName, ledger, func(rnd tss.Round) (interface{}, []tss.ParsedMessage, error) {
+		base, err := baseOf(rnd)
+		if err != nil {
+			return nil, nil, err
+		}
+		state := &snapshotState{
+			OldOK:     base.oldOK,
+			NewOK:     base.newOK,
+			Input:     p.input,
+			Save:      p.save,
+			NewVs:     p.temp.NewVs,
+			NewShares: p.temp.NewShares,
+			VD:        p.temp.VD,
+			NewXi:     p.temp.newXi,
+			NewKs:     p.temp.newKs,
+			NewBigXjs: p.temp.newBigXjs,
+			SSID:      p.temp.ssid,
+			SSIDNonce: p.temp.ssidNonce,
+		}
+		return state, p.temp.localMessageStore.stored(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	plaintext, jsonErr := json.Marshal(snap)
+	if jsonErr != nil {
+		return nil, jsonErr
+	}
+	curveName, ok := tss.GetCurveName(p.params.EC())
+	if !ok {
+		return nil, errors.New("Snapshot: the curve of the party is not registered")
+	}
+	header := sealed.Header{
+		Protocol:        SnapshotProtocol,
+		ProtocolVersion: SnapshotProtocolVersion,
+		Curve:           curveName,
+		Threshold:       p.params.NewThreshold(),
+		PartyCount:      p.params.NewPartyCount(),
+	}
+	return sealed.Seal(header, plaintext, key)
+}
+
+// ResumeLocalParty rebuilds a party from a snapshot sealed by LocalParty.Snapshot, with the same parameters that the
+// party was created with. The snapshot is consumed in the ledger. The party continues in the round of the snapshot
+// with the messages it had received; it must not be started, and the messages sent to it while it was down must be
+// sent to it again.
+func ResumeLocalParty(
+	container []byte,
+	key sealed.Key,
+	ledger tss.SnapshotLedger,
+	params *tss.ReSharingParameters,
+	out chan<- tss.Message,
+	end chan<- *keygen.LocalPartySaveData,
+) (tss.Party, error) {
+	header, plaintext, err := sealed.Open(container, key)
+	if err != nil {
+		return nil, err
+	}
+	if header.Protocol != SnapshotProtocol {
+		return nil, fmt.Errorf("ResumeLocalParty: the container holds %q, not %q", header.Protocol, SnapshotProtocol)
+	}
+	if header.ProtocolVersion != SnapshotProtocolVersion {
+		return nil, fmt.Errorf("ResumeLocalParty: unsupported version %d", header.ProtocolVersion)
+	}
+	if curveName, ok := tss.GetCurveName(params.EC()); !ok || curveName != header.Curve ||
+		header.Threshold != params.NewThreshold() || header.PartyCount != params.NewPartyCount() {
+		return nil, errors.New("ResumeLocalParty: the snapshot does not match the parameters")
+	}
+	snap := new(tss.Snapshot)
+	if err = json.Unmarshal(plaintext, snap); err != nil {
+		return nil, err
+	}
+	state := new(snapshotState)
+	if err = json.Unmarshal(snap.State, state); err != nil {
+		return nil, err
+	}
+	if len(state.OldOK) != params.OldPartyCount() || len(state.NewOK) != params.NewPartyCount() ||
+		len(state.Save.Ks) != params.NewPartyCount() {
+		return nil, errors.New("ResumeLocalParty: the state in the snapshot does not match the parameters")
+	}
+
+	p := NewLocalParty(params, state.Input, out, end).(*LocalParty)
+	p.input = state.Input
+	p.save = state.Save
+	p.temp.NewVs = state.NewVs
+	p.temp.NewShares = state.NewShares
+	p.temp.VD = state.VD
+	p.temp.newXi = state.NewXi
+	p.temp.newKs = state.NewKs
+	p.temp.newBigXjs = state.NewBigXjs
+	p.temp.ssid = state.SSID
+	p.temp.ssidNonce = state.SSIDNonce
+
+	round, err := p.roundAt(snap.Round, state.OldOK, state.NewOK)
+	if err != nil {
+		return nil, err
+	}
+	if err := tss.BaseResume(p, snap, ledger, params.OldAndNewParties(), round, TaskName); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// ----- //
+
+// roundAt returns the started round with the given number and progress
+func (p *LocalParty) roundAt(number int, oldOK, newOK []bool) (tss.Round, error) {
+	r1 := newRound1(p.params, &p.input, &p.save, &p.temp, p.out, p.end).(*round1)
+	r1.number, r1.started, r1.oldOK, r1.newOK = number, true, oldOK, newOK
+	switch number {
+	case 1:
+		return r1, nil
+	case 2:
+		return &round2{r1}, nil
+	case 3:
+		return &round3{&round2{r1}}, nil
+	case 4:
+		return &round4{&round3{&round2{r1}}}, nil
+	}
+	return nil, fmt.Errorf("ResumeLocalParty: a snapshot cannot be taken in round %d", number)
+}
+
+// baseOf returns the base of a round that can be resumed by roundAt. The last round is not one: it takes no messages,
+// so the party is finishing and a snapshot of it could never be resumed.
+func baseOf(rnd tss.Round) (*base, error) {
+	switch r := rnd.(type) {
+	case *round1:
+		return r.base, nil
+	case *round2:
+		return r.base, nil
+	case *round3:
+		return r.base, nil
+	case *round4:
+		return r.base, nil
+	}
+	return nil, fmt.Errorf("unexpected round %T", rnd)
+}
+
+func (store *localMessageStore) stored() []tss.ParsedMessage {
+	msgs := make([]tss.ParsedMessage, 0)
+	for _, round := range [][]tss.ParsedMessage{
+		store.dgRound1Messages,
+		store.dgRound2Message1s,
+		store.dgRound2Message2s,
+		store.dgRound3Message1s,
+		store.dgRound3Message2s,
+		store.dgRound4Message1s,
+		store.dgRound4Message2s,
+	} {
+		for _, msg := range round {
+			if msg != nil {
+				msgs = append(msgs, msg)
+			}
+		}
+	}
+	return msgs
+}
@@ -0,0 +1,116 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package resharing_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto/sealed"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	. "github.com/bnb-chain/tss-lib/v2/ecdsa/resharing"
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func TestSnapshotAndResumeAfterCrash(t *testing.T) {
+	setUp("info")
+
+	oldKeys, oldPIDs, err := keygen.LoadKeygenTestFixtures(testThreshold+2, 1)
+	assert.NoError(t, err, "should load keygen fixtures")
+	fixtures, _, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	ledger, err := tss.NewFileSnapshotLedger(t.TempDir())
+	assert.NoError(t, err)
+	key := sealed.PassphraseWithParams([]byte("correct horse"), sealed.Argon2Params{Time: 1, Memory: 1024, Threads: 1})
+
+	oldP2PCtx := tss.NewPeerContext(oldPIDs)
+	newPIDs := tss.GenerateTestPartyIDs(testParticipants)
+	newP2PCtx := tss.NewPeerContext(newPIDs)
+	errCh := make(chan *tss.Error, len(oldPIDs)+len(newPIDs))
+	outCh := make(chan tss.Message, len(oldPIDs)+len(newPIDs))
+	endCh := make(chan *keygen.LocalPartySaveData, len(oldPIDs)+len(newPIDs))
+	newParams := func(pID *tss.PartyID) *tss.ReSharingParameters {
+		params := tss.NewReSharingParameters(tss.S256(), oldP2PCtx, newP2PCtx, pID, len(oldPIDs), testThreshold, len(newPIDs), testThreshold)
+		// do not use in untrusted setting
+		params.SetNoProofMod()
+		// do not use in untrusted setting
+		params.SetNoProofFac()
+		return params
+	}
+
+	oldCommittee := make([]tss.Party, len(oldPIDs))
+	for j, pID := range oldPIDs {
+		oldCommittee[j] = NewLocalParty(newParams(pID), oldKeys[j], outCh, endCh)
+	}
+	newCommittee := make([]tss.Party, len(newPIDs))
+	for j, pID := range newPIDs {
+		save := keygen.NewLocalPartySaveData(len(newPIDs))
+		save.LocalPreParams = fixtures[j].LocalPreParams
+		newCommittee[j] = NewLocalParty(newParams(pID), save, outCh, endCh)
+	}
+	for _, P := range append(append([]tss.Party{}, newCommittee...), oldCommittee...) {
+		go func(P tss.Party) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	// the first party of the new committee crashes in round 2; the messages to it are held until it is resumed
+	var held []tss.Message
+	resumed := false
+	deliver := func(P tss.Party, msg tss.Message) {
+		_, isRound1 := msg.(tss.ParsedMessage).Content().(*DGRound1Message)
+		if P == newCommittee[0] && !resumed && !isRound1 {
+			held = append(held, msg)
+			return
+		}
+		go test.SharedPartyUpdater(P, msg, errCh)
+	}
+
+	newKeys := make([]*keygen.LocalPartySaveData, 0, len(newPIDs))
+	for ended := 0; ended < len(oldPIDs)+len(newPIDs); {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+		case msg := <-outCh:
+			if _, ok := msg.(tss.ParsedMessage).Content().(*DGRound2Message1); ok && msg.GetFrom() == newPIDs[0] && !resumed {
+				container, err := newCommittee[0].(*LocalParty).Snapshot(ledger, key)
+				assert.NoError(t, err)
+				P, err := ResumeLocalParty(container, key, ledger, newParams(newPIDs[0]), outCh, endCh)
+				assert.NoError(t, err)
+				newCommittee[0], resumed = P, true
+				for _, m := range held {
+					deliver(P, m)
+				}
+			}
+			dest := msg.GetTo()
+			if msg.IsToOldCommittee() || msg.IsToOldAndNewCommittees() {
+				for _, destP := range dest[:len(oldCommittee)] {
+					deliver(oldCommittee[destP.Index], msg)
+				}
+			}
+			if !msg.IsToOldCommittee() || msg.IsToOldAndNewCommittees() {
+				for _, destP := range dest {
+					deliver(newCommittee[destP.Index], msg)
+				}
+			}
+		case save := <-endCh:
+			if save.Xi != nil {
+				newKeys = append(newKeys, save)
+			}
+			ended++
+		}
+	}
+	assert.True(t, resumed)
+	assert.Len(t, newKeys, len(newPIDs))
+	for _, save := range newKeys {
+		assert.True(t, oldKeys[0].ECDSAPub.Equals(save.ECDSAPub), "the public key must not change")
+	}
+}
@@ -0,0 +1,423 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/crypto/paillier"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// abortTranscriptDomain separates the signatures of the blame messages from the other Schnorr proofs made with the
+// key shares
+const abortTranscriptDomain = "tss-lib/ecdsa/signing/abort-evidence/v1"
+
+// VerifyAbortEvidence re-runs the checks of the identifiable abort phase on the evidence attached to a *tss.Error
+// returned by signing, and returns the parties that deviated from the protocol.
+// `parties` must be the signing parties and `key` any save data of the same key (only its public fields are used);
+// when signing with a key derivation delta, `key` must hold the derived ECDSAPub and BigXj.
+// Each party signs its messages in the evidence with its key share in its blame message, and evidence holding a
+// message that its sender did not sign is rejected, so that the evidence cannot name a party that followed the
+// protocol. A party that sent a broadcast message other than the one it signed is named by the parties that received
+// it, but their evidence does not verify; echo broadcast proves such an equivocation.
+// The P2P ciphertexts are echoed by both the sender and the receiver, and both are named when their copies differ;
+// such a dispute can only be settled with an authenticated record of the P2P messages.
+func VerifyAbortEvidence(ec elliptic.Curve, parties tss.SortedPartyIDs, key keygen.LocalPartySaveData, evidence []byte) ([]*tss.PartyID, error) {
+	ev := new(SignAbortEvidence)
+	if err := proto.Unmarshal(evidence, ev); err != nil {
+		return nil, err
+	}
+	subset := keygen.BuildLocalSaveDataSubset(key, parties)
+	if err := checkEvidenceLength(len(subset.Ks), ev); err != nil {
+		return nil, err
+	}
+	for j := range parties {
+		if !signsTranscript(ec, &subset, ev, j) {
+			return nil, fmt.Errorf("the messages of %s in the evidence are not signed by it", parties[j])
+		}
+	}
+	culprits, err := identifyCulprits(ec, &subset, ev)
+	if err != nil {
+		return nil, err
+	}
+	culpritIDs := make([]*tss.PartyID, len(culprits))
+	for c, j := range culprits {
+		culpritIDs[c] = parties[j]
+	}
+	return culpritIDs, nil
+}
+
+// identifyCulprits returns the indexes of the parties that deviated from the protocol according to the evidence.
+// When the final signature failed to verify, each s_j is checked against its commitment V_j = s_j*R + l_j*G.
+// When the check U = T failed, the revealed k_j and MtA openings are used to check every theta_j and,
+// once R is known to be correct, every V_j against s_j*G = m*k_j*G + r*sigma_j*G.
+func identifyCulprits(ec elliptic.Curve, key *keygen.LocalPartySaveData, ev *SignAbortEvidence) ([]int, error) {
+	n := len(key.Ks)
+	if err := checkEvidenceLength(n, ev); err != nil {
+		return nil, err
+	}
+	finalCheckFailed := 0 < len(ev.GetRound9Messages())
+	c := newCulprits(n)
+
+	// 1. open the commitments to Gamma_j, V_j, A_j, U_j and T_j
+	bigGammas, bigVs, bigAs := make([]curvePoint, n), make([]curvePoint, n), make([]curvePoint, n)
+	bigUs, bigTs := make([]curvePoint, n), make([]curvePoint, n)
+	for j := 0; j < n; j++ {
+		blame := ev.BlameMessages[j]
+		if !blame.ValidateBasic() || blame.HasOpenings() == finalCheckFailed || !signsTranscript(ec, key, ev, j) {
+			c.add(j)
+			continue
+		}
+		gamma, ok := deCommitPoints(ec, ev.Round1Messages[j].UnmarshalCommitment(), ev.Round4Messages[j].UnmarshalDeCommitment(), 1)
+		if !ok {
+			c.add(j)
+			continue
+		}
+		va, ok := deCommitPoints(ec, ev.Round5Messages[j].UnmarshalCommitment(), ev.Round6Messages[j].UnmarshalDeCommitment(), 2)
+		if !ok {
+			c.add(j)
+			continue
+		}
+		ut, ok := deCommitPoints(ec, ev.Round7Messages[j].UnmarshalCommitment(), ev.Round8Messages[j].UnmarshalDeCommitment(), 2)
+		if !ok {
+			c.add(j)
+			continue
+		}
+		bigGammas[j], bigVs[j], bigAs[j], bigUs[j], bigTs[j] = gamma[0], va[0], va[1], ut[0], ut[1]
+	}
+	if c.any() {
+		return c.list(), nil
+	}
+
+	if finalCheckFailed {
+		// 2. s_j*R + l_j*G = V_j
+		bigR, err := computeBigRFromEvidence(ec, bigGammas, ev)
+		if err != nil {
+			return nil, err
+		}
+		for j := 0; j < n; j++ {
+			sj, lj := ev.Round9Messages[j].UnmarshalS(), ev.BlameMessages[j].UnmarshalL()
+			if !bigR.mult(ec, sj).add(ec, baseMult(ec, lj)).equals(bigVs[j]) {
+				c.add(j)
+			}
+		}
+		return c.result()
+	}
+
+	// 2. parse the revealed openings and the published beta_jp*G, nu_jp*G
+	q := ec.Params().N
+	modQ := common.ModInt(q)
+	openings := make([]*mtaOpenings, n)
+	bigBetas, bigNus := make([][]*crypto.ECPoint, n), make([][]*crypto.ECPoint, n)
+	for j := 0; j < n; j++ {
+		openings[j] = ev.BlameMessages[j].UnmarshalOpenings()
+		var errB, errN error
+		bigBetas[j], errB = ev.Round3Messages[j].UnmarshalBigBetas(ec)
+		bigNus[j], errN = ev.Round3Messages[j].UnmarshalBigNus(ec)
+		if errB != nil || errN != nil || len(openings[j].cSent) != n || len(bigBetas[j]) != n || len(bigNus[j]) != n {
+			c.add(j)
+			continue
+		}
+		for p := 0; p < n; p++ {
+			if p != j && (bigBetas[j][p] == nil || bigNus[j][p] == nil) {
+				c.add(j)
+				break
+			}
+		}
+	}
+	if c.any() {
+		return c.list(), nil
+	}
+
+	// 3. check the MtA between Alice j and Bob p
+	_, bigWs := PrepareForSigning(ec, 0, n, big.NewInt(0), key.Ks, key.BigXj)
+	for j := 0; j < n; j++ {
+		opJ, pkJ := openings[j], key.PaillierPKs[j]
+		for p := 0; p < n; p++ {
+			if p == j {
+				continue
+			}
+			opP := openings[p]
+			// 3a. the ciphertexts echoed by the sender and the receiver must be the same
+			if opJ.cSent[p].Cmp(opP.cReceived[j]) != 0 ||
+				opJ.c1Received[p].Cmp(opP.c1Sent[j]) != 0 ||
+				opJ.c2Received[p].Cmp(opP.c2Sent[j]) != 0 {
+				c.add(j, p)
+				continue
+			}
+			// 3b. Alice j must open her encryption of k_j and her decryptions of Bob p's ciphertexts
+			if !opensTo(pkJ, opJ.cSent[p], opJ.k, opJ.cRandomness[p]) ||
+				!opensTo(pkJ, opJ.c1Received[p], opJ.alpha[p], opJ.alphaRandomness[p]) ||
+				!opensTo(pkJ, opJ.c2Received[p], opJ.u[p], opJ.uRandomness[p]) {
+				c.add(j)
+				continue
+			}
+			// 3c. Bob p's shares must complete them: alpha_jp + beta_pj = k_j*gamma_p and u_jp + nu_pj = k_j*w_p
+			kj := opJ.k
+			alphaJP, uJP := new(big.Int).Mod(opJ.alpha[p], q), new(big.Int).Mod(opJ.u[p], q)
+			if !baseMult(ec, alphaJP).add(ec, fromECPoint(bigBetas[p][j])).equals(bigGammas[p].mult(ec, kj)) ||
+				!baseMult(ec, uJP).add(ec, fromECPoint(bigNus[p][j])).equals(fromECPoint(bigWs[p]).mult(ec, kj)) {
+				c.add(p)
+			}
+		}
+	}
+	if c.any() {
+		return c.list(), nil
+	}
+
+	// 4. theta_j*G = k_j*Gamma_j + sum_p alpha_jp*G + sum_p beta_jp*G
+	for j := 0; j < n; j++ {
+		opJ := openings[j]
+		thetaMinusAlphas := ev.Round3Messages[j].UnmarshalTheta()
+		rhs := bigGammas[j].mult(ec, opJ.k)
+		for p := 0; p < n; p++ {
+			if p == j {
+				continue
+			}
+			thetaMinusAlphas = modQ.Sub(thetaMinusAlphas, opJ.alpha[p])
+			rhs = rhs.add(ec, fromECPoint(bigBetas[j][p]))
+		}
+		if !baseMult(ec, thetaMinusAlphas).equals(rhs) {
+			c.add(j)
+		}
+	}
+	if c.any() {
+		return c.list(), nil
+	}
+
+	// 5. the thetas are correct so R = k^-1*G; check A_j = rho_j*G, U_j = rho_j*V and T_j = l_j*A
+	bigR, err := computeBigRFromEvidence(ec, bigGammas, ev)
+	if err != nil {
+		return nil, err
+	}
+	m := new(big.Int).SetBytes(ev.GetM())
+	r := new(big.Int).Mod(bigR.X, q)
+	bigY := fromECPoint(key.ECDSAPub)
+	bigV := baseMult(ec, modQ.Sub(big.NewInt(0), m)).add(ec, bigY.mult(ec, modQ.Sub(big.NewInt(0), r)))
+	var bigA curvePoint
+	for j := 0; j < n; j++ {
+		bigV = bigV.add(ec, bigVs[j])
+		bigA = bigA.add(ec, bigAs[j])
+	}
+	for j := 0; j < n; j++ {
+		rhoJ, lJ := openings[j].rho, ev.BlameMessages[j].UnmarshalL()
+		if !baseMult(ec, rhoJ).equals(bigAs[j]) || !bigV.mult(ec, rhoJ).equals(bigUs[j]) || !bigA.mult(ec, lJ).equals(bigTs[j]) {
+			c.add(j)
+		}
+	}
+	if c.any() {
+		return c.list(), nil
+	}
+
+	// 6. s_j*G = k*(V_j - l_j*G) must equal m*k_j*G + r*sigma_j*G, where
+	// sigma_j*G = k_j*W_j + sum_p u_jp*G + sum_p nu_jp*G
+	k := big.NewInt(0)
+	for j := 0; j < n; j++ {
+		k = modQ.Add(k, openings[j].k)
+	}
+	for j := 0; j < n; j++ {
+		opJ := openings[j]
+		lJ := ev.BlameMessages[j].UnmarshalL()
+		sjG := bigVs[j].add(ec, baseMult(ec, modQ.Sub(big.NewInt(0), lJ))).mult(ec, k)
+		sumU := big.NewInt(0)
+		bigSigma := fromECPoint(bigWs[j]).mult(ec, opJ.k)
+		for p := 0; p < n; p++ {
+			if p == j {
+				continue
+			}
+			sumU = modQ.Add(sumU, opJ.u[p])
+			bigSigma = bigSigma.add(ec, fromECPoint(bigNus[j][p]))
+		}
+		bigSigma = bigSigma.add(ec, baseMult(ec, sumU))
+		if !baseMult(ec, modQ.Mul(m, opJ.k)).add(ec, bigSigma.mult(ec, r)).equals(sjG) {
+			c.add(j)
+		}
+	}
+	return c.result()
+}
+
+// checkEvidenceLength checks that the evidence holds the messages of each of the n parties
+func checkEvidenceLength(n int, ev *SignAbortEvidence) error {
+	for _, l := range []int{len(ev.GetRound1Messages()), len(ev.GetRound3Messages()), len(ev.GetRound4Messages()),
+		len(ev.GetRound5Messages()), len(ev.GetRound6Messages()), len(ev.GetRound7Messages()),
+		len(ev.GetRound8Messages()), len(ev.GetBlameMessages())} {
+		if l != n {
+			return fmt.Errorf("the evidence must contain a message of each of the %d parties", n)
+		}
+	}
+	if l := len(ev.GetRound9Messages()); l != 0 && l != n {
+		return fmt.Errorf("the evidence must contain a round 9 message of each of the %d parties", n)
+	}
+	return nil
+}
+
+// signsTranscript returns whether the blame message of party j signs m and its messages in the evidence with the
+// secret of BigXj[j] (see signAbortTranscript)
+func signsTranscript(ec elliptic.Curve, key *keygen.LocalPartySaveData, ev *SignAbortEvidence, j int) bool {
+	blame := ev.BlameMessages[j]
+	signature, err := blame.UnmarshalSignature(ec)
+	if err != nil {
+		return false
+	}
+	unsigned := proto.Clone(blame).(*SignBlameMessage)
+	unsigned.SignatureAlphaX, unsigned.SignatureAlphaY, unsigned.SignatureT = nil, nil, nil
+	msgs := []proto.Message{ev.Round1Messages[j], ev.Round3Messages[j], ev.Round4Messages[j], ev.Round5Messages[j],
+		ev.Round6Messages[j], ev.Round7Messages[j], ev.Round8Messages[j]}
+	if 0 < len(ev.GetRound9Messages()) {
+		msgs = append(msgs, ev.Round9Messages[j])
+	}
+	session, err := abortTranscript(new(big.Int).SetBytes(ev.GetM()), append(msgs, unsigned)...)
+	if err != nil {
+		return false
+	}
+	return signature.Verify(session, key.BigXj[j])
+}
+
+// abortTranscript hashes m and the messages that a party signs in its blame message. The messages are encoded
+// deterministically, so that the hash of a message decoded from the evidence is that of the message as sent.
+func abortTranscript(m *big.Int, msgs ...proto.Message) ([]byte, error) {
+	parts := make([][]byte, 0, len(msgs)+2)
+	parts = append(parts, []byte(abortTranscriptDomain), m.Bytes())
+	for _, msg := range msgs {
+		bz, err := proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, bz)
+	}
+	return common.SHA512_256(parts...), nil
+}
+
+// computeBigRFromEvidence returns R = (sum Gamma_j)^(theta^-1) with theta = sum theta_j
+func computeBigRFromEvidence(ec elliptic.Curve, bigGammas []curvePoint, ev *SignAbortEvidence) (curvePoint, error) {
+	modQ := common.ModInt(ec.Params().N)
+	theta := big.NewInt(0)
+	var bigGamma curvePoint
+	for j := range bigGammas {
+		theta = modQ.Add(theta, ev.Round3Messages[j].UnmarshalTheta())
+		bigGamma = bigGamma.add(ec, bigGammas[j])
+	}
+	if theta.Sign() == 0 {
+		return curvePoint{}, errors.New("theta is zero")
+	}
+	return bigGamma.mult(ec, modQ.ModInverse(theta)), nil
+}
+
+// deCommitPoints opens a hash commitment to `count` curve points
+func deCommitPoints(ec elliptic.Curve, C *big.Int, D []*big.Int, count int) ([]curvePoint, bool) {
+	cmtDeCmt := commitments.HashCommitDecommit{C: C, D: D}
+	ok, values := cmtDeCmt.DeCommit()
+	if !ok || len(values) != 2*count {
+		return nil, false
+	}
+	points := make([]curvePoint, count)
+	for c := range points {
+		point, err := crypto.NewECPoint(ec, values[2*c], values[2*c+1])
+		if err != nil {
+			return nil, false
+		}
+		points[c] = fromECPoint(point)
+	}
+	return points, true
+}
+
+func opensTo(pk *paillier.PublicKey, c, m, x *big.Int) bool {
+	opened, err := pk.EncryptWithRandomness(m, x)
+	return err == nil && opened.Cmp(c) == 0
+}
+
+// ----- //
+
+// curvePoint is an affine point for the checks above; unlike crypto.ECPoint it may be the point at infinity (0, 0)
+// which a cheating party could otherwise use to make a check panic.
+type curvePoint struct {
+	X, Y *big.Int
+}
+
+func fromECPoint(p *crypto.ECPoint) curvePoint {
+	return curvePoint{p.X(), p.Y()}
+}
+
+func baseMult(ec elliptic.Curve, k *big.Int) curvePoint {
+	x, y := ec.ScalarBaseMult(new(big.Int).Mod(k, ec.Params().N).Bytes())
+	return curvePoint{x, y}
+}
+
+func (p curvePoint) isInfinity() bool {
+	return p.X == nil || (p.X.Sign() == 0 && p.Y.Sign() == 0)
+}
+
+func (p curvePoint) mult(ec elliptic.Curve, k *big.Int) curvePoint {
+	if p.isInfinity() {
+		return curvePoint{}
+	}
+	x, y := ec.ScalarMult(p.X, p.Y, new(big.Int).Mod(k, ec.Params().N).Bytes())
+	return curvePoint{x, y}
+}
+
+func (p curvePoint) add(ec elliptic.Curve, p2 curvePoint) curvePoint {
+	if p.isInfinity() {
+		return p2
+	}
+	if p2.isInfinity() {
+		return p
+	}
+	x, y := ec.Add(p.X, p.Y, p2.X, p2.Y)
+	return curvePoint{x, y}
+}
+
+func (p curvePoint) equals(p2 curvePoint) bool {
+	if p.isInfinity() || p2.isInfinity() {
+		return p.isInfinity() && p2.isInfinity()
+	}
+	return p.X.Cmp(p2.X) == 0 && p.Y.Cmp(p2.Y) == 0
+}
+
+// ----- //
+
+type culpritSet map[int]struct{}
+
+func newCulprits(n int) culpritSet {
+	return make(culpritSet, n)
+}
+
+func (c culpritSet) add(js ...int) {
+	for _, j := range js {
+		c[j] = struct{}{}
+	}
+}
+
+func (c culpritSet) any() bool {
+	return 0 < len(c)
+}
+
+func (c culpritSet) list() []int {
+	js := make([]int, 0, len(c))
+	for j := range c {
+		js = append(js, j)
+	}
+	sort.Ints(js)
+	return js
+}
+
+func (c culpritSet) result() ([]int, error) {
+	if !c.any() {
+		return nil, errors.New("the evidence does not show that any party deviated from the protocol")
+	}
+	return c.list(), nil
+}
@@ -0,0 +1,124 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// runSigningWithCheater delivers the messages of a signing run one at a time so that `tamper` can change the state of
+// a party right after it sent a message, and returns the error of each party.
+func runSigningWithCheater(t *testing.T, tamper func(parties []*LocalParty, msg tss.Message)) ([]*tss.Error, []keygen.LocalPartySaveData, tss.SortedPartyIDs) {
+	keys, signPIDs, err := keygen.LoadKeygenTestFixtures(testThreshold + 1)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]*LocalParty, 0, len(signPIDs))
+	n := len(signPIDs)
+	outCh := make(chan tss.Message, n*n*10)
+	endCh := make(chan *common.SignatureData, n)
+	for i := 0; i < n; i++ {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], n, testThreshold)
+		P := NewLocalParty(big.NewInt(42), params, keys[i], outCh, endCh).(*LocalParty)
+		parties = append(parties, P)
+	}
+
+	errs := make([]*tss.Error, n)
+	errCh := make(chan *tss.Error, n*n*10)
+	for _, P := range parties {
+		if err := P.Start(); err != nil {
+			errs[P.PartyID().Index] = err
+		}
+	}
+	for 0 < len(outCh) {
+		msg := <-outCh
+		tamper(parties, msg)
+		for _, P := range parties {
+			if (msg.IsBroadcast() || msg.GetTo()[0].Index == P.PartyID().Index) && errs[P.PartyID().Index] == nil {
+				test.SharedPartyUpdater(P, msg, errCh)
+			}
+			select {
+			case err := <-errCh:
+				errs[P.PartyID().Index] = err
+			default:
+			}
+		}
+	}
+	assert.Equal(t, 0, len(endCh), "no party should output a signature")
+	return errs, keys, signPIDs
+}
+
+func assertCulprit(t *testing.T, errs []*tss.Error, keys []keygen.LocalPartySaveData, signPIDs tss.SortedPartyIDs, cheater int) {
+	for j, err := range errs {
+		if !assert.NotNilf(t, err, "party %d should abort", j) {
+			continue
+		}
+		if assert.Equal(t, 1, len(err.Culprits()), err.Error()) {
+			assert.Equal(t, signPIDs[cheater], err.Culprits()[0])
+		}
+		culprits, verr := VerifyAbortEvidence(tss.S256(), signPIDs, keys[0], err.Evidence())
+		assert.NoError(t, verr)
+		assert.Equal(t, err.Culprits(), culprits, "the evidence should name the same culprits")
+	}
+}
+
+func TestIdentifyAbortWrongTheta(t *testing.T) {
+	setUp("info")
+	const cheater = 1
+
+	// once round 2 is done the cheater uses a different k_i than the one it encrypted for the MtA, so U != T
+	errs, keys, signPIDs := runSigningWithCheater(t, func(parties []*LocalParty, msg tss.Message) {
+		if _, ok := msg.(tss.ParsedMessage).Content().(*SignRound2Message); ok && msg.GetFrom().Index == cheater && msg.GetTo()[0].Index == 0 {
+			parties[cheater].temp.k = new(big.Int).Add(parties[cheater].temp.k, big.NewInt(1))
+		}
+	})
+	assertCulprit(t, errs, keys, signPIDs, cheater)
+	for _, err := range errs {
+		assert.Equal(t, 10, err.Round(), "the parties should abort after round 9")
+	}
+
+	// evidence that changes a message of a party that followed the protocol does not verify
+	const honest = 0
+	ev := new(SignAbortEvidence)
+	assert.NoError(t, proto.Unmarshal(errs[honest].Evidence(), ev))
+	theta := new(big.Int).SetBytes(ev.Round3Messages[honest].GetTheta())
+	ev.Round3Messages[honest].Theta = new(big.Int).Add(theta, big.NewInt(1)).Bytes()
+	forged, err := proto.Marshal(ev)
+	assert.NoError(t, err)
+	culprits, err := VerifyAbortEvidence(tss.S256(), signPIDs, keys[0], forged)
+	assert.Error(t, err, "evidence with a message that its sender did not sign should be rejected")
+	assert.Nil(t, culprits)
+}
+
+func TestIdentifyAbortWrongS(t *testing.T) {
+	setUp("info")
+	const cheater = 2
+
+	// the cheater broadcasts an s_i that does not match its commitment to V_i, so the signature does not verify
+	errs, keys, signPIDs := runSigningWithCheater(t, func(parties []*LocalParty, msg tss.Message) {
+		if _, ok := msg.(tss.ParsedMessage).Content().(*SignRound8Message); ok && msg.GetFrom().Index == cheater {
+			parties[cheater].temp.si = new(big.Int).Add(parties[cheater].temp.si, big.NewInt(1))
+		}
+	})
+	assertCulprit(t, errs, keys, signPIDs, cheater)
+	for _, err := range errs {
+		assert.Equal(t, 11, err.Round(), "the parties should abort after the final signature check")
+	}
+
+	tampered, err := VerifyAbortEvidence(tss.S256(), signPIDs, keys[0], []byte{1, 2, 3})
+	assert.Error(t, err, "malformed evidence should be rejected")
+	assert.Nil(t, tampered)
+}
@@ -0,0 +1,437 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	BatchTaskName = "signing-batch"
+
+	// the last round in which the lanes send messages: the finalization, in which they send their blame messages
+	lastBatchRound = 10
+)
+
+// Implements Party
+// Implements Stringer
+var (
+	_ tss.Party    = (*BatchLocalParty)(nil)
+	_ fmt.Stringer = (*BatchLocalParty)(nil)
+)
+
+type (
+	// BatchLocalParty signs many messages in one session. It runs a signing session for each message, and sends the
+	// messages of a round of all of the sessions bundled in one SignBatchMessage to each party, so that the whole batch
+	// takes as many round trips as a single signature.
+	BatchLocalParty struct {
+		*tss.BaseParty
+		params *tss.Parameters
+
+		temp batchTempData
+
+		// outbound messaging
+		out chan<- tss.Message
+		end chan<- *common.SignatureData
+	}
+
+	batchTempData struct {
+		lanes []*batchLane
+		// bundles that were received and not passed on to the lanes yet
+		received []tss.ParsedMessage
+		// the bundles that were received, of which only the first from each sender in each round is kept
+		bundles map[batchBundle]struct{}
+	}
+
+	// batchBundle identifies the broadcast or point-to-point bundle of a sender in a round
+	batchBundle struct {
+		from, round int
+		broadcast   bool
+	}
+
+	// batchLane is the signing session of one message in a batch. Its rounds are run by the rounds of the batch rather
+	// than by a party of its own, and the messages it sends are collected from its channel when the batch sends.
+	batchLane struct {
+		*LocalParty
+		round tss.Round
+		out   chan tss.Message
+		end   chan *common.SignatureData
+	}
+
+	batchBase struct {
+		*tss.Parameters
+		temp    *batchTempData
+		out     chan<- tss.Message
+		end     chan<- *common.SignatureData
+		started bool
+		number  int
+	}
+	batchRound struct {
+		*batchBase
+	}
+	batchFinalization struct {
+		*batchBase
+	}
+)
+
+var (
+	_ tss.Round = (*batchRound)(nil)
+	_ tss.Round = (*batchFinalization)(nil)
+)
+
+// NewBatchLocalParty returns a party that signs each of msgs with the same parties in one session. A
+// SignatureData is sent through `end` for each message, in the order of msgs, once all of them are signed.
+func NewBatchLocalParty(
+	msgs []*big.Int,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+	fullBytesLen ...int,
+) tss.Party {
+	partyCount := len(params.Parties().IDs())
+	p := &BatchLocalParty{
+		BaseParty: new(tss.BaseParty),
+		params:    params,
+		out:       out,
+		end:       end,
+	}
+	p.temp.lanes = make([]*batchLane, len(msgs))
+	p.temp.bundles = make(map[batchBundle]struct{})
+	for idx, msg := range msgs {
+		// a round of signing sends at most one message to each other party and one broadcast
+		lane := &batchLane{
+			out: make(chan tss.Message, partyCount),
+			end: make(chan *common.SignatureData, 1),
+		}
+		lane.LocalParty = NewLocalParty(msg, params, key, lane.out, lane.end, fullBytesLen...).(*LocalParty)
+		lane.round = lane.FirstRound()
+		lane.temp.ssidNonce = big.NewInt(int64(idx))
+		p.temp.lanes[idx] = lane
+	}
+	return p
+}
+
+func (p *BatchLocalParty) FirstRound() tss.Round {
+	return &batchRound{&batchBase{p.params, &p.temp, p.out, p.end, false, 1}}
+}
+
+func (p *BatchLocalParty) Start() *tss.Error {
+	return tss.BaseStart(p, BatchTaskName, func(round tss.Round) *tss.Error {
+		round1, ok := round.(*batchRound)
+		if !ok {
+			return round.WrapError(errors.New("unable to Start(). party is in an unexpected round"))
+		}
+		if err := round1.prepare(); err != nil {
+			return round.WrapError(err)
+		}
+		return nil
+	})
+}
+
+func (p *BatchLocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
+	return tss.BaseUpdate(p, msg, BatchTaskName)
+}
+
+func (p *BatchLocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.Update(msg)
+}
+
+func (p *BatchLocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	if ok, err := p.BaseParty.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	// check that the message's "from index" will fit into the array
+	if maxFromIdx := len(p.params.Parties().IDs()) - 1; maxFromIdx < msg.GetFrom().Index {
+		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
+			maxFromIdx, msg.GetFrom().Index), msg.GetFrom())
+	}
+	return true, nil
+}
+
+func (p *BatchLocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	// ValidateBasic is cheap; double-check the message here in case the public StoreMessage was called externally
+	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	content, ok := msg.Content().(*SignBatchMessage)
+	if !ok {
+		common.Logger.Warningf("unrecognised message ignored: %v", msg)
+		return false, nil
+	}
+	if r := content.GetRound(); r < 1 || lastBatchRound < r {
+		return false, p.WrapError(fmt.Errorf("received a bundle of round %d of a batch of %d rounds", r,
+			lastBatchRound), msg.GetFrom())
+	}
+	bundle := batchBundle{msg.GetFrom().Index, int(content.GetRound()), msg.IsBroadcast()}
+	if _, dup := p.temp.bundles[bundle]; dup {
+		common.Logger.Warningf("duplicate bundle of round %d from %s ignored", bundle.round, msg.GetFrom())
+		return false, nil
+	}
+	p.temp.bundles[bundle] = struct{}{}
+	// the bundles are passed on to the lanes by the round that they were sent in
+	p.temp.received = append(p.temp.received, msg)
+	return true, nil
+}
+
+func (p *BatchLocalParty) PartyID() *tss.PartyID {
+	return p.params.PartyID()
+}
+
+func (p *BatchLocalParty) String() string {
+	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
+}
+
+// ----- //
+
+func (round *batchBase) Params() *tss.Parameters {
+	return round.Parameters
+}
+
+func (round *batchBase) RoundNumber() int {
+	return round.number
+}
+
+// Out is used by the echo broadcast of the party
+func (round *batchBase) Out() chan<- tss.Message {
+	return round.out
+}
+
+func (round *batchBase) WrapError(err error, culprits ...*tss.PartyID) *tss.Error {
+	return tss.NewError(err, BatchTaskName, round.number, round.PartyID(), culprits...)
+}
+
+// laneError wraps an error of the session of the message at idx, keeping its culprits and evidence
+func (round *batchBase) laneError(idx int, err *tss.Error) *tss.Error {
+	return tss.NewErrorWithEvidence(fmt.Errorf("message %d: %w", idx, err.Cause()), BatchTaskName, round.number,
+		round.PartyID(), err.Evidence(), err.Culprits()...)
+}
+
+// ----- //
+
+// prepare works out this party's share of the key and the public shares of the others once for all of the lanes
+func (round *batchRound) prepare() error {
+	if len(round.temp.lanes) == 0 {
+		return errors.New("there are no messages to sign")
+	}
+	first := round.temp.lanes[0].round.(*round1)
+	if err := first.prepare(); err != nil {
+		return err
+	}
+	for _, lane := range round.temp.lanes[1:] {
+		lane.temp.w = first.temp.w
+		lane.temp.bigWs = first.temp.bigWs
+	}
+	return nil
+}
+
+func (round *batchRound) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.started = true
+
+	if round.number == 1 {
+		for idx, lane := range round.temp.lanes {
+			if err := lane.round.Start(); err != nil {
+				return round.laneError(idx, err)
+			}
+		}
+	}
+
+	// the lanes sent the messages of this round when they started it
+	Ps := round.Parties().IDs()
+	var broadcast []*SignBatchEntry
+	p2p := make([][]*SignBatchEntry, len(Ps))
+	for idx, lane := range round.temp.lanes {
+		for len(lane.out) > 0 {
+			msg := (<-lane.out).(tss.ParsedMessage)
+			entry, err := NewSignBatchEntry(idx, msg)
+			if err != nil {
+				return round.WrapError(err)
+			}
+			if msg.IsBroadcast() {
+				broadcast = append(broadcast, entry)
+				continue
+			}
+			j := msg.GetTo()[0].Index
+			p2p[j] = append(p2p[j], entry)
+		}
+	}
+	if len(broadcast) > 0 {
+		round.out <- tss.WithRound(NewSignBatchMessage(nil, round.PartyID(), round.number, broadcast), round.number)
+	}
+	for j, entries := range p2p {
+		if len(entries) > 0 {
+			enc, err := tss.EncryptMessage(round.Params(), NewSignBatchMessage(Ps[j], round.PartyID(), round.number, entries))
+			if err != nil {
+				return round.WrapError(err)
+			}
+			round.out <- tss.WithRound(enc, round.number)
+		}
+	}
+	return nil
+}
+
+func (round *batchRound) Update() (bool, *tss.Error) {
+	// bundles of later rounds are held back, so that each round is complete before the lanes act on the next one
+	later := make([]tss.ParsedMessage, 0, len(round.temp.received))
+	for _, msg := range round.temp.received {
+		if int(msg.Content().(*SignBatchMessage).GetRound()) > round.number {
+			later = append(later, msg)
+			continue
+		}
+		if err := round.deliver(msg); err != nil {
+			return false, err
+		}
+	}
+	round.temp.received = later
+
+	for idx, lane := range round.temp.lanes {
+		if err := lane.update(); err != nil {
+			return false, round.laneError(idx, err)
+		}
+	}
+	return true, nil
+}
+
+// deliver stores the messages in a bundle in their lanes
+func (round *batchRound) deliver(msg tss.ParsedMessage) *tss.Error {
+	from := msg.GetFrom()
+	indices, msgs, err := msg.Content().(*SignBatchMessage).UnmarshalEntries(from, msg.IsBroadcast())
+	if err != nil {
+		return round.WrapError(err, from)
+	}
+	for e, idx := range indices {
+		if idx >= len(round.temp.lanes) {
+			return round.WrapError(fmt.Errorf("received a message for session %d of a batch of %d", idx,
+				len(round.temp.lanes)), from)
+		}
+		if _, err := round.temp.lanes[idx].StoreMessage(msgs[e]); err != nil {
+			return round.laneError(idx, err)
+		}
+	}
+	return nil
+}
+
+func (round *batchRound) CanAccept(msg tss.ParsedMessage) bool {
+	if content, ok := msg.Content().(*SignBatchMessage); ok {
+		return int(content.GetRound()) == round.number
+	}
+	return false
+}
+
+// CanProceed is true once every lane has moved on from this round
+func (round *batchRound) CanProceed() bool {
+	if !round.started {
+		return false
+	}
+	for _, lane := range round.temp.lanes {
+		if lane.round != nil && lane.round.RoundNumber() <= round.number {
+			return false
+		}
+	}
+	return true
+}
+
+// WaitingFor is called by a Party for reporting back to the caller
+func (round *batchRound) WaitingFor() []*tss.PartyID {
+	Ps := round.Parties().IDs()
+	waiting := make([]bool, len(Ps))
+	for _, lane := range round.temp.lanes {
+		if lane.round == nil || lane.round.RoundNumber() > round.number {
+			continue
+		}
+		for _, Pj := range lane.round.WaitingFor() {
+			waiting[Pj.Index] = true
+		}
+	}
+	ids := make([]*tss.PartyID, 0, len(Ps))
+	for j, w := range waiting {
+		if w {
+			ids = append(ids, Ps[j])
+		}
+	}
+	return ids
+}
+
+func (round *batchRound) NextRound() tss.Round {
+	round.started = false
+	round.number++
+	for _, lane := range round.temp.lanes {
+		if lane.round != nil {
+			return &batchRound{round.batchBase}
+		}
+	}
+	return &batchFinalization{round.batchBase}
+}
+
+// ----- //
+
+func (round *batchFinalization) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.started = true
+
+	for _, lane := range round.temp.lanes {
+		round.end <- <-lane.end
+	}
+	return nil
+}
+
+func (round *batchFinalization) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *batchFinalization) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *batchFinalization) CanProceed() bool {
+	return round.started
+}
+
+func (round *batchFinalization) WaitingFor() []*tss.PartyID {
+	return []*tss.PartyID{}
+}
+
+func (round *batchFinalization) NextRound() tss.Round {
+	return nil // finished!
+}
+
+// ----- //
+
+// update runs the lane through the rounds that its stored messages complete
+func (lane *batchLane) update() *tss.Error {
+	for lane.round != nil {
+		if _, err := lane.round.Update(); err != nil {
+			return err
+		}
+		if !lane.round.CanProceed() {
+			return nil
+		}
+		if lane.round = lane.round.NextRound(); lane.round != nil {
+			if err := lane.round.Start(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
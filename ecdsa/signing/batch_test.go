@@ -0,0 +1,127 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func TestE2EBatchConcurrent(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	msgs := []*big.Int{big.NewInt(42), big.NewInt(43), new(big.Int).SetBytes([]byte("withdrawal #3"))}
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]tss.Party, 0, len(signPIDs))
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs)*len(msgs))
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		P := NewBatchLocalParty(msgs, params, keys[i], outCh, endCh)
+		parties = append(parties, P)
+		go func(P tss.Party) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	// the first party sends one broadcast in each round and one message to each other party in rounds 1 and 2, as
+	// when it signs a single message
+	sent := 0
+	signatures := make([]*common.SignatureData, 0, len(signPIDs)*len(msgs))
+	for len(signatures) < len(signPIDs)*len(msgs) {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+		case msg := <-outCh:
+			if msg.GetFrom().Index == 0 {
+				sent++
+			}
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index != msg.GetFrom().Index {
+						go test.SharedPartyUpdater(P, msg, errCh)
+					}
+				}
+				continue
+			}
+			go test.SharedPartyUpdater(parties[dest[0].Index], msg, errCh)
+		case sig := <-endCh:
+			signatures = append(signatures, sig)
+		}
+	}
+	assert.Equal(t, 8+2*(len(signPIDs)-1), sent)
+
+	pk := ecdsa.PublicKey{Curve: tss.S256(), X: keys[0].ECDSAPub.X(), Y: keys[0].ECDSAPub.Y()}
+	for s, sig := range signatures {
+		// each party sends its signatures in the order of the messages
+		m := msgs[s%len(msgs)]
+		assert.Equal(t, m.Bytes(), sig.M)
+		ok := ecdsa.Verify(&pk, m.Bytes(), new(big.Int).SetBytes(sig.R), new(big.Int).SetBytes(sig.S))
+		assert.True(t, ok, "ecdsa verify must pass")
+	}
+}
+
+func TestBatchRejectsUnknownSession(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	params := tss.NewParameters(tss.S256(), tss.NewPeerContext(signPIDs), signPIDs[0], len(signPIDs), testThreshold)
+	P := NewBatchLocalParty([]*big.Int{big.NewInt(42)}, params, keys[0], make(chan tss.Message, len(signPIDs)), nil)
+	assert.Nil(t, P.Start())
+
+	entry, err := NewSignBatchEntry(1, NewSignRound1Message2(signPIDs[1], big.NewInt(1)))
+	assert.NoError(t, err)
+	_, tssErr := P.Update(NewSignBatchMessage(nil, signPIDs[1], 1, []*SignBatchEntry{entry}))
+	if assert.NotNil(t, tssErr) {
+		assert.Equal(t, []*tss.PartyID{signPIDs[1]}, tssErr.Culprits())
+	}
+}
+
+func TestBatchKeepsOneBundlePerSenderAndRound(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	params := tss.NewParameters(tss.S256(), tss.NewPeerContext(signPIDs), signPIDs[0], len(signPIDs), testThreshold)
+	P := NewBatchLocalParty([]*big.Int{big.NewInt(42)}, params, keys[0], make(chan tss.Message, len(signPIDs)), nil).(*BatchLocalParty)
+
+	entry, err := NewSignBatchEntry(0, NewSignRound1Message2(signPIDs[1], big.NewInt(1)))
+	assert.NoError(t, err)
+	bundle := func(round int) tss.ParsedMessage {
+		return NewSignBatchMessage(nil, signPIDs[1], round, []*SignBatchEntry{entry})
+	}
+
+	_, tssErr := P.StoreMessage(bundle(1<<32 - 1))
+	if assert.NotNil(t, tssErr, "a bundle of a round after the last must be rejected") {
+		assert.Equal(t, []*tss.PartyID{signPIDs[1]}, tssErr.Culprits())
+	}
+	ok, tssErr := P.StoreMessage(bundle(3))
+	assert.True(t, ok)
+	assert.Nil(t, tssErr)
+	ok, tssErr = P.StoreMessage(bundle(3))
+	assert.False(t, ok, "a second bundle of the same round from the same sender must be ignored")
+	assert.Nil(t, tssErr)
+	assert.Len(t, P.temp.received, 1)
+}
@@ -0,0 +1,150 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/paillier"
+	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// BenchmarkSigning measures the latency of a signing by all of the parties, with and without a pool of encryption
+// randomness for each Paillier key. The time spent filling the pools between signings is not counted.
+func BenchmarkSigning(b *testing.B) {
+	setUp("error")
+	for _, n := range []int{5, 15} {
+		for _, pooled := range []bool{false, true} {
+			name := fmt.Sprintf("%d parties", n)
+			if pooled {
+				name += " with randomness pools"
+			}
+			n, pooled := n, pooled
+			b.Run(name, func(b *testing.B) {
+				keys, pIDs, pks := dealBenchmarkKeys(b, n)
+				// each party encrypts under its own key for each other party in round 1, and under the key of each
+				// other party twice in round 2
+				perKey := 3 * n * (n - 1) / len(pks)
+				pools := make([]*paillier.RandomnessPool, 0, len(pks))
+				for _, pk := range pks {
+					if !pooled {
+						break
+					}
+					pool, err := paillier.NewRandomnessPool(pk, rand.Reader, perKey)
+					if err != nil {
+						b.Fatal(err)
+					}
+					if err := pk.SetRandomnessPool(pool); err != nil {
+						b.Fatal(err)
+					}
+					pools = append(pools, pool)
+				}
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					b.StopTimer()
+					for _, pool := range pools {
+						if err := pool.Fill(context.Background()); err != nil {
+							b.Fatal(err)
+						}
+					}
+					b.StartTimer()
+					runBenchmarkSigning(b, keys, pIDs)
+				}
+			})
+		}
+	}
+}
+
+// dealBenchmarkKeys shares a random key among n parties, as a keygen would, reusing the pre-params of the keygen
+// fixtures so that more parties than there are fixtures can sign. It returns the Paillier public keys, which the
+// parties share.
+func dealBenchmarkKeys(b *testing.B, n int) ([]keygen.LocalPartySaveData, tss.SortedPartyIDs, []*paillier.PublicKey) {
+	fixtures, _, err := keygen.LoadKeygenTestFixtures(test.TestParticipants)
+	if err != nil {
+		b.Fatal(err)
+	}
+	if n < len(fixtures) {
+		fixtures = fixtures[:n]
+	}
+	pks := make([]*paillier.PublicKey, len(fixtures))
+	for i, fixture := range fixtures {
+		pks[i] = &paillier.PublicKey{N: fixture.PaillierSK.N}
+	}
+
+	ec := tss.S256()
+	pIDs := tss.GenerateTestPartyIDs(n)
+	ids := make([]*big.Int, n)
+	for j, pID := range pIDs {
+		ids[j] = pID.KeyInt()
+	}
+	secret := common.GetRandomPositiveInt(rand.Reader, ec.Params().N)
+	_, shares, err := vss.Create(ec, n/2, secret, ids, rand.Reader)
+	if err != nil {
+		b.Fatal(err)
+	}
+	keys := make([]keygen.LocalPartySaveData, n)
+	for i := range keys {
+		key := keygen.NewLocalPartySaveData(n)
+		key.LocalPreParams = fixtures[i%len(fixtures)].LocalPreParams
+		key.Xi, key.ShareID = shares[i].Share, shares[i].ID
+		key.ECDSAPub = crypto.ScalarBaseMult(ec, secret)
+		for j := range ids {
+			fixture := fixtures[j%len(fixtures)]
+			key.Ks[j] = shares[j].ID
+			key.NTildej[j], key.H1j[j], key.H2j[j] = fixture.NTildei, fixture.H1i, fixture.H2i
+			key.BigXj[j] = crypto.ScalarBaseMult(ec, shares[j].Share)
+			key.PaillierPKs[j] = pks[j%len(pks)]
+		}
+		keys[i] = key
+	}
+	return keys, pIDs, pks
+}
+
+func runBenchmarkSigning(b *testing.B, keys []keygen.LocalPartySaveData, pIDs tss.SortedPartyIDs) {
+	p2pCtx := tss.NewPeerContext(pIDs)
+	parties := make([]tss.Party, 0, len(pIDs))
+	errCh := make(chan *tss.Error, len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs))
+	endCh := make(chan *common.SignatureData, len(pIDs))
+	for i := range pIDs {
+		params := tss.NewParameters(tss.S256(), p2pCtx, pIDs[i], len(pIDs), len(pIDs)/2)
+		P := NewLocalParty(big.NewInt(42), params, keys[i], outCh, endCh)
+		parties = append(parties, P)
+		go func(P tss.Party) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+	for ended := 0; ended < len(pIDs); {
+		select {
+		case err := <-errCh:
+			b.Fatal(err)
+		case msg := <-outCh:
+			if dest := msg.GetTo(); dest != nil {
+				go test.SharedPartyUpdater(parties[dest[0].Index], msg, errCh)
+				continue
+			}
+			for _, P := range parties {
+				if P.PartyID().Index != msg.GetFrom().Index {
+					go test.SharedPartyUpdater(P, msg, errCh)
+				}
+			}
+		case <-endCh:
+			ended++
+		}
+	}
+}
@@ -15,6 +15,7 @@ package signing
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	anypb "google.golang.org/protobuf/types/known/anypb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -213,6 +214,10 @@ type SignRound3Message struct {
 	unknownFields protoimpl.UnknownFields
 
 	Theta []byte `protobuf:"bytes,1,opt,name=theta,proto3" json:"theta,omitempty"`
+	// beta_ij*G and nu_ij*G of the MtA shares this party (as Bob) kept for each party j, flattened as x, y pairs.
+	// the pair at this party's own index is empty. these are only used to identify a cheater after an abort.
+	BigBetas [][]byte `protobuf:"bytes,2,rep,name=big_betas,json=bigBetas,proto3" json:"big_betas,omitempty"`
+	BigNus   [][]byte `protobuf:"bytes,3,rep,name=big_nus,json=bigNus,proto3" json:"big_nus,omitempty"`
 }
 
 func (x *SignRound3Message) Reset() {
@@ -254,6 +259,20 @@ func (x *SignRound3Message) GetTheta() []byte {
 	return nil
 }
 
+func (x *SignRound3Message) GetBigBetas() [][]byte {
+	if x != nil {
+		return x.BigBetas
+	}
+	return nil
+}
+
+func (x *SignRound3Message) GetBigNus() [][]byte {
+	if x != nil {
+		return x.BigNus
+	}
+	return nil
+}
+
 //
 // Represents a BROADCAST message sent to all parties during Round 4 of the ECDSA TSS signing protocol.
 type SignRound4Message struct {
@@ -628,45 +647,579 @@ func (x *SignRound9Message) GetS() []byte {
 	return nil
 }
 
+//
+// Represents a P2P message sent to each party during round 5 of ECDSA TSS presigning. It holds bar_r = k_i*R with a
+// proof that k_i is the plaintext of the ciphertext sent to the recipient in round 1.
+type SignPreSignMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BarRX        []byte   `protobuf:"bytes,1,opt,name=bar_r_x,json=barRX,proto3" json:"bar_r_x,omitempty"`
+	BarRY        []byte   `protobuf:"bytes,2,opt,name=bar_r_y,json=barRY,proto3" json:"bar_r_y,omitempty"`
+	LogstarProof [][]byte `protobuf:"bytes,3,rep,name=logstar_proof,json=logstarProof,proto3" json:"logstar_proof,omitempty"`
+}
+
+func (x *SignPreSignMessage) Reset() {
+	*x = SignPreSignMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_signing_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignPreSignMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignPreSignMessage) ProtoMessage() {}
+
+func (x *SignPreSignMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_signing_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignPreSignMessage.ProtoReflect.Descriptor instead.
+func (*SignPreSignMessage) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_signing_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SignPreSignMessage) GetBarRX() []byte {
+	if x != nil {
+		return x.BarRX
+	}
+	return nil
+}
+
+func (x *SignPreSignMessage) GetBarRY() []byte {
+	if x != nil {
+		return x.BarRY
+	}
+	return nil
+}
+
+func (x *SignPreSignMessage) GetLogstarProof() [][]byte {
+	if x != nil {
+		return x.LogstarProof
+	}
+	return nil
+}
+
+//
+// Represents a BROADCAST message sent to all parties during the online round of ECDSA TSS signing with a presignature.
+type SignOnlineMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PreSignatureId []byte `protobuf:"bytes,1,opt,name=pre_signature_id,json=preSignatureId,proto3" json:"pre_signature_id,omitempty"`
+	S              []byte `protobuf:"bytes,2,opt,name=s,proto3" json:"s,omitempty"`
+}
+
+func (x *SignOnlineMessage) Reset() {
+	*x = SignOnlineMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_signing_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignOnlineMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignOnlineMessage) ProtoMessage() {}
+
+func (x *SignOnlineMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_signing_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignOnlineMessage.ProtoReflect.Descriptor instead.
+func (*SignOnlineMessage) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_signing_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SignOnlineMessage) GetPreSignatureId() []byte {
+	if x != nil {
+		return x.PreSignatureId
+	}
+	return nil
+}
+
+func (x *SignOnlineMessage) GetS() []byte {
+	if x != nil {
+		return x.S
+	}
+	return nil
+}
+
+//
+// Represents a BROADCAST message sent to all parties during the identifiable abort phase of ECDSA TSS signing.
+// When only `l` is set the final signature failed to verify; otherwise the check U = T of round 9 failed and the
+// ephemeral k_i and the openings of the MtA ciphertexts are revealed as well. All repeated fields are indexed by party.
+// The signature binds the messages of the party in the abort evidence to it.
+type SignBlameMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	L   []byte `protobuf:"bytes,1,opt,name=l,proto3" json:"l,omitempty"`
+	Rho []byte `protobuf:"bytes,2,opt,name=rho,proto3" json:"rho,omitempty"`
+	K   []byte `protobuf:"bytes,3,opt,name=k,proto3" json:"k,omitempty"`
+	// as Alice: the encryptions of k sent to each party in round 1 and their randomness
+	CSent       [][]byte `protobuf:"bytes,4,rep,name=c_sent,json=cSent,proto3" json:"c_sent,omitempty"`
+	CRandomness [][]byte `protobuf:"bytes,5,rep,name=c_randomness,json=cRandomness,proto3" json:"c_randomness,omitempty"`
+	// as Bob: the encryptions of k_j received in round 1 and the MtA ciphertexts sent back in round 2
+	CReceived [][]byte `protobuf:"bytes,6,rep,name=c_received,json=cReceived,proto3" json:"c_received,omitempty"`
+	C1Sent    [][]byte `protobuf:"bytes,7,rep,name=c1_sent,json=c1Sent,proto3" json:"c1_sent,omitempty"`
+	C2Sent    [][]byte `protobuf:"bytes,8,rep,name=c2_sent,json=c2Sent,proto3" json:"c2_sent,omitempty"`
+	// as Alice: the MtA ciphertexts received in round 2, their plaintexts and randomness
+	C1Received      [][]byte `protobuf:"bytes,9,rep,name=c1_received,json=c1Received,proto3" json:"c1_received,omitempty"`
+	Alpha           [][]byte `protobuf:"bytes,10,rep,name=alpha,proto3" json:"alpha,omitempty"`
+	AlphaRandomness [][]byte `protobuf:"bytes,11,rep,name=alpha_randomness,json=alphaRandomness,proto3" json:"alpha_randomness,omitempty"`
+	C2Received      [][]byte `protobuf:"bytes,12,rep,name=c2_received,json=c2Received,proto3" json:"c2_received,omitempty"`
+	U               [][]byte `protobuf:"bytes,13,rep,name=u,proto3" json:"u,omitempty"`
+	URandomness     [][]byte `protobuf:"bytes,14,rep,name=u_randomness,json=uRandomness,proto3" json:"u_randomness,omitempty"`
+	// a Schnorr signature with the key share x_i over m and the broadcast messages of the party, this one included
+	SignatureAlphaX []byte `protobuf:"bytes,15,opt,name=signature_alpha_x,json=signatureAlphaX,proto3" json:"signature_alpha_x,omitempty"`
+	SignatureAlphaY []byte `protobuf:"bytes,16,opt,name=signature_alpha_y,json=signatureAlphaY,proto3" json:"signature_alpha_y,omitempty"`
+	SignatureT      []byte `protobuf:"bytes,17,opt,name=signature_t,json=signatureT,proto3" json:"signature_t,omitempty"`
+}
+
+func (x *SignBlameMessage) Reset() {
+	*x = SignBlameMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_signing_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignBlameMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignBlameMessage) ProtoMessage() {}
+
+func (x *SignBlameMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_signing_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignBlameMessage.ProtoReflect.Descriptor instead.
+func (*SignBlameMessage) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_signing_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SignBlameMessage) GetL() []byte {
+	if x != nil {
+		return x.L
+	}
+	return nil
+}
+
+func (x *SignBlameMessage) GetRho() []byte {
+	if x != nil {
+		return x.Rho
+	}
+	return nil
+}
+
+func (x *SignBlameMessage) GetK() []byte {
+	if x != nil {
+		return x.K
+	}
+	return nil
+}
+
+func (x *SignBlameMessage) GetCSent() [][]byte {
+	if x != nil {
+		return x.CSent
+	}
+	return nil
+}
+
+func (x *SignBlameMessage) GetCRandomness() [][]byte {
+	if x != nil {
+		return x.CRandomness
+	}
+	return nil
+}
+
+func (x *SignBlameMessage) GetCReceived() [][]byte {
+	if x != nil {
+		return x.CReceived
+	}
+	return nil
+}
+
+func (x *SignBlameMessage) GetC1Sent() [][]byte {
+	if x != nil {
+		return x.C1Sent
+	}
+	return nil
+}
+
+func (x *SignBlameMessage) GetC2Sent() [][]byte {
+	if x != nil {
+		return x.C2Sent
+	}
+	return nil
+}
+
+func (x *SignBlameMessage) GetC1Received() [][]byte {
+	if x != nil {
+		return x.C1Received
+	}
+	return nil
+}
+
+func (x *SignBlameMessage) GetAlpha() [][]byte {
+	if x != nil {
+		return x.Alpha
+	}
+	return nil
+}
+
+func (x *SignBlameMessage) GetAlphaRandomness() [][]byte {
+	if x != nil {
+		return x.AlphaRandomness
+	}
+	return nil
+}
+
+func (x *SignBlameMessage) GetC2Received() [][]byte {
+	if x != nil {
+		return x.C2Received
+	}
+	return nil
+}
+
+func (x *SignBlameMessage) GetU() [][]byte {
+	if x != nil {
+		return x.U
+	}
+	return nil
+}
+
+func (x *SignBlameMessage) GetURandomness() [][]byte {
+	if x != nil {
+		return x.URandomness
+	}
+	return nil
+}
+
+func (x *SignBlameMessage) GetSignatureAlphaX() []byte {
+	if x != nil {
+		return x.SignatureAlphaX
+	}
+	return nil
+}
+
+func (x *SignBlameMessage) GetSignatureAlphaY() []byte {
+	if x != nil {
+		return x.SignatureAlphaY
+	}
+	return nil
+}
+
+func (x *SignBlameMessage) GetSignatureT() []byte {
+	if x != nil {
+		return x.SignatureT
+	}
+	return nil
+}
+
+//
+// Represents a message of batch signing, sent to all parties or to one party in a round. It bundles the messages that
+// the signing sessions of the batch send in that round, each with the index of its session.
+type SignBatchMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Round   uint32            `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`
+	Entries []*SignBatchEntry `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
+}
+
+func (x *SignBatchMessage) Reset() {
+	*x = SignBatchMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_signing_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignBatchMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignBatchMessage) ProtoMessage() {}
+
+func (x *SignBatchMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_signing_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignBatchMessage.ProtoReflect.Descriptor instead.
+func (*SignBatchMessage) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_signing_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SignBatchMessage) GetRound() uint32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *SignBatchMessage) GetEntries() []*SignBatchEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type SignBatchEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Index   uint32     `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Content *anypb.Any `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+}
+
+func (x *SignBatchEntry) Reset() {
+	*x = SignBatchEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_signing_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignBatchEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignBatchEntry) ProtoMessage() {}
+
+func (x *SignBatchEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_signing_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignBatchEntry.ProtoReflect.Descriptor instead.
+func (*SignBatchEntry) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_signing_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SignBatchEntry) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *SignBatchEntry) GetContent() *anypb.Any {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+//
+// The evidence attached to the error returned by the identifiable abort phase of ECDSA TSS signing.
+// It holds the broadcast messages of all parties, indexed by party, so that the culprits can be re-identified.
+type SignAbortEvidence struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	M              []byte                `protobuf:"bytes,1,opt,name=m,proto3" json:"m,omitempty"`
+	Round1Messages []*SignRound1Message2 `protobuf:"bytes,2,rep,name=round1_messages,json=round1Messages,proto3" json:"round1_messages,omitempty"` // the broadcast part of round 1
+	Round3Messages []*SignRound3Message  `protobuf:"bytes,3,rep,name=round3_messages,json=round3Messages,proto3" json:"round3_messages,omitempty"`
+	Round4Messages []*SignRound4Message  `protobuf:"bytes,4,rep,name=round4_messages,json=round4Messages,proto3" json:"round4_messages,omitempty"`
+	Round5Messages []*SignRound5Message  `protobuf:"bytes,5,rep,name=round5_messages,json=round5Messages,proto3" json:"round5_messages,omitempty"`
+	Round6Messages []*SignRound6Message  `protobuf:"bytes,6,rep,name=round6_messages,json=round6Messages,proto3" json:"round6_messages,omitempty"`
+	Round7Messages []*SignRound7Message  `protobuf:"bytes,7,rep,name=round7_messages,json=round7Messages,proto3" json:"round7_messages,omitempty"`
+	Round8Messages []*SignRound8Message  `protobuf:"bytes,8,rep,name=round8_messages,json=round8Messages,proto3" json:"round8_messages,omitempty"`
+	Round9Messages []*SignRound9Message  `protobuf:"bytes,9,rep,name=round9_messages,json=round9Messages,proto3" json:"round9_messages,omitempty"`
+	BlameMessages  []*SignBlameMessage   `protobuf:"bytes,10,rep,name=blame_messages,json=blameMessages,proto3" json:"blame_messages,omitempty"`
+}
+
+func (x *SignAbortEvidence) Reset() {
+	*x = SignAbortEvidence{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_signing_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignAbortEvidence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignAbortEvidence) ProtoMessage() {}
+
+func (x *SignAbortEvidence) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_signing_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignAbortEvidence.ProtoReflect.Descriptor instead.
+func (*SignAbortEvidence) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_signing_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SignAbortEvidence) GetM() []byte {
+	if x != nil {
+		return x.M
+	}
+	return nil
+}
+
+func (x *SignAbortEvidence) GetRound1Messages() []*SignRound1Message2 {
+	if x != nil {
+		return x.Round1Messages
+	}
+	return nil
+}
+
+func (x *SignAbortEvidence) GetRound3Messages() []*SignRound3Message {
+	if x != nil {
+		return x.Round3Messages
+	}
+	return nil
+}
+
+func (x *SignAbortEvidence) GetRound4Messages() []*SignRound4Message {
+	if x != nil {
+		return x.Round4Messages
+	}
+	return nil
+}
+
+func (x *SignAbortEvidence) GetRound5Messages() []*SignRound5Message {
+	if x != nil {
+		return x.Round5Messages
+	}
+	return nil
+}
+
+func (x *SignAbortEvidence) GetRound6Messages() []*SignRound6Message {
+	if x != nil {
+		return x.Round6Messages
+	}
+	return nil
+}
+
+func (x *SignAbortEvidence) GetRound7Messages() []*SignRound7Message {
+	if x != nil {
+		return x.Round7Messages
+	}
+	return nil
+}
+
+func (x *SignAbortEvidence) GetRound8Messages() []*SignRound8Message {
+	if x != nil {
+		return x.Round8Messages
+	}
+	return nil
+}
+
+func (x *SignAbortEvidence) GetRound9Messages() []*SignRound9Message {
+	if x != nil {
+		return x.Round9Messages
+	}
+	return nil
+}
+
+func (x *SignAbortEvidence) GetBlameMessages() []*SignBlameMessage {
+	if x != nil {
+		return x.BlameMessages
+	}
+	return nil
+}
+
 var File_protob_ecdsa_signing_proto protoreflect.FileDescriptor
 
 var file_protob_ecdsa_signing_proto_rawDesc = []byte{
 	0x0a, 0x1a, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x2f, 0x65, 0x63, 0x64, 0x73, 0x61, 0x2d, 0x73,
 	0x69, 0x67, 0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x1c, 0x62, 0x69,
 	0x6e, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x74, 0x73, 0x73, 0x6c, 0x69, 0x62, 0x2e, 0x65, 0x63, 0x64,
-	0x73, 0x61, 0x2e, 0x73, 0x69, 0x67, 0x6e, 0x69, 0x6e, 0x67, 0x22, 0x4e, 0x0a, 0x12, 0x53, 0x69,
-	0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x31, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x31,
-	0x12, 0x0c, 0x0a, 0x01, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x63, 0x12, 0x2a,
-	0x0a, 0x11, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x61, 0x6c,
-	0x69, 0x63, 0x65, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0f, 0x72, 0x61, 0x6e, 0x67, 0x65,
-	0x50, 0x72, 0x6f, 0x6f, 0x66, 0x41, 0x6c, 0x69, 0x63, 0x65, 0x22, 0x34, 0x0a, 0x12, 0x53, 0x69,
-	0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x31, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x32,
-	0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74,
-	0x22, 0x72, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x32, 0x4d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x63, 0x31, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x02, 0x63, 0x31, 0x12, 0x0e, 0x0a, 0x02, 0x63, 0x32, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x02, 0x63, 0x32, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x62,
-	0x6f, 0x62, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x42,
-	0x6f, 0x62, 0x12, 0x20, 0x0a, 0x0c, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x62, 0x6f, 0x62, 0x5f,
-	0x77, 0x63, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x42,
-	0x6f, 0x62, 0x57, 0x63, 0x22, 0x29, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e,
-	0x64, 0x33, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x68, 0x65,
-	0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x74, 0x68, 0x65, 0x74, 0x61, 0x22,
-	0x99, 0x01, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x34, 0x4d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x65, 0x5f, 0x63, 0x6f, 0x6d, 0x6d,
-	0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0c, 0x64, 0x65,
-	0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x22, 0x0a, 0x0d, 0x70, 0x72,
-	0x6f, 0x6f, 0x66, 0x5f, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x5f, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x0b, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x41, 0x6c, 0x70, 0x68, 0x61, 0x58, 0x12, 0x22,
-	0x0a, 0x0d, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x5f, 0x79, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x41, 0x6c, 0x70, 0x68,
-	0x61, 0x59, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x74, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x54, 0x22, 0x33, 0x0a, 0x11, 0x53,
-	0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x35, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74,
-	0x22, 0x9f, 0x02, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x36, 0x4d,
+	0x73, 0x61, 0x2e, 0x73, 0x69, 0x67, 0x6e, 0x69, 0x6e, 0x67, 0x1a, 0x19, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x61, 0x6e, 0x79, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x4e, 0x0a, 0x12, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75,
+	0x6e, 0x64, 0x31, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x31, 0x12, 0x0c, 0x0a, 0x01, 0x63,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x63, 0x12, 0x2a, 0x0a, 0x11, 0x72, 0x61, 0x6e,
+	0x67, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0c, 0x52, 0x0f, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x50, 0x72, 0x6f, 0x6f, 0x66,
+	0x41, 0x6c, 0x69, 0x63, 0x65, 0x22, 0x34, 0x0a, 0x12, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75,
+	0x6e, 0x64, 0x31, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x32, 0x12, 0x1e, 0x0a, 0x0a, 0x63,
+	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0x72, 0x0a, 0x11, 0x53,
+	0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x32, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x12, 0x0e, 0x0a, 0x02, 0x63, 0x31, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x63, 0x31,
+	0x12, 0x0e, 0x0a, 0x02, 0x63, 0x32, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x63, 0x32,
+	0x12, 0x1b, 0x0a, 0x09, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x62, 0x6f, 0x62, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x0c, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x42, 0x6f, 0x62, 0x12, 0x20, 0x0a,
+	0x0c, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x62, 0x6f, 0x62, 0x5f, 0x77, 0x63, 0x18, 0x04, 0x20,
+	0x03, 0x28, 0x0c, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x42, 0x6f, 0x62, 0x57, 0x63, 0x22,
+	0x5f, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x33, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x68, 0x65, 0x74, 0x61, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x05, 0x74, 0x68, 0x65, 0x74, 0x61, 0x12, 0x1b, 0x0a, 0x09, 0x62, 0x69,
+	0x67, 0x5f, 0x62, 0x65, 0x74, 0x61, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x08, 0x62,
+	0x69, 0x67, 0x42, 0x65, 0x74, 0x61, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x62, 0x69, 0x67, 0x5f, 0x6e,
+	0x75, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x06, 0x62, 0x69, 0x67, 0x4e, 0x75, 0x73,
+	0x22, 0x99, 0x01, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x34, 0x4d,
 	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x65, 0x5f, 0x63, 0x6f, 0x6d,
 	0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0c, 0x64,
 	0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x22, 0x0a, 0x0d, 0x70,
@@ -675,26 +1228,148 @@ var file_protob_ecdsa_signing_proto_rawDesc = []byte{
 	0x22, 0x0a, 0x0d, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x5f, 0x79,
 	0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x41, 0x6c, 0x70,
 	0x68, 0x61, 0x59, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x74, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x54, 0x12, 0x25, 0x0a, 0x0f,
-	0x76, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x5f, 0x78, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x76, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x41, 0x6c, 0x70,
-	0x68, 0x61, 0x58, 0x12, 0x25, 0x0a, 0x0f, 0x76, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x61,
-	0x6c, 0x70, 0x68, 0x61, 0x5f, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x76, 0x50,
-	0x72, 0x6f, 0x6f, 0x66, 0x41, 0x6c, 0x70, 0x68, 0x61, 0x59, 0x12, 0x1a, 0x0a, 0x09, 0x76, 0x5f,
-	0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x76,
-	0x50, 0x72, 0x6f, 0x6f, 0x66, 0x54, 0x12, 0x1a, 0x0a, 0x09, 0x76, 0x5f, 0x70, 0x72, 0x6f, 0x6f,
-	0x66, 0x5f, 0x75, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x76, 0x50, 0x72, 0x6f, 0x6f,
-	0x66, 0x55, 0x22, 0x33, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x37,
-	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69,
-	0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x63, 0x6f, 0x6d,
-	0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0x38, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x52,
-	0x6f, 0x75, 0x6e, 0x64, 0x38, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x23, 0x0a, 0x0d,
-	0x64, 0x65, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20,
-	0x03, 0x28, 0x0c, 0x52, 0x0c, 0x64, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e,
-	0x74, 0x22, 0x21, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x39, 0x4d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0c, 0x0a, 0x01, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x01, 0x73, 0x42, 0x0f, 0x5a, 0x0d, 0x65, 0x63, 0x64, 0x73, 0x61, 0x2f, 0x73, 0x69,
-	0x67, 0x6e, 0x69, 0x6e, 0x67, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x54, 0x22, 0x33, 0x0a, 0x11,
+	0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x35, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e,
+	0x74, 0x22, 0x9f, 0x02, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x36,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x65, 0x5f, 0x63, 0x6f,
+	0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0c,
+	0x64, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x22, 0x0a, 0x0d,
+	0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x5f, 0x78, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x0b, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x41, 0x6c, 0x70, 0x68, 0x61, 0x58,
+	0x12, 0x22, 0x0a, 0x0d, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x5f,
+	0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x41, 0x6c,
+	0x70, 0x68, 0x61, 0x59, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x74, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x54, 0x12, 0x25, 0x0a,
+	0x0f, 0x76, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x5f, 0x78,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x76, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x41, 0x6c,
+	0x70, 0x68, 0x61, 0x58, 0x12, 0x25, 0x0a, 0x0f, 0x76, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x5f, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x76,
+	0x50, 0x72, 0x6f, 0x6f, 0x66, 0x41, 0x6c, 0x70, 0x68, 0x61, 0x59, 0x12, 0x1a, 0x0a, 0x09, 0x76,
+	0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07,
+	0x76, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x54, 0x12, 0x1a, 0x0a, 0x09, 0x76, 0x5f, 0x70, 0x72, 0x6f,
+	0x6f, 0x66, 0x5f, 0x75, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x76, 0x50, 0x72, 0x6f,
+	0x6f, 0x66, 0x55, 0x22, 0x33, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64,
+	0x37, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x6d,
+	0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x63, 0x6f,
+	0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0x38, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e,
+	0x52, 0x6f, 0x75, 0x6e, 0x64, 0x38, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x23, 0x0a,
+	0x0d, 0x64, 0x65, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0c, 0x52, 0x0c, 0x64, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65,
+	0x6e, 0x74, 0x22, 0x21, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x39,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0c, 0x0a, 0x01, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x01, 0x73, 0x22, 0x69, 0x0a, 0x12, 0x53, 0x69, 0x67, 0x6e, 0x50, 0x72, 0x65,
+	0x53, 0x69, 0x67, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x07, 0x62,
+	0x61, 0x72, 0x5f, 0x72, 0x5f, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x62, 0x61,
+	0x72, 0x52, 0x58, 0x12, 0x16, 0x0a, 0x07, 0x62, 0x61, 0x72, 0x5f, 0x72, 0x5f, 0x79, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x62, 0x61, 0x72, 0x52, 0x59, 0x12, 0x23, 0x0a, 0x0d, 0x6c,
+	0x6f, 0x67, 0x73, 0x74, 0x61, 0x72, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x0c, 0x52, 0x0c, 0x6c, 0x6f, 0x67, 0x73, 0x74, 0x61, 0x72, 0x50, 0x72, 0x6f, 0x6f, 0x66,
+	0x22, 0x4b, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x4f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x28, 0x0a, 0x10, 0x70, 0x72, 0x65, 0x5f, 0x73, 0x69, 0x67,
+	0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x0e, 0x70, 0x72, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x49, 0x64, 0x12,
+	0x0c, 0x0a, 0x01, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x73, 0x22, 0xf8, 0x03,
+	0x0a, 0x10, 0x53, 0x69, 0x67, 0x6e, 0x42, 0x6c, 0x61, 0x6d, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x0c, 0x0a, 0x01, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x6c,
+	0x12, 0x10, 0x0a, 0x03, 0x72, 0x68, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x72,
+	0x68, 0x6f, 0x12, 0x0c, 0x0a, 0x01, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x6b,
+	0x12, 0x15, 0x0a, 0x06, 0x63, 0x5f, 0x73, 0x65, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0c,
+	0x52, 0x05, 0x63, 0x53, 0x65, 0x6e, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x5f, 0x72, 0x61, 0x6e,
+	0x64, 0x6f, 0x6d, 0x6e, 0x65, 0x73, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0b, 0x63,
+	0x52, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x6e, 0x65, 0x73, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x5f,
+	0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x09,
+	0x63, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x63, 0x31, 0x5f,
+	0x73, 0x65, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x06, 0x63, 0x31, 0x53, 0x65,
+	0x6e, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x63, 0x32, 0x5f, 0x73, 0x65, 0x6e, 0x74, 0x18, 0x08, 0x20,
+	0x03, 0x28, 0x0c, 0x52, 0x06, 0x63, 0x32, 0x53, 0x65, 0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x63,
+	0x31, 0x5f, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0c,
+	0x52, 0x0a, 0x63, 0x31, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x05, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x12, 0x29, 0x0a, 0x10, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x5f, 0x72, 0x61, 0x6e, 0x64,
+	0x6f, 0x6d, 0x6e, 0x65, 0x73, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0f, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x52, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x6e, 0x65, 0x73, 0x73, 0x12, 0x1f, 0x0a,
+	0x0b, 0x63, 0x32, 0x5f, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x18, 0x0c, 0x20, 0x03,
+	0x28, 0x0c, 0x52, 0x0a, 0x63, 0x32, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x12, 0x0c,
+	0x0a, 0x01, 0x75, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x01, 0x75, 0x12, 0x21, 0x0a, 0x0c,
+	0x75, 0x5f, 0x72, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x6e, 0x65, 0x73, 0x73, 0x18, 0x0e, 0x20, 0x03,
+	0x28, 0x0c, 0x52, 0x0b, 0x75, 0x52, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x6e, 0x65, 0x73, 0x73, 0x12,
+	0x2a, 0x0a, 0x11, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x5f, 0x78, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x73, 0x69, 0x67, 0x6e,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x41, 0x6c, 0x70, 0x68, 0x61, 0x58, 0x12, 0x2a, 0x0a, 0x11, 0x73,
+	0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x5f, 0x79,
+	0x18, 0x10, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x41, 0x6c, 0x70, 0x68, 0x61, 0x59, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x69, 0x67, 0x6e, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x5f, 0x74, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x73, 0x69,
+	0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x54, 0x22, 0x70, 0x0a, 0x10, 0x53, 0x69, 0x67, 0x6e,
+	0x42, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x72, 0x6f, 0x75,
+	0x6e, 0x64, 0x12, 0x46, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x62, 0x69, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x74, 0x73,
+	0x73, 0x6c, 0x69, 0x62, 0x2e, 0x65, 0x63, 0x64, 0x73, 0x61, 0x2e, 0x73, 0x69, 0x67, 0x6e, 0x69,
+	0x6e, 0x67, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x22, 0x56, 0x0a, 0x0e, 0x53, 0x69,
+	0x67, 0x6e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x69, 0x6e, 0x64,
+	0x65, 0x78, 0x12, 0x2e, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65,
+	0x6e, 0x74, 0x22, 0xc9, 0x06, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x41, 0x62, 0x6f, 0x72, 0x74,
+	0x45, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x0c, 0x0a, 0x01, 0x6d, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x01, 0x6d, 0x12, 0x59, 0x0a, 0x0f, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x31,
+	0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x30, 0x2e, 0x62, 0x69, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x74, 0x73, 0x73, 0x6c, 0x69, 0x62,
+	0x2e, 0x65, 0x63, 0x64, 0x73, 0x61, 0x2e, 0x73, 0x69, 0x67, 0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x53,
+	0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x31, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x32, 0x52, 0x0e, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x31, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x73, 0x12, 0x58, 0x0a, 0x0f, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x33, 0x5f, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x62, 0x69, 0x6e,
+	0x61, 0x6e, 0x63, 0x65, 0x2e, 0x74, 0x73, 0x73, 0x6c, 0x69, 0x62, 0x2e, 0x65, 0x63, 0x64, 0x73,
+	0x61, 0x2e, 0x73, 0x69, 0x67, 0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f,
+	0x75, 0x6e, 0x64, 0x33, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x0e, 0x72, 0x6f, 0x75,
+	0x6e, 0x64, 0x33, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x12, 0x58, 0x0a, 0x0f, 0x72,
+	0x6f, 0x75, 0x6e, 0x64, 0x34, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x18, 0x04,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x62, 0x69, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x74,
+	0x73, 0x73, 0x6c, 0x69, 0x62, 0x2e, 0x65, 0x63, 0x64, 0x73, 0x61, 0x2e, 0x73, 0x69, 0x67, 0x6e,
+	0x69, 0x6e, 0x67, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x34, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x0e, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x34, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x73, 0x12, 0x58, 0x0a, 0x0f, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x35, 0x5f,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f,
+	0x2e, 0x62, 0x69, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x74, 0x73, 0x73, 0x6c, 0x69, 0x62, 0x2e,
+	0x65, 0x63, 0x64, 0x73, 0x61, 0x2e, 0x73, 0x69, 0x67, 0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x53, 0x69,
+	0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x35, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52,
+	0x0e, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x35, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x12,
+	0x58, 0x0a, 0x0f, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x36, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x62, 0x69, 0x6e, 0x61, 0x6e,
+	0x63, 0x65, 0x2e, 0x74, 0x73, 0x73, 0x6c, 0x69, 0x62, 0x2e, 0x65, 0x63, 0x64, 0x73, 0x61, 0x2e,
+	0x73, 0x69, 0x67, 0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e,
+	0x64, 0x36, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x0e, 0x72, 0x6f, 0x75, 0x6e, 0x64,
+	0x36, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x12, 0x58, 0x0a, 0x0f, 0x72, 0x6f, 0x75,
+	0x6e, 0x64, 0x37, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x62, 0x69, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x74, 0x73, 0x73,
+	0x6c, 0x69, 0x62, 0x2e, 0x65, 0x63, 0x64, 0x73, 0x61, 0x2e, 0x73, 0x69, 0x67, 0x6e, 0x69, 0x6e,
+	0x67, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x37, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x52, 0x0e, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x37, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x73, 0x12, 0x58, 0x0a, 0x0f, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x38, 0x5f, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x62,
+	0x69, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x74, 0x73, 0x73, 0x6c, 0x69, 0x62, 0x2e, 0x65, 0x63,
+	0x64, 0x73, 0x61, 0x2e, 0x73, 0x69, 0x67, 0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x53, 0x69, 0x67, 0x6e,
+	0x52, 0x6f, 0x75, 0x6e, 0x64, 0x38, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x0e, 0x72,
+	0x6f, 0x75, 0x6e, 0x64, 0x38, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x12, 0x58, 0x0a,
+	0x0f, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x39, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73,
+	0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x62, 0x69, 0x6e, 0x61, 0x6e, 0x63, 0x65,
+	0x2e, 0x74, 0x73, 0x73, 0x6c, 0x69, 0x62, 0x2e, 0x65, 0x63, 0x64, 0x73, 0x61, 0x2e, 0x73, 0x69,
+	0x67, 0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x39,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x0e, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x39, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x12, 0x55, 0x0a, 0x0e, 0x62, 0x6c, 0x61, 0x6d, 0x65,
+	0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x2e, 0x2e, 0x62, 0x69, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x74, 0x73, 0x73, 0x6c, 0x69, 0x62,
+	0x2e, 0x65, 0x63, 0x64, 0x73, 0x61, 0x2e, 0x73, 0x69, 0x67, 0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x53,
+	0x69, 0x67, 0x6e, 0x42, 0x6c, 0x61, 0x6d, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52,
+	0x0d, 0x62, 0x6c, 0x61, 0x6d, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x42, 0x0f,
+	0x5a, 0x0d, 0x65, 0x63, 0x64, 0x73, 0x61, 0x2f, 0x73, 0x69, 0x67, 0x6e, 0x69, 0x6e, 0x67, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -709,7 +1384,7 @@ func file_protob_ecdsa_signing_proto_rawDescGZIP() []byte {
 	return file_protob_ecdsa_signing_proto_rawDescData
 }
 
-var file_protob_ecdsa_signing_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_protob_ecdsa_signing_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
 var file_protob_ecdsa_signing_proto_goTypes = []interface{}{
 	(*SignRound1Message1)(nil), // 0: binance.tsslib.ecdsa.signing.SignRound1Message1
 	(*SignRound1Message2)(nil), // 1: binance.tsslib.ecdsa.signing.SignRound1Message2
@@ -721,13 +1396,31 @@ var file_protob_ecdsa_signing_proto_goTypes = []interface{}{
 	(*SignRound7Message)(nil),  // 7: binance.tsslib.ecdsa.signing.SignRound7Message
 	(*SignRound8Message)(nil),  // 8: binance.tsslib.ecdsa.signing.SignRound8Message
 	(*SignRound9Message)(nil),  // 9: binance.tsslib.ecdsa.signing.SignRound9Message
+	(*SignPreSignMessage)(nil), // 10: binance.tsslib.ecdsa.signing.SignPreSignMessage
+	(*SignOnlineMessage)(nil),  // 11: binance.tsslib.ecdsa.signing.SignOnlineMessage
+	(*SignBlameMessage)(nil),   // 12: binance.tsslib.ecdsa.signing.SignBlameMessage
+	(*SignBatchMessage)(nil),   // 13: binance.tsslib.ecdsa.signing.SignBatchMessage
+	(*SignBatchEntry)(nil),     // 14: binance.tsslib.ecdsa.signing.SignBatchEntry
+	(*SignAbortEvidence)(nil),  // 15: binance.tsslib.ecdsa.signing.SignAbortEvidence
+	(*anypb.Any)(nil),          // 16: google.protobuf.Any
 }
 var file_protob_ecdsa_signing_proto_depIdxs = []int32{
-	0, // [0:0] is the sub-list for method output_type
-	0, // [0:0] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	14, // 0: binance.tsslib.ecdsa.signing.SignBatchMessage.entries:type_name -> binance.tsslib.ecdsa.signing.SignBatchEntry
+	16, // 1: binance.tsslib.ecdsa.signing.SignBatchEntry.content:type_name -> google.protobuf.Any
+	1,  // 2: binance.tsslib.ecdsa.signing.SignAbortEvidence.round1_messages:type_name -> binance.tsslib.ecdsa.signing.SignRound1Message2
+	3,  // 3: binance.tsslib.ecdsa.signing.SignAbortEvidence.round3_messages:type_name -> binance.tsslib.ecdsa.signing.SignRound3Message
+	4,  // 4: binance.tsslib.ecdsa.signing.SignAbortEvidence.round4_messages:type_name -> binance.tsslib.ecdsa.signing.SignRound4Message
+	5,  // 5: binance.tsslib.ecdsa.signing.SignAbortEvidence.round5_messages:type_name -> binance.tsslib.ecdsa.signing.SignRound5Message
+	6,  // 6: binance.tsslib.ecdsa.signing.SignAbortEvidence.round6_messages:type_name -> binance.tsslib.ecdsa.signing.SignRound6Message
+	7,  // 7: binance.tsslib.ecdsa.signing.SignAbortEvidence.round7_messages:type_name -> binance.tsslib.ecdsa.signing.SignRound7Message
+	8,  // 8: binance.tsslib.ecdsa.signing.SignAbortEvidence.round8_messages:type_name -> binance.tsslib.ecdsa.signing.SignRound8Message
+	9,  // 9: binance.tsslib.ecdsa.signing.SignAbortEvidence.round9_messages:type_name -> binance.tsslib.ecdsa.signing.SignRound9Message
+	12, // 10: binance.tsslib.ecdsa.signing.SignAbortEvidence.blame_messages:type_name -> binance.tsslib.ecdsa.signing.SignBlameMessage
+	11, // [11:11] is the sub-list for method output_type
+	11, // [11:11] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
 }
 
 func init() { file_protob_ecdsa_signing_proto_init() }
@@ -856,6 +1549,78 @@ func file_protob_ecdsa_signing_proto_init() {
 				return nil
 			}
 		}
+		file_protob_ecdsa_signing_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignPreSignMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_signing_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignOnlineMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_signing_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignBlameMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_signing_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignBatchMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_signing_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignBatchEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_signing_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignAbortEvidence); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -863,7 +1628,7 @@ func file_protob_ecdsa_signing_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_protob_ecdsa_signing_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   10,
+			NumMessages:   16,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
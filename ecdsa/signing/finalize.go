@@ -36,9 +36,45 @@ func (round *finalization) Start() *tss.Error {
 		sumS = modN.Add(sumS, r9msg.UnmarshalS())
 	}
 
+	if err := round.finishSignature(sumS); err != nil {
+		common.Logger.Warnf("party %s: %s, revealing l_i to identify the culprits", round.PartyID(), err.Cause())
+		return round.startBlame(false)
+	}
+	return nil
+}
+
+func (round *finalization) CanAccept(msg tss.ParsedMessage) bool {
+	if round.temp.abort {
+		return round.canAcceptBlame(msg)
+	}
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *finalization) Update() (bool, *tss.Error) {
+	if round.temp.abort {
+		return round.updateBlame()
+	}
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *finalization) NextRound() tss.Round {
+	if round.temp.abort {
+		round.started = false
+		return &identifyAbort{round.base}
+	}
+	return nil // finished!
+}
+
+// finishSignature normalises the summed s, assembles the signature data, verifies it against the public key and sends it out
+func (round *base) finishSignature(sumS *big.Int) *tss.Error {
+	N := round.Params().EC().Params().N
 	recid := 0
-	// byte v = if(R.X > curve.N) then 2 else 0) | (if R.Y.IsEven then 0 else 1);
-	if round.temp.rx.Cmp(round.Params().EC().Params().N) > 0 {
+	// byte v = if(R.X >= curve.N) then 2 else 0) | (if R.Y.IsEven then 0 else 1);
+	// r is R.X reduced mod N, which only differs from R.X in the rare overflow case flagged by bit 2
+	r := new(big.Int).Mod(round.temp.rx, N)
+	if round.temp.rx.Cmp(N) >= 0 {
 		recid = 2
 	}
 	if round.temp.ry.Bit(0) != 0 {
@@ -49,15 +85,18 @@ func (round *finalization) Start() *tss.Error {
 	// https://github.com/btcsuite/btcd/blob/c26ffa870fd817666a857af1bf6498fabba1ffe3/btcec/signature.go#L442-L444
 	// This is needed because of tendermint checks here:
 	// https://github.com/tendermint/tendermint/blob/d9481e3648450cb99e15c6a070c1fb69aa0c255b/crypto/secp256k1/secp256k1_nocgo.go#L43-L47
-	secp256k1halfN := new(big.Int).Rsh(round.Params().EC().Params().N, 1)
-	if sumS.Cmp(secp256k1halfN) > 0 {
-		sumS.Sub(round.Params().EC().Params().N, sumS)
-		recid ^= 1
+	// Low-S is a secp256k1 convention only; P-256 verifiers accept either s and expect it untouched.
+	if tss.SameCurve(round.Params().EC(), tss.S256()) {
+		secp256k1halfN := new(big.Int).Rsh(N, 1)
+		if sumS.Cmp(secp256k1halfN) > 0 {
+			sumS.Sub(N, sumS)
+			recid ^= 1
+		}
 	}
 
 	// save the signature for final output
 	bitSizeInBytes := round.Params().EC().Params().BitSize / 8
-	round.data.R = padToLengthBytesInPlace(round.temp.rx.Bytes(), bitSizeInBytes)
+	round.data.R = padToLengthBytesInPlace(r.Bytes(), bitSizeInBytes)
 	round.data.S = padToLengthBytesInPlace(sumS.Bytes(), bitSizeInBytes)
 	round.data.Signature = append(round.data.R, round.data.S...)
 	round.data.SignatureRecovery = []byte{byte(recid)}
@@ -75,7 +114,7 @@ func (round *finalization) Start() *tss.Error {
 		Y:     round.key.ECDSAPub.Y(),
 	}
 
-	ok := ecdsa.Verify(&pk, round.data.M, round.temp.rx, sumS)
+	ok := ecdsa.Verify(&pk, round.data.M, r, sumS)
 	if !ok {
 		return round.WrapError(fmt.Errorf("signature verification failed"))
 	}
@@ -85,20 +124,6 @@ func (round *finalization) Start() *tss.Error {
 	return nil
 }
 
-func (round *finalization) CanAccept(msg tss.ParsedMessage) bool {
-	// not expecting any incoming messages in this round
-	return false
-}
-
-func (round *finalization) Update() (bool, *tss.Error) {
-	// not expecting any incoming messages in this round
-	return false, nil
-}
-
-func (round *finalization) NextRound() tss.Round {
-	return nil // finished!
-}
-
 func padToLengthBytesInPlace(src []byte, length int) []byte {
 	oriLen := len(src)
 	if oriLen < length {
@@ -36,8 +36,9 @@ type (
 		data *common.SignatureData
 
 		// outbound messaging
-		out chan<- tss.Message
-		end chan<- *common.SignatureData
+		out    chan<- tss.Message
+		end    chan<- *common.SignatureData
+		preEnd chan<- *PreSignatureData
 	}
 
 	localMessageStore struct {
@@ -50,7 +51,10 @@ type (
 		signRound6Messages,
 		signRound7Messages,
 		signRound8Messages,
-		signRound9Messages []tss.ParsedMessage
+		signRound9Messages,
+		signPreSignMessages,
+		signOnlineMessages,
+		signBlameMessages []tss.ParsedMessage
 	}
 
 	localTempData struct {
@@ -97,6 +101,13 @@ type (
 
 		ssidNonce *big.Int
 		ssid      []byte
+
+		// online signing with a presignature
+		preSig      *PreSignatureData
+		nonceLedger tss.NonceLedger
+
+		// set once a check failed and the parties reveal their blame messages
+		abort bool
 	}
 )
 
@@ -110,6 +121,40 @@ func NewLocalParty(
 	return NewLocalPartyWithKDD(msg, params, key, nil, out, end, fullBytesLen...)
 }
 
+// NewPreSignLocalParty returns a party that runs the message-independent rounds of signing ahead of time.
+// Instead of a signature, a PreSignatureData is sent through `end` that can later be used to sign one message
+// in a single round with NewLocalPartyWithPreSignature.
+func NewPreSignLocalParty(
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *PreSignatureData,
+) tss.Party {
+	p := NewLocalPartyWithKDD(nil, params, key, nil, out, nil).(*LocalParty)
+	p.preEnd = end
+	return p
+}
+
+// NewLocalPartyWithPreSignature returns a party that signs msg in one round using a presignature made earlier by
+// the same set of parties. The ID of the presignature is recorded in the ledger as the party starts, before its share
+// of the signature is sent, and the party fails with tss.ErrNonceUsed if it was recorded before; the ledger must be
+// durable and must outlive every copy of the presignature. The presignature is wiped as well.
+func NewLocalPartyWithPreSignature(
+	msg *big.Int,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	preSig *PreSignatureData,
+	ledger tss.NonceLedger,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+	fullBytesLen ...int,
+) tss.Party {
+	p := NewLocalPartyWithKDD(msg, params, key, nil, out, end, fullBytesLen...).(*LocalParty)
+	p.temp.preSig = preSig
+	p.temp.nonceLedger = ledger
+	return p
+}
+
 // NewLocalPartyWithKDD returns a party with key derivation delta for HD support
 func NewLocalPartyWithKDD(
 	msg *big.Int,
@@ -141,6 +186,9 @@ func NewLocalPartyWithKDD(
 	p.temp.signRound7Messages = make([]tss.ParsedMessage, partyCount)
 	p.temp.signRound8Messages = make([]tss.ParsedMessage, partyCount)
 	p.temp.signRound9Messages = make([]tss.ParsedMessage, partyCount)
+	p.temp.signPreSignMessages = make([]tss.ParsedMessage, partyCount)
+	p.temp.signOnlineMessages = make([]tss.ParsedMessage, partyCount)
+	p.temp.signBlameMessages = make([]tss.ParsedMessage, partyCount)
 	// temp data init
 	p.temp.keyDerivationDelta = keyDerivationDelta
 	p.temp.m = msg
@@ -161,11 +209,18 @@ func NewLocalPartyWithKDD(
 }
 
 func (p *LocalParty) FirstRound() tss.Round {
-	return newRound1(p.params, &p.keys, p.data, &p.temp, p.out, p.end)
+	if p.temp.preSig != nil {
+		return newOnlineRound(p.params, &p.keys, p.data, &p.temp, p.out, p.end)
+	}
+	return newRound1(p.params, &p.keys, p.data, &p.temp, p.out, p.end, p.preEnd)
 }
 
 func (p *LocalParty) Start() *tss.Error {
 	return tss.BaseStart(p, TaskName, func(round tss.Round) *tss.Error {
+		if _, ok := round.(*onlineRound); ok {
+			// the key share was already used during presigning
+			return nil
+		}
 		round1, ok := round.(*round1)
 		if !ok {
 			return round.WrapError(errors.New("unable to Start(). party is in an unexpected round"))
@@ -231,6 +286,12 @@ func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
 		p.temp.signRound8Messages[fromPIdx] = msg
 	case *SignRound9Message:
 		p.temp.signRound9Messages[fromPIdx] = msg
+	case *SignPreSignMessage:
+		p.temp.signPreSignMessages[fromPIdx] = msg
+	case *SignOnlineMessage:
+		p.temp.signOnlineMessages[fromPIdx] = msg
+	case *SignBlameMessage:
+		p.temp.signBlameMessages[fromPIdx] = msg
 	default: // unrecognised message, just ignore!
 		common.Logger.Warningf("unrecognised message ignored: %v", msg)
 		return false, nil
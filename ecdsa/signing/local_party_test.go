@@ -131,6 +131,77 @@ signing:
 	}
 }
 
+func TestE2EConcurrentP256(t *testing.T) {
+	setUp("info")
+	threshold := testThreshold
+	ec := tss.P256()
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSetForCurve(ec, testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load P-256 keygen fixtures")
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]*LocalParty, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+
+	updater := test.SharedPartyUpdater
+	msg := big.NewInt(42)
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(ec, p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		P := NewLocalParty(msg, params, keys[i], outCh, endCh).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	var ended int32
+signing:
+	for {
+		select {
+		case err := <-errCh:
+			common.Logger.Errorf("Error: %s", err)
+			assert.FailNow(t, err.Error())
+			break signing
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else {
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case data := <-endCh:
+			if atomic.AddInt32(&ended, 1) < int32(len(signPIDs)) {
+				continue
+			}
+			assert.Len(t, data.R, 32)
+			assert.Len(t, data.S, 32)
+			pk := ecdsa.PublicKey{
+				Curve: ec,
+				X:     keys[0].ECDSAPub.X(),
+				Y:     keys[0].ECDSAPub.Y(),
+			}
+			ok := ecdsa.Verify(&pk, msg.Bytes(), new(big.Int).SetBytes(data.R), new(big.Int).SetBytes(data.S))
+			assert.True(t, ok, "ecdsa verify must pass")
+			// s is left as computed on P-256, so the recovery id is R's own parity
+			R := parties[0].temp.bigR
+			assert.Equal(t, R.Y().Bit(0), uint(data.SignatureRecovery[0]&1))
+			break signing
+		}
+	}
+}
+
 func TestE2EConcurrentWithLeadingZeroInMSG(t *testing.T) {
 	setUp("info")
 	threshold := testThreshold
@@ -8,11 +8,15 @@ package signing
 
 import (
 	"crypto/elliptic"
+	"errors"
 	"math/big"
 
+	"google.golang.org/protobuf/types/known/anypb"
+
 	"github.com/bnb-chain/tss-lib/v2/common"
 	"github.com/bnb-chain/tss-lib/v2/crypto"
 	cmt "github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/crypto/logstarproof"
 	"github.com/bnb-chain/tss-lib/v2/crypto/mta"
 	"github.com/bnb-chain/tss-lib/v2/crypto/schnorr"
 	"github.com/bnb-chain/tss-lib/v2/tss"
@@ -34,6 +38,10 @@ var (
 		(*SignRound7Message)(nil),
 		(*SignRound8Message)(nil),
 		(*SignRound9Message)(nil),
+		(*SignPreSignMessage)(nil),
+		(*SignOnlineMessage)(nil),
+		(*SignBlameMessage)(nil),
+		(*SignBatchMessage)(nil),
 	}
 )
 
@@ -145,13 +153,16 @@ func (m *SignRound2Message) UnmarshalProofBobWC(ec elliptic.Curve) (*mta.ProofBo
 func NewSignRound3Message(
 	from *tss.PartyID,
 	theta *big.Int,
+	bigBetas, bigNus []*crypto.ECPoint,
 ) tss.ParsedMessage {
 	meta := tss.MessageRouting{
 		From:        from,
 		IsBroadcast: true,
 	}
 	content := &SignRound3Message{
-		Theta: theta.Bytes(),
+		Theta:    theta.Bytes(),
+		BigBetas: flattenECPointsWithGaps(bigBetas),
+		BigNus:   flattenECPointsWithGaps(bigNus),
 	}
 	msg := tss.NewMessageWrapper(meta, content)
 	return tss.NewMessage(meta, content, msg)
@@ -159,7 +170,21 @@ func NewSignRound3Message(
 
 func (m *SignRound3Message) ValidateBasic() bool {
 	return m != nil &&
-		common.NonEmptyBytes(m.Theta)
+		common.NonEmptyBytes(m.Theta) &&
+		len(m.BigBetas)%2 == 0 &&
+		len(m.BigBetas) == len(m.BigNus)
+}
+
+func (m *SignRound3Message) UnmarshalTheta() *big.Int {
+	return new(big.Int).SetBytes(m.GetTheta())
+}
+
+func (m *SignRound3Message) UnmarshalBigBetas(ec elliptic.Curve) ([]*crypto.ECPoint, error) {
+	return unFlattenECPointsWithGaps(ec, m.GetBigBetas())
+}
+
+func (m *SignRound3Message) UnmarshalBigNus(ec elliptic.Curve) ([]*crypto.ECPoint, error) {
+	return unFlattenECPointsWithGaps(ec, m.GetBigNus())
 }
 
 // ----- //
@@ -389,3 +414,279 @@ func (m *SignRound9Message) ValidateBasic() bool {
 func (m *SignRound9Message) UnmarshalS() *big.Int {
 	return new(big.Int).SetBytes(m.S)
 }
+
+// ----- //
+
+func NewSignPreSignMessage(
+	to, from *tss.PartyID,
+	barR *crypto.ECPoint,
+	proof *logstarproof.ProofLogstar,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	pfBz := proof.Bytes()
+	content := &SignPreSignMessage{
+		BarRX:        barR.X().Bytes(),
+		BarRY:        barR.Y().Bytes(),
+		LogstarProof: pfBz[:],
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *SignPreSignMessage) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetBarRX()) &&
+		common.NonEmptyBytes(m.GetBarRY()) &&
+		common.NonEmptyMultiBytes(m.GetLogstarProof(), logstarproof.ProofLogstarBytesParts)
+}
+
+func (m *SignPreSignMessage) UnmarshalBarR(ec elliptic.Curve) (*crypto.ECPoint, error) {
+	return crypto.NewECPoint(ec, new(big.Int).SetBytes(m.GetBarRX()), new(big.Int).SetBytes(m.GetBarRY()))
+}
+
+func (m *SignPreSignMessage) UnmarshalLogstarProof(ec elliptic.Curve) (*logstarproof.ProofLogstar, error) {
+	return logstarproof.NewProofFromBytes(ec, m.GetLogstarProof())
+}
+
+// ----- //
+
+func NewSignOnlineMessage(
+	from *tss.PartyID,
+	preSignatureID []byte,
+	si *big.Int,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &SignOnlineMessage{
+		PreSignatureId: preSignatureID,
+		S:              si.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *SignOnlineMessage) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.PreSignatureId) &&
+		common.NonEmptyBytes(m.S)
+}
+
+func (m *SignOnlineMessage) UnmarshalS() *big.Int {
+	return new(big.Int).SetBytes(m.S)
+}
+
+// ----- //
+
+func NewSignBlameMessage(
+	from *tss.PartyID,
+	l *big.Int,
+	openings *mtaOpenings,
+	signature *schnorr.ZKProof,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := newSignBlameContent(l, openings)
+	content.SignatureAlphaX = signature.Alpha.X().Bytes()
+	content.SignatureAlphaY = signature.Alpha.Y().Bytes()
+	content.SignatureT = signature.T.Bytes()
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+// newSignBlameContent returns a blame message without its signature, which signs it
+func newSignBlameContent(l *big.Int, openings *mtaOpenings) *SignBlameMessage {
+	content := &SignBlameMessage{
+		L: l.Bytes(),
+	}
+	if openings != nil {
+		content.Rho = openings.rho.Bytes()
+		content.K = openings.k.Bytes()
+		content.CSent = common.BigIntsToBytes(openings.cSent)
+		content.CRandomness = common.BigIntsToBytes(openings.cRandomness)
+		content.CReceived = common.BigIntsToBytes(openings.cReceived)
+		content.C1Sent = common.BigIntsToBytes(openings.c1Sent)
+		content.C2Sent = common.BigIntsToBytes(openings.c2Sent)
+		content.C1Received = common.BigIntsToBytes(openings.c1Received)
+		content.Alpha = common.BigIntsToBytes(openings.alpha)
+		content.AlphaRandomness = common.BigIntsToBytes(openings.alphaRandomness)
+		content.C2Received = common.BigIntsToBytes(openings.c2Received)
+		content.U = common.BigIntsToBytes(openings.u)
+		content.URandomness = common.BigIntsToBytes(openings.uRandomness)
+	}
+	return content
+}
+
+func (m *SignBlameMessage) ValidateBasic() bool {
+	if m == nil || !common.NonEmptyBytes(m.GetL()) || !common.NonEmptyBytes(m.GetSignatureAlphaX()) ||
+		!common.NonEmptyBytes(m.GetSignatureAlphaY()) || !common.NonEmptyBytes(m.GetSignatureT()) {
+		return false
+	}
+	if !m.HasOpenings() {
+		return true
+	}
+	n := len(m.GetCSent())
+	for _, bzs := range [][][]byte{m.GetCRandomness(), m.GetCReceived(), m.GetC1Sent(), m.GetC2Sent(),
+		m.GetC1Received(), m.GetAlpha(), m.GetAlphaRandomness(), m.GetC2Received(), m.GetU(), m.GetURandomness()} {
+		if len(bzs) != n {
+			return false
+		}
+	}
+	return 0 < n && common.NonEmptyBytes(m.GetRho())
+}
+
+// HasOpenings is true when the MtA openings were revealed after the check U = T failed
+func (m *SignBlameMessage) HasOpenings() bool {
+	return common.NonEmptyBytes(m.GetK())
+}
+
+func (m *SignBlameMessage) UnmarshalL() *big.Int {
+	return new(big.Int).SetBytes(m.GetL())
+}
+
+func (m *SignBlameMessage) UnmarshalSignature(ec elliptic.Curve) (*schnorr.ZKProof, error) {
+	point, err := crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(m.GetSignatureAlphaX()),
+		new(big.Int).SetBytes(m.GetSignatureAlphaY()))
+	if err != nil {
+		return nil, err
+	}
+	return &schnorr.ZKProof{
+		Alpha: point,
+		T:     new(big.Int).SetBytes(m.GetSignatureT()),
+	}, nil
+}
+
+func (m *SignBlameMessage) UnmarshalOpenings() *mtaOpenings {
+	return &mtaOpenings{
+		rho:             new(big.Int).SetBytes(m.GetRho()),
+		k:               new(big.Int).SetBytes(m.GetK()),
+		cSent:           common.MultiBytesToBigInts(m.GetCSent()),
+		cRandomness:     common.MultiBytesToBigInts(m.GetCRandomness()),
+		cReceived:       common.MultiBytesToBigInts(m.GetCReceived()),
+		c1Sent:          common.MultiBytesToBigInts(m.GetC1Sent()),
+		c2Sent:          common.MultiBytesToBigInts(m.GetC2Sent()),
+		c1Received:      common.MultiBytesToBigInts(m.GetC1Received()),
+		alpha:           common.MultiBytesToBigInts(m.GetAlpha()),
+		alphaRandomness: common.MultiBytesToBigInts(m.GetAlphaRandomness()),
+		c2Received:      common.MultiBytesToBigInts(m.GetC2Received()),
+		u:               common.MultiBytesToBigInts(m.GetU()),
+		uRandomness:     common.MultiBytesToBigInts(m.GetURandomness()),
+	}
+}
+
+// ----- //
+
+// NewSignBatchMessage bundles the messages sent in a round of batch signing, either to all parties when to is nil or
+// to one party
+func NewSignBatchMessage(
+	to, from *tss.PartyID,
+	round int,
+	entries []*SignBatchEntry,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: to == nil,
+	}
+	if to != nil {
+		meta.To = []*tss.PartyID{to}
+	}
+	content := &SignBatchMessage{
+		Round:   uint32(round),
+		Entries: entries,
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func NewSignBatchEntry(index int, msg tss.ParsedMessage) (*SignBatchEntry, error) {
+	any, err := anypb.New(msg.Content())
+	if err != nil {
+		return nil, err
+	}
+	return &SignBatchEntry{
+		Index:   uint32(index),
+		Content: any,
+	}, nil
+}
+
+func (m *SignBatchMessage) ValidateBasic() bool {
+	if m == nil || m.GetRound() == 0 || len(m.GetEntries()) == 0 {
+		return false
+	}
+	for _, entry := range m.GetEntries() {
+		if entry.GetContent() == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// UnmarshalEntries returns the messages in the bundle with the routing of the bundle, and the index of the signing
+// session of each one
+func (m *SignBatchMessage) UnmarshalEntries(from *tss.PartyID, isBroadcast bool) ([]int, []tss.ParsedMessage, error) {
+	indices := make([]int, len(m.GetEntries()))
+	msgs := make([]tss.ParsedMessage, len(m.GetEntries()))
+	for e, entry := range m.GetEntries() {
+		pb, err := entry.GetContent().UnmarshalNew()
+		if err != nil {
+			return nil, nil, err
+		}
+		content, ok := pb.(tss.MessageContent)
+		if !ok {
+			return nil, nil, errors.New("a batch entry holds an unknown message")
+		}
+		if _, nested := content.(*SignBatchMessage); nested {
+			return nil, nil, errors.New("a batch entry holds another batch")
+		}
+		meta := tss.MessageRouting{
+			From:        from,
+			IsBroadcast: isBroadcast,
+		}
+		indices[e] = int(entry.GetIndex())
+		msgs[e] = tss.NewMessage(meta, content, tss.NewMessageWrapper(meta, content))
+	}
+	return indices, msgs, nil
+}
+
+// ----- //
+
+// flattenECPointsWithGaps flattens the points into x, y pairs, leaving an empty pair for each nil point
+func flattenECPointsWithGaps(points []*crypto.ECPoint) [][]byte {
+	bzs := make([][]byte, 0, len(points)*2)
+	for _, point := range points {
+		if point == nil {
+			bzs = append(bzs, nil, nil)
+			continue
+		}
+		bzs = append(bzs, point.X().Bytes(), point.Y().Bytes())
+	}
+	return bzs
+}
+
+func unFlattenECPointsWithGaps(ec elliptic.Curve, bzs [][]byte) ([]*crypto.ECPoint, error) {
+	if len(bzs)%2 != 0 {
+		return nil, errors.New("unFlattenECPointsWithGaps expected an even number of coordinates")
+	}
+	points := make([]*crypto.ECPoint, len(bzs)/2)
+	for j := range points {
+		x, y := bzs[2*j], bzs[2*j+1]
+		if len(x) == 0 && len(y) == 0 {
+			continue
+		}
+		point, err := crypto.NewECPoint(ec, new(big.Int).SetBytes(x), new(big.Int).SetBytes(y))
+		if err != nil {
+			return nil, err
+		}
+		points[j] = point
+	}
+	return points, nil
+}
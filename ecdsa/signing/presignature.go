@@ -0,0 +1,66 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+)
+
+var ErrPreSignatureUsed = errors.New("presignature has already been used")
+
+type (
+	// PreSignatureData is the output of a presigning session (see NewPreSignLocalParty).
+	// K and Sigma are secret and must be stored as carefully as the key share itself.
+	// A presignature must only ever be used to sign ONE message; signing two different messages with
+	// the same presignature reveals the private key. NewLocalPartyWithPreSignature records the ID in a
+	// tss.NonceLedger before it signs, which refuses any copy of a used presignature, and wipes K and Sigma.
+	PreSignatureData struct {
+		// ID is shared by all of the parties that took part in the same presigning session
+		ID       []byte
+		Ks       []*big.Int
+		ECDSAPub *crypto.ECPoint
+		BigR     *crypto.ECPoint
+		K        *big.Int
+		Sigma    *big.Int
+
+		mtx sync.Mutex
+	}
+)
+
+// ValidateBasic checks that the presignature is complete
+func (pre *PreSignatureData) ValidateBasic() bool {
+	return pre != nil &&
+		len(pre.ID) > 0 &&
+		len(pre.Ks) > 0 &&
+		pre.ECDSAPub != nil &&
+		pre.BigR != nil && pre.BigR.ValidateBasic() &&
+		pre.K != nil && pre.K.Sign() > 0 &&
+		pre.Sigma != nil
+}
+
+// Used returns true once the presignature has been handed to an online signing round
+func (pre *PreSignatureData) Used() bool {
+	pre.mtx.Lock()
+	defer pre.mtx.Unlock()
+	return pre.K == nil
+}
+
+// consume returns the secret nonce shares and wipes them from the record so that it cannot be used again
+func (pre *PreSignatureData) consume() (k, sigma *big.Int, err error) {
+	pre.mtx.Lock()
+	defer pre.mtx.Unlock()
+	if pre.K == nil || pre.Sigma == nil {
+		return nil, nil, ErrPreSignatureUsed
+	}
+	k, sigma = pre.K, pre.Sigma
+	pre.K, pre.Sigma = nil, nil
+	return k, sigma, nil
+}
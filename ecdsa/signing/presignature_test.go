@@ -0,0 +1,213 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func TestE2EPreSignAndOnlineSign(t *testing.T) {
+	setUp("info")
+	threshold := testThreshold
+
+	// PHASE: load keygen fixtures
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	updater := test.SharedPartyUpdater
+
+	// PHASE: presigning
+	preParties := make([]*LocalParty, 0, len(signPIDs))
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	preEndChs := make([]chan *PreSignatureData, len(signPIDs))
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		preEndChs[i] = make(chan *PreSignatureData, 1)
+		P := NewPreSignLocalParty(params, keys[i], outCh, preEndChs[i]).(*LocalParty)
+		preParties = append(preParties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	// the presignatures are collected per party as they hold different shares
+	endedCh := make(chan int, len(signPIDs))
+	preSigs := make([]*PreSignatureData, len(signPIDs))
+	for i, ch := range preEndChs {
+		go func(i int, ch <-chan *PreSignatureData) {
+			preSigs[i] = <-ch
+			endedCh <- i
+		}(i, ch)
+	}
+	for ended := 0; ended < len(signPIDs); {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+		case msg := <-outCh:
+			routeTestMessage(t, preParties, msg, errCh, updater)
+		case <-endedCh:
+			ended++
+		}
+	}
+	for _, preSig := range preSigs {
+		assert.True(t, preSig.ValidateBasic())
+		assert.Equal(t, preSigs[0].ID, preSig.ID, "all parties should agree on the presignature id")
+		assert.True(t, preSigs[0].BigR.Equals(preSig.BigR), "all parties should agree on R")
+	}
+
+	// presignatures are serializable; sign with the decoded copies
+	backups := make([][]byte, len(preSigs))
+	for i, preSig := range preSigs {
+		bz, err := json.Marshal(preSig)
+		assert.NoError(t, err)
+		decoded := new(PreSignatureData)
+		assert.NoError(t, json.Unmarshal(bz, decoded))
+		preSigs[i], backups[i] = decoded, bz
+	}
+
+	// PHASE: online signing
+	msg := big.NewInt(42)
+	parties := make([]*LocalParty, 0, len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+	ledgers := make([]tss.NonceLedger, len(signPIDs))
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		ledgers[i], err = tss.NewFileNonceLedger(t.TempDir())
+		assert.NoError(t, err)
+		P := NewLocalPartyWithPreSignature(msg, params, keys[i], preSigs[i], ledgers[i], outCh, endCh).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	var sigData *common.SignatureData
+	for ended := 0; ended < len(signPIDs); {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+		case msg := <-outCh:
+			routeTestMessage(t, parties, msg, errCh, updater)
+		case sigData = <-endCh:
+			ended++
+		}
+	}
+
+	pk := ecdsa.PublicKey{
+		Curve: tss.EC(),
+		X:     keys[0].ECDSAPub.X(),
+		Y:     keys[0].ECDSAPub.Y(),
+	}
+	ok := ecdsa.Verify(&pk, msg.Bytes(), new(big.Int).SetBytes(sigData.R), new(big.Int).SetBytes(sigData.S))
+	assert.True(t, ok, "ecdsa verify must pass")
+
+	// PHASE: a presignature cannot be used twice
+	for _, preSig := range preSigs {
+		assert.True(t, preSig.Used())
+		assert.Nil(t, preSig.K)
+		assert.Nil(t, preSig.Sigma)
+	}
+	params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[0], len(signPIDs), threshold)
+	P := NewLocalPartyWithPreSignature(big.NewInt(43), params, keys[0], preSigs[0], ledgers[0], outCh, endCh)
+	tssErr := P.Start()
+	if assert.NotNil(t, tssErr) {
+		assert.ErrorIs(t, tssErr.Cause(), ErrPreSignatureUsed)
+	}
+
+	// nor can a copy of it restored from a backup, which the ledger refuses
+	restored := new(PreSignatureData)
+	assert.NoError(t, json.Unmarshal(backups[0], restored))
+	assert.False(t, restored.Used())
+	P = NewLocalPartyWithPreSignature(big.NewInt(43), params, keys[0], restored, ledgers[0], outCh, endCh)
+	tssErr = P.Start()
+	if assert.NotNil(t, tssErr) {
+		assert.ErrorIs(t, tssErr.Cause(), tss.ErrNonceUsed)
+	}
+	assert.Equal(t, 0, len(outCh), "no share of a signature should be sent with a used presignature")
+}
+
+func routeTestMessage(t *testing.T, parties []*LocalParty, msg tss.Message, errCh chan<- *tss.Error, updater func(tss.Party, tss.Message, chan<- *tss.Error)) {
+	dest := msg.GetTo()
+	if dest == nil {
+		for _, P := range parties {
+			if P.PartyID().Index == msg.GetFrom().Index {
+				continue
+			}
+			go updater(P, msg, errCh)
+		}
+		return
+	}
+	if dest[0].Index == msg.GetFrom().Index {
+		t.Fatalf("party %d tried to send a message to itself (%d)", dest[0].Index, msg.GetFrom().Index)
+	}
+	go updater(parties[dest[0].Index], msg, errCh)
+}
+
+func TestPreSignCatchesInconsistentNonce(t *testing.T) {
+	setUp("info")
+	const cheater = 1
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixtures(testThreshold + 1)
+	assert.NoError(t, err, "should load keygen fixtures")
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	n := len(signPIDs)
+	parties := make([]*LocalParty, 0, n)
+	outCh := make(chan tss.Message, n*n*10)
+	preEndCh := make(chan *PreSignatureData, n)
+	for i := 0; i < n; i++ {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], n, testThreshold)
+		parties = append(parties, NewPreSignLocalParty(params, keys[i], outCh, preEndCh).(*LocalParty))
+	}
+
+	errs := make([]*tss.Error, n)
+	errCh := make(chan *tss.Error, n*n*10)
+	for _, P := range parties {
+		assert.Nil(t, P.Start())
+	}
+	for 0 < len(outCh) {
+		msg := <-outCh
+		// once round 2 is done the cheater uses a different k_i than the one it encrypted for the MtA, so R is wrong
+		if _, ok := msg.(tss.ParsedMessage).Content().(*SignRound2Message); ok && msg.GetFrom().Index == cheater && msg.GetTo()[0].Index == 0 {
+			parties[cheater].temp.k = new(big.Int).Add(parties[cheater].temp.k, big.NewInt(1))
+		}
+		for _, P := range parties {
+			if (msg.IsBroadcast() || msg.GetTo()[0].Index == P.PartyID().Index) && errs[P.PartyID().Index] == nil {
+				test.SharedPartyUpdater(P, msg, errCh)
+			}
+			select {
+			case err := <-errCh:
+				errs[P.PartyID().Index] = err
+			default:
+			}
+		}
+	}
+	assert.Equal(t, 0, len(preEndCh), "no party should output a presignature")
+	for j, err := range errs {
+		if j == cheater {
+			continue
+		}
+		if assert.NotNilf(t, err, "party %d should abort", j) {
+			assert.Equal(t, 6, err.Round(), "the parties should abort before the presignature is output")
+			assert.Equal(t, []*tss.PartyID{signPIDs[cheater]}, err.Culprits())
+		}
+	}
+}
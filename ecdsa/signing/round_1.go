@@ -22,9 +22,9 @@ import (
 var zero = big.NewInt(0)
 
 // round 1 represents round 1 of the signing part of the GG18 ECDSA TSS spec (Gennaro, Goldfeder; 2018)
-func newRound1(params *tss.Parameters, key *keygen.LocalPartySaveData, data *common.SignatureData, temp *localTempData, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Round {
+func newRound1(params *tss.Parameters, key *keygen.LocalPartySaveData, data *common.SignatureData, temp *localTempData, out chan<- tss.Message, end chan<- *common.SignatureData, preEnd chan<- *PreSignatureData) tss.Round {
 	return &round1{
-		&base{params, key, data, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1},
+		&base{params, key, data, temp, out, end, preEnd, make([]bool, len(params.Parties().IDs())), false, 1},
 	}
 }
 
@@ -37,14 +37,18 @@ func (round *round1) Start() *tss.Error {
 	// but considered different blockchain use different hash function we accept the converted big.Int
 	// if this big.Int is not belongs to Zq, the client might not comply with common rule (for ECDSA):
 	// https://github.com/btcsuite/btcd/blob/c26ffa870fd817666a857af1bf6498fabba1ffe3/btcec/signature.go#L263
-	if round.temp.m.Cmp(round.Params().EC().Params().N) >= 0 {
+	// when presigning the message is not known yet and is checked in the online round instead
+	if round.preEnd == nil && round.temp.m.Cmp(round.Params().EC().Params().N) >= 0 {
 		return round.WrapError(errors.New("hashed message is not valid"))
 	}
 
 	round.number = 1
 	round.started = true
 	round.resetOK()
-	round.temp.ssidNonce = new(big.Int).SetUint64(0)
+	if round.temp.ssidNonce == nil {
+		// the sessions of a batch are told apart by their nonce
+		round.temp.ssidNonce = new(big.Int).SetUint64(0)
+	}
 	ssid, err := round.getSSID()
 	if err != nil {
 		return round.WrapError(err)
@@ -74,31 +78,38 @@ func (round *round1) Start() *tss.Error {
 		}
 		r1msg1 := NewSignRound1Message1(Pj, round.PartyID(), cA, pi)
 		round.temp.cis[j] = cA
-		round.out <- r1msg1
+		enc, err := tss.EncryptMessage(round.Params(), r1msg1)
+		if err != nil {
+			return round.WrapError(err)
+		}
+		round.out <- tss.WithRound(enc, round.number)
 	}
 
 	r1msg2 := NewSignRound1Message2(round.PartyID(), cmt.C)
 	round.temp.signRound1Message2s[i] = r1msg2
-	round.out <- r1msg2
+	round.out <- tss.WithRound(r1msg2, round.number)
 
 	return nil
 }
 
 func (round *round1) Update() (bool, *tss.Error) {
+	ret := true
 	for j, msg1 := range round.temp.signRound1Message1s {
 		if round.ok[j] {
 			continue
 		}
 		if msg1 == nil || !round.CanAccept(msg1) {
-			return false, nil
+			ret = false
+			continue
 		}
 		msg2 := round.temp.signRound1Message2s[j]
 		if msg2 == nil || !round.CanAccept(msg2) {
-			return false, nil
+			ret = false
+			continue
 		}
 		round.ok[j] = true
 	}
-	return true, nil
+	return ret, nil
 }
 
 func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
@@ -13,6 +13,7 @@ import (
 
 	errorspkg "github.com/pkg/errors"
 
+	"github.com/bnb-chain/tss-lib/v2/common"
 	"github.com/bnb-chain/tss-lib/v2/crypto/mta"
 	"github.com/bnb-chain/tss-lib/v2/tss"
 )
@@ -32,6 +33,10 @@ func (round *round2) Start() *tss.Error {
 	wg := sync.WaitGroup{}
 	wg.Add((len(round.Parties().IDs()) - 1) * 2)
 	ContextI := append(round.temp.ssid, new(big.Int).SetUint64(uint64(i)).Bytes()...)
+	rands, err := common.ForkRandom(round.Rand(), len(round.Parties().IDs())*2)
+	if err != nil {
+		return round.WrapError(err)
+	}
 	for j, Pj := range round.Parties().IDs() {
 		if j == i {
 			continue
@@ -58,7 +63,7 @@ func (round *round2) Start() *tss.Error {
 				round.key.NTildej[i],
 				round.key.H1j[i],
 				round.key.H2j[i],
-				round.Rand(),
+				rands[2*j],
 			)
 			// should be thread safe as these are pre-allocated
 			round.temp.betas[j] = beta
@@ -91,7 +96,7 @@ func (round *round2) Start() *tss.Error {
 				round.key.H1j[i],
 				round.key.H2j[i],
 				round.temp.bigWs[i],
-				round.Rand(),
+				rands[2*j+1],
 			)
 			round.temp.vs[j] = v
 			round.temp.c2jis[j] = c2ji
@@ -118,7 +123,11 @@ func (round *round2) Start() *tss.Error {
 		}
 		r2msg := NewSignRound2Message(
 			Pj, round.PartyID(), round.temp.c1jis[j], round.temp.pi1jis[j], round.temp.c2jis[j], round.temp.pi2jis[j])
-		round.out <- r2msg
+		enc, err := tss.EncryptMessage(round.Params(), r2msg)
+		if err != nil {
+			return round.WrapError(err)
+		}
+		round.out <- tss.WithRound(enc, round.number)
 	}
 	return nil
 }
@@ -14,6 +14,7 @@ import (
 	errorspkg "github.com/pkg/errors"
 
 	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
 	"github.com/bnb-chain/tss-lib/v2/crypto/mta"
 	"github.com/bnb-chain/tss-lib/v2/tss"
 )
@@ -115,11 +116,22 @@ func (round *round3) Start() *tss.Error {
 		sigma = modN.Add(sigma, us[j].Add(us[j], round.temp.vs[j]))
 	}
 
+	// publish beta_ij*G and nu_ij*G so that the MtA shares can be checked if signing is aborted later on
+	bigBetas := make([]*crypto.ECPoint, len(round.Parties().IDs()))
+	bigNus := make([]*crypto.ECPoint, len(round.Parties().IDs()))
+	for j := range round.Parties().IDs() {
+		if j == round.PartyID().Index {
+			continue
+		}
+		bigBetas[j] = crypto.ScalarBaseMult(round.Params().EC(), round.temp.betas[j])
+		bigNus[j] = crypto.ScalarBaseMult(round.Params().EC(), round.temp.vs[j])
+	}
+
 	round.temp.theta = thelta
 	round.temp.sigma = sigma
-	r3msg := NewSignRound3Message(round.PartyID(), thelta)
+	r3msg := NewSignRound3Message(round.PartyID(), thelta, bigBetas, bigNus)
 	round.temp.signRound3Messages[round.PartyID().Index] = r3msg
-	round.out <- r3msg
+	round.out <- tss.WithRound(r3msg, round.number)
 
 	return nil
 }
@@ -37,7 +37,7 @@ func (round *round6) Start() *tss.Error {
 
 	r6msg := NewSignRound6Message(round.PartyID(), round.temp.DPower, piAi, piV)
 	round.temp.signRound6Messages[round.PartyID().Index] = r6msg
-	round.out <- r6msg
+	round.out <- tss.WithRound(r6msg, round.number)
 	return nil
 }
 
@@ -86,7 +86,7 @@ func (round *round7) Start() *tss.Error {
 	cmt := commitments.NewHashCommitment(round.Rand(), UiX, UiY, TiX, TiY)
 	r7msg := NewSignRound7Message(round.PartyID(), cmt.C)
 	round.temp.signRound7Messages[round.PartyID().Index] = r7msg
-	round.out <- r7msg
+	round.out <- tss.WithRound(r7msg, round.number)
 	round.temp.DTelda = cmt.D
 
 	return nil
@@ -22,7 +22,7 @@ func (round *round8) Start() *tss.Error {
 
 	r8msg := NewSignRound8Message(round.PartyID(), round.temp.DTelda)
 	round.temp.signRound8Messages[round.PartyID().Index] = r8msg
-	round.out <- r8msg
+	round.out <- tss.WithRound(r8msg, round.number)
 
 	return nil
 }
@@ -41,16 +41,20 @@ func (round *round9) Start() *tss.Error {
 		TX, TY = round.Params().EC().Add(TX, TY, TjX, TjY)
 	}
 	if UX.Cmp(TX) != 0 || UY.Cmp(TY) != 0 {
-		return round.WrapError(errors.New("U doesn't equal T"), round.PartyID())
+		// no s_i has been revealed yet, so every party can open its MtA inputs to find out who cheated
+		return round.startBlame(true)
 	}
 
 	r9msg := NewSignRound9Message(round.PartyID(), round.temp.si)
 	round.temp.signRound9Messages[round.PartyID().Index] = r9msg
-	round.out <- r9msg
+	round.out <- tss.WithRound(r9msg, round.number)
 	return nil
 }
 
 func (round *round9) Update() (bool, *tss.Error) {
+	if round.temp.abort {
+		return round.updateBlame()
+	}
 	ret := true
 	for j, msg := range round.temp.signRound9Messages {
 		if round.ok[j] {
@@ -66,6 +70,9 @@ func (round *round9) Update() (bool, *tss.Error) {
 }
 
 func (round *round9) CanAccept(msg tss.ParsedMessage) bool {
+	if round.temp.abort {
+		return round.canAcceptBlame(msg)
+	}
 	if _, ok := msg.Content().(*SignRound9Message); ok {
 		return msg.IsBroadcast()
 	}
@@ -74,5 +81,8 @@ func (round *round9) CanAccept(msg tss.ParsedMessage) bool {
 
 func (round *round9) NextRound() tss.Round {
 	round.started = false
+	if round.temp.abort {
+		return &identifyAbort{round.base}
+	}
 	return &finalization{round}
 }
@@ -0,0 +1,243 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+	"math/big"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto/schnorr"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+type (
+	// mtaOpenings are revealed in a blame message after the check U = T of round 9 failed.
+	// All slices are indexed by party and hold nil at this party's own index.
+	mtaOpenings struct {
+		rho,
+		k *big.Int
+		cSent,
+		cRandomness,
+		cReceived,
+		c1Sent,
+		c2Sent,
+		c1Received,
+		alpha,
+		alphaRandomness,
+		c2Received,
+		u,
+		uRandomness []*big.Int
+	}
+)
+
+// startBlame is called by the round that detected a failed check. It broadcasts this party's blame message and
+// makes the round wait for the blame messages of the other parties, after which it proceeds to identifyAbort.
+// When withOpenings is set the ephemeral k_i and the openings of all MtA ciphertexts are revealed; this is only safe
+// while no s_i has been broadcast.
+func (round *base) startBlame(withOpenings bool) *tss.Error {
+	i := round.PartyID().Index
+	round.temp.abort = true
+	round.resetOK()
+	round.ok[i] = true
+
+	var openings *mtaOpenings
+	if withOpenings {
+		var err error
+		if openings, err = round.mtaOpenings(); err != nil {
+			return round.WrapError(err)
+		}
+	}
+	signature, err := round.signAbortTranscript(newSignBlameContent(round.temp.li, openings), !withOpenings)
+	if err != nil {
+		return round.WrapError(err)
+	}
+	msg := NewSignBlameMessage(round.PartyID(), round.temp.li, openings, signature)
+	round.temp.signBlameMessages[i] = msg
+	round.out <- tss.WithRound(msg, round.number)
+	return nil
+}
+
+// mtaOpenings recovers the plaintexts and randomness of the Paillier ciphertexts this party sent and received
+func (round *base) mtaOpenings() (*mtaOpenings, error) {
+	i := round.PartyID().Index
+	n := len(round.Parties().IDs())
+	sk := round.key.PaillierSK
+	op := &mtaOpenings{
+		rho:             round.temp.roi,
+		cSent:           make([]*big.Int, n),
+		cRandomness:     make([]*big.Int, n),
+		cReceived:       make([]*big.Int, n),
+		c1Sent:          make([]*big.Int, n),
+		c2Sent:          make([]*big.Int, n),
+		c1Received:      make([]*big.Int, n),
+		alpha:           make([]*big.Int, n),
+		alphaRandomness: make([]*big.Int, n),
+		c2Received:      make([]*big.Int, n),
+		u:               make([]*big.Int, n),
+		uRandomness:     make([]*big.Int, n),
+	}
+	for j := range round.Parties().IDs() {
+		if j == i {
+			continue
+		}
+		var err error
+		// k_i was wiped in round 5, so it is recovered from the ciphertexts sent in round 1
+		op.cSent[j] = round.temp.cis[j]
+		if op.k, op.cRandomness[j], err = sk.DecryptAndRecoverRandomness(op.cSent[j]); err != nil {
+			return nil, err
+		}
+		op.cReceived[j] = round.temp.signRound1Message1s[j].Content().(*SignRound1Message1).UnmarshalC()
+		op.c1Sent[j], op.c2Sent[j] = round.temp.c1jis[j], round.temp.c2jis[j]
+
+		r2msg := round.temp.signRound2Messages[j].Content().(*SignRound2Message)
+		op.c1Received[j] = new(big.Int).SetBytes(r2msg.GetC1())
+		if op.alpha[j], op.alphaRandomness[j], err = sk.DecryptAndRecoverRandomness(op.c1Received[j]); err != nil {
+			return nil, err
+		}
+		op.c2Received[j] = new(big.Int).SetBytes(r2msg.GetC2())
+		if op.u[j], op.uRandomness[j], err = sk.DecryptAndRecoverRandomness(op.c2Received[j]); err != nil {
+			return nil, err
+		}
+	}
+	if op.k == nil {
+		return nil, errors.New("there are no other parties to reveal the MtA openings to")
+	}
+	return op, nil
+}
+
+// signAbortTranscript signs m and the broadcast messages of this party that the abort evidence holds with x_i, the
+// secret of BigXj[i], so that the evidence cannot attribute other messages to it. The messages of round 9 are only
+// signed after the final signature failed to verify.
+func (round *base) signAbortTranscript(blame *SignBlameMessage, withRound9 bool) (*schnorr.ZKProof, error) {
+	i := round.PartyID().Index
+	msgs := []proto.Message{
+		round.temp.signRound1Message2s[i].Content(),
+		round.temp.signRound3Messages[i].Content(),
+		round.temp.signRound4Messages[i].Content(),
+		round.temp.signRound5Messages[i].Content(),
+		round.temp.signRound6Messages[i].Content(),
+		round.temp.signRound7Messages[i].Content(),
+		round.temp.signRound8Messages[i].Content(),
+	}
+	if withRound9 {
+		msgs = append(msgs, round.temp.signRound9Messages[i].Content())
+	}
+	session, err := abortTranscript(round.temp.m, append(msgs, blame)...)
+	if err != nil {
+		return nil, err
+	}
+	xi := round.key.Xi
+	if round.temp.keyDerivationDelta != nil {
+		// the BigXj of the key were shifted by the delta, see UpdatePublicKeyAndAdjustBigXj
+		xi = common.ModInt(round.EC().Params().N).Add(round.temp.keyDerivationDelta, xi)
+	}
+	return schnorr.NewZKProof(session, xi, round.key.BigXj[i], round.Rand())
+}
+
+func (round *base) canAcceptBlame(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignBlameMessage); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *base) updateBlame() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.signBlameMessages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.canAcceptBlame(msg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+// ----- //
+
+// identifyAbort collects the blame messages of all parties and returns an error naming the parties that deviated
+// from the protocol. The error carries a SignAbortEvidence that can be checked with VerifyAbortEvidence.
+func (round *identifyAbort) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number++ // follows the round that detected the failed check
+	round.started = true
+	round.resetOK()
+
+	evidence := round.abortEvidence()
+	culprits, err := identifyCulprits(round.Params().EC(), round.key, evidence)
+	if err != nil {
+		return round.WrapError(err)
+	}
+	bz, err := proto.Marshal(evidence)
+	if err != nil {
+		return round.WrapError(err)
+	}
+	Ps := round.Parties().IDs()
+	culpritIDs := make([]*tss.PartyID, len(culprits))
+	for c, j := range culprits {
+		culpritIDs[c] = Ps[j]
+	}
+	return tss.NewErrorWithEvidence(errors.New("signing aborted; the culprits deviated from the protocol"),
+		TaskName, round.number, round.PartyID(), bz, culpritIDs...)
+}
+
+func (round *identifyAbort) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *identifyAbort) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *identifyAbort) NextRound() tss.Round {
+	return nil // aborted
+}
+
+// abortEvidence gathers the broadcast messages of all parties
+func (round *identifyAbort) abortEvidence() *SignAbortEvidence {
+	n := len(round.Parties().IDs())
+	ev := &SignAbortEvidence{
+		M:              round.temp.m.Bytes(),
+		Round1Messages: make([]*SignRound1Message2, n),
+		Round3Messages: make([]*SignRound3Message, n),
+		Round4Messages: make([]*SignRound4Message, n),
+		Round5Messages: make([]*SignRound5Message, n),
+		Round6Messages: make([]*SignRound6Message, n),
+		Round7Messages: make([]*SignRound7Message, n),
+		Round8Messages: make([]*SignRound8Message, n),
+		BlameMessages:  make([]*SignBlameMessage, n),
+	}
+	i := round.PartyID().Index
+	finalCheckFailed := !round.temp.signBlameMessages[i].Content().(*SignBlameMessage).HasOpenings()
+	if finalCheckFailed {
+		ev.Round9Messages = make([]*SignRound9Message, n)
+	}
+	for j := 0; j < n; j++ {
+		ev.Round1Messages[j] = round.temp.signRound1Message2s[j].Content().(*SignRound1Message2)
+		ev.Round3Messages[j] = round.temp.signRound3Messages[j].Content().(*SignRound3Message)
+		ev.Round4Messages[j] = round.temp.signRound4Messages[j].Content().(*SignRound4Message)
+		ev.Round5Messages[j] = round.temp.signRound5Messages[j].Content().(*SignRound5Message)
+		ev.Round6Messages[j] = round.temp.signRound6Messages[j].Content().(*SignRound6Message)
+		ev.Round7Messages[j] = round.temp.signRound7Messages[j].Content().(*SignRound7Message)
+		ev.Round8Messages[j] = round.temp.signRound8Messages[j].Content().(*SignRound8Message)
+		ev.BlameMessages[j] = round.temp.signBlameMessages[j].Content().(*SignBlameMessage)
+		if finalCheckFailed {
+			ev.Round9Messages[j] = round.temp.signRound9Messages[j].Content().(*SignRound9Message)
+		}
+	}
+	return ev
+}
@@ -0,0 +1,152 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// the online round signs the message with a presignature: s_i = m*k_i + r*sigma_i is broadcast and the shares are summed
+func newOnlineRound(params *tss.Parameters, key *keygen.LocalPartySaveData, data *common.SignatureData, temp *localTempData, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Round {
+	return &onlineRound{
+		&base{params, key, data, temp, out, end, nil, make([]bool, len(params.Parties().IDs())), false, 1},
+	}
+}
+
+func (round *onlineRound) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	if round.temp.m == nil || round.temp.m.Cmp(round.Params().EC().Params().N) >= 0 {
+		return round.WrapError(errors.New("hashed message is not valid"))
+	}
+	pre := round.temp.preSig
+	if pre.Used() {
+		return round.WrapError(ErrPreSignatureUsed)
+	}
+	if !pre.ValidateBasic() {
+		return round.WrapError(errors.New("presignature is invalid"))
+	}
+	if len(pre.Ks) != len(round.key.Ks) {
+		return round.WrapError(fmt.Errorf("presignature was made by %d parties but %d are signing", len(pre.Ks), len(round.key.Ks)))
+	}
+	for j, kj := range round.key.Ks {
+		if kj.Cmp(pre.Ks[j]) != 0 {
+			return round.WrapError(errors.New("presignature was made by a different set of parties"))
+		}
+	}
+	if !pre.ECDSAPub.Equals(round.key.ECDSAPub) {
+		return round.WrapError(errors.New("presignature was made for a different key"))
+	}
+	// the ID is recorded before s_i is computed, so that no copy of the presignature can sign another message
+	if round.temp.nonceLedger == nil {
+		return round.WrapError(errors.New("a nonce ledger is required to sign with a presignature"))
+	}
+	if err := round.temp.nonceLedger.Use(pre.ID); err != nil {
+		return round.WrapError(err)
+	}
+	k, sigma, err := pre.consume()
+	if err != nil {
+		return round.WrapError(err)
+	}
+
+	modN := common.ModInt(round.Params().EC().Params().N)
+	rx, ry := pre.BigR.X(), pre.BigR.Y()
+	si := modN.Add(modN.Mul(round.temp.m, k), modN.Mul(rx, sigma))
+
+	round.temp.bigR = pre.BigR
+	round.temp.rx = rx
+	round.temp.ry = ry
+	round.temp.si = si
+
+	i := round.PartyID().Index
+	round.ok[i] = true
+
+	msg := NewSignOnlineMessage(round.PartyID(), pre.ID, si)
+	round.temp.signOnlineMessages[i] = msg
+	round.out <- tss.WithRound(msg, round.number)
+	return nil
+}
+
+func (round *onlineRound) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.signOnlineMessages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *onlineRound) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignOnlineMessage); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *onlineRound) NextRound() tss.Round {
+	round.started = false
+	return &onlineFinalization{round}
+}
+
+// ----- //
+
+func (round *onlineFinalization) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	sumS := round.temp.si
+	modN := common.ModInt(round.Params().EC().Params().N)
+
+	for j, Pj := range round.Parties().IDs() {
+		round.ok[j] = true
+		if j == round.PartyID().Index {
+			continue
+		}
+		onlineMsg := round.temp.signOnlineMessages[j].Content().(*SignOnlineMessage)
+		if !bytes.Equal(onlineMsg.GetPreSignatureId(), round.temp.preSig.ID) {
+			return round.WrapError(errors.New("party signed with a different presignature"), Pj)
+		}
+		sumS = modN.Add(sumS, onlineMsg.UnmarshalS())
+	}
+
+	return round.finishSignature(sumS)
+}
+
+func (round *onlineFinalization) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *onlineFinalization) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *onlineFinalization) NextRound() tss.Round {
+	return nil // finished!
+}
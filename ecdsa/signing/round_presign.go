@@ -0,0 +1,178 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/logstarproof"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// preSignRound5 takes the place of round 5 when presigning: R is computed and each party sends bar_R_i = k_i*R to
+// the others, with a proof that k_i is the plaintext of the ciphertext it sent them in round 1. As sum bar_R_j = G
+// only holds for R = k^-1*G, a presignature is only output once R is known to be correct (GG20 Phase 5).
+func (round *preSignRound5) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 5
+	round.started = true
+	round.resetOK()
+
+	R, err := round.computeBigR()
+	if err != nil {
+		return err
+	}
+	round.temp.bigR = R
+	barRi := R.ScalarMult(round.temp.k)
+
+	i := round.PartyID().Index
+	round.ok[i] = true
+
+	ContextI := common.AppendBigIntToBytesSlice(round.temp.ssid, big.NewInt(int64(i)))
+	sk := round.key.PaillierSK
+	for j, Pj := range round.Parties().IDs() {
+		if j == i {
+			continue
+		}
+		_, rho, err := sk.DecryptAndRecoverRandomness(round.temp.cis[j])
+		if err != nil {
+			return round.WrapError(err)
+		}
+		proof, err := logstarproof.NewProof(ContextI, round.EC(), &sk.PublicKey, round.temp.cis[j], barRi, R,
+			round.key.NTildej[j], round.key.H1j[j], round.key.H2j[j], round.temp.k, rho, round.Rand())
+		if err != nil {
+			return round.WrapError(err)
+		}
+		enc, err := tss.EncryptMessage(round.Params(), NewSignPreSignMessage(Pj, round.PartyID(), barRi, proof))
+		if err != nil {
+			return round.WrapError(err)
+		}
+		round.out <- tss.WithRound(enc, round.number)
+	}
+	return nil
+}
+
+func (round *preSignRound5) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.signPreSignMessages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *preSignRound5) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignPreSignMessage); ok {
+		return !msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *preSignRound5) NextRound() tss.Round {
+	round.started = false
+	return &preSignFinalization{round}
+}
+
+// ----- //
+
+// preSignFinalization checks the proofs of bar_R_j and that sum bar_R_j = G, and then hands the message-independent
+// shares k_i and sigma_i to the caller instead of combining them with the message
+func (round *preSignFinalization) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 6
+	round.started = true
+	round.resetOK()
+
+	ec := round.EC()
+	i := round.PartyID().Index
+	R := round.temp.bigR
+	sumBarR := R.ScalarMult(round.temp.k)
+	culprits := make([]*tss.PartyID, 0)
+	for j, Pj := range round.Parties().IDs() {
+		if j == i {
+			continue
+		}
+		ContextJ := common.AppendBigIntToBytesSlice(round.temp.ssid, big.NewInt(int64(j)))
+		msg := round.temp.signPreSignMessages[j].Content().(*SignPreSignMessage)
+		barRj, err := msg.UnmarshalBarR(ec)
+		if err != nil {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		proof, err := msg.UnmarshalLogstarProof(ec)
+		if err != nil {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		cj := round.temp.signRound1Message1s[j].Content().(*SignRound1Message1).UnmarshalC()
+		if !proof.Verify(ContextJ, ec, round.key.PaillierPKs[j], cj, barRj, R,
+			round.key.NTildej[i], round.key.H1j[i], round.key.H2j[i]) {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		if sumBarR, err = sumBarR.Add(barRj); err != nil {
+			culprits = append(culprits, Pj)
+		}
+	}
+	if 0 < len(culprits) {
+		return round.WrapError(errors.New("failed to verify the proof of bar_R_j"), culprits...)
+	}
+	if !sumBarR.Equals(crypto.ScalarBaseMult(ec, big.NewInt(1))) {
+		// every bar_R_j was proven, so R itself is wrong: a party lied about its Gamma_j or its theta_j
+		return round.WrapError(errors.New("sum of bar_R_j does not equal G; R is not consistent with the nonce shares"))
+	}
+
+	ks := make([]*big.Int, len(round.key.Ks))
+	copy(ks, round.key.Ks)
+	preSig := &PreSignatureData{
+		ID:       common.SHA512_256(round.temp.ssid, R.X().Bytes(), R.Y().Bytes()),
+		Ks:       ks,
+		ECDSAPub: round.key.ECDSAPub,
+		BigR:     R,
+		K:        round.temp.k,
+		Sigma:    round.temp.sigma,
+	}
+
+	// clear temp.w, temp.k and temp.sigma from memory, lint ignore
+	round.temp.w = zero
+	round.temp.k = zero
+	round.temp.sigma = zero
+
+	for j := range round.ok {
+		round.ok[j] = true
+	}
+	round.preEnd <- preSig
+
+	return nil
+}
+
+func (round *preSignFinalization) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *preSignFinalization) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *preSignFinalization) NextRound() tss.Round {
+	return nil // finished!
+}
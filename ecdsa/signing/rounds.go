@@ -28,6 +28,7 @@ type (
 		temp    *localTempData
 		out     chan<- tss.Message
 		end     chan<- *common.SignatureData
+		preEnd  chan<- *PreSignatureData
 		ok      []bool // `ok` tracks parties which have been verified by Update()
 		started bool
 		number  int
@@ -62,6 +63,25 @@ type (
 	finalization struct {
 		*round9
 	}
+	identifyAbort struct {
+		*base
+	}
+
+	// presigning
+	preSignRound5 struct {
+		*round4
+	}
+	preSignFinalization struct {
+		*preSignRound5
+	}
+
+	// online signing with a presignature
+	onlineRound struct {
+		*base
+	}
+	onlineFinalization struct {
+		*onlineRound
+	}
 )
 
 var (
@@ -75,6 +95,11 @@ var (
 	_ tss.Round = (*round8)(nil)
 	_ tss.Round = (*round9)(nil)
 	_ tss.Round = (*finalization)(nil)
+	_ tss.Round = (*identifyAbort)(nil)
+	_ tss.Round = (*preSignRound5)(nil)
+	_ tss.Round = (*preSignFinalization)(nil)
+	_ tss.Round = (*onlineRound)(nil)
+	_ tss.Round = (*onlineFinalization)(nil)
 )
 
 // ----- //
@@ -87,6 +112,11 @@ func (round *base) RoundNumber() int {
 	return round.number
 }
 
+// Out is used by the echo broadcast of the party
+func (round *base) Out() chan<- tss.Message {
+	return round.out
+}
+
 // CanProceed is inherited by other rounds
 func (round *base) CanProceed() bool {
 	if !round.started {
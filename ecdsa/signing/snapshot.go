@@ -0,0 +1,330 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	cmt "github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/crypto/mta"
+	"github.com/bnb-chain/tss-lib/v2/crypto/sealed"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	// SnapshotProtocol identifies a snapshot of a signing or presigning party in a sealed container
+	SnapshotProtocol = "ecdsa-signing-snapshot"
+	// SnapshotProtocolVersion is the version of the encoding of a snapshot in a sealed container
+	SnapshotProtocolVersion = 1
+)
+
+// snapshotState is the state of a party in a snapshot: the progress of its round, the key it signs with, the message
+// and its temp data, including its nonces k_i and gamma_i and the shares of the MtA
+type snapshotState struct {
+	OK                 []bool
+	PreSign            bool
+	Key                keygen.LocalPartySaveData
+	M                  *big.Int
+	KeyDerivationDelta *big.Int
+	FullBytesLen       int
+
+	W, K, Theta, ThetaInverse, Sigma, Gamma *big.Int
+	Cis                                     []*big.Int
+	BigWs                                   []*crypto.ECPoint
+	PointGamma                              *crypto.ECPoint
+	DeCommit                                cmt.HashDeCommitment
+
+	Betas, C1jis, C2jis, Vs []*big.Int
+	Pi1jis                  []*mta.ProofBob
+	Pi2jis                  []*mta.ProofBobWC
+
+	Li, Si, Rx, Ry, Roi *big.Int
+	BigR, BigAi, BigVi  *crypto.ECPoint
+	DPower              cmt.HashDeCommitment
+
+	Ui, Ti *crypto.ECPoint
+	DTelda cmt.HashDeCommitment
+
+	SSID      []byte
+	SSIDNonce *big.Int
+}
+
+// Snapshot seals the state of a running party with a passphrase or wrapping key (see crypto/sealed), so that it may
+// be resumed with ResumeLocalParty, or ResumePreSignLocalParty when presigning, if the process dies. Each snapshot is
+// recorded in the ledger, which lets only the latest snapshot be resumed, and only once. A party that signs with a
+// presignature, or that has entered the identifiable abort, cannot be snapshotted.
+func (p *LocalParty) Snapshot(ledger tss.SnapshotLedger, key sealed.Key) ([]byte, error) {
+	snap, err := tss.BaseSnapshot(p, TaskName, ledger, func(rnd tss.Round) (interface{}, []tss.ParsedMessage, error) {
+		if p.temp.abort {
+			return nil, nil, errors.New("a party in the identifiable abort cannot be snapshotted")
+		}
+		base, err := baseOf(rnd)
+		if err != nil {
+			return nil, nil, err
+		}
+		state := &snapshotState{
+			OK:                 base.ok,
+			PreSign:            p.preEnd != nil,
+			Key:                p.keys,
+			M:                  p.temp.m,
+			KeyDerivationDelta: p.temp.keyDerivationDelta,
+			FullBytesLen:       p.temp.fullBytesLen,
+			W:                  p.temp.w,
+			K:                  p.temp.k,
+			Theta:              p.temp.theta,
+			ThetaInverse:       p.temp.thetaInverse,
+			Sigma:              p.temp.sigma,
+			Gamma:              p.temp.gamma,
+			Cis:                p.temp.cis,
+			BigWs:              p.temp.bigWs,
+			PointGamma:         p.temp.pointGamma,
+			DeCommit:           p.temp.deCommit,
+			Betas:              p.temp.betas,
+			C1jis:              p.temp.c1jis,
+			C2jis:              p.temp.c2jis,
+			Vs:                 p.temp.vs,
+			Pi1jis:             p.temp.pi1jis,
+			Pi2jis:             p.temp.pi2jis,
+			Li:                 p.temp.li,
+			Si:                 p.temp.si,
+			Rx:                 p.temp.rx,
+			Ry:                 p.temp.ry,
+			Roi:                p.temp.roi,
+			BigR:               p.temp.bigR,
+			BigAi:              p.temp.bigAi,
+			BigVi:              p.temp.bigVi,
+			DPower:             p.temp.DPower,
+			Ui:                 p.temp.Ui,
+			Ti:                 p.temp.Ti,
+			DTelda:             p.temp.DTelda,
+			SSID:               p.temp.ssid,
+			SSIDNonce:          p.temp.ssidNonce,
+		}
+		return state, p.temp.localMessageStore.stored(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	plaintext, jsonErr := json.Marshal(snap)
+	if jsonErr != nil {
+		return nil, jsonErr
+	}
+	curveName, ok := tss.GetCurveName(p.params.EC())
+	if !ok {
+		return nil, errors.New("Snapshot: the curve of the party is not registered")
+	}
+	header := sealed.Header{
+		Protocol:        SnapshotProtocol,
+		ProtocolVersion: SnapshotProtocolVersion,
+		Curve:           curveName,
+		Threshold:       p.params.Threshold(),
+		PartyCount:      p.params.PartyCount(),
+	}
+	return sealed.Seal(header, plaintext, key)
+}
+
+// ResumeLocalParty rebuilds a signing party from a snapshot sealed by LocalParty.Snapshot, with the same parameters
+// that the party was created with. The snapshot is consumed in the ledger. The party continues in the round of the
+// snapshot with the nonces and the messages it had; it must not be started, and the messages sent to it while it was
+// down must be sent to it again.
+func ResumeLocalParty(
+	container []byte,
+	key sealed.Key,
+	ledger tss.SnapshotLedger,
+	params *tss.Parameters,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+) (tss.Party, error) {
+	return resumeLocalParty(container, key, ledger, params, out, end, nil)
+}
+
+// ResumePreSignLocalParty rebuilds a presigning party from a snapshot sealed by LocalParty.Snapshot, like
+// ResumeLocalParty
+func ResumePreSignLocalParty(
+	container []byte,
+	key sealed.Key,
+	ledger tss.SnapshotLedger,
+	params *tss.Parameters,
+	out chan<- tss.Message,
+	end chan<- *PreSignatureData,
+) (tss.Party, error) {
+	return resumeLocalParty(container, key, ledger, params, out, nil, end)
+}
+
+// ----- //
+
+func resumeLocalParty(
+	container []byte,
+	key sealed.Key,
+	ledger tss.SnapshotLedger,
+	params *tss.Parameters,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+	preEnd chan<- *PreSignatureData,
+) (tss.Party, error) {
+	header, plaintext, err := sealed.Open(container, key)
+	if err != nil {
+		return nil, err
+	}
+	if header.Protocol != SnapshotProtocol {
+		return nil, fmt.Errorf("ResumeLocalParty: the container holds %q, not %q", header.Protocol, SnapshotProtocol)
+	}
+	if header.ProtocolVersion != SnapshotProtocolVersion {
+		return nil, fmt.Errorf("ResumeLocalParty: unsupported version %d", header.ProtocolVersion)
+	}
+	if curveName, ok := tss.GetCurveName(params.EC()); !ok || curveName != header.Curve ||
+		header.Threshold != params.Threshold() || header.PartyCount != params.PartyCount() {
+		return nil, errors.New("ResumeLocalParty: the snapshot does not match the parameters")
+	}
+	snap := new(tss.Snapshot)
+	if err = json.Unmarshal(plaintext, snap); err != nil {
+		return nil, err
+	}
+	state := new(snapshotState)
+	if err = json.Unmarshal(snap.State, state); err != nil {
+		return nil, err
+	}
+	partyCount := len(params.Parties().IDs())
+	if len(state.OK) != partyCount || len(state.Key.Ks) != partyCount || len(state.Cis) != partyCount {
+		return nil, errors.New("ResumeLocalParty: the state in the snapshot does not match the parameters")
+	}
+	if state.PreSign != (preEnd != nil) {
+		return nil, errors.New("ResumeLocalParty: a snapshot of presigning must be resumed with ResumePreSignLocalParty")
+	}
+
+	p := NewLocalPartyWithKDD(state.M, params, state.Key, state.KeyDerivationDelta, out, end, state.FullBytesLen).(*LocalParty)
+	p.preEnd = preEnd
+	p.temp.w = state.W
+	p.temp.k = state.K
+	p.temp.theta = state.Theta
+	p.temp.thetaInverse = state.ThetaInverse
+	p.temp.sigma = state.Sigma
+	p.temp.gamma = state.Gamma
+	p.temp.cis = state.Cis
+	p.temp.bigWs = state.BigWs
+	p.temp.pointGamma = state.PointGamma
+	p.temp.deCommit = state.DeCommit
+	p.temp.betas = state.Betas
+	p.temp.c1jis = state.C1jis
+	p.temp.c2jis = state.C2jis
+	p.temp.vs = state.Vs
+	p.temp.pi1jis = state.Pi1jis
+	p.temp.pi2jis = state.Pi2jis
+	p.temp.li = state.Li
+	p.temp.si = state.Si
+	p.temp.rx = state.Rx
+	p.temp.ry = state.Ry
+	p.temp.roi = state.Roi
+	p.temp.bigR = state.BigR
+	p.temp.bigAi = state.BigAi
+	p.temp.bigVi = state.BigVi
+	p.temp.DPower = state.DPower
+	p.temp.Ui = state.Ui
+	p.temp.Ti = state.Ti
+	p.temp.DTelda = state.DTelda
+	p.temp.ssid = state.SSID
+	p.temp.ssidNonce = state.SSIDNonce
+
+	round, err := p.roundAt(snap.Round, state.OK)
+	if err != nil {
+		return nil, err
+	}
+	if err := tss.BaseResume(p, snap, ledger, params.Parties().IDs(), round, TaskName); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// roundAt returns the started round with the given number and progress; presigning ends after round 5
+func (p *LocalParty) roundAt(number int, ok []bool) (tss.Round, error) {
+	r1 := newRound1(p.params, &p.keys, p.data, &p.temp, p.out, p.end, p.preEnd).(*round1)
+	r1.number, r1.started, r1.ok = number, true, ok
+	r2 := &round2{r1}
+	r3 := &round3{r2}
+	r4 := &round4{r3}
+	switch {
+	case number == 1:
+		return r1, nil
+	case number == 2:
+		return r2, nil
+	case number == 3:
+		return r3, nil
+	case number == 4:
+		return r4, nil
+	case p.preEnd != nil && number == 5:
+		return &preSignRound5{r4}, nil
+	case p.preEnd != nil:
+		break
+	case number == 5:
+		return &round5{r4}, nil
+	case number == 6:
+		return &round6{&round5{r4}}, nil
+	case number == 7:
+		return &round7{&round6{&round5{r4}}}, nil
+	case number == 8:
+		return &round8{&round7{&round6{&round5{r4}}}}, nil
+	case number == 9:
+		return &round9{&round8{&round7{&round6{&round5{r4}}}}}, nil
+	}
+	return nil, fmt.Errorf("ResumeLocalParty: a snapshot cannot be taken in round %d", number)
+}
+
+func baseOf(rnd tss.Round) (*base, error) {
+	switch r := rnd.(type) {
+	case *round1:
+		return r.base, nil
+	case *round2:
+		return r.base, nil
+	case *round3:
+		return r.base, nil
+	case *round4:
+		return r.base, nil
+	case *round5:
+		return r.base, nil
+	case *round6:
+		return r.base, nil
+	case *round7:
+		return r.base, nil
+	case *round8:
+		return r.base, nil
+	case *round9:
+		return r.base, nil
+	case *preSignRound5:
+		return r.base, nil
+	}
+	return nil, fmt.Errorf("unexpected round %T", rnd)
+}
+
+func (store *localMessageStore) stored() []tss.ParsedMessage {
+	msgs := make([]tss.ParsedMessage, 0)
+	for _, round := range [][]tss.ParsedMessage{
+		store.signRound1Message1s,
+		store.signRound1Message2s,
+		store.signRound2Messages,
+		store.signRound3Messages,
+		store.signRound4Messages,
+		store.signRound5Messages,
+		store.signRound6Messages,
+		store.signRound7Messages,
+		store.signRound8Messages,
+		store.signRound9Messages,
+		store.signPreSignMessages,
+	} {
+		for _, msg := range round {
+			if msg != nil {
+				msgs = append(msgs, msg)
+			}
+		}
+	}
+	return msgs
+}
@@ -0,0 +1,129 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto/sealed"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func TestSnapshotAndResumeAfterCrash(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	ledger, err := tss.NewFileSnapshotLedger(t.TempDir())
+	assert.NoError(t, err)
+	key := sealed.PassphraseWithParams([]byte("correct horse"), sealed.Argon2Params{Time: 1, Memory: 1024, Threads: 1})
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+	newParams := func(pID *tss.PartyID) *tss.Parameters {
+		return tss.NewParameters(tss.S256(), p2pCtx, pID, len(signPIDs), testThreshold)
+	}
+	msg := big.NewInt(42)
+	parties := make([]tss.Party, len(signPIDs))
+	for i, pID := range signPIDs {
+		parties[i] = NewLocalParty(msg, newParams(pID), keys[i], outCh, endCh)
+		go func(P tss.Party) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(parties[i])
+	}
+
+	// the first party crashes in round 3, after its MtA; the later messages to it are held until it is resumed
+	var held []tss.Message
+	resumed := false
+	deliver := func(P tss.Party, msg tss.Message) {
+		switch msg.(tss.ParsedMessage).Content().(type) {
+		case *SignRound1Message1, *SignRound1Message2, *SignRound2Message:
+		default:
+			if P.PartyID().Index == 0 && !resumed {
+				held = append(held, msg)
+				return
+			}
+		}
+		go test.SharedPartyUpdater(P, msg, errCh)
+	}
+
+	sigs := make([]*common.SignatureData, 0, len(signPIDs))
+	for len(sigs) < len(signPIDs) {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+		case msg := <-outCh:
+			if _, ok := msg.(tss.ParsedMessage).Content().(*SignRound3Message); ok && msg.GetFrom().Index == 0 && !resumed {
+				container, err := parties[0].(*LocalParty).Snapshot(ledger, key)
+				assert.NoError(t, err)
+				P, err := ResumeLocalParty(container, key, ledger, newParams(signPIDs[0]), outCh, endCh)
+				assert.NoError(t, err)
+				assert.Contains(t, P.String(), "round: 3")
+				_, err = ResumeLocalParty(container, key, ledger, newParams(signPIDs[0]), outCh, endCh)
+				assert.True(t, errors.Is(err, tss.ErrSnapshotResumed), "a snapshot must not be resumed twice")
+
+				parties[0], resumed = P, true
+				for _, m := range held {
+					deliver(P, m)
+				}
+			}
+			if dest := msg.GetTo(); dest != nil {
+				deliver(parties[dest[0].Index], msg)
+				continue
+			}
+			for _, P := range parties {
+				if P.PartyID().Index != msg.GetFrom().Index {
+					deliver(P, msg)
+				}
+			}
+		case sig := <-endCh:
+			sigs = append(sigs, sig)
+		}
+	}
+	assert.True(t, resumed)
+	pkX, pkY := keys[0].ECDSAPub.X(), keys[0].ECDSAPub.Y()
+	pk := ecdsa.PublicKey{Curve: tss.EC(), X: pkX, Y: pkY}
+	for _, sig := range sigs {
+		r, s := new(big.Int).SetBytes(sig.R), new(big.Int).SetBytes(sig.S)
+		assert.True(t, ecdsa.Verify(&pk, msg.Bytes(), r, s), "the signature must verify")
+	}
+}
+
+func TestResumePreSignSnapshot(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	ledger, err := tss.NewFileSnapshotLedger(t.TempDir())
+	assert.NoError(t, err)
+	key := sealed.PassphraseWithParams([]byte("correct horse"), sealed.Argon2Params{Time: 1, Memory: 1024, Threads: 1})
+
+	params := tss.NewParameters(tss.S256(), tss.NewPeerContext(signPIDs), signPIDs[0], len(signPIDs), testThreshold)
+	out := make(chan tss.Message, 2*len(signPIDs))
+	P := NewPreSignLocalParty(params, keys[0], out, make(chan *PreSignatureData, 1)).(*LocalParty)
+	assert.Nil(t, P.Start())
+	container, err := P.Snapshot(ledger, key)
+	assert.NoError(t, err)
+
+	_, err = ResumeLocalParty(container, key, ledger, params, out, nil)
+	assert.Error(t, err, "a snapshot of presigning must not be resumed as signing")
+	resumed, err := ResumePreSignLocalParty(container, key, ledger, params, out, make(chan *PreSignatureData, 1))
+	assert.NoError(t, err)
+	assert.True(t, resumed.Running())
+}
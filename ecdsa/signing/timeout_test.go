@@ -0,0 +1,78 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// round 1 must record the messages of every party that sent them, and not only those before the first party missing,
+// so that a timeout names the silent party alone
+func TestRoundTimeoutNamesOnlySilentParty(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]*LocalParty, 0, len(signPIDs))
+	errCh := make(chan *tss.Error, len(signPIDs)*len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs)*len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		params.SetRoundTimeout(10 * time.Second)
+		parties = append(parties, NewLocalParty(big.NewInt(42), params, keys[i], outCh, endCh).(*LocalParty))
+	}
+	// the first party's messages are never delivered
+	silent := parties[0]
+	for _, P := range parties {
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+	go func() {
+		for msg := range outCh {
+			if msg.GetFrom().Index == silent.PartyID().Index {
+				continue
+			}
+			for _, P := range parties {
+				if P.PartyID().Index != msg.GetFrom().Index && (msg.GetTo() == nil || msg.GetTo()[0].Index == P.PartyID().Index) {
+					go test.SharedPartyUpdater(P, msg, errCh)
+				}
+			}
+		}
+	}()
+
+	for _, P := range parties[1:] {
+		select {
+		case <-P.Done():
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+		case <-time.After(time.Minute):
+			assert.FailNow(t, "the party must be aborted by its round timeout")
+		}
+		err := P.Err()
+		if assert.NotNil(t, err) {
+			assert.True(t, errors.Is(err, tss.ErrRoundTimeout))
+			assert.Equal(t, 1, err.Round())
+			assert.Equal(t, []*tss.PartyID{silent.PartyID()}, err.Culprits())
+		}
+	}
+}
@@ -0,0 +1,187 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package frost
+
+import (
+	"crypto/elliptic"
+	"crypto/sha512"
+	"errors"
+	"io"
+	"math/big"
+	"sort"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+)
+
+// The FROST(Ed25519, SHA-512) ciphersuite of RFC 9591, section 6.1
+const (
+	contextString = "FROST-ED25519-SHA512-v1"
+	scalarLen     = 32
+)
+
+// h1 hashes to a scalar the input of a binding factor
+func h1(ec elliptic.Curve, m ...[]byte) *big.Int {
+	return hashToScalar(ec, append([][]byte{[]byte(contextString + "rho")}, m...)...)
+}
+
+// h2 is the challenge hash; it has no prefix so that the signatures are plain Ed25519 signatures
+func h2(ec elliptic.Curve, m ...[]byte) *big.Int {
+	return hashToScalar(ec, m...)
+}
+
+// h3 hashes to a scalar the input of a nonce
+func h3(ec elliptic.Curve, m ...[]byte) *big.Int {
+	return hashToScalar(ec, append([][]byte{[]byte(contextString + "nonce")}, m...)...)
+}
+
+// h4 hashes the message
+func h4(m []byte) []byte {
+	return hash([]byte(contextString+"msg"), m)
+}
+
+// h5 hashes the encoded commitment list
+func h5(m []byte) []byte {
+	return hash([]byte(contextString+"com"), m)
+}
+
+func hash(in ...[]byte) []byte {
+	h := sha512.New()
+	for _, bz := range in {
+		h.Write(bz)
+	}
+	return h.Sum(nil)
+}
+
+// hashToScalar interprets the SHA-512 digest of the input as a little-endian integer and reduces it modulo the order
+func hashToScalar(ec elliptic.Curve, in ...[]byte) *big.Int {
+	digest := hash(in...)
+	reverseBytes(digest)
+	return new(big.Int).Mod(new(big.Int).SetBytes(digest), ec.Params().N)
+}
+
+// serializeScalar encodes a scalar as 32 little-endian bytes
+func serializeScalar(ec elliptic.Curve, s *big.Int) []byte {
+	bz := new(big.Int).Mod(s, ec.Params().N).FillBytes(make([]byte, scalarLen))
+	reverseBytes(bz)
+	return bz
+}
+
+// serializeElement encodes a point as in RFC 8032: y in little-endian with the sign of x in the top bit
+func serializeElement(p *crypto.ECPoint) []byte {
+	bz := p.Y().FillBytes(make([]byte, scalarLen))
+	reverseBytes(bz)
+	if p.X().Bit(0) == 1 {
+		bz[scalarLen-1] |= 0x80
+	}
+	return bz
+}
+
+func reverseBytes(bz []byte) {
+	for i, j := 0, len(bz)-1; i < j; i, j = i+1, j-1 {
+		bz[i], bz[j] = bz[j], bz[i]
+	}
+}
+
+// isValidElement checks that p is not the identity and lies in the prime order subgroup, as DeserializeElement does
+func isValidElement(ec elliptic.Curve, p *crypto.ECPoint) bool {
+	if p == nil || !p.ValidateBasic() || (p.X().Sign() == 0 && p.Y().Cmp(big.NewInt(1)) == 0) {
+		return false
+	}
+	x, y := ec.ScalarMult(p.X(), p.Y(), ec.Params().N.Bytes())
+	return x.Sign() == 0 && y.Cmp(big.NewInt(1)) == 0
+}
+
+// nonceGenerate derives a nonce from fresh randomness and the secret share, RFC 9591 section 4.1
+func nonceGenerate(ec elliptic.Curve, rand io.Reader, secret *big.Int) (*big.Int, error) {
+	randomBytes, err := common.GetRandomBytes(rand, 32)
+	if err != nil {
+		return nil, err
+	}
+	return h3(ec, randomBytes, serializeScalar(ec, secret)), nil
+}
+
+// identifiers returns the FROST identifiers of the parties, which are their Shamir share ids reduced modulo the order
+func identifiers(ec elliptic.Curve, ks []*big.Int) ([]*big.Int, error) {
+	ids := make([]*big.Int, len(ks))
+	for j, kj := range ks {
+		ids[j] = new(big.Int).Mod(kj, ec.Params().N)
+		if ids[j].Sign() == 0 {
+			return nil, errors.New("a party id is zero modulo the order")
+		}
+	}
+	return ids, nil
+}
+
+// bindingFactors computes the binding factor of every party, RFC 9591 section 4.4. The commitment list is
+// encoded in the order of the identifiers, independently of the order of the parties.
+func bindingFactors(ec elliptic.Curve, pub *crypto.ECPoint, ids []*big.Int, hiding, binding []*crypto.ECPoint, msg []byte) []*big.Int {
+	order := make([]int, len(ids))
+	for j := range order {
+		order[j] = j
+	}
+	sort.Slice(order, func(a, b int) bool { return ids[order[a]].Cmp(ids[order[b]]) < 0 })
+
+	encodedCommitments := make([]byte, 0, len(ids)*3*scalarLen)
+	for _, j := range order {
+		encodedCommitments = append(encodedCommitments, serializeScalar(ec, ids[j])...)
+		encodedCommitments = append(encodedCommitments, serializeElement(hiding[j])...)
+		encodedCommitments = append(encodedCommitments, serializeElement(binding[j])...)
+	}
+	prefix := append(append(serializeElement(pub), h4(msg)...), h5(encodedCommitments)...)
+
+	rhos := make([]*big.Int, len(ids))
+	for j, id := range ids {
+		rhos[j] = h1(ec, prefix, serializeScalar(ec, id))
+	}
+	return rhos
+}
+
+// groupCommitment computes R = sum_j D_j + rho_j*E_j, RFC 9591 section 4.5
+func groupCommitment(ec elliptic.Curve, hiding, binding []*crypto.ECPoint, rhos []*big.Int) (*crypto.ECPoint, error) {
+	var R *crypto.ECPoint
+	for j := range hiding {
+		term, err := hiding[j].Add(binding[j].ScalarMult(rhos[j]))
+		if err != nil {
+			return nil, err
+		}
+		if R == nil {
+			R = term
+			continue
+		}
+		if R, err = R.Add(term); err != nil {
+			return nil, err
+		}
+	}
+	return R, nil
+}
+
+// challenge computes c = H2(R || PK || msg), RFC 9591 section 4.6
+func challenge(ec elliptic.Curve, R, pub *crypto.ECPoint, msg []byte) *big.Int {
+	return h2(ec, serializeElement(R), serializeElement(pub), msg)
+}
+
+// lagrangeCoefficient computes the coefficient of party i at 0 for the given identifiers, RFC 9591 section 4.2
+func lagrangeCoefficient(ec elliptic.Curve, ids []*big.Int, i int) *big.Int {
+	modQ := common.ModInt(ec.Params().N)
+	num, den := big.NewInt(1), big.NewInt(1)
+	for j, id := range ids {
+		if j == i {
+			continue
+		}
+		num = modQ.Mul(num, id)
+		den = modQ.Mul(den, modQ.Sub(id, ids[i]))
+	}
+	return modQ.Mul(num, modQ.ModInverse(den))
+}
+
+func reversed(bz []byte) []byte {
+	out := make([]byte, len(bz))
+	copy(out, bz)
+	reverseBytes(out)
+	return out
+}
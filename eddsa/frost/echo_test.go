@@ -0,0 +1,82 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package frost
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func TestE2EPreprocessAndSignWithEchoBroadcast(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	n := len(signPIDs)
+	outCh := make(chan tss.Message, n*n)
+	newParams := func(pID *tss.PartyID) *tss.Parameters {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, pID, n, testThreshold)
+		params.SetEchoBroadcast(true)
+		return params
+	}
+	countEchoes := func(parties []*LocalParty) ([]*tss.Error, int) {
+		seen := make(chan tss.Message, 4*n*n)
+		errs := runInOrder(t, parties, outCh, seen)
+		close(seen)
+		echoes := 0
+		for msg := range seen {
+			if _, ok := msg.(tss.ParsedMessage).Content().(*tss.EchoMessage); ok {
+				echoes++
+			}
+		}
+		return errs, echoes
+	}
+
+	preParties := make([]*LocalParty, 0, n)
+	noncesChs := make([]chan *SigningNonces, n)
+	for i := 0; i < n; i++ {
+		noncesChs[i] = make(chan *SigningNonces, 1)
+		preParties = append(preParties, NewPreprocessLocalParty(newParams(signPIDs[i]), keys[i], outCh, noncesChs[i]).(*LocalParty))
+	}
+	errs, echoes := countEchoes(preParties)
+	assert.NotZero(t, echoes, "the commitments must be echoed")
+	nonces := make([]*SigningNonces, n)
+	for i := 0; i < n; i++ {
+		if !assert.Nil(t, errs[i]) || !assert.Equal(t, 1, len(noncesChs[i])) {
+			return
+		}
+		nonces[i] = <-noncesChs[i]
+	}
+
+	msg := big.NewInt(42)
+	parties := make([]*LocalParty, 0, n)
+	endCh := make(chan *common.SignatureData, n)
+	for i := 0; i < n; i++ {
+		parties = append(parties, NewLocalParty(msg, newParams(signPIDs[i]), keys[i], nonces[i], newNonceLedger(t), outCh, endCh).(*LocalParty))
+	}
+	errs, echoes = countEchoes(parties)
+	assert.NotZero(t, echoes, "the signature shares must be echoed")
+	for i := 0; i < n; i++ {
+		assert.Nil(t, errs[i])
+	}
+	if !assert.Equal(t, n, len(endCh)) {
+		return
+	}
+	sigData := <-endCh
+	pk := edwards.PublicKey{Curve: tss.Edwards(), X: keys[0].EDDSAPub.X(), Y: keys[0].EDDSAPub.Y()}
+	sig, err := edwards.ParseSignature(sigData.Signature)
+	assert.NoError(t, err)
+	assert.True(t, edwards.Verify(&pk, msg.Bytes(), sig.R, sig.S), "eddsa verify must pass")
+}
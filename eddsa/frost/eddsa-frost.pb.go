@@ -0,0 +1,257 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        v3.14.0
+// source: protob/eddsa-frost.proto
+
+package frost
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+//
+// Represents a BROADCAST message sent to all parties during the preprocessing round of the FROST signing protocol.
+// It carries the party's hiding and binding nonce commitments D_i and E_i.
+type PreprocessMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	HidingX  []byte `protobuf:"bytes,1,opt,name=hiding_x,json=hidingX,proto3" json:"hiding_x,omitempty"`
+	HidingY  []byte `protobuf:"bytes,2,opt,name=hiding_y,json=hidingY,proto3" json:"hiding_y,omitempty"`
+	BindingX []byte `protobuf:"bytes,3,opt,name=binding_x,json=bindingX,proto3" json:"binding_x,omitempty"`
+	BindingY []byte `protobuf:"bytes,4,opt,name=binding_y,json=bindingY,proto3" json:"binding_y,omitempty"`
+}
+
+func (x *PreprocessMessage) Reset() {
+	*x = PreprocessMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_eddsa_frost_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PreprocessMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreprocessMessage) ProtoMessage() {}
+
+func (x *PreprocessMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_eddsa_frost_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreprocessMessage.ProtoReflect.Descriptor instead.
+func (*PreprocessMessage) Descriptor() ([]byte, []int) {
+	return file_protob_eddsa_frost_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PreprocessMessage) GetHidingX() []byte {
+	if x != nil {
+		return x.HidingX
+	}
+	return nil
+}
+
+func (x *PreprocessMessage) GetHidingY() []byte {
+	if x != nil {
+		return x.HidingY
+	}
+	return nil
+}
+
+func (x *PreprocessMessage) GetBindingX() []byte {
+	if x != nil {
+		return x.BindingX
+	}
+	return nil
+}
+
+func (x *PreprocessMessage) GetBindingY() []byte {
+	if x != nil {
+		return x.BindingY
+	}
+	return nil
+}
+
+//
+// Represents a BROADCAST message sent to all parties during the signing round of the FROST signing protocol.
+type SignShareMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NoncesId []byte `protobuf:"bytes,1,opt,name=nonces_id,json=noncesId,proto3" json:"nonces_id,omitempty"`
+	Z        []byte `protobuf:"bytes,2,opt,name=z,proto3" json:"z,omitempty"`
+}
+
+func (x *SignShareMessage) Reset() {
+	*x = SignShareMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_eddsa_frost_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignShareMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignShareMessage) ProtoMessage() {}
+
+func (x *SignShareMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_eddsa_frost_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignShareMessage.ProtoReflect.Descriptor instead.
+func (*SignShareMessage) Descriptor() ([]byte, []int) {
+	return file_protob_eddsa_frost_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SignShareMessage) GetNoncesId() []byte {
+	if x != nil {
+		return x.NoncesId
+	}
+	return nil
+}
+
+func (x *SignShareMessage) GetZ() []byte {
+	if x != nil {
+		return x.Z
+	}
+	return nil
+}
+
+var File_protob_eddsa_frost_proto protoreflect.FileDescriptor
+
+var file_protob_eddsa_frost_proto_rawDesc = []byte{
+	0x0a, 0x18, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x2f, 0x65, 0x64, 0x64, 0x73, 0x61, 0x2d, 0x66,
+	0x72, 0x6f, 0x73, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x1a, 0x62, 0x69, 0x6e, 0x61,
+	0x6e, 0x63, 0x65, 0x2e, 0x74, 0x73, 0x73, 0x6c, 0x69, 0x62, 0x2e, 0x65, 0x64, 0x64, 0x73, 0x61,
+	0x2e, 0x66, 0x72, 0x6f, 0x73, 0x74, 0x22, 0x83, 0x01, 0x0a, 0x11, 0x50, 0x72, 0x65, 0x70, 0x72,
+	0x6f, 0x63, 0x65, 0x73, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x19, 0x0a, 0x08,
+	0x68, 0x69, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07,
+	0x68, 0x69, 0x64, 0x69, 0x6e, 0x67, 0x58, 0x12, 0x19, 0x0a, 0x08, 0x68, 0x69, 0x64, 0x69, 0x6e,
+	0x67, 0x5f, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x68, 0x69, 0x64, 0x69, 0x6e,
+	0x67, 0x59, 0x12, 0x1b, 0x0a, 0x09, 0x62, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x78, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x62, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x58, 0x12,
+	0x1b, 0x0a, 0x09, 0x62, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x79, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x08, 0x62, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x59, 0x22, 0x3d, 0x0a, 0x10,
+	0x53, 0x69, 0x67, 0x6e, 0x53, 0x68, 0x61, 0x72, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x73, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x08, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x73, 0x49, 0x64, 0x12, 0x0c, 0x0a,
+	0x01, 0x7a, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x7a, 0x42, 0x0d, 0x5a, 0x0b, 0x65,
+	0x64, 0x64, 0x73, 0x61, 0x2f, 0x66, 0x72, 0x6f, 0x73, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+}
+
+var (
+	file_protob_eddsa_frost_proto_rawDescOnce sync.Once
+	file_protob_eddsa_frost_proto_rawDescData = file_protob_eddsa_frost_proto_rawDesc
+)
+
+func file_protob_eddsa_frost_proto_rawDescGZIP() []byte {
+	file_protob_eddsa_frost_proto_rawDescOnce.Do(func() {
+		file_protob_eddsa_frost_proto_rawDescData = protoimpl.X.CompressGZIP(file_protob_eddsa_frost_proto_rawDescData)
+	})
+	return file_protob_eddsa_frost_proto_rawDescData
+}
+
+var file_protob_eddsa_frost_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_protob_eddsa_frost_proto_goTypes = []interface{}{
+	(*PreprocessMessage)(nil), // 0: binance.tsslib.eddsa.frost.PreprocessMessage
+	(*SignShareMessage)(nil),  // 1: binance.tsslib.eddsa.frost.SignShareMessage
+}
+var file_protob_eddsa_frost_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_protob_eddsa_frost_proto_init() }
+func file_protob_eddsa_frost_proto_init() {
+	if File_protob_eddsa_frost_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_protob_eddsa_frost_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PreprocessMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_eddsa_frost_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignShareMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_protob_eddsa_frost_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_protob_eddsa_frost_proto_goTypes,
+		DependencyIndexes: file_protob_eddsa_frost_proto_depIdxs,
+		MessageInfos:      file_protob_eddsa_frost_proto_msgTypes,
+	}.Build()
+	File_protob_eddsa_frost_proto = out.File
+	file_protob_eddsa_frost_proto_rawDesc = nil
+	file_protob_eddsa_frost_proto_goTypes = nil
+	file_protob_eddsa_frost_proto_depIdxs = nil
+}
@@ -0,0 +1,199 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package frost
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	eddsakeygen "github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// Implements Party
+// Implements Stringer
+var (
+	_ tss.Party    = (*LocalParty)(nil)
+	_ fmt.Stringer = (*LocalParty)(nil)
+)
+
+type (
+	LocalParty struct {
+		*tss.BaseParty
+		params *tss.Parameters
+
+		keys eddsakeygen.LocalPartySaveData
+		temp localTempData
+		data *common.SignatureData
+
+		// outbound messaging
+		out       chan<- tss.Message
+		end       chan<- *common.SignatureData
+		noncesEnd chan<- *SigningNonces
+	}
+
+	localMessageStore struct {
+		preprocessMessages,
+		signShareMessages []tss.ParsedMessage
+	}
+
+	localTempData struct {
+		localMessageStore
+
+		// preprocessing
+		d,
+		e *big.Int
+		bigD,
+		bigE *crypto.ECPoint
+
+		// signing with preprocessed nonces
+		m            *big.Int
+		nonces       *SigningNonces
+		nonceLedger  tss.NonceLedger
+		ids          []*big.Int
+		rhos         []*big.Int
+		bigR         *crypto.ECPoint
+		c            *big.Int
+		z            *big.Int
+		fullBytesLen int
+	}
+)
+
+// NewPreprocessLocalParty returns a party that runs the FROST preprocessing round with the other parties in `params`,
+// who must all hold a share of `key`. The SigningNonces sent through `end` can be stored and later used to sign one
+// message in a single round with NewLocalParty.
+func NewPreprocessLocalParty(
+	params *tss.Parameters,
+	key eddsakeygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *SigningNonces,
+) tss.Party {
+	p := newLocalParty(nil, params, key, nil, out, nil)
+	p.noncesEnd = end
+	return p
+}
+
+// NewLocalParty returns a party that signs msg in one round using nonces preprocessed earlier by the same set of
+// parties. The ID of the nonces is recorded in the ledger as the party starts, before its signature share is sent,
+// and the party fails with tss.ErrNonceUsed if it was recorded before; the ledger must be durable and must outlive
+// every copy of the nonces. The nonces are wiped as well. The output is a plain Ed25519 signature, in the same format
+// as that of the `signing` package.
+func NewLocalParty(
+	msg *big.Int,
+	params *tss.Parameters,
+	key eddsakeygen.LocalPartySaveData,
+	nonces *SigningNonces,
+	ledger tss.NonceLedger,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+	fullBytesLen ...int,
+) tss.Party {
+	if nonces == nil {
+		panic(errors.New("frost.NewLocalParty requires preprocessed nonces"))
+	}
+	p := newLocalParty(msg, params, key, nonces, out, end, fullBytesLen...)
+	p.temp.nonceLedger = ledger
+	return p
+}
+
+func newLocalParty(
+	msg *big.Int,
+	params *tss.Parameters,
+	key eddsakeygen.LocalPartySaveData,
+	nonces *SigningNonces,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+	fullBytesLen ...int,
+) *LocalParty {
+	partyCount := len(params.Parties().IDs())
+	p := &LocalParty{
+		BaseParty: new(tss.BaseParty),
+		params:    params,
+		keys:      eddsakeygen.BuildLocalSaveDataSubset(key, params.Parties().IDs()),
+		temp:      localTempData{},
+		data:      &common.SignatureData{},
+		out:       out,
+		end:       end,
+	}
+	// msgs init
+	p.temp.preprocessMessages = make([]tss.ParsedMessage, partyCount)
+	p.temp.signShareMessages = make([]tss.ParsedMessage, partyCount)
+	// temp data init
+	p.temp.m = msg
+	p.temp.nonces = nonces
+	if len(fullBytesLen) > 0 {
+		p.temp.fullBytesLen = fullBytesLen[0]
+	}
+	return p
+}
+
+func (p *LocalParty) FirstRound() tss.Round {
+	if p.temp.nonces != nil {
+		return newSignRound(p.params, &p.keys, p.data, &p.temp, p.out, p.end)
+	}
+	return newPreprocessRound(p.params, &p.keys, &p.temp, p.out, p.noncesEnd)
+}
+
+func (p *LocalParty) Start() *tss.Error {
+	return tss.BaseStart(p, TaskName)
+}
+
+func (p *LocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
+	return tss.BaseUpdate(p, msg, TaskName)
+}
+
+func (p *LocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.Update(msg)
+}
+
+func (p *LocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	if ok, err := p.BaseParty.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	// check that the message's "from index" will fit into the array
+	if maxFromIdx := len(p.params.Parties().IDs()) - 1; maxFromIdx < msg.GetFrom().Index {
+		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
+			maxFromIdx, msg.GetFrom().Index), msg.GetFrom())
+	}
+	return true, nil
+}
+
+func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	// ValidateBasic is cheap; double-check the message here in case the public StoreMessage was called externally
+	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	fromPIdx := msg.GetFrom().Index
+
+	// switch/case is necessary to store any messages beyond current round
+	// this does not handle message replays. we expect the caller to apply replay and spoofing protection.
+	switch msg.Content().(type) {
+	case *PreprocessMessage:
+		p.temp.preprocessMessages[fromPIdx] = msg
+	case *SignShareMessage:
+		p.temp.signShareMessages[fromPIdx] = msg
+	default: // unrecognised message, just ignore!
+		common.Logger.Warningf("unrecognised message ignored: %v", msg)
+		return false, nil
+	}
+	return true, nil
+}
+
+func (p *LocalParty) PartyID() *tss.PartyID {
+	return p.params.PartyID()
+}
+
+func (p *LocalParty) String() string {
+	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
+}
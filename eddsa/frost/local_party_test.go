@@ -0,0 +1,223 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package frost
+
+import (
+	"crypto/ed25519"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+
+	// only for test
+	tss.SetCurve(tss.Edwards())
+}
+
+func TestE2EPreprocessAndSign(t *testing.T) {
+	setUp("info")
+
+	// PHASE: load keygen fixtures
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	n := len(signPIDs)
+	outCh := make(chan tss.Message, n*n)
+
+	// PHASE: preprocessing
+	preParties := make([]*LocalParty, 0, n)
+	noncesChs := make([]chan *SigningNonces, n)
+	for i := 0; i < n; i++ {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, signPIDs[i], n, testThreshold)
+		noncesChs[i] = make(chan *SigningNonces, 1)
+		preParties = append(preParties, NewPreprocessLocalParty(params, keys[i], outCh, noncesChs[i]).(*LocalParty))
+	}
+	errs := runInOrder(t, preParties, outCh)
+	nonces := make([]*SigningNonces, n)
+	for i := 0; i < n; i++ {
+		if !assert.Nil(t, errs[i]) || !assert.Equal(t, 1, len(noncesChs[i])) {
+			return
+		}
+		nonces[i] = <-noncesChs[i]
+		assert.True(t, nonces[i].ValidateBasic())
+		assert.Equal(t, nonces[0].ID, nonces[i].ID, "all parties should agree on the nonces id")
+	}
+
+	// nonces are serializable; sign with the decoded copies
+	backups := make([][]byte, n)
+	for i, nonce := range nonces {
+		bz, err := json.Marshal(nonce)
+		assert.NoError(t, err)
+		decoded := new(SigningNonces)
+		assert.NoError(t, json.Unmarshal(bz, decoded))
+		nonces[i], backups[i] = decoded, bz
+	}
+
+	// PHASE: signing
+	msg := big.NewInt(42)
+	parties := make([]*LocalParty, 0, n)
+	endCh := make(chan *common.SignatureData, n)
+	ledgers := make([]tss.NonceLedger, n)
+	for i := 0; i < n; i++ {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, signPIDs[i], n, testThreshold)
+		ledgers[i], err = tss.NewFileNonceLedger(t.TempDir())
+		assert.NoError(t, err)
+		parties = append(parties, NewLocalParty(msg, params, keys[i], nonces[i], ledgers[i], outCh, endCh).(*LocalParty))
+	}
+	errs = runInOrder(t, parties, outCh)
+	for i := 0; i < n; i++ {
+		assert.Nil(t, errs[i])
+	}
+	if !assert.Equal(t, n, len(endCh)) {
+		return
+	}
+	sigData := <-endCh
+
+	pk := edwards.PublicKey{
+		Curve: tss.Edwards(),
+		X:     keys[0].EDDSAPub.X(),
+		Y:     keys[0].EDDSAPub.Y(),
+	}
+	sig, err := edwards.ParseSignature(sigData.Signature)
+	assert.NoError(t, err)
+	assert.True(t, edwards.Verify(&pk, msg.Bytes(), sig.R, sig.S), "eddsa verify must pass")
+
+	// the signature is a plain Ed25519 signature
+	stdPK := ed25519.PublicKey(serializeElement(keys[0].EDDSAPub))
+	assert.True(t, ed25519.Verify(stdPK, msg.Bytes(), sigData.Signature), "ed25519 verify must pass")
+
+	// PHASE: nonces cannot be used twice
+	for _, nonce := range nonces {
+		assert.True(t, nonce.Used())
+		assert.Nil(t, nonce.HidingNonce)
+		assert.Nil(t, nonce.BindingNonce)
+	}
+	params := tss.NewParameters(tss.Edwards(), p2pCtx, signPIDs[0], n, testThreshold)
+	P := NewLocalParty(big.NewInt(43), params, keys[0], nonces[0], ledgers[0], outCh, endCh)
+	tssErr := P.Start()
+	if assert.NotNil(t, tssErr) {
+		assert.ErrorIs(t, tssErr.Cause(), ErrNoncesUsed)
+	}
+
+	// nor can a copy of them restored from a backup, which the ledger refuses
+	restored := new(SigningNonces)
+	assert.NoError(t, json.Unmarshal(backups[0], restored))
+	assert.False(t, restored.Used())
+	P = NewLocalParty(big.NewInt(43), params, keys[0], restored, ledgers[0], outCh, endCh)
+	tssErr = P.Start()
+	if assert.NotNil(t, tssErr) {
+		assert.ErrorIs(t, tssErr.Cause(), tss.ErrNonceUsed)
+	}
+	assert.Equal(t, 0, len(outCh), "no signature share should be sent with used nonces")
+}
+
+func TestIdentifyWrongShare(t *testing.T) {
+	setUp("info")
+	const cheater = 1
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixtures(testThreshold + 1)
+	assert.NoError(t, err, "should load keygen fixtures")
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	n := len(signPIDs)
+	outCh := make(chan tss.Message, n*n)
+
+	preParties := make([]*LocalParty, 0, n)
+	noncesChs := make([]chan *SigningNonces, n)
+	for i := 0; i < n; i++ {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, signPIDs[i], n, testThreshold)
+		noncesChs[i] = make(chan *SigningNonces, 1)
+		preParties = append(preParties, NewPreprocessLocalParty(params, keys[i], outCh, noncesChs[i]).(*LocalParty))
+	}
+	errs := runInOrder(t, preParties, outCh)
+	nonces := make([]*SigningNonces, n)
+	for i := 0; i < n; i++ {
+		if !assert.Nil(t, errs[i]) || !assert.Equal(t, 1, len(noncesChs[i])) {
+			return
+		}
+		nonces[i] = <-noncesChs[i]
+	}
+
+	// the cheater signs with a binding nonce that does not match its commitment E_i
+	nonces[cheater].BindingNonce = new(big.Int).Add(nonces[cheater].BindingNonce, big.NewInt(1))
+	parties := make([]*LocalParty, 0, n)
+	endCh := make(chan *common.SignatureData, n)
+	for i := 0; i < n; i++ {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, signPIDs[i], n, testThreshold)
+		parties = append(parties, NewLocalParty(big.NewInt(42), params, keys[i], nonces[i], newNonceLedger(t), outCh, endCh).(*LocalParty))
+	}
+	errs = runInOrder(t, parties, outCh)
+	assert.Equal(t, 0, len(endCh), "no party should output a signature")
+	for j, err := range errs {
+		if !assert.NotNilf(t, err, "party %d should abort", j) {
+			continue
+		}
+		assert.Equal(t, 2, err.Round(), err.Error())
+		if assert.Equal(t, 1, len(err.Culprits()), err.Error()) {
+			assert.Equal(t, signPIDs[cheater], err.Culprits()[0])
+		}
+	}
+}
+
+// ----- //
+
+// runInOrder delivers the messages of the parties one at a time, and copies them to seen if given, and returns the
+// error of each party
+func runInOrder(t *testing.T, parties []*LocalParty, outCh chan tss.Message, seen ...chan<- tss.Message) []*tss.Error {
+	n := len(parties)
+	errs := make([]*tss.Error, n)
+	errCh := make(chan *tss.Error, n*n)
+	for _, P := range parties {
+		if err := P.Start(); err != nil {
+			errs[P.PartyID().Index] = err
+		}
+	}
+	for 0 < len(outCh) {
+		msg := <-outCh
+		for _, ch := range seen {
+			ch <- msg
+		}
+		for _, P := range parties {
+			if P.PartyID().Index == msg.GetFrom().Index {
+				continue
+			}
+			if (msg.IsBroadcast() || msg.GetTo()[0].Index == P.PartyID().Index) && errs[P.PartyID().Index] == nil {
+				test.SharedPartyUpdater(P, msg, errCh)
+			}
+			select {
+			case err := <-errCh:
+				errs[P.PartyID().Index] = err
+			default:
+			}
+		}
+	}
+	return errs
+}
+
+// newNonceLedger returns a ledger of its own for a party
+func newNonceLedger(t *testing.T) tss.NonceLedger {
+	ledger, err := tss.NewFileNonceLedger(t.TempDir())
+	assert.NoError(t, err)
+	return ledger
+}
@@ -0,0 +1,98 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package frost
+
+import (
+	"crypto/elliptic"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// These messages were generated from Protocol Buffers definitions into eddsa-frost.pb.go
+// The following messages are registered on the Protocol Buffers "wire"
+
+var (
+	// Ensure that frost messages implement ValidateBasic
+	_ = []tss.MessageContent{
+		(*PreprocessMessage)(nil),
+		(*SignShareMessage)(nil),
+	}
+)
+
+// ----- //
+
+func NewPreprocessMessage(
+	from *tss.PartyID,
+	hiding, binding *crypto.ECPoint,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &PreprocessMessage{
+		HidingX:  hiding.X().Bytes(),
+		HidingY:  hiding.Y().Bytes(),
+		BindingX: binding.X().Bytes(),
+		BindingY: binding.Y().Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *PreprocessMessage) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetHidingX()) &&
+		common.NonEmptyBytes(m.GetHidingY()) &&
+		common.NonEmptyBytes(m.GetBindingX()) &&
+		common.NonEmptyBytes(m.GetBindingY())
+}
+
+func (m *PreprocessMessage) UnmarshalHiding(ec elliptic.Curve) (*crypto.ECPoint, error) {
+	return crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(m.GetHidingX()),
+		new(big.Int).SetBytes(m.GetHidingY()))
+}
+
+func (m *PreprocessMessage) UnmarshalBinding(ec elliptic.Curve) (*crypto.ECPoint, error) {
+	return crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(m.GetBindingX()),
+		new(big.Int).SetBytes(m.GetBindingY()))
+}
+
+// ----- //
+
+func NewSignShareMessage(
+	from *tss.PartyID,
+	noncesID []byte,
+	z *big.Int,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &SignShareMessage{
+		NoncesId: noncesID,
+		Z:        z.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *SignShareMessage) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetNoncesId()) &&
+		common.NonEmptyBytes(m.GetZ())
+}
+
+func (m *SignShareMessage) UnmarshalZ() *big.Int {
+	return new(big.Int).SetBytes(m.GetZ())
+}
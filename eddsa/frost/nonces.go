@@ -0,0 +1,77 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package frost
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+)
+
+var ErrNoncesUsed = errors.New("signing nonces have already been used")
+
+type (
+	// SigningNonces is the output of a FROST preprocessing session (see NewPreprocessLocalParty): the commitments
+	// (D_j, E_j) of every party and this party's own nonces (d_i, e_i). The nonces are secret and must only ever be
+	// used to sign ONE message; NewLocalParty records the ID in a tss.NonceLedger before it signs, which refuses any
+	// copy of used nonces, and wipes them.
+	SigningNonces struct {
+		// ID is shared by all of the parties that took part in the same preprocessing session
+		ID       []byte
+		Ks       []*big.Int
+		EDDSAPub *crypto.ECPoint
+		// HidingCommitments[j] = d_j*G and BindingCommitments[j] = e_j*G
+		HidingCommitments  []*crypto.ECPoint
+		BindingCommitments []*crypto.ECPoint
+		HidingNonce        *big.Int
+		BindingNonce       *big.Int
+
+		mtx sync.Mutex
+	}
+)
+
+// ValidateBasic checks that the nonces are complete
+func (n *SigningNonces) ValidateBasic() bool {
+	if n == nil ||
+		len(n.ID) == 0 ||
+		len(n.Ks) == 0 ||
+		n.EDDSAPub == nil ||
+		len(n.HidingCommitments) != len(n.Ks) ||
+		len(n.BindingCommitments) != len(n.Ks) ||
+		n.HidingNonce == nil || n.HidingNonce.Sign() <= 0 ||
+		n.BindingNonce == nil || n.BindingNonce.Sign() <= 0 {
+		return false
+	}
+	for j := range n.Ks {
+		if n.HidingCommitments[j] == nil || !n.HidingCommitments[j].ValidateBasic() ||
+			n.BindingCommitments[j] == nil || !n.BindingCommitments[j].ValidateBasic() {
+			return false
+		}
+	}
+	return true
+}
+
+// Used returns true once the nonces have been handed to a signing round
+func (n *SigningNonces) Used() bool {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	return n.HidingNonce == nil
+}
+
+// consume returns the secret nonces and wipes them from the record so that they cannot be used again
+func (n *SigningNonces) consume() (d, e *big.Int, err error) {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	if n.HidingNonce == nil || n.BindingNonce == nil {
+		return nil, nil, ErrNoncesUsed
+	}
+	d, e = n.HidingNonce, n.BindingNonce
+	n.HidingNonce, n.BindingNonce = nil, nil
+	return d, e, nil
+}
@@ -0,0 +1,144 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package frost
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	eddsakeygen "github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// the preprocessing round samples the nonces (d_i, e_i) and broadcasts their commitments (D_i, E_i), RFC 9591 section 5.1
+func newPreprocessRound(params *tss.Parameters, key *eddsakeygen.LocalPartySaveData, temp *localTempData, out chan<- tss.Message, end chan<- *SigningNonces) tss.Round {
+	return &preprocessRound{
+		&base{params, key, nil, temp, out, nil, end, make([]bool, len(params.Parties().IDs())), false, 1},
+	}
+}
+
+func (round *preprocessRound) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	ec := round.EC()
+	d, err := nonceGenerate(ec, round.Rand(), round.key.Xi)
+	if err != nil {
+		return round.WrapError(err)
+	}
+	e, err := nonceGenerate(ec, round.Rand(), round.key.Xi)
+	if err != nil {
+		return round.WrapError(err)
+	}
+	round.temp.d, round.temp.e = d, e
+	round.temp.bigD = crypto.ScalarBaseMult(ec, d)
+	round.temp.bigE = crypto.ScalarBaseMult(ec, e)
+
+	i := round.PartyID().Index
+	round.ok[i] = true
+
+	msg := NewPreprocessMessage(round.PartyID(), round.temp.bigD, round.temp.bigE)
+	round.temp.preprocessMessages[i] = msg
+	round.out <- tss.WithRound(msg, round.number)
+	return nil
+}
+
+func (round *preprocessRound) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.preprocessMessages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *preprocessRound) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*PreprocessMessage); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *preprocessRound) NextRound() tss.Round {
+	round.started = false
+	return &preprocessOutput{round}
+}
+
+// ----- //
+
+func (round *preprocessOutput) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	ec := round.EC()
+	Ps := round.Parties().IDs()
+	hiding := make([]*crypto.ECPoint, len(Ps))
+	binding := make([]*crypto.ECPoint, len(Ps))
+	culprits := make([]*tss.PartyID, 0, len(Ps))
+	for j, Pj := range Ps {
+		round.ok[j] = true
+		r1msg := round.temp.preprocessMessages[j].Content().(*PreprocessMessage)
+		Dj, err1 := r1msg.UnmarshalHiding(ec)
+		Ej, err2 := r1msg.UnmarshalBinding(ec)
+		if err1 != nil || err2 != nil || !isValidElement(ec, Dj) || !isValidElement(ec, Ej) {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		hiding[j], binding[j] = Dj, Ej
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(errors.New("invalid nonce commitments"), culprits...)
+	}
+
+	// every party derives the same id from the public data of the session
+	idList := []*big.Int{round.key.EDDSAPub.X(), round.key.EDDSAPub.Y()}
+	idList = append(idList, round.key.Ks...)
+	for j := range Ps {
+		idList = append(idList, hiding[j].X(), hiding[j].Y(), binding[j].X(), binding[j].Y())
+	}
+	round.noncesEnd <- &SigningNonces{
+		ID:                 common.SHA512_256i(idList...).Bytes(),
+		Ks:                 round.key.Ks,
+		EDDSAPub:           round.key.EDDSAPub,
+		HidingCommitments:  hiding,
+		BindingCommitments: binding,
+		HidingNonce:        round.temp.d,
+		BindingNonce:       round.temp.e,
+	}
+	round.temp.d, round.temp.e = nil, nil
+	return nil
+}
+
+func (round *preprocessOutput) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *preprocessOutput) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *preprocessOutput) NextRound() tss.Round {
+	return nil // finished!
+}
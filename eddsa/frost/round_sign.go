@@ -0,0 +1,227 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package frost
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	eddsakeygen "github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// the sign round computes the share z_i = d_i + e_i*rho_i + lambda_i*x_i*c with preprocessed nonces, RFC 9591 section 5.2
+func newSignRound(params *tss.Parameters, key *eddsakeygen.LocalPartySaveData, data *common.SignatureData, temp *localTempData, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Round {
+	return &signRound{
+		&base{params, key, data, temp, out, end, nil, make([]bool, len(params.Parties().IDs())), false, 1},
+	}
+}
+
+func (round *signRound) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	if round.temp.m == nil {
+		return round.WrapError(errors.New("message is not valid"))
+	}
+	nonces := round.temp.nonces
+	if nonces.Used() {
+		return round.WrapError(ErrNoncesUsed)
+	}
+	if !nonces.ValidateBasic() {
+		return round.WrapError(errors.New("signing nonces are invalid"))
+	}
+	if len(nonces.Ks) != len(round.key.Ks) {
+		return round.WrapError(fmt.Errorf("signing nonces were made by %d parties but %d are signing", len(nonces.Ks), len(round.key.Ks)))
+	}
+	for j, kj := range round.key.Ks {
+		if kj.Cmp(nonces.Ks[j]) != 0 {
+			return round.WrapError(errors.New("signing nonces were made by a different set of parties"))
+		}
+	}
+	if !nonces.EDDSAPub.Equals(round.key.EDDSAPub) {
+		return round.WrapError(errors.New("signing nonces were made for a different key"))
+	}
+	// the ID is recorded before z_i is computed, so that no copy of the nonces can sign another message
+	if round.temp.nonceLedger == nil {
+		return round.WrapError(errors.New("a nonce ledger is required to sign with preprocessed nonces"))
+	}
+	if err := round.temp.nonceLedger.Use(nonces.ID); err != nil {
+		return round.WrapError(err)
+	}
+	d, e, err := nonces.consume()
+	if err != nil {
+		return round.WrapError(err)
+	}
+
+	ec := round.EC()
+	ids, err := identifiers(ec, round.key.Ks)
+	if err != nil {
+		return round.WrapError(err)
+	}
+	if round.temp.fullBytesLen == 0 {
+		round.data.M = round.temp.m.Bytes()
+	} else {
+		var mBytes = make([]byte, round.temp.fullBytesLen)
+		round.temp.m.FillBytes(mBytes)
+		round.data.M = mBytes
+	}
+	rhos := bindingFactors(ec, round.key.EDDSAPub, ids, nonces.HidingCommitments, nonces.BindingCommitments, round.data.M)
+	bigR, err := groupCommitment(ec, nonces.HidingCommitments, nonces.BindingCommitments, rhos)
+	if err != nil {
+		return round.WrapError(err)
+	}
+	c := challenge(ec, bigR, round.key.EDDSAPub, round.data.M)
+
+	i := round.PartyID().Index
+	modQ := common.ModInt(ec.Params().N)
+	lambda := lagrangeCoefficient(ec, ids, i)
+	z := modQ.Add(modQ.Add(d, modQ.Mul(e, rhos[i])), modQ.Mul(modQ.Mul(lambda, round.key.Xi), c))
+
+	round.temp.ids = ids
+	round.temp.rhos = rhos
+	round.temp.bigR = bigR
+	round.temp.c = c
+	round.temp.z = z
+	round.ok[i] = true
+
+	msg := NewSignShareMessage(round.PartyID(), nonces.ID, z)
+	round.temp.signShareMessages[i] = msg
+	round.out <- tss.WithRound(msg, round.number)
+	return nil
+}
+
+func (round *signRound) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.signShareMessages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *signRound) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignShareMessage); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *signRound) NextRound() tss.Round {
+	round.started = false
+	return &signFinalization{round}
+}
+
+// ----- //
+
+// the finalization sums the shares into z and outputs the Ed25519 signature (R, z), RFC 9591 section 5.3
+func (round *signFinalization) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	Ps := round.Parties().IDs()
+	q := round.EC().Params().N
+	modQ := common.ModInt(q)
+	zs := make([]*big.Int, len(Ps))
+	sumZ := new(big.Int)
+	for j, Pj := range Ps {
+		round.ok[j] = true
+		r1msg := round.temp.signShareMessages[j].Content().(*SignShareMessage)
+		if !bytes.Equal(r1msg.GetNoncesId(), round.temp.nonces.ID) {
+			return round.WrapError(errors.New("party signed with different nonces"), Pj)
+		}
+		zs[j] = r1msg.UnmarshalZ()
+		sumZ = modQ.Add(sumZ, zs[j])
+	}
+
+	if err := round.finishSignature(sumZ); err != nil {
+		// z_j*G = D_j + rho_j*E_j + c*lambda_j*Y_j holds for the share of every honest party
+		culprits := make([]*tss.PartyID, 0, len(Ps))
+		for j, Pj := range Ps {
+			if !round.shareIsValid(j, zs[j]) {
+				culprits = append(culprits, Pj)
+			}
+		}
+		return round.WrapError(err, culprits...)
+	}
+	return nil
+}
+
+func (round *signFinalization) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *signFinalization) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *signFinalization) NextRound() tss.Round {
+	return nil // finished!
+}
+
+// shareIsValid checks the share of party j; it is computed on raw coordinates as z_j is chosen by party j
+func (round *signFinalization) shareIsValid(j int, zj *big.Int) bool {
+	ec, nonces := round.EC(), round.temp.nonces
+	q := ec.Params().N
+	if zj.Cmp(q) >= 0 {
+		return false
+	}
+	lx, ly := ec.ScalarBaseMult(zj.Bytes())
+	ex, ey := ec.ScalarMult(nonces.BindingCommitments[j].X(), nonces.BindingCommitments[j].Y(), round.temp.rhos[j].Bytes())
+	rx, ry := ec.Add(nonces.HidingCommitments[j].X(), nonces.HidingCommitments[j].Y(), ex, ey)
+	cLambda := common.ModInt(q).Mul(round.temp.c, lagrangeCoefficient(ec, round.temp.ids, j))
+	yx, yy := ec.ScalarMult(round.key.BigXj[j].X(), round.key.BigXj[j].Y(), cLambda.Bytes())
+	rx, ry = ec.Add(rx, ry, yx, yy)
+	return lx.Cmp(rx) == 0 && ly.Cmp(ry) == 0
+}
+
+// finishSignature assembles the signature data, verifies it against the public key and sends it out
+func (round *signFinalization) finishSignature(sumZ *big.Int) error {
+	ec := round.EC()
+	encodedR := serializeElement(round.temp.bigR)
+	encodedZ := serializeScalar(ec, sumZ)
+
+	// save the signature for final output, in the same format as the signing package
+	r := new(big.Int).SetBytes(reversed(encodedR))
+	round.data.Signature = append(encodedR, encodedZ...)
+	round.data.R = r.Bytes()
+	round.data.S = sumZ.Bytes()
+
+	pk := edwards.PublicKey{
+		Curve: ec,
+		X:     round.key.EDDSAPub.X(),
+		Y:     round.key.EDDSAPub.Y(),
+	}
+	if ok := edwards.Verify(&pk, round.data.M, r, sumZ); !ok {
+		return errors.New("signature verification failed")
+	}
+
+	round.end <- round.data
+
+	return nil
+}
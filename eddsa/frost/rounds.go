@@ -0,0 +1,109 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package frost
+
+import (
+	"github.com/bnb-chain/tss-lib/v2/common"
+	eddsakeygen "github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	TaskName = "eddsa-frost"
+)
+
+type (
+	base struct {
+		*tss.Parameters
+		key       *eddsakeygen.LocalPartySaveData
+		data      *common.SignatureData
+		temp      *localTempData
+		out       chan<- tss.Message
+		end       chan<- *common.SignatureData
+		noncesEnd chan<- *SigningNonces
+		ok        []bool // `ok` tracks parties which have been verified by Update()
+		started   bool
+		number    int
+	}
+
+	// preprocessing
+	preprocessRound struct {
+		*base
+	}
+	preprocessOutput struct {
+		*preprocessRound
+	}
+
+	// online signing with preprocessed nonces
+	signRound struct {
+		*base
+	}
+	signFinalization struct {
+		*signRound
+	}
+)
+
+var (
+	_ tss.Round = (*preprocessRound)(nil)
+	_ tss.Round = (*preprocessOutput)(nil)
+	_ tss.Round = (*signRound)(nil)
+	_ tss.Round = (*signFinalization)(nil)
+)
+
+// ----- //
+
+func (round *base) Params() *tss.Parameters {
+	return round.Parameters
+}
+
+func (round *base) RoundNumber() int {
+	return round.number
+}
+
+// Out is used by the echo broadcast of the party
+func (round *base) Out() chan<- tss.Message {
+	return round.out
+}
+
+// CanProceed is inherited by other rounds
+func (round *base) CanProceed() bool {
+	if !round.started {
+		return false
+	}
+	for _, ok := range round.ok {
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// WaitingFor is called by a Party for reporting back to the caller
+func (round *base) WaitingFor() []*tss.PartyID {
+	Ps := round.Parties().IDs()
+	ids := make([]*tss.PartyID, 0, len(round.ok))
+	for j, ok := range round.ok {
+		if ok {
+			continue
+		}
+		ids = append(ids, Ps[j])
+	}
+	return ids
+}
+
+func (round *base) WrapError(err error, culprits ...*tss.PartyID) *tss.Error {
+	return tss.NewError(err, TaskName, round.number, round.PartyID(), culprits...)
+}
+
+// ----- //
+
+// `ok` tracks parties which have been verified by Update()
+func (round *base) resetOK() {
+	for j := range round.ok {
+		round.ok[j] = false
+	}
+}
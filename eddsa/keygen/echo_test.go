@@ -0,0 +1,183 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	cmt "github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// newEchoingParties creates parties that run a consistency round after each round with broadcast messages
+func newEchoingParties(outCh chan tss.Message, endCh chan *LocalPartySaveData) []*LocalParty {
+	pIDs := tss.GenerateTestPartyIDs(testParticipants)
+	p2pCtx := tss.NewPeerContext(pIDs)
+	parties := make([]*LocalParty, len(pIDs))
+	for i, pID := range pIDs {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, pID, len(pIDs), testThreshold)
+		params.SetEchoBroadcast(true)
+		parties[i] = NewLocalParty(params, outCh, endCh).(*LocalParty)
+	}
+	return parties
+}
+
+func TestE2EConcurrentWithEchoBroadcast(t *testing.T) {
+	setUp("info")
+
+	errCh := make(chan *tss.Error, testParticipants)
+	outCh := make(chan tss.Message, testParticipants)
+	endCh := make(chan *LocalPartySaveData, testParticipants)
+	parties := newEchoingParties(outCh, endCh)
+	for _, P := range parties {
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	echoes := 0
+	saves := make([]*LocalPartySaveData, 0, len(parties))
+	for len(saves) < len(parties) {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+		case msg := <-outCh:
+			if _, ok := msg.(tss.ParsedMessage).Content().(*tss.EchoMessage); ok {
+				echoes++
+			}
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index != msg.GetFrom().Index {
+						go test.SharedPartyUpdater(P, msg, errCh)
+					}
+				}
+				continue
+			}
+			go test.SharedPartyUpdater(parties[dest[0].Index], msg, errCh)
+		case save := <-endCh:
+			saves = append(saves, save)
+		}
+	}
+	// rounds 1 and 2 have broadcast messages
+	assert.Equal(t, 2*len(parties), echoes)
+	for _, save := range saves {
+		assert.True(t, saves[0].EDDSAPub.Equals(save.EDDSAPub), "all parties must agree on the public key")
+	}
+}
+
+// the echoes are not signed, so the honest parties report the equivocated broadcast of the sender but name nobody
+func TestEquivocatingBroadcastIsReported(t *testing.T) {
+	setUp("info")
+
+	errCh := make(chan *tss.Error, testParticipants)
+	outCh := make(chan tss.Message, testParticipants)
+	endCh := make(chan *LocalPartySaveData, testParticipants)
+	parties := newEchoingParties(outCh, endCh)
+	for _, P := range parties {
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	cheater, victim := parties[0].PartyID(), parties[1].PartyID()
+	for {
+		select {
+		case err := <-errCh:
+			var mismatch *tss.BroadcastMismatch
+			if assert.True(t, errors.As(err, &mismatch), err.Error()) {
+				assert.Equal(t, cheater, mismatch.Sender)
+				assert.Equal(t, 1, mismatch.Round)
+			}
+			assert.Empty(t, err.Culprits())
+			return
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest != nil {
+				go test.SharedPartyUpdater(parties[dest[0].Index], msg, errCh)
+				continue
+			}
+			for _, P := range parties {
+				if P.PartyID().Index == msg.GetFrom().Index {
+					continue
+				}
+				// the cheater gives the victim a different commitment than the others
+				if _, ok := msg.(tss.ParsedMessage).Content().(*KGRound1Message); ok && msg.GetFrom() == cheater && P.PartyID() == victim {
+					forged := NewKGRound1Message(cheater, cmt.NewHashCommitment(rand.Reader, big.NewInt(1)).C)
+					go test.SharedPartyUpdater(P, forged, errCh)
+					continue
+				}
+				go test.SharedPartyUpdater(P, msg, errCh)
+			}
+		case <-endCh:
+			assert.FailNow(t, "keygen must not finish with an equivocating party")
+		}
+	}
+}
+
+func TestLyingEchoerCannotFrameSender(t *testing.T) {
+	setUp("info")
+
+	errCh := make(chan *tss.Error, testParticipants*testParticipants)
+	outCh := make(chan tss.Message, testParticipants)
+	endCh := make(chan *LocalPartySaveData, testParticipants)
+	parties := newEchoingParties(outCh, endCh)
+	for _, P := range parties {
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	liar := parties[0].PartyID()
+	for {
+		select {
+		case err := <-errCh:
+			var mismatch *tss.BroadcastMismatch
+			if assert.True(t, errors.As(err, &mismatch), err.Error()) {
+				assert.NotEqual(t, liar, err.Victim())
+				assert.Empty(t, err.Culprits(), "an unsigned echo must not name the sender, nor an echoer")
+			}
+			return
+		case msg := <-outCh:
+			// the liar claims to have received a different broadcast from each sender
+			if echo, ok := msg.(tss.ParsedMessage).Content().(*tss.EchoMessage); ok && msg.GetFrom() == liar {
+				lie := &tss.EchoMessage{Round: echo.GetRound()}
+				for _, d := range echo.GetDigests() {
+					hash := append([]byte{}, d.GetHash()...)
+					hash[0] ^= 1
+					lie.Digests = append(lie.Digests, &tss.EchoMessage_Digest{PartyKey: d.GetPartyKey(), Hash: hash})
+				}
+				routing := tss.MessageRouting{From: liar, IsBroadcast: true}
+				msg = tss.NewMessage(routing, lie, tss.NewMessageWrapper(routing, lie))
+			}
+			dest := msg.GetTo()
+			if dest != nil {
+				go test.SharedPartyUpdater(parties[dest[0].Index], msg, errCh)
+				continue
+			}
+			for _, P := range parties {
+				if P.PartyID().Index != msg.GetFrom().Index {
+					go test.SharedPartyUpdater(P, msg, errCh)
+				}
+			}
+		case <-endCh:
+			assert.FailNow(t, "keygen must not finish with a lying echoer")
+		}
+	}
+}
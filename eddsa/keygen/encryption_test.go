@@ -0,0 +1,173 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// newEncryptingParties creates parties whose point-to-point messages are encrypted to each other
+func newEncryptingParties(t *testing.T, outCh chan tss.Message, endCh chan *LocalPartySaveData) []*LocalParty {
+	pIDs := tss.GenerateTestPartyIDs(testParticipants)
+	keys := make([]*tss.DecryptionKey, len(pIDs))
+	for i, pID := range pIDs {
+		var err error
+		keys[i], err = tss.GenerateDecryptionKey(rand.Reader)
+		assert.NoError(t, err)
+		pID.EncryptionKey = keys[i].PublicKey()
+	}
+	p2pCtx := tss.NewPeerContext(pIDs)
+	parties := make([]*LocalParty, len(pIDs))
+	for i, pID := range pIDs {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, pID, len(pIDs), testThreshold)
+		params.SetDecryptionKey(keys[i])
+		parties[i] = NewLocalParty(params, outCh, endCh).(*LocalParty)
+	}
+	return parties
+}
+
+func TestE2EConcurrentWithEncryptedP2P(t *testing.T) {
+	setUp("info")
+
+	errCh := make(chan *tss.Error, testParticipants)
+	outCh := make(chan tss.Message, testParticipants)
+	endCh := make(chan *LocalPartySaveData, testParticipants)
+	parties := newEncryptingParties(t, outCh, endCh)
+	for _, P := range parties {
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	saves := make([]*LocalPartySaveData, 0, len(parties))
+	for len(saves) < len(parties) {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index != msg.GetFrom().Index {
+						go test.SharedPartyUpdater(P, msg, errCh)
+					}
+				}
+				continue
+			}
+			_, isShare := msg.(tss.ParsedMessage).Content().(*KGRound2Message1)
+			assert.True(t, isShare)
+			assert.True(t, msg.WireMsg().GetMessage().MessageIs((*tss.EncryptedMessage)(nil)), "the share must be encrypted on the wire")
+			go test.SharedPartyUpdater(parties[dest[0].Index], msg, errCh)
+		case save := <-endCh:
+			saves = append(saves, save)
+		}
+	}
+	for _, save := range saves {
+		assert.True(t, saves[0].EDDSAPub.Equals(save.EDDSAPub), "all parties must agree on the public key")
+	}
+}
+
+func TestTamperedP2PMessageNamesOnlyAuthenticatedSender(t *testing.T) {
+	setUp("info")
+
+	parties := newEncryptingParties(t, make(chan tss.Message, testParticipants), make(chan *LocalPartySaveData, testParticipants))
+	from, to := parties[0].PartyID(), parties[1].PartyID()
+	share := &vss.Share{Threshold: testThreshold, ID: to.KeyInt(), Share: big.NewInt(42)}
+	encrypt := func() tss.ParsedMessage {
+		msg, err := tss.EncryptMessage(parties[0].params, NewKGRound2Message1(to, from, share))
+		assert.NoError(t, err)
+		return msg
+	}
+	bz, _, err := encrypt().WireBytes()
+	assert.NoError(t, err)
+
+	tampered := new(tss.EncryptedMessage)
+	wire := encrypt().WireMsg().GetMessage()
+	assert.NoError(t, wire.UnmarshalTo(tampered))
+	tampered.Ciphertext[0] ^= 1
+	assert.NoError(t, wire.MarshalFrom(tampered))
+	tamperedBz, err := proto.Marshal(wire)
+	assert.NoError(t, err)
+
+	// the same share sent in the clear
+	clear := NewKGRound2Message1(&tss.PartyID{MessageWrapper_PartyID: to.MessageWrapper_PartyID, Index: to.Index}, from, share)
+	clearBz, _, err := clear.WireBytes()
+	assert.NoError(t, err)
+
+	// anyone can send these in the name of the sender, so they are dropped
+	ok, tssErr := parties[1].UpdateFromBytes(tamperedBz, from, false)
+	assert.False(t, ok, "a tampered ciphertext must be dropped")
+	assert.Nil(t, tssErr, "an unauthenticated sender must not be blamed")
+	ok, tssErr = parties[2].UpdateFromBytes(bz, from, false)
+	assert.False(t, ok, "a message encrypted to another party must be dropped")
+	assert.Nil(t, tssErr)
+	ok, tssErr = parties[1].UpdateFromBytes(clearBz, from, false)
+	assert.False(t, ok, "a point-to-point message in the clear must be dropped")
+	assert.Nil(t, tssErr)
+
+	_, tssErr = parties[1].UpdateFromBytes(bz, from, false)
+	assert.Nil(t, tssErr, "the untampered share must be accepted")
+
+	// with authenticated messages, as through an envelope.Guard, the sender is the culprit
+	tss.SetAuthenticated(parties[2])
+	_, tssErr = parties[2].UpdateFromBytes(tamperedBz, from, false)
+	if assert.NotNil(t, tssErr, "a tampered ciphertext must be rejected") {
+		assert.Equal(t, []*tss.PartyID{from}, tssErr.Culprits())
+	}
+	tss.SetAuthenticated(parties[3])
+	_, tssErr = parties[3].UpdateFromBytes(clearBz, from, false)
+	if assert.NotNil(t, tssErr, "a point-to-point message in the clear must be rejected") {
+		assert.Equal(t, []*tss.PartyID{from}, tssErr.Culprits())
+	}
+}
+
+func TestEncryptionFailureFailsRound(t *testing.T) {
+	setUp("info")
+
+	errCh := make(chan *tss.Error, testParticipants)
+	outCh := make(chan tss.Message, testParticipants)
+	parties := newEncryptingParties(t, outCh, make(chan *LocalPartySaveData, testParticipants))
+	// a low-order point, which X25519 refuses to encrypt to
+	broken := parties[1].PartyID()
+	broken.EncryptionKey = make([]byte, 32)
+	for _, P := range parties {
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	for {
+		select {
+		case err := <-errCh:
+			assert.Equal(t, 2, err.Round())
+			assert.Contains(t, err.Error(), "failed to encrypt")
+			return
+		case msg := <-outCh:
+			for _, P := range parties {
+				if P.PartyID().Index != msg.GetFrom().Index {
+					go test.SharedPartyUpdater(P, msg, errCh)
+				}
+			}
+		case <-time.After(time.Minute):
+			assert.FailNow(t, "a party must fail to start round 2")
+		}
+	}
+}
@@ -82,7 +82,7 @@ func (round *round1) Start() *tss.Error {
 	{
 		msg := NewKGRound1Message(round.PartyID(), cmt.C)
 		round.temp.kgRound1Messages[i] = msg
-		round.out <- msg
+		round.out <- tss.WithRound(msg, round.number)
 	}
 	return nil
 }
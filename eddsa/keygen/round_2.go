@@ -42,7 +42,11 @@ func (round *round2) Start() *tss.Error {
 			continue
 		}
 		round.temp.kgRound2Message1s[i] = r2msg1
-		round.out <- r2msg1
+		enc, err := tss.EncryptMessage(round.Params(), r2msg1)
+		if err != nil {
+			return round.WrapError(err)
+		}
+		round.out <- tss.WithRound(enc, round.number)
 	}
 
 	// 5. compute Schnorr prove
@@ -55,7 +59,7 @@ func (round *round2) Start() *tss.Error {
 	// 5. BROADCAST de-commitments of Shamir poly*G and Schnorr prove
 	r2msg2 := NewKGRound2Message2(round.PartyID(), round.temp.deCommitPolyG, pii)
 	round.temp.kgRound2Message2s[i] = r2msg2
-	round.out <- r2msg2
+	round.out <- tss.WithRound(r2msg2, round.number)
 
 	return nil
 }
@@ -0,0 +1,55 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func TestMigrateJSONSaveData(t *testing.T) {
+	keys, _, err := LoadKeygenTestFixtures(1)
+	assert.NoError(t, err, "should load keygen fixtures")
+	legacy, err := ioutil.ReadFile(makeTestFixtureFilePath(0))
+	assert.NoError(t, err)
+
+	bz, err := MigrateJSONSaveData(legacy, tss.Edwards())
+	assert.NoError(t, err)
+	migrated, err := UnmarshalLocalPartySaveData(bz)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, keys[0].Xi.Cmp(migrated.Xi))
+	assert.Equal(t, 0, keys[0].ShareID.Cmp(migrated.ShareID))
+	assert.True(t, keys[0].EDDSAPub.Equals(migrated.EDDSAPub))
+	assert.True(t, tss.SameCurve(tss.Edwards(), migrated.EDDSAPub.Curve()))
+	for j := range keys[0].Ks {
+		assert.Equal(t, 0, keys[0].Ks[j].Cmp(migrated.Ks[j]))
+		assert.True(t, keys[0].BigXj[j].Equals(migrated.BigXj[j]))
+	}
+
+	// the public part can be read on its own from the full encoding
+	pb := new(SaveData)
+	assert.NoError(t, proto.Unmarshal(bz, pb))
+	assert.Equal(t, string(tss.Ed25519), pb.GetPublic().GetEddsaPub().GetCurve())
+	public, err := keys[0].ToPublicProto()
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(public, pb.GetPublic()))
+
+	// points written without a curve name are taken to be on the given curve
+	unnamed := []byte(strings.ReplaceAll(string(legacy), `"Curve": "ed25519",`, ""))
+	assert.NotEqual(t, legacy, unnamed)
+	migrated, err = ParseLegacyJSONSaveData(unnamed, tss.Edwards())
+	assert.NoError(t, err)
+	assert.True(t, keys[0].EDDSAPub.Equals(migrated.EDDSAPub))
+	_, err = MigrateJSONSaveData(unnamed, tss.S256())
+	assert.Error(t, err, "the points are not on secp256k1")
+}
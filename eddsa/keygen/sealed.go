@@ -0,0 +1,75 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto/sealed"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	// SealedProtocol identifies EdDSA save data in a sealed container
+	SealedProtocol = "eddsa-keygen"
+	// SealedProtocolVersion is the version of the JSON encoding of LocalPartySaveData in a sealed container
+	SealedProtocolVersion = 1
+)
+
+// Seal encrypts the save data for storage at rest with a passphrase or wrapping key (see crypto/sealed). The threshold
+// is not part of the save data and is recorded in the header along with the curve and party count.
+func (save LocalPartySaveData) Seal(threshold int, key sealed.Key) ([]byte, error) {
+	if save.EDDSAPub == nil || save.Xi == nil {
+		return nil, errors.New("Seal: the save data is incomplete")
+	}
+	curveName, ok := tss.GetCurveName(save.EDDSAPub.Curve())
+	if !ok {
+		return nil, errors.New("Seal: the curve of the save data is not registered")
+	}
+	if threshold < 1 || threshold >= len(save.Ks) {
+		return nil, fmt.Errorf("Seal: invalid threshold %d for %d parties", threshold, len(save.Ks))
+	}
+	plaintext, err := json.Marshal(&save)
+	if err != nil {
+		return nil, err
+	}
+	header := sealed.Header{
+		Protocol:        SealedProtocol,
+		ProtocolVersion: SealedProtocolVersion,
+		Curve:           curveName,
+		Threshold:       threshold,
+		PartyCount:      len(save.Ks),
+	}
+	return sealed.Seal(header, plaintext, key)
+}
+
+// OpenLocalPartySaveData decrypts save data sealed by LocalPartySaveData.Seal, returning it with the container header
+func OpenLocalPartySaveData(container []byte, key sealed.Key) (*LocalPartySaveData, *sealed.Header, error) {
+	header, plaintext, err := sealed.Open(container, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	if header.Protocol != SealedProtocol {
+		return nil, nil, fmt.Errorf("OpenLocalPartySaveData: the container holds %q, not %q", header.Protocol, SealedProtocol)
+	}
+	if header.ProtocolVersion != SealedProtocolVersion {
+		return nil, nil, fmt.Errorf("OpenLocalPartySaveData: unsupported version %d", header.ProtocolVersion)
+	}
+	save := new(LocalPartySaveData)
+	if err = json.Unmarshal(plaintext, save); err != nil {
+		return nil, nil, err
+	}
+	if save.EDDSAPub == nil || len(save.Ks) != header.PartyCount {
+		return nil, nil, errors.New("OpenLocalPartySaveData: the save data does not match the header")
+	}
+	if curveName, ok := tss.GetCurveName(save.EDDSAPub.Curve()); !ok || curveName != header.Curve {
+		return nil, nil, errors.New("OpenLocalPartySaveData: the curve of the save data does not match the header")
+	}
+	return save, header, nil
+}
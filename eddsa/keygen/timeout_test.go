@@ -0,0 +1,121 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func TestRoundTimeoutNamesSilentParty(t *testing.T) {
+	setUp("info")
+
+	pIDs := tss.GenerateTestPartyIDs(testParticipants)
+	p2pCtx := tss.NewPeerContext(pIDs)
+	errCh := make(chan *tss.Error, len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs))
+	endCh := make(chan *LocalPartySaveData, len(pIDs))
+	parties := make([]*LocalParty, len(pIDs))
+	for i, pID := range pIDs {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, pID, len(pIDs), testThreshold)
+		params.SetRoundTimeout(time.Second)
+		parties[i] = NewLocalParty(params, outCh, endCh).(*LocalParty)
+	}
+	// the last party never starts
+	silent := parties[len(parties)-1]
+	for _, P := range parties[:len(parties)-1] {
+		assert.Nil(t, P.Start())
+	}
+
+	go func() {
+		for msg := range outCh {
+			for _, P := range parties {
+				if P != silent && P.PartyID().Index != msg.GetFrom().Index {
+					go test.SharedPartyUpdater(P, msg, errCh)
+				}
+			}
+		}
+	}()
+
+	for _, P := range parties[:len(parties)-1] {
+		select {
+		case <-P.Done():
+		case <-time.After(10 * time.Second):
+			assert.FailNow(t, "the party must be aborted by its round timeout")
+		}
+		err := P.Err()
+		if assert.NotNil(t, err) {
+			assert.True(t, errors.Is(err, tss.ErrRoundTimeout))
+			assert.Equal(t, []*tss.PartyID{silent.PartyID()}, err.Culprits())
+			assert.Equal(t, TaskName, err.Task())
+			assert.Equal(t, 1, err.Round())
+		}
+		assert.False(t, P.Running())
+	}
+}
+
+func TestStartWithContextAbortsOnCancel(t *testing.T) {
+	setUp("info")
+
+	pIDs := tss.GenerateTestPartyIDs(testParticipants)
+	p2pCtx := tss.NewPeerContext(pIDs)
+	outCh := make(chan tss.Message, len(pIDs))
+	params := tss.NewParameters(tss.Edwards(), p2pCtx, pIDs[0], len(pIDs), testThreshold)
+	P := NewLocalParty(params, outCh, make(chan *LocalPartySaveData, 1)).(*LocalParty)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	assert.Nil(t, tss.StartWithContext(ctx, P))
+	<-outCh // the round 1 broadcast
+	cancel()
+	<-P.Done()
+
+	err := P.Err()
+	if assert.NotNil(t, err) {
+		assert.True(t, errors.Is(err, context.Canceled))
+		assert.ElementsMatch(t, pIDs[1:], err.Culprits(), "no messages were received, so every other party stalled")
+	}
+
+	// an aborted party rejects further messages with the same error
+	other := NewLocalParty(tss.NewParameters(tss.Edwards(), p2pCtx, pIDs[1], len(pIDs), testThreshold), outCh, nil).(*LocalParty)
+	assert.Nil(t, other.Start())
+	msg := <-outCh
+	_, updateErr := P.Update(msg.(tss.ParsedMessage))
+	assert.Equal(t, err, updateErr)
+
+	expired, stop := context.WithCancel(context.Background())
+	stop()
+	late := NewLocalParty(tss.NewParameters(tss.Edwards(), p2pCtx, pIDs[2], len(pIDs), testThreshold), outCh, nil).(*LocalParty)
+	assert.NotNil(t, tss.StartWithContext(expired, late))
+	assert.False(t, late.Running())
+}
+
+func TestFailedStartIsNotReportedAsTimeout(t *testing.T) {
+	setUp("info")
+
+	pIDs := tss.GenerateTestPartyIDs(testParticipants)
+	params := tss.NewParameters(tss.Edwards(), tss.NewPeerContext(pIDs), pIDs[0], len(pIDs), 0)
+	params.SetRoundTimeout(50 * time.Millisecond)
+	P := NewLocalParty(params, make(chan tss.Message, len(pIDs)), nil).(*LocalParty)
+
+	// a threshold of 0 fails the VSS of round 1
+	startErr := P.Start()
+	if assert.NotNil(t, startErr) {
+		<-P.Done()
+		assert.Equal(t, startErr, P.Err())
+		// the timer of round 1 was stopped
+		time.Sleep(200 * time.Millisecond)
+		assert.Equal(t, startErr, P.Err())
+		assert.False(t, errors.Is(P.Err(), tss.ErrRoundTimeout))
+	}
+}
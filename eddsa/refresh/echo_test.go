@@ -0,0 +1,71 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package refresh_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	. "github.com/bnb-chain/tss-lib/v2/eddsa/refresh"
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func TestE2EConcurrentWithEchoBroadcast(t *testing.T) {
+	setUp("info")
+
+	oldKeys, pIDs, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	p2pCtx := tss.NewPeerContext(pIDs)
+
+	parties := make([]*LocalParty, 0, len(pIDs))
+	errCh := make(chan *tss.Error, len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs))
+	endCh := make(chan *keygen.LocalPartySaveData, len(pIDs))
+	for j, pID := range pIDs {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, pID, len(pIDs), testThreshold)
+		params.SetEchoBroadcast(true)
+		P := NewLocalParty(params, oldKeys[j], outCh, endCh).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	echoes := 0
+	newKeys := make([]*keygen.LocalPartySaveData, 0, len(pIDs))
+	for len(newKeys) < len(pIDs) {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+		case msg := <-outCh:
+			if _, ok := msg.(tss.ParsedMessage).Content().(*tss.EchoMessage); ok {
+				echoes++
+			}
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index != msg.GetFrom().Index {
+						go test.SharedPartyUpdater(P, msg, errCh)
+					}
+				}
+				continue
+			}
+			go test.SharedPartyUpdater(parties[dest[0].Index], msg, errCh)
+		case save := <-endCh:
+			newKeys = append(newKeys, save)
+		}
+	}
+	assert.NotZero(t, echoes, "the broadcasts must be echoed")
+	for _, save := range newKeys {
+		assert.True(t, oldKeys[0].EDDSAPub.Equals(save.EDDSAPub), "the refresh must keep the public key")
+	}
+}
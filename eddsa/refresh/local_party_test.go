@@ -0,0 +1,168 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package refresh_test
+
+import (
+	"math/big"
+	"sync/atomic"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	. "github.com/bnb-chain/tss-lib/v2/eddsa/refresh"
+	"github.com/bnb-chain/tss-lib/v2/eddsa/signing"
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+func TestE2EConcurrent(t *testing.T) {
+	setUp("info")
+
+	// PHASE: load keygen fixtures
+	oldKeys, pIDs, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	p2pCtx := tss.NewPeerContext(pIDs)
+
+	// PHASE: refresh
+	parties := make([]*LocalParty, 0, len(pIDs))
+	errCh := make(chan *tss.Error, len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs))
+	endCh := make(chan *keygen.LocalPartySaveData, len(pIDs))
+
+	updater := test.SharedPartyUpdater
+
+	for j, pID := range pIDs {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, pID, len(pIDs), testThreshold)
+		P := NewLocalParty(params, oldKeys[j], outCh, endCh).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	newKeys := make([]keygen.LocalPartySaveData, len(pIDs))
+	var ended int32
+refresh:
+	for {
+		select {
+		case err := <-errCh:
+			common.Logger.Errorf("Error: %s", err)
+			assert.FailNow(t, err.Error())
+			return
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else {
+				if dest[0].Index == msg.GetFrom().Index {
+					t.Fatalf("party %d tried to send a message to itself (%d)", dest[0].Index, msg.GetFrom().Index)
+				}
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case save := <-endCh:
+			index, err := save.OriginalIndex()
+			assert.NoErrorf(t, err, "should not be an error getting a party's index from save data")
+			newKeys[index] = *save
+			if atomic.AddInt32(&ended, 1) == int32(len(pIDs)) {
+				t.Logf("Refresh done. Refreshed %d participants", ended)
+				break refresh
+			}
+		}
+	}
+
+	for j, key := range newKeys {
+		assert.True(t, key.EDDSAPub.Equals(oldKeys[j].EDDSAPub), "the public key must not change")
+		assert.NotEqual(t, 0, key.Xi.Cmp(oldKeys[j].Xi), "the share must change")
+		for _, other := range newKeys {
+			assert.True(t, other.BigXj[j].Equals(key.BigXj[j]), "all parties must agree on BigX_j")
+		}
+		// xj test: BigXj == xj*G
+		assert.True(t, key.BigXj[j].Equals(crypto.ScalarBaseMult(tss.Edwards(), key.Xi)), "ensure BigX_j == g^x_j")
+	}
+
+	// PHASE: signing with the refreshed shares
+	signKeys, signPIDs := newKeys[:testThreshold+1], tss.SortPartyIDs(tss.UnSortedPartyIDs(pIDs[:testThreshold+1]))
+	signP2pCtx := tss.NewPeerContext(signPIDs)
+	signParties := make([]*signing.LocalParty, 0, len(signPIDs))
+
+	signErrCh := make(chan *tss.Error, len(signPIDs))
+	signOutCh := make(chan tss.Message, len(signPIDs))
+	signEndCh := make(chan *common.SignatureData, len(signPIDs))
+
+	for j, signPID := range signPIDs {
+		params := tss.NewParameters(tss.Edwards(), signP2pCtx, signPID, len(signPIDs), testThreshold)
+		P := signing.NewLocalParty(big.NewInt(42), params, signKeys[j], signOutCh, signEndCh).(*signing.LocalParty)
+		signParties = append(signParties, P)
+		go func(P *signing.LocalParty) {
+			if err := P.Start(); err != nil {
+				signErrCh <- err
+			}
+		}(P)
+	}
+
+	var signEnded int32
+	for {
+		select {
+		case err := <-signErrCh:
+			common.Logger.Errorf("Error: %s", err)
+			assert.FailNow(t, err.Error())
+			return
+
+		case msg := <-signOutCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range signParties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, signErrCh)
+				}
+			} else {
+				go updater(signParties[dest[0].Index], msg, signErrCh)
+			}
+
+		case signData := <-signEndCh:
+			if atomic.AddInt32(&signEnded, 1) == int32(len(signPIDs)) {
+				pk := edwards.PublicKey{
+					Curve: tss.Edwards(),
+					X:     oldKeys[0].EDDSAPub.X(),
+					Y:     oldKeys[0].EDDSAPub.Y(),
+				}
+				sig, err := edwards.ParseSignature(signData.Signature)
+				assert.NoError(t, err, "should parse the signature")
+				ok := edwards.Verify(&pk, big.NewInt(42).Bytes(), sig.R, sig.S)
+				assert.True(t, ok, "eddsa verify must pass")
+				return
+			}
+		}
+	}
+}
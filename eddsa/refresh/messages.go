@@ -0,0 +1,149 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package refresh
+
+import (
+	"crypto/elliptic"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	cmt "github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/crypto/schnorr"
+	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// These messages were generated from Protocol Buffers definitions into eddsa-refresh.pb.go
+// The following messages are registered on the Protocol Buffers "wire"
+
+var (
+	// Ensure that refresh messages implement ValidateBasic
+	_ = []tss.MessageContent{
+		(*RefreshRound1Message)(nil),
+		(*RefreshRound2Message1)(nil),
+		(*RefreshRound2Message2)(nil),
+		(*RefreshRound3Message)(nil),
+	}
+)
+
+// ----- //
+
+func NewRefreshRound1Message(from *tss.PartyID, ct cmt.HashCommitment) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &RefreshRound1Message{
+		Commitment: ct.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *RefreshRound1Message) ValidateBasic() bool {
+	return m != nil && common.NonEmptyBytes(m.GetCommitment())
+}
+
+func (m *RefreshRound1Message) UnmarshalCommitment() *big.Int {
+	return new(big.Int).SetBytes(m.GetCommitment())
+}
+
+// ----- //
+
+func NewRefreshRound2Message1(
+	to, from *tss.PartyID,
+	share *vss.Share,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	content := &RefreshRound2Message1{
+		Share: share.Share.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *RefreshRound2Message1) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetShare())
+}
+
+func (m *RefreshRound2Message1) UnmarshalShare() *big.Int {
+	return new(big.Int).SetBytes(m.Share)
+}
+
+// ----- //
+
+func NewRefreshRound2Message2(
+	from *tss.PartyID,
+	deCommitment cmt.HashDeCommitment,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	dcBzs := common.BigIntsToBytes(deCommitment)
+	content := &RefreshRound2Message2{
+		DeCommitment: dcBzs,
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *RefreshRound2Message2) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyMultiBytes(m.GetDeCommitment())
+}
+
+func (m *RefreshRound2Message2) UnmarshalDeCommitment() []*big.Int {
+	deComBzs := m.GetDeCommitment()
+	return cmt.NewHashDeCommitmentFromBytes(deComBzs)
+}
+
+// ----- //
+
+func NewRefreshRound3Message(
+	from *tss.PartyID,
+	proof *schnorr.ZKProof,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &RefreshRound3Message{
+		ProofAlphaX: proof.Alpha.X().Bytes(),
+		ProofAlphaY: proof.Alpha.Y().Bytes(),
+		ProofT:      proof.T.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *RefreshRound3Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetProofAlphaX()) &&
+		common.NonEmptyBytes(m.GetProofAlphaY()) &&
+		common.NonEmptyBytes(m.GetProofT())
+}
+
+func (m *RefreshRound3Message) UnmarshalZKProof(ec elliptic.Curve) (*schnorr.ZKProof, error) {
+	point, err := crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(m.GetProofAlphaX()),
+		new(big.Int).SetBytes(m.GetProofAlphaY()))
+	if err != nil {
+		return nil, err
+	}
+	return &schnorr.ZKProof{
+		Alpha: point,
+		T:     new(big.Int).SetBytes(m.GetProofT()),
+	}, nil
+}
@@ -0,0 +1,90 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package resharing_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	. "github.com/bnb-chain/tss-lib/v2/eddsa/resharing"
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func TestE2EConcurrentWithEchoBroadcast(t *testing.T) {
+	setUp("info")
+
+	oldKeys, oldPIDs, err := keygen.LoadKeygenTestFixtures(testThreshold+1, 0)
+	assert.NoError(t, err, "should load keygen fixtures")
+	oldP2PCtx := tss.NewPeerContext(oldPIDs)
+	newPIDs := tss.GenerateTestPartyIDs(testParticipants)
+	newP2PCtx := tss.NewPeerContext(newPIDs)
+
+	errCh := make(chan *tss.Error, len(oldPIDs)+len(newPIDs))
+	outCh := make(chan tss.Message, len(oldPIDs)+len(newPIDs))
+	endCh := make(chan *keygen.LocalPartySaveData, len(oldPIDs)+len(newPIDs))
+
+	newParams := func(pID *tss.PartyID) *tss.ReSharingParameters {
+		params := tss.NewReSharingParameters(tss.Edwards(), oldP2PCtx, newP2PCtx, pID, testParticipants, testThreshold, len(newPIDs), testThreshold)
+		params.SetEchoBroadcast(true)
+		return params
+	}
+	oldCommittee := make([]*LocalParty, 0, len(oldPIDs))
+	for j, pID := range oldPIDs {
+		oldCommittee = append(oldCommittee, NewLocalParty(newParams(pID), oldKeys[j], outCh, endCh).(*LocalParty))
+	}
+	newCommittee := make([]*LocalParty, 0, len(newPIDs))
+	for _, pID := range newPIDs {
+		newCommittee = append(newCommittee, NewLocalParty(newParams(pID), keygen.NewLocalPartySaveData(len(newPIDs)), outCh, endCh).(*LocalParty))
+	}
+	for _, P := range append(newCommittee, oldCommittee...) {
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	echoes := 0
+	newKeys := make([]*keygen.LocalPartySaveData, 0, len(newCommittee))
+	for ended := 0; ended < len(oldCommittee)+len(newCommittee); {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+		case msg := <-outCh:
+			if _, ok := msg.(tss.ParsedMessage).Content().(*tss.EchoMessage); ok {
+				echoes++
+			}
+			dest := msg.GetTo()
+			if msg.IsToOldCommittee() || msg.IsToOldAndNewCommittees() {
+				for _, destP := range dest[:len(oldCommittee)] {
+					go test.SharedPartyUpdater(oldCommittee[destP.Index], msg, errCh)
+				}
+			}
+			if !msg.IsToOldCommittee() || msg.IsToOldAndNewCommittees() {
+				for _, destP := range dest {
+					go test.SharedPartyUpdater(newCommittee[destP.Index], msg, errCh)
+				}
+			}
+		case save := <-endCh:
+			if save.Xi != nil {
+				newKeys = append(newKeys, save)
+			}
+			ended++
+		}
+	}
+	assert.NotZero(t, echoes, "the parties must have exchanged echoes")
+	assert.Len(t, newKeys, len(newCommittee))
+	for _, key := range newKeys {
+		index, err := key.OriginalIndex()
+		assert.NoError(t, err)
+		assert.True(t, key.BigXj[index].Equals(crypto.ScalarBaseMult(tss.Edwards(), key.Xi)), "ensure BigX_j == g^x_j")
+	}
+}
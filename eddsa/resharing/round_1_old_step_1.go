@@ -72,7 +72,7 @@ func (round *round1) Start() *tss.Error {
 		round.NewParties().IDs().Exclude(round.PartyID()), round.PartyID(),
 		round.input.EDDSAPub, vCmt.C)
 	round.temp.dgRound1Messages[i] = r1msg
-	round.out <- r1msg
+	round.out <- tss.WithRound(r1msg, round.number)
 
 	return nil
 }
@@ -127,7 +127,7 @@ func (round *round4) Start() *tss.Error {
 	// 21. Send an "ACK" message to both committees to signal that we're ready to save our data
 	r4msg := NewDGRound4Message(round.OldAndNewParties(), Pi)
 	round.temp.dgRound4Messages[i] = r4msg
-	round.out <- r4msg
+	round.out <- tss.WithRound(r4msg, round.number)
 
 	return nil
 }
@@ -0,0 +1,104 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package resharing_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	. "github.com/bnb-chain/tss-lib/v2/eddsa/resharing"
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// round 3 must record the shares of every old party that sent them, and not only those before the first party
+// missing, so that a timeout of the new committee names the silent old party alone
+func TestRoundTimeoutNamesOnlySilentOldParty(t *testing.T) {
+	setUp("info")
+
+	oldKeys, oldPIDs, err := keygen.LoadKeygenTestFixtures(testThreshold+1, 0)
+	assert.NoError(t, err, "should load keygen fixtures")
+	oldP2PCtx := tss.NewPeerContext(oldPIDs)
+	newPIDs := tss.GenerateTestPartyIDs(testParticipants)
+	newP2PCtx := tss.NewPeerContext(newPIDs)
+
+	count := len(oldPIDs) + len(newPIDs)
+	errCh := make(chan *tss.Error, count*count)
+	outCh := make(chan tss.Message, count*count)
+	endCh := make(chan *keygen.LocalPartySaveData, count)
+
+	newParams := func(pID *tss.PartyID) *tss.ReSharingParameters {
+		params := tss.NewReSharingParameters(tss.Edwards(), oldP2PCtx, newP2PCtx, pID, testParticipants, testThreshold, len(newPIDs), testThreshold)
+		params.SetRoundTimeout(5 * time.Second)
+		return params
+	}
+	oldCommittee := make([]*LocalParty, 0, len(oldPIDs))
+	for j, pID := range oldPIDs {
+		oldCommittee = append(oldCommittee, NewLocalParty(newParams(pID), oldKeys[j], outCh, endCh).(*LocalParty))
+	}
+	newCommittee := make([]*LocalParty, 0, len(newPIDs))
+	for _, pID := range newPIDs {
+		newCommittee = append(newCommittee, NewLocalParty(newParams(pID), keygen.NewLocalPartySaveData(len(newPIDs)), outCh, endCh).(*LocalParty))
+	}
+	// the round 3 messages of the first old party are never delivered
+	silent := oldCommittee[0]
+	for _, P := range append(newCommittee, oldCommittee...) {
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+	go func() {
+		for msg := range outCh {
+			switch msg.(tss.ParsedMessage).Content().(type) {
+			case *DGRound3Message1, *DGRound3Message2:
+				if msg.GetFrom() == silent.PartyID() {
+					continue
+				}
+			}
+			dest := msg.GetTo()
+			if msg.IsToOldCommittee() || msg.IsToOldAndNewCommittees() {
+				for _, destP := range dest[:len(oldCommittee)] {
+					go test.SharedPartyUpdater(oldCommittee[destP.Index], msg, errCh)
+				}
+			}
+			if !msg.IsToOldCommittee() || msg.IsToOldAndNewCommittees() {
+				for _, destP := range dest {
+					go test.SharedPartyUpdater(newCommittee[destP.Index], msg, errCh)
+				}
+			}
+		}
+	}()
+
+	// the later updates of an aborted party report its timeout again
+	for _, P := range newCommittee {
+	wait:
+		for {
+			select {
+			case <-P.Done():
+				break wait
+			case err := <-errCh:
+				if !errors.Is(err, tss.ErrRoundTimeout) {
+					assert.FailNow(t, err.Error())
+				}
+			case <-time.After(time.Minute):
+				assert.FailNow(t, "the party must be aborted by its round timeout")
+			}
+		}
+		err := P.Err()
+		if assert.NotNil(t, err) {
+			assert.True(t, errors.Is(err, tss.ErrRoundTimeout))
+			assert.Equal(t, 3, err.Round())
+			assert.Equal(t, []*tss.PartyID{silent.PartyID()}, err.Culprits())
+		}
+	}
+}
@@ -47,6 +47,7 @@ type (
 		localMessageStore
 
 		// temp data (thrown away after sign) / round 1
+		keyDerivationDelta,
 		wi,
 		m,
 		ri *big.Int
@@ -73,6 +74,20 @@ func NewLocalParty(
 	out chan<- tss.Message,
 	end chan<- *common.SignatureData,
 	fullBytesLen ...int,
+) tss.Party {
+	return NewLocalPartyWithKDD(msg, params, key, nil, out, end, fullBytesLen...)
+}
+
+// NewLocalPartyWithKDD returns a party with key derivation delta for HD support. The key is the parent key; the
+// delta is applied to its Xi, BigXj and EDDSAPub before signing, so the signature verifies under the child public key.
+func NewLocalPartyWithKDD(
+	msg *big.Int,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	keyDerivationDelta *big.Int,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+	fullBytesLen ...int,
 ) tss.Party {
 	partyCount := len(params.Parties().IDs())
 	p := &LocalParty{
@@ -90,6 +105,7 @@ func NewLocalParty(
 	p.temp.signRound3Messages = make([]tss.ParsedMessage, partyCount)
 
 	// temp data init
+	p.temp.keyDerivationDelta = keyDerivationDelta
 	p.temp.m = msg
 	if len(fullBytesLen) > 0 {
 		p.temp.fullBytesLen = fullBytesLen[0]
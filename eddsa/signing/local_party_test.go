@@ -7,6 +7,7 @@
 package signing
 
 import (
+	"crypto/rand"
 	"encoding/hex"
 	"fmt"
 	"math/big"
@@ -19,6 +20,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto/ckd"
 	"github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
 	"github.com/bnb-chain/tss-lib/v2/test"
 	"github.com/bnb-chain/tss-lib/v2/tss"
@@ -248,3 +250,100 @@ signing:
 		}
 	}
 }
+
+func TestE2EConcurrentWithHDKDD(t *testing.T) {
+	setUp("info")
+
+	threshold := testThreshold
+
+	// PHASE: load keygen fixtures
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	// PHASE: derive the child key m/0/1/2 from the public key alone
+	chainCode, err := common.GetRandomBytes(rand.Reader, 32)
+	assert.NoError(t, err)
+	root := &ckd.ExtendedKey{
+		PublicKey: *keys[0].EDDSAPub.ToECDSAPubKey(),
+		ChainCode: chainCode,
+		ParentFP:  []byte{0x00, 0x00, 0x00, 0x00},
+	}
+	keyDerivationDelta, child, err := ckd.DeriveChildKeyFromHierarchy([]uint32{0, 1, 2}, root, tss.Edwards().Params().N, tss.Edwards())
+	assert.NoError(t, err)
+
+	// PHASE: signing
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]*LocalParty, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+
+	updater := test.SharedPartyUpdater
+
+	msg := big.NewInt(200)
+	// init the parties
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+
+		P := NewLocalPartyWithKDD(msg, params, keys[i], keyDerivationDelta, outCh, endCh).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	var ended int32
+signing:
+	for {
+		select {
+		case err := <-errCh:
+			common.Logger.Errorf("Error: %s", err)
+			assert.FailNow(t, err.Error())
+			break signing
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else {
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case <-endCh:
+			atomic.AddInt32(&ended, 1)
+			if atomic.LoadInt32(&ended) == int32(len(signPIDs)) {
+				t.Logf("Done. Received signature data from %d participants", ended)
+
+				// BEGIN EDDSA verify with the child public key
+				pk := edwards.PublicKey{
+					Curve: tss.Edwards(),
+					X:     child.X,
+					Y:     child.Y,
+				}
+				newSig, err := edwards.ParseSignature(parties[0].data.Signature)
+				assert.NoError(t, err)
+				ok := edwards.Verify(&pk, msg.Bytes(), newSig.R, newSig.S)
+				assert.True(t, ok, "eddsa verify must pass with the child key")
+
+				parentPk := edwards.PublicKey{
+					Curve: tss.Edwards(),
+					X:     keys[0].EDDSAPub.X(),
+					Y:     keys[0].EDDSAPub.Y(),
+				}
+				assert.False(t, edwards.Verify(&parentPk, msg.Bytes(), newSig.R, newSig.S), "the parent key must not verify")
+				// END EDDSA verify
+
+				break signing
+			}
+		}
+	}
+}
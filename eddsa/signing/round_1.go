@@ -58,7 +58,7 @@ func (round *round1) Start() *tss.Error {
 	// 4. broadcast commitment
 	r1msg2 := NewSignRound1Message(round.PartyID(), cmt.C)
 	round.temp.signRound1Messages[i] = r1msg2
-	round.out <- r1msg2
+	round.out <- tss.WithRound(r1msg2, round.number)
 
 	return nil
 }
@@ -99,6 +99,17 @@ func (round *round1) prepare() error {
 	xi := round.key.Xi
 	ks := round.key.Ks
 
+	if round.temp.keyDerivationDelta != nil {
+		// adding the key derivation delta to the xi's
+		// Suppose x has shamir shares x_0,     x_1,     ..., x_n
+		// So x + D has shamir shares  x_0 + D, x_1 + D, ..., x_n + D
+		// and the public key and public shares all move by D*G
+		if err := round.applyKeyDerivationDelta(round.temp.keyDerivationDelta); err != nil {
+			return err
+		}
+		xi = round.key.Xi
+	}
+
 	if round.Threshold()+1 > len(ks) {
 		return fmt.Errorf("t+1=%d is not satisfied by the key count of %d", round.Threshold()+1, len(ks))
 	}
@@ -107,3 +118,22 @@ func (round *round1) prepare() error {
 	round.temp.wi = wi
 	return nil
 }
+
+func (round *round1) applyKeyDerivationDelta(delta *big.Int) error {
+	ec := round.Params().EC()
+	mod := common.ModInt(ec.Params().N)
+	round.key.Xi = mod.Add(delta, round.key.Xi)
+
+	gDelta := crypto.ScalarBaseMult(ec, delta)
+	pub, err := round.key.EDDSAPub.Add(gDelta)
+	if err != nil {
+		return fmt.Errorf("failed to apply the key derivation delta to the public key: %v", err)
+	}
+	round.key.EDDSAPub = pub
+	for j, bigXj := range round.key.BigXj {
+		if round.key.BigXj[j], err = bigXj.Add(gDelta); err != nil {
+			return fmt.Errorf("failed to apply the key derivation delta to BigXj: %v", err)
+		}
+	}
+	return nil
+}
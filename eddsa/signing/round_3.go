@@ -104,7 +104,7 @@ func (round *round3) Start() *tss.Error {
 	// 10. broadcast si to other parties
 	r3msg := NewSignRound3Message(round.PartyID(), encodedBytesToBigInt(&localS))
 	round.temp.signRound3Messages[round.PartyID().Index] = r3msg
-	round.out <- r3msg
+	round.out <- tss.WithRound(r3msg, round.number)
 
 	return nil
 }
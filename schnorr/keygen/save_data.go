@@ -0,0 +1,88 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"encoding/hex"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+type (
+	LocalSecrets struct {
+		// secret fields (not shared, but stored locally)
+		Xi, ShareID *big.Int // xi, kj
+	}
+
+	// Everything in LocalPartySaveData is saved locally to user's HD when done
+	LocalPartySaveData struct {
+		LocalSecrets
+
+		// original indexes (ki in signing preparation phase)
+		Ks []*big.Int
+
+		// public keys (Xj = uj*G for each Pj)
+		BigXj []*crypto.ECPoint // Xj
+
+		// the BIP-340 public key; its Y is always even (see NormalizeEvenY)
+		SchnorrPub *crypto.ECPoint // y
+	}
+)
+
+func NewLocalPartySaveData(partyCount int) (saveData LocalPartySaveData) {
+	saveData.Ks = make([]*big.Int, partyCount)
+	saveData.BigXj = make([]*crypto.ECPoint, partyCount)
+	return
+}
+
+// BuildLocalSaveDataSubset re-creates the LocalPartySaveData to contain data for only the list of signing parties.
+func BuildLocalSaveDataSubset(sourceData LocalPartySaveData, sortedIDs tss.SortedPartyIDs) LocalPartySaveData {
+	keysToIndices := make(map[string]int, len(sourceData.Ks))
+	for j, kj := range sourceData.Ks {
+		keysToIndices[hex.EncodeToString(kj.Bytes())] = j
+	}
+	newData := NewLocalPartySaveData(sortedIDs.Len())
+	newData.LocalSecrets = sourceData.LocalSecrets
+	newData.SchnorrPub = sourceData.SchnorrPub
+	for j, id := range sortedIDs {
+		savedIdx, ok := keysToIndices[hex.EncodeToString(id.Key)]
+		if !ok {
+			panic("BuildLocalSaveDataSubset: unable to find a signer party in the local save data")
+		}
+		newData.Ks[j] = sourceData.Ks[savedIdx]
+		newData.BigXj[j] = sourceData.BigXj[savedIdx]
+	}
+	return newData
+}
+
+// NormalizeEvenY negates the key when its public key has an odd Y, so that it is the public key of BIP-340 with its
+// implicit even Y. Every share is negated with it, so all of the parties must normalize the same key.
+func NormalizeEvenY(save *LocalPartySaveData) error {
+	if save.SchnorrPub.Y().Bit(0) == 0 {
+		return nil
+	}
+	ec := save.SchnorrPub.Curve()
+	var err error
+	if save.SchnorrPub, err = negatePoint(save.SchnorrPub); err != nil {
+		return err
+	}
+	for j, Xj := range save.BigXj {
+		if save.BigXj[j], err = negatePoint(Xj); err != nil {
+			return err
+		}
+	}
+	if save.Xi != nil {
+		save.Xi = new(big.Int).Mod(new(big.Int).Neg(save.Xi), ec.Params().N)
+	}
+	return nil
+}
+
+func negatePoint(p *crypto.ECPoint) (*crypto.ECPoint, error) {
+	return crypto.NewECPoint(p.Curve(), p.X(), new(big.Int).Sub(p.Curve().Params().P, p.Y()))
+}
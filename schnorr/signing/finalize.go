@@ -0,0 +1,106 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func (round *finalization) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 4
+	round.started = true
+	round.resetOK()
+
+	Ps := round.Parties().IDs()
+	q := round.Params().EC().Params().N
+	modQ := common.ModInt(q)
+	sjs := make([]*big.Int, len(Ps))
+	sumS := new(big.Int)
+	for j := range Ps {
+		round.ok[j] = true
+		r3msg := round.temp.signRound3Messages[j].Content().(*SignRound3Message)
+		sjs[j] = r3msg.UnmarshalS()
+		sumS = modQ.Add(sumS, sjs[j])
+	}
+
+	// save the signature for final output: bytes(R) || bytes(s)
+	round.data.R = bytes32(round.temp.bigR.X())
+	round.data.S = bytes32(sumS)
+	round.data.Signature = append(append([]byte{}, round.data.R...), round.data.S...)
+	round.data.M = bytes32(round.temp.m)
+
+	if !round.verify() {
+		// s_j*G = ±R_j + e*w_j*G holds for the share of every honest party
+		culprits := make([]*tss.PartyID, 0, len(Ps))
+		for j, Pj := range Ps {
+			if !round.shareIsValid(j, sjs[j]) {
+				culprits = append(culprits, Pj)
+			}
+		}
+		return round.WrapError(errors.New("signature verification failed"), culprits...)
+	}
+	round.end <- round.data
+
+	return nil
+}
+
+func (round *finalization) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *finalization) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *finalization) NextRound() tss.Round {
+	return nil // finished!
+}
+
+// verify checks the signature against the x-only public key as a BIP-340 verifier does
+func (round *finalization) verify() bool {
+	pk, err := schnorr.ParsePubKey(bytes32(round.key.SchnorrPub.X()))
+	if err != nil {
+		return false
+	}
+	sig, err := schnorr.ParseSignature(round.data.Signature)
+	if err != nil {
+		return false
+	}
+	return sig.Verify(round.data.M, pk)
+}
+
+// shareIsValid checks the share of party j; it is computed on raw coordinates as s_j is chosen by party j
+func (round *finalization) shareIsValid(j int, sj *big.Int) bool {
+	ec := round.Params().EC()
+	q := ec.Params().N
+	if sj.Cmp(q) >= 0 {
+		return false
+	}
+	Rj := round.temp.pointRjs[j]
+	rjx, rjy := Rj.X(), Rj.Y()
+	if round.temp.bigR.Y().Bit(0) == 1 {
+		rjy = new(big.Int).Sub(ec.Params().P, rjy)
+	}
+	// w_j*G = lambda_j*X_j, where PrepareForSigning gives lambda_j for x_j = 1
+	lambdaJ := PrepareForSigning(ec, j, len(round.key.Ks), big.NewInt(1), round.key.Ks)
+	eLambdaJ := common.ModInt(q).Mul(round.temp.e, lambdaJ)
+	lx, ly := ec.ScalarBaseMult(sj.Bytes())
+	wx, wy := ec.ScalarMult(round.key.BigXj[j].X(), round.key.BigXj[j].Y(), eLambdaJ.Bytes())
+	rx, ry := ec.Add(rjx, rjy, wx, wy)
+	return lx.Cmp(rx) == 0 && ly.Cmp(ry) == 0
+}
@@ -0,0 +1,157 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/schnorr/keygen"
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+func TestE2EConcurrent(t *testing.T) {
+	setUp("info")
+
+	// PHASE: load keygen fixtures
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	assert.Equal(t, testThreshold+1, len(keys))
+	assert.Equal(t, testThreshold+1, len(signPIDs))
+
+	// PHASE: signing
+	msg, _ := hex.DecodeString("00f163ee51bcaeff9cdff5e0e3c1a646abd19885fffbab0b3b4236e0cf95c9f5")
+	sigData := runSigning(t, keys, signPIDs, new(big.Int).SetBytes(msg))
+	if sigData == nil {
+		return
+	}
+	assert.Equal(t, msg, sigData.M)
+	assert.Equal(t, 64, len(sigData.Signature))
+
+	// BIP-340 verify against the x-only public key
+	pk, err := schnorr.ParsePubKey(keys[0].SchnorrPub.X().FillBytes(make([]byte, 32)))
+	assert.NoError(t, err)
+	sig, err := schnorr.ParseSignature(sigData.Signature)
+	assert.NoError(t, err)
+	assert.True(t, sig.Verify(msg, pk), "bip-340 verify must pass")
+}
+
+func TestE2ETaproot(t *testing.T) {
+	setUp("info")
+
+	for _, merkleRoot := range [][]byte{nil, bytes.Repeat([]byte{0xab}, 32)} {
+		// the tweak updates the keys in place, so they are loaded again each time
+		keys, signPIDs, err := keygen.LoadKeygenTestFixtures(testThreshold + 1)
+		if !assert.NoError(t, err, "should load keygen fixtures") {
+			return
+		}
+		internalKey, err := schnorr.ParsePubKey(keys[0].SchnorrPub.X().FillBytes(make([]byte, 32)))
+		assert.NoError(t, err)
+
+		// the output key matches that of btcd
+		var outputKey *btcec.PublicKey
+		if merkleRoot == nil {
+			outputKey = txscript.ComputeTaprootKeyNoScript(internalKey)
+		} else {
+			outputKey = txscript.ComputeTaprootOutputKey(internalKey, merkleRoot)
+		}
+		assert.NoError(t, UpdateKeysWithTaprootTweak(merkleRoot, keys))
+		for _, key := range keys {
+			assert.Equal(t, schnorr.SerializePubKey(outputKey), key.SchnorrPub.X().FillBytes(make([]byte, 32)))
+			assert.Zero(t, key.SchnorrPub.Y().Bit(0), "the output key should have an even Y")
+		}
+
+		msg := big.NewInt(42)
+		sigData := runSigning(t, keys, signPIDs, msg)
+		if sigData == nil {
+			return
+		}
+		sig, err := schnorr.ParseSignature(sigData.Signature)
+		assert.NoError(t, err)
+		assert.True(t, sig.Verify(msg.FillBytes(make([]byte, 32)), outputKey), "bip-340 verify must pass for the output key")
+	}
+}
+
+// ----- //
+
+func runSigning(t *testing.T, keys []keygen.LocalPartySaveData, signPIDs tss.SortedPartyIDs, msg *big.Int) *common.SignatureData {
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]*LocalParty, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+
+	updater := test.SharedPartyUpdater
+
+	// init the parties
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		P := NewLocalParty(msg, params, keys[i], outCh, endCh).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	var sigData *common.SignatureData
+	for ended := 0; ended < len(signPIDs); {
+		select {
+		case err := <-errCh:
+			common.Logger.Errorf("Error: %s", err)
+			assert.FailNow(t, err.Error())
+			return nil
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else {
+				if dest[0].Index == msg.GetFrom().Index {
+					t.Fatalf("party %d tried to send a message to itself (%d)", dest[0].Index, msg.GetFrom().Index)
+				}
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case data := <-endCh:
+			if sigData != nil {
+				assert.Equal(t, sigData.Signature, data.Signature, "all parties should output the same signature")
+			}
+			sigData = data
+			ended++
+		}
+	}
+	return sigData
+}
@@ -0,0 +1,115 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/schnorr/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// round 1 represents round 1 of the signing part of the BIP-340 Schnorr TSS spec
+func newRound1(params *tss.Parameters, key *keygen.LocalPartySaveData, data *common.SignatureData, temp *localTempData, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Round {
+	return &round1{
+		&base{params, key, data, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1},
+	}
+}
+
+func (round *round1) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	round.temp.ssidNonce = new(big.Int).SetUint64(0)
+	var err error
+	round.temp.ssid, err = round.getSSID()
+	if err != nil {
+		return round.WrapError(err)
+	}
+	// 1. select ri
+	ri := common.GetRandomPositiveInt(round.Rand(), round.Params().EC().Params().N)
+
+	// 2. make commitment
+	pointRi := crypto.ScalarBaseMult(round.Params().EC(), ri)
+	cmt := commitments.NewHashCommitment(round.Rand(), pointRi.X(), pointRi.Y())
+
+	// 3. store r1 message pieces
+	round.temp.ri = ri
+	round.temp.pointRi = pointRi
+	round.temp.deCommit = cmt.D
+
+	i := round.PartyID().Index
+	round.ok[i] = true
+
+	// 4. broadcast commitment
+	r1msg2 := NewSignRound1Message(round.PartyID(), cmt.C)
+	round.temp.signRound1Messages[i] = r1msg2
+	round.out <- tss.WithRound(r1msg2, round.number)
+
+	return nil
+}
+
+func (round *round1) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.signRound1Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignRound1Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round1) NextRound() tss.Round {
+	round.started = false
+	return &round2{round}
+}
+
+// ----- //
+
+// helper to call into PrepareForSigning()
+func (round *round1) prepare() error {
+	i := round.PartyID().Index
+
+	xi := round.key.Xi
+	ks := round.key.Ks
+
+	if round.Threshold()+1 > len(ks) {
+		return fmt.Errorf("t+1=%d is not satisfied by the key count of %d", round.Threshold()+1, len(ks))
+	}
+	if round.temp.m == nil || round.temp.m.Sign() < 0 || round.temp.m.BitLen() > 8*messageLen {
+		return fmt.Errorf("the message must fit in %d bytes", messageLen)
+	}
+	if round.key.SchnorrPub == nil || round.key.SchnorrPub.Y().Bit(0) != 0 {
+		return errors.New("the public key must have an even Y; see keygen.NormalizeEvenY")
+	}
+	wi := PrepareForSigning(round.Params().EC(), i, len(ks), xi, ks)
+
+	round.temp.wi = wi
+	return nil
+}
@@ -0,0 +1,125 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"math/big"
+
+	"github.com/pkg/errors"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func (round *round3) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+
+	round.number = 3
+	round.started = true
+	round.resetOK()
+
+	// 1. init R
+	i := round.PartyID().Index
+	R := round.temp.pointRi
+	round.temp.pointRjs[i] = round.temp.pointRi
+
+	// 2-6. compute R
+	for j, Pj := range round.Parties().IDs() {
+		if j == i {
+			continue
+		}
+
+		ContextJ := common.AppendBigIntToBytesSlice(round.temp.ssid, big.NewInt(int64(j)))
+		msg := round.temp.signRound2Messages[j]
+		r2msg := msg.Content().(*SignRound2Message)
+		cmtDeCmt := commitments.HashCommitDecommit{C: round.temp.cjs[j], D: r2msg.UnmarshalDeCommitment()}
+		ok, coordinates := cmtDeCmt.DeCommit()
+		if !ok {
+			return round.WrapError(errors.New("de-commitment verify failed"), Pj)
+		}
+		if len(coordinates) != 2 {
+			return round.WrapError(errors.New("length of de-commitment should be 2"), Pj)
+		}
+
+		Rj, err := crypto.NewECPoint(round.Params().EC(), coordinates[0], coordinates[1])
+		if err != nil {
+			return round.WrapError(errors.Wrapf(err, "NewECPoint(Rj)"), Pj)
+		}
+		proof, err := r2msg.UnmarshalZKProof(round.Params().EC())
+		if err != nil {
+			return round.WrapError(errors.New("failed to unmarshal Rj proof"), Pj)
+		}
+		ok = proof.Verify(ContextJ, Rj)
+		if !ok {
+			return round.WrapError(errors.New("failed to prove Rj"), Pj)
+		}
+		round.temp.pointRjs[j] = Rj
+
+		if R, err = R.Add(Rj); err != nil {
+			return round.WrapError(errors.Wrapf(err, "R.Add(Rj)"), Pj)
+		}
+	}
+
+	// 7. BIP-340 uses the nonce k of R = k*G with an even Y, so every party negates its ri when R has an odd Y
+	q := round.Params().EC().Params().N
+	modQ := common.ModInt(q)
+	ki := round.temp.ri
+	if R.Y().Bit(0) == 1 {
+		ki = modQ.Sub(big.NewInt(0), ki)
+	}
+
+	// 8. e = int(hash_BIP0340/challenge(bytes(R) || bytes(P) || m)) mod n
+	mBytes := bytes32(round.temp.m)
+	eHash := taggedHash("BIP0340/challenge", bytes32(R.X()), bytes32(round.key.SchnorrPub.X()), mBytes)
+	e := new(big.Int).Mod(new(big.Int).SetBytes(eHash), q)
+
+	// 9. compute si
+	si := modQ.Add(ki, modQ.Mul(e, round.temp.wi))
+
+	// 10. store r3 message pieces
+	round.temp.bigR = R
+	round.temp.e = e
+	round.temp.si = si
+
+	// 11. broadcast si to other parties
+	r3msg := NewSignRound3Message(round.PartyID(), si)
+	round.temp.signRound3Messages[i] = r3msg
+	round.out <- tss.WithRound(r3msg, round.number)
+
+	return nil
+}
+
+func (round *round3) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.signRound3Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round3) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignRound3Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round3) NextRound() tss.Round {
+	round.started = false
+	return &finalization{round}
+}
@@ -0,0 +1,53 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/schnorr/keygen"
+)
+
+// UpdateKeysWithTaprootTweak applies the BIP-341 tweak t = hash_TapTweak(bytes(P) || merkleRoot) to the keys, so that
+// they sign for the taproot output key Q = P + t*G. merkleRoot is the root of the script tree, or empty for a key
+// path only output (BIP-86). The tweak is public, so it is added to every share Xi and BigXj just as
+// ecdsa/signing.UpdatePublicKeyAndAdjustBigXj does with a derivation delta; the keys are then normalized to an even Y.
+func UpdateKeysWithTaprootTweak(merkleRoot []byte, keys []keygen.LocalPartySaveData) error {
+	for k := range keys {
+		P := keys[k].SchnorrPub
+		if P == nil || P.Y().Bit(0) != 0 {
+			return errors.New("the internal key must have an even Y")
+		}
+		ec := P.Curve()
+		t := new(big.Int).SetBytes(taggedHash("TapTweak", bytes32(P.X()), merkleRoot))
+		if t.Cmp(ec.Params().N) >= 0 {
+			return errors.New("the taproot tweak is not a valid scalar")
+		}
+		gT := crypto.ScalarBaseMult(ec, t)
+
+		var err error
+		if keys[k].SchnorrPub, err = P.Add(gT); err != nil {
+			return err
+		}
+		// Suppose x has shamir shares x_1, ..., x_n
+		// So x + t has shamir shares x_1 + t, ..., x_n + t
+		for j := range keys[k].BigXj {
+			if keys[k].BigXj[j], err = keys[k].BigXj[j].Add(gT); err != nil {
+				return err
+			}
+		}
+		if keys[k].Xi != nil {
+			keys[k].Xi = new(big.Int).Mod(new(big.Int).Add(keys[k].Xi, t), ec.Params().N)
+		}
+		if err = keygen.NormalizeEvenY(&keys[k]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/sha256"
+	"math/big"
+)
+
+const (
+	// BIP-340 signs 32-byte messages and uses 32-byte encodings of field elements and scalars
+	messageLen = 32
+	scalarLen  = 32
+)
+
+// taggedHash is the hash_tag(x) = SHA256(SHA256(tag) || SHA256(tag) || x) of BIP-340
+func taggedHash(tag string, msgs ...[]byte) []byte {
+	tagHash := sha256.Sum256([]byte(tag))
+	h := sha256.New()
+	h.Write(tagHash[:])
+	h.Write(tagHash[:])
+	for _, m := range msgs {
+		h.Write(m)
+	}
+	return h.Sum(nil)
+}
+
+// bytes32 is the bytes(x) of BIP-340: the 32-byte big-endian encoding of x
+func bytes32(x *big.Int) []byte {
+	return x.FillBytes(make([]byte, scalarLen))
+}
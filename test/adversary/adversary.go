@@ -0,0 +1,200 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Package adversary wraps a party so that it tampers with the messages it sends, for testing that the honest parties
+// abort and name it as the culprit. It must only be used in tests.
+package adversary
+
+import (
+	"fmt"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+type (
+	// Tamper changes a message that the adversary sends to the parties in to, who are the other parties of its
+	// committee when the message is a broadcast. It returns the messages to send in its place: none to withhold it, or
+	// more than one to send different messages to different parties. A Tamper that cannot change the message panics,
+	// as the scenario is wrong.
+	Tamper func(msg tss.ParsedMessage, to []*tss.PartyID) []tss.ParsedMessage
+
+	// Rule tampers with the messages that the adversary sends in a round
+	Rule struct {
+		Round int
+		// the name of the type of the messages, such as "KGRound2Message1"; all the messages of the round when empty
+		Type   string
+		Tamper Tamper
+	}
+
+	// Party is a party that tampers with the messages it sends by its rules
+	Party struct {
+		tss.Party
+		params *tss.Parameters
+		out    chan<- tss.Message
+		rules  []Rule
+
+		mtx      sync.Mutex
+		tampered int
+	}
+)
+
+// New creates a party with newParty, giving it a channel to send its messages through, and passes them on to out
+// after tampering with them by the first of rules that applies
+func New(newParty func(out chan<- tss.Message) tss.Party, out chan<- tss.Message, rules ...Rule) *Party {
+	in := make(chan tss.Message, cap(out))
+	p := &Party{out: out, rules: rules}
+	p.Party = newParty(in)
+	p.params = p.FirstRound().Params()
+	go p.intercept(in)
+	return p
+}
+
+// Tampered returns the number of messages that were tampered with so far
+func (p *Party) Tampered() int {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return p.tampered
+}
+
+// ----- //
+
+// Withhold drops the message
+func Withhold() Tamper {
+	return func(tss.ParsedMessage, []*tss.PartyID) []tss.ParsedMessage {
+		return nil
+	}
+}
+
+// Modify sends a copy of the message whose content was changed by change
+func Modify(change func(content proto.Message)) Tamper {
+	return func(msg tss.ParsedMessage, _ []*tss.PartyID) []tss.ParsedMessage {
+		content := proto.Clone(msg.Content()).(tss.MessageContent)
+		change(content)
+		return []tss.ParsedMessage{readdress(msg, content, msg.GetTo())}
+	}
+}
+
+// FlipBit flips the lowest bit of a bytes field of the message, or of the first element of a repeated bytes field.
+// The field is named as in the .proto file.
+func FlipBit(field string) Tamper {
+	return Modify(func(content proto.Message) {
+		m := content.ProtoReflect()
+		fd := m.Descriptor().Fields().ByName(protoreflect.Name(field))
+		if fd == nil || fd.Kind() != protoreflect.BytesKind {
+			panic(fmt.Sprintf("adversary: %s has no bytes field %q", m.Descriptor().FullName(), field))
+		}
+		flip := func(bz []byte) []byte {
+			if len(bz) == 0 {
+				panic(fmt.Sprintf("adversary: the field %q of %s is empty", field, m.Descriptor().FullName()))
+			}
+			bz = append([]byte(nil), bz...)
+			bz[len(bz)-1] ^= 1
+			return bz
+		}
+		if fd.IsList() {
+			list := m.Mutable(fd).List()
+			if list.Len() == 0 {
+				panic(fmt.Sprintf("adversary: the field %q of %s is empty", field, m.Descriptor().FullName()))
+			}
+			list.Set(0, protoreflect.ValueOfBytes(flip(list.Get(0).Bytes())))
+			return
+		}
+		m.Set(fd, protoreflect.ValueOfBytes(flip(m.Get(fd).Bytes())))
+	})
+}
+
+// Equivocate sends each recipient of a broadcast its own copy of it: the first half of them get the message as it was,
+// and the others get it as changed by change
+func Equivocate(change Tamper) Tamper {
+	return func(msg tss.ParsedMessage, to []*tss.PartyID) []tss.ParsedMessage {
+		if !msg.IsBroadcast() || len(to) < 2 {
+			panic(fmt.Sprintf("adversary: cannot equivocate %s", msg))
+		}
+		half := len(to) / 2
+		msgs := make([]tss.ParsedMessage, 0, len(to))
+		for _, pID := range to[:half] {
+			msgs = append(msgs, readdress(msg, msg.Content(), []*tss.PartyID{pID}))
+		}
+		for _, pID := range to[half:] {
+			for _, changed := range change(msg, []*tss.PartyID{pID}) {
+				msgs = append(msgs, readdress(changed, changed.Content(), []*tss.PartyID{pID}))
+			}
+		}
+		return msgs
+	}
+}
+
+// ----- //
+
+func (p *Party) intercept(in <-chan tss.Message) {
+	for msg := range in {
+		parsed := msg.(tss.ParsedMessage)
+		rule := p.ruleFor(parsed)
+		if rule == nil {
+			p.out <- msg
+			continue
+		}
+		p.mtx.Lock()
+		p.tampered++
+		p.mtx.Unlock()
+		for _, tampered := range rule.Tamper(parsed, p.recipients(parsed)) {
+			// the tampered content goes out encrypted again to a recipient with an encryption key
+			enc, err := tss.EncryptMessage(p.params, tampered)
+			if err != nil {
+				common.Logger.Errorf("adversary %s: %v", p.PartyID(), err)
+				continue
+			}
+			p.out <- enc
+		}
+	}
+}
+
+func (p *Party) ruleFor(msg tss.ParsedMessage) *Rule {
+	// the echoes of the consistency rounds are not messages of the protocol
+	if _, ok := msg.Content().(*tss.EchoMessage); ok {
+		return nil
+	}
+	name := string(msg.Content().ProtoReflect().Descriptor().Name())
+	round := tss.RoundOf(msg)
+	for i, rule := range p.rules {
+		if rule.Round == round && (rule.Type == "" || rule.Type == name) {
+			return &p.rules[i]
+		}
+	}
+	return nil
+}
+
+// recipients returns the parties that a message is delivered to
+func (p *Party) recipients(msg tss.ParsedMessage) []*tss.PartyID {
+	if to := msg.GetTo(); to != nil {
+		return to
+	}
+	self := p.PartyID()
+	to := make([]*tss.PartyID, 0)
+	for _, pID := range p.params.Parties().IDs() {
+		if pID.KeyInt().Cmp(self.KeyInt()) != 0 {
+			to = append(to, pID)
+		}
+	}
+	return to
+}
+
+// readdress makes a message with the content and routing of msg, but sent to the parties in to
+func readdress(msg tss.ParsedMessage, content tss.MessageContent, to []*tss.PartyID) tss.ParsedMessage {
+	routing := tss.MessageRouting{
+		From:                    msg.GetFrom(),
+		To:                      to,
+		IsBroadcast:             msg.IsBroadcast(),
+		IsToOldCommittee:        msg.IsToOldCommittee(),
+		IsToOldAndNewCommittees: msg.IsToOldAndNewCommittees(),
+	}
+	return tss.WithRound(tss.NewMessage(routing, content, tss.NewMessageWrapper(routing, content)), tss.RoundOf(msg))
+}
@@ -0,0 +1,277 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package adversary
+
+import (
+	"bytes"
+	"crypto/elliptic"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	ecdsakeygen "github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	ecdsaresharing "github.com/bnb-chain/tss-lib/v2/ecdsa/resharing"
+	ecdsasigning "github.com/bnb-chain/tss-lib/v2/ecdsa/signing"
+	eddsakeygen "github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	eddsaresharing "github.com/bnb-chain/tss-lib/v2/eddsa/resharing"
+	eddsasigning "github.com/bnb-chain/tss-lib/v2/eddsa/signing"
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+
+	scenarioTimeout = 2 * time.Minute
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+// newPartyFunc creates the party with the given parameters, which sends its messages through out
+type newPartyFunc func(i int, params *tss.Parameters, out chan<- tss.Message) tss.Party
+
+func TestECDSAKeygen(t *testing.T) {
+	setUp("info")
+	fixtures, pIDs, err := ecdsakeygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	end := make(chan *ecdsakeygen.LocalPartySaveData, len(pIDs))
+	runScenarios(t, ECDSAKeygen, tss.S256(), pIDs, func(i int, params *tss.Parameters, out chan<- tss.Message) tss.Party {
+		// do not use in untrusted setting
+		params.SetNoProofMod()
+		// do not use in untrusted setting
+		params.SetNoProofFac()
+		return ecdsakeygen.NewLocalParty(params, out, end, fixtures[i].LocalPreParams)
+	})
+}
+
+func TestECDSASigning(t *testing.T) {
+	setUp("info")
+	keys, pIDs, err := ecdsakeygen.LoadKeygenTestFixtures(testThreshold + 1)
+	assert.NoError(t, err, "should load keygen fixtures")
+	end := make(chan *common.SignatureData, len(pIDs))
+	runScenarios(t, ECDSASigning, tss.S256(), pIDs, func(i int, params *tss.Parameters, out chan<- tss.Message) tss.Party {
+		return ecdsasigning.NewLocalParty(big.NewInt(42), params, keys[i], out, end)
+	})
+}
+
+func TestECDSAReSharing(t *testing.T) {
+	setUp("info")
+	oldKeys, oldPIDs, err := ecdsakeygen.LoadKeygenTestFixtures(testThreshold + 1)
+	assert.NoError(t, err, "should load keygen fixtures")
+	// re-use the fixture pre-params for speed
+	fixtures, _, err := ecdsakeygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	end := make(chan *ecdsakeygen.LocalPartySaveData, len(oldPIDs)+testParticipants)
+	runReSharingScenarios(t, ECDSAReSharing, tss.S256(), oldPIDs, func(i int, params *tss.ReSharingParameters, out chan<- tss.Message) tss.Party {
+		if params.IsOldCommittee() {
+			return ecdsaresharing.NewLocalParty(params, oldKeys[i], out, end)
+		}
+		// do not use in untrusted setting
+		params.SetNoProofMod()
+		// do not use in untrusted setting
+		params.SetNoProofFac()
+		save := ecdsakeygen.NewLocalPartySaveData(testParticipants)
+		save.LocalPreParams = fixtures[i].LocalPreParams
+		return ecdsaresharing.NewLocalParty(params, save, out, end)
+	})
+}
+
+func TestEdDSAKeygen(t *testing.T) {
+	setUp("info")
+	pIDs := tss.GenerateTestPartyIDs(testParticipants)
+	end := make(chan *eddsakeygen.LocalPartySaveData, len(pIDs))
+	runScenarios(t, EdDSAKeygen, tss.Edwards(), pIDs, func(i int, params *tss.Parameters, out chan<- tss.Message) tss.Party {
+		return eddsakeygen.NewLocalParty(params, out, end)
+	})
+}
+
+func TestEdDSASigning(t *testing.T) {
+	setUp("info")
+	keys, pIDs, err := eddsakeygen.LoadKeygenTestFixtures(testThreshold + 1)
+	assert.NoError(t, err, "should load keygen fixtures")
+	end := make(chan *common.SignatureData, len(pIDs))
+	runScenarios(t, EdDSASigning, tss.Edwards(), pIDs, func(i int, params *tss.Parameters, out chan<- tss.Message) tss.Party {
+		return eddsasigning.NewLocalParty(big.NewInt(42), params, keys[i], out, end)
+	})
+}
+
+func TestEdDSAReSharing(t *testing.T) {
+	setUp("info")
+	oldKeys, oldPIDs, err := eddsakeygen.LoadKeygenTestFixtures(testThreshold + 1)
+	assert.NoError(t, err, "should load keygen fixtures")
+	end := make(chan *eddsakeygen.LocalPartySaveData, len(oldPIDs)+testParticipants)
+	runReSharingScenarios(t, EdDSAReSharing, tss.Edwards(), oldPIDs, func(i int, params *tss.ReSharingParameters, out chan<- tss.Message) tss.Party {
+		if params.IsOldCommittee() {
+			return eddsaresharing.NewLocalParty(params, oldKeys[i], out, end)
+		}
+		return eddsaresharing.NewLocalParty(params, eddsakeygen.NewLocalPartySaveData(testParticipants), out, end)
+	})
+}
+
+func TestEquivocateSplitsBroadcast(t *testing.T) {
+	pIDs := tss.GenerateTestPartyIDs(4)
+	routing := tss.MessageRouting{From: pIDs[0], IsBroadcast: true}
+	content := &eddsakeygen.KGRound1Message{Commitment: []byte{2}}
+	msg := tss.NewMessage(routing, content, tss.NewMessageWrapper(routing, content))
+
+	msgs := Equivocate(FlipBit("commitment"))(msg, pIDs[1:])
+	if assert.Len(t, msgs, 3) {
+		for i, m := range msgs {
+			assert.True(t, m.IsBroadcast())
+			assert.Equal(t, []*tss.PartyID{pIDs[i+1]}, m.GetTo())
+		}
+		assert.Equal(t, []byte{2}, msgs[0].Content().(*eddsakeygen.KGRound1Message).GetCommitment())
+		assert.Equal(t, []byte{3}, msgs[1].Content().(*eddsakeygen.KGRound1Message).GetCommitment())
+		assert.Equal(t, []byte{3}, msgs[2].Content().(*eddsakeygen.KGRound1Message).GetCommitment())
+	}
+	assert.Equal(t, []byte{2}, content.GetCommitment(), "the original message must not be changed")
+}
+
+// ----- //
+
+// runScenarios runs each scenario with the first party as the adversary
+func runScenarios(t *testing.T, scenarios []Scenario, ec elliptic.Curve, pIDs tss.SortedPartyIDs, newParty newPartyFunc) {
+	for _, s := range scenarios {
+		s := s
+		t.Run(s.Name, func(t *testing.T) {
+			p2pCtx := tss.NewPeerContext(pIDs)
+			outCh := make(chan tss.Message, len(pIDs))
+			parties := make([]tss.Party, len(pIDs))
+			var adversary *Party
+			for i, pID := range pIDs {
+				params := tss.NewParameters(ec, p2pCtx, pID, len(pIDs), testThreshold)
+				params.SetEchoBroadcast(s.EchoBroadcast)
+				params.SetRoundTimeout(s.RoundTimeout)
+				if i == 0 {
+					adversary = New(func(out chan<- tss.Message) tss.Party { return newParty(i, params, out) }, outCh, s.Rules...)
+					parties[i] = adversary
+					continue
+				}
+				parties[i] = newParty(i, params, outCh)
+			}
+			assertCaught(t, s, parties, parties[1:], adversary, outCh)
+		})
+	}
+}
+
+// runReSharingScenarios runs each scenario with the first party of the old committee as the adversary
+func runReSharingScenarios(t *testing.T, scenarios []Scenario, ec elliptic.Curve, oldPIDs tss.SortedPartyIDs, newParty func(i int, params *tss.ReSharingParameters, out chan<- tss.Message) tss.Party) {
+	for _, s := range scenarios {
+		s := s
+		t.Run(s.Name, func(t *testing.T) {
+			newPIDs := tss.GenerateTestPartyIDs(testParticipants)
+			oldCtx, newCtx := tss.NewPeerContext(oldPIDs), tss.NewPeerContext(newPIDs)
+			outCh := make(chan tss.Message, len(oldPIDs)+len(newPIDs))
+			parties := make([]tss.Party, 0, len(oldPIDs)+len(newPIDs))
+			var adversary *Party
+			pIDs := make([]*tss.PartyID, 0, len(oldPIDs)+len(newPIDs))
+			pIDs = append(append(pIDs, oldPIDs...), newPIDs...)
+			for i, pID := range pIDs {
+				params := tss.NewReSharingParameters(ec, oldCtx, newCtx, pID, len(oldPIDs), testThreshold, len(newPIDs), testThreshold)
+				params.SetEchoBroadcast(s.EchoBroadcast)
+				params.SetRoundTimeout(s.RoundTimeout)
+				j := pID.Index
+				if i == 0 {
+					adversary = New(func(out chan<- tss.Message) tss.Party { return newParty(j, params, out) }, outCh, s.Rules...)
+					parties = append(parties, adversary)
+					continue
+				}
+				parties = append(parties, newParty(j, params, outCh))
+			}
+			assertCaught(t, s, parties, parties[len(oldPIDs):], adversary, outCh)
+		})
+	}
+}
+
+// assertCaught starts the parties and delivers their messages until each of the victims has failed, and checks that
+// they failed in the round of the scenario naming the adversary
+func assertCaught(t *testing.T, s Scenario, parties, victims []tss.Party, adversary *Party, outCh <-chan tss.Message) {
+	errCh := make(chan *tss.Error, len(parties))
+	for _, P := range parties {
+		go func(P tss.Party) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	errs := make(map[tss.Party]*tss.Error, len(victims))
+	victimOf := func(err *tss.Error) tss.Party {
+		for _, P := range victims {
+			if err.Victim() != nil && bytes.Equal(err.Victim().GetKey(), P.PartyID().GetKey()) {
+				return P
+			}
+		}
+		return nil
+	}
+	// the parties aborted by their round timeout do not fail an update
+	for _, P := range victims {
+		go func(P tss.Party) {
+			<-P.(tss.Lifecycle).Done()
+			if err := P.(tss.Lifecycle).Err(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+	deadline := time.After(scenarioTimeout)
+	for len(errs) < len(victims) {
+		select {
+		case msg := <-outCh:
+			for _, P := range parties {
+				if isTo(P, msg) {
+					go test.SharedPartyUpdater(P, msg, errCh)
+				}
+			}
+		case err := <-errCh:
+			if P := victimOf(err); P != nil && errs[P] == nil {
+				errs[P] = err
+			}
+		case <-deadline:
+			assert.FailNow(t, "the honest parties did not abort")
+		}
+	}
+
+	assert.NotZero(t, adversary.Tampered(), "the rules must have applied")
+	for P, err := range errs {
+		assert.Equal(t, s.Round, err.Round(), "party %s: %s", P.PartyID(), err)
+		if s.EchoBroadcast {
+			// the echoes are not signed, so the adversary is reported as the sender but nobody is named
+			var mismatch *tss.BroadcastMismatch
+			if assert.True(t, errors.As(err, &mismatch), "party %s: %s", P.PartyID(), err) {
+				assert.True(t, bytes.Equal(adversary.PartyID().GetKey(), mismatch.Sender.GetKey()), "party %s: %s", P.PartyID(), err)
+			}
+			assert.Empty(t, err.Culprits(), "party %s: %s", P.PartyID(), err)
+			continue
+		}
+		assert.Equal(t, []*tss.PartyID{adversary.PartyID()}, err.Culprits(), "party %s: %s", P.PartyID(), err)
+	}
+}
+
+func isTo(P tss.Party, msg tss.Message) bool {
+	if bytes.Equal(P.PartyID().GetKey(), msg.GetFrom().GetKey()) {
+		return false
+	}
+	if msg.GetTo() == nil {
+		return true
+	}
+	for _, pID := range msg.GetTo() {
+		if bytes.Equal(pID.GetKey(), P.PartyID().GetKey()) {
+			return true
+		}
+	}
+	return false
+}
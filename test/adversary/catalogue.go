@@ -0,0 +1,164 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package adversary
+
+import (
+	"time"
+)
+
+// Scenario is a way for one party to misbehave in a protocol, and the round in which the honest parties abort naming
+// it as the only culprit, or, for an equivocated broadcast, as the sender in a tss.BroadcastMismatch. In a re-sharing, the adversary is in the old committee and the honest parties that abort are
+// those of the new committee.
+type Scenario struct {
+	Name  string
+	Rules []Rule
+	// what the honest parties need to catch the adversary: echo broadcast to find an equivocated broadcast, and a
+	// round timeout to find a withheld message
+	EchoBroadcast bool
+	RoundTimeout  time.Duration
+	Round         int
+}
+
+// withholdTimeout is long enough for the slowest round of a test run to finish on a busy machine
+const withholdTimeout = 20 * time.Second
+
+var (
+	ECDSAKeygen = []Scenario{
+		{
+			Name:  "bad share",
+			Rules: []Rule{{Round: 2, Type: "KGRound2Message1", Tamper: FlipBit("share")}},
+			Round: 3,
+		},
+		{
+			Name:  "bad de-commitment",
+			Rules: []Rule{{Round: 2, Type: "KGRound2Message2", Tamper: FlipBit("de_commitment")}},
+			Round: 3,
+		},
+		{
+			Name:          "equivocated commitment",
+			Rules:         []Rule{{Round: 1, Tamper: Equivocate(FlipBit("commitment"))}},
+			EchoBroadcast: true,
+			Round:         1,
+		},
+		{
+			Name:         "withheld share",
+			Rules:        []Rule{{Round: 2, Type: "KGRound2Message1", Tamper: Withhold()}},
+			RoundTimeout: withholdTimeout,
+			Round:        2,
+		},
+	}
+
+	ECDSASigning = []Scenario{
+		{
+			Name:  "bad ProofBob",
+			Rules: []Rule{{Round: 2, Tamper: FlipBit("proof_bob")}},
+			Round: 3,
+		},
+		{
+			Name:  "bad de-commitment of Gamma",
+			Rules: []Rule{{Round: 4, Tamper: FlipBit("de_commitment")}},
+			Round: 5,
+		},
+		{
+			Name:          "equivocated commitment",
+			Rules:         []Rule{{Round: 1, Type: "SignRound1Message2", Tamper: Equivocate(FlipBit("commitment"))}},
+			EchoBroadcast: true,
+			Round:         1,
+		},
+		{
+			Name:         "withheld theta",
+			Rules:        []Rule{{Round: 3, Tamper: Withhold()}},
+			RoundTimeout: withholdTimeout,
+			Round:        3,
+		},
+	}
+
+	ECDSAReSharing = []Scenario{
+		{
+			Name:  "bad share",
+			Rules: []Rule{{Round: 3, Type: "DGRound3Message1", Tamper: FlipBit("share")}},
+			Round: 4,
+		},
+		{
+			Name:          "equivocated commitment",
+			Rules:         []Rule{{Round: 1, Tamper: Equivocate(FlipBit("v_commitment"))}},
+			EchoBroadcast: true,
+			Round:         1,
+		},
+		{
+			Name:         "withheld share",
+			Rules:        []Rule{{Round: 3, Type: "DGRound3Message1", Tamper: Withhold()}},
+			RoundTimeout: withholdTimeout,
+			Round:        3,
+		},
+	}
+
+	EdDSAKeygen = []Scenario{
+		{
+			Name:  "bad share",
+			Rules: []Rule{{Round: 2, Type: "KGRound2Message1", Tamper: FlipBit("share")}},
+			Round: 3,
+		},
+		{
+			Name:  "bad proof",
+			Rules: []Rule{{Round: 2, Type: "KGRound2Message2", Tamper: FlipBit("proof_t")}},
+			Round: 3,
+		},
+		{
+			Name:          "equivocated commitment",
+			Rules:         []Rule{{Round: 1, Tamper: Equivocate(FlipBit("commitment"))}},
+			EchoBroadcast: true,
+			Round:         1,
+		},
+		{
+			Name:         "withheld de-commitment",
+			Rules:        []Rule{{Round: 2, Type: "KGRound2Message2", Tamper: Withhold()}},
+			RoundTimeout: withholdTimeout,
+			Round:        2,
+		},
+	}
+
+	EdDSASigning = []Scenario{
+		{
+			Name:  "bad proof",
+			Rules: []Rule{{Round: 2, Tamper: FlipBit("proof_t")}},
+			Round: 3,
+		},
+		{
+			Name:          "equivocated commitment",
+			Rules:         []Rule{{Round: 1, Tamper: Equivocate(FlipBit("commitment"))}},
+			EchoBroadcast: true,
+			Round:         1,
+		},
+		{
+			Name:         "withheld signature share",
+			Rules:        []Rule{{Round: 3, Tamper: Withhold()}},
+			RoundTimeout: withholdTimeout,
+			Round:        3,
+		},
+	}
+
+	EdDSAReSharing = []Scenario{
+		{
+			Name:  "bad share",
+			Rules: []Rule{{Round: 3, Type: "DGRound3Message1", Tamper: FlipBit("share")}},
+			Round: 4,
+		},
+		{
+			Name:          "equivocated commitment",
+			Rules:         []Rule{{Round: 1, Tamper: Equivocate(FlipBit("v_commitment"))}},
+			EchoBroadcast: true,
+			Round:         1,
+		},
+		{
+			Name:         "withheld share",
+			Rules:        []Rule{{Round: 3, Type: "DGRound3Message1", Tamper: Withhold()}},
+			RoundTimeout: withholdTimeout,
+			Round:        3,
+		},
+	}
+)
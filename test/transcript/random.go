@@ -0,0 +1,73 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package transcript
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"fmt"
+	"io"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const seedLen = 32
+
+// seededRandom is the AES-256-CTR key stream of a 32-byte seed. It is deterministic, for reproducing a run, and must
+// never be used with real keys.
+type seededRandom struct {
+	stream io.Reader
+}
+
+var _ common.ForkableReader = (*seededRandom)(nil)
+
+func newSeededRandom(seed []byte) (*seededRandom, error) {
+	block, err := aes.NewCipher(seed)
+	if err != nil {
+		return nil, err
+	}
+	stream := cipher.NewCTR(block, make([]byte, aes.BlockSize))
+	return &seededRandom{stream: cipher.StreamReader{S: stream, R: zeroReader{}}}, nil
+}
+
+func (r *seededRandom) Read(p []byte) (int, error) {
+	return r.stream.Read(p)
+}
+
+// Fork returns the stream seeded from the next 32 bytes of r
+func (r *seededRandom) Fork() (io.Reader, error) {
+	seed := make([]byte, seedLen)
+	if _, err := io.ReadFull(r, seed); err != nil {
+		return nil, err
+	}
+	return newSeededRandom(seed)
+}
+
+// setSeededRandom makes the party of params draw its randomness from the seeds of its input
+func setSeededRandom(params *tss.Parameters, in *Input) error {
+	rand, err := newSeededRandom(in.Seed)
+	if err != nil {
+		return fmt.Errorf("transcript: invalid seed: %v", err)
+	}
+	partialKeyRand, err := newSeededRandom(in.PartialKeySeed)
+	if err != nil {
+		return fmt.Errorf("transcript: invalid partial key seed: %v", err)
+	}
+	params.SetRand(rand)
+	params.SetPartialKeyRand(partialKeyRand)
+	return nil
+}
+
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 0
+	}
+	return len(p), nil
+}
@@ -0,0 +1,268 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package transcript
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	ecdsakeygen "github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	eddsakeygen "github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// Recorder records a run of a protocol. Each party is registered with the method for its protocol before it is
+// created, which seeds the random readers of its parameters, and is then created with the channels returned by Out and
+// by the End method for its protocol in place of its own.
+type Recorder struct {
+	mtx  sync.Mutex
+	t    *Transcript
+	err  error
+	done chan struct{}
+}
+
+func NewRecorder(protocol Protocol) *Recorder {
+	return &Recorder{
+		t:    &Transcript{Version: Version, Protocol: protocol},
+		done: make(chan struct{}),
+	}
+}
+
+func (r *Recorder) ECDSAKeygenParty(params *tss.Parameters, preParams ecdsakeygen.LocalPreParams) error {
+	return r.party(ECDSAKeygen, params, nil, preParams, nil)
+}
+
+func (r *Recorder) ECDSASigningParty(params *tss.Parameters, key ecdsakeygen.LocalPartySaveData, msg *big.Int, fullBytesLen ...int) error {
+	return r.party(ECDSASigning, params, nil, key, msg, fullBytesLen...)
+}
+
+func (r *Recorder) ECDSAReSharingParty(params *tss.ReSharingParameters, key ecdsakeygen.LocalPartySaveData) error {
+	return r.party(ECDSAReSharing, params.Parameters, params, key, nil)
+}
+
+func (r *Recorder) EdDSAKeygenParty(params *tss.Parameters) error {
+	return r.party(EdDSAKeygen, params, nil, nil, nil)
+}
+
+func (r *Recorder) EdDSASigningParty(params *tss.Parameters, key eddsakeygen.LocalPartySaveData, msg *big.Int, fullBytesLen ...int) error {
+	return r.party(EdDSASigning, params, nil, key, msg, fullBytesLen...)
+}
+
+func (r *Recorder) EdDSAReSharingParty(params *tss.ReSharingParameters, key eddsakeygen.LocalPartySaveData) error {
+	return r.party(EdDSAReSharing, params.Parameters, params, key, nil)
+}
+
+// Out returns the channel for a party to send its messages through; they are recorded and passed on to out
+func (r *Recorder) Out(out chan<- tss.Message) chan<- tss.Message {
+	in := make(chan tss.Message, cap(out))
+	go func() {
+		for {
+			select {
+			case msg := <-in:
+				r.recordMessage(msg)
+				select {
+				case out <- msg:
+				case <-r.done:
+					return
+				}
+			case <-r.done:
+				return
+			}
+		}
+	}()
+	return in
+}
+
+// Received records a message that party received from a party that is not recorded, such as one in another process.
+// Messages between recorded parties are recorded when they are sent.
+func (r *Recorder) Received(party tss.Party, msg tss.ParsedMessage) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	for _, in := range r.t.Inputs {
+		if bytes.Equal(in.Party, msg.GetFrom().GetKey()) {
+			return
+		}
+	}
+	bz, err := proto.Marshal(msg.WireMsg())
+	if err != nil {
+		r.err = err
+		return
+	}
+	r.t.Messages = append(r.t.Messages, &Message{Wire: bz, ReceivedBy: party.PartyID().GetKey()})
+}
+
+// ECDSAEnd returns the channel for an ECDSA keygen or re-sharing party to send its output through
+func (r *Recorder) ECDSAEnd(end chan<- *ecdsakeygen.LocalPartySaveData) chan<- *ecdsakeygen.LocalPartySaveData {
+	in := make(chan *ecdsakeygen.LocalPartySaveData, cap(end))
+	go func() {
+		for {
+			select {
+			case save := <-in:
+				r.recordOutput(save)
+				select {
+				case end <- save:
+				case <-r.done:
+					return
+				}
+			case <-r.done:
+				return
+			}
+		}
+	}()
+	return in
+}
+
+// EdDSAEnd returns the channel for an EdDSA keygen or re-sharing party to send its output through
+func (r *Recorder) EdDSAEnd(end chan<- *eddsakeygen.LocalPartySaveData) chan<- *eddsakeygen.LocalPartySaveData {
+	in := make(chan *eddsakeygen.LocalPartySaveData, cap(end))
+	go func() {
+		for {
+			select {
+			case save := <-in:
+				r.recordOutput(save)
+				select {
+				case end <- save:
+				case <-r.done:
+					return
+				}
+			case <-r.done:
+				return
+			}
+		}
+	}()
+	return in
+}
+
+// SignatureEnd returns the channel for a signing party to send its output through
+func (r *Recorder) SignatureEnd(end chan<- *common.SignatureData) chan<- *common.SignatureData {
+	in := make(chan *common.SignatureData, cap(end))
+	go func() {
+		for {
+			select {
+			case sig := <-in:
+				r.recordOutput(sig)
+				select {
+				case end <- sig:
+				case <-r.done:
+					return
+				}
+			case <-r.done:
+				return
+			}
+		}
+	}()
+	return in
+}
+
+// Transcript stops the recording and returns the transcript. It is called once the parties have finished; anything
+// they send later is dropped.
+func (r *Recorder) Transcript() (*Transcript, error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	select {
+	case <-r.done:
+	default:
+		close(r.done)
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	return r.t, nil
+}
+
+// ----- //
+
+func (r *Recorder) party(protocol Protocol, params *tss.Parameters, rgParams *tss.ReSharingParameters, data interface{}, msg *big.Int, fullBytesLen ...int) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if protocol != r.t.Protocol {
+		return fmt.Errorf("transcript: cannot record a party of %s in a transcript of %s", protocol, r.t.Protocol)
+	}
+	if params.DecryptionKey() != nil {
+		// the ephemeral keys of the encryption are not drawn from the seeded readers
+		return errors.New("transcript: cannot record a party that encrypts its point-to-point messages")
+	}
+	curveName, ok := tss.GetCurveName(params.EC())
+	if !ok {
+		return errors.New("transcript: the curve of the party is not registered")
+	}
+	parties := params.Parties().IDs()
+	if rgParams != nil {
+		parties = rgParams.OldParties().IDs()
+	}
+	if r.t.Parties == nil {
+		r.t.Curve = curveName
+		r.t.Parties = newPartyIDs(parties)
+		r.t.Threshold = params.Threshold()
+		if rgParams != nil {
+			r.t.NewParties = newPartyIDs(rgParams.NewParties().IDs())
+			r.t.NewThreshold = rgParams.NewThreshold()
+		}
+	} else if curveName != r.t.Curve || len(parties) != len(r.t.Parties) || params.Threshold() != r.t.Threshold {
+		return errors.New("transcript: the parameters of the party differ from those of the parties recorded before")
+	}
+
+	in := &Input{
+		Party:        params.PartyID().Key,
+		NewCommittee: rgParams != nil && !rgParams.IsOldCommittee(),
+		NoProofMod:   params.NoProofMod(),
+		NoProofFac:   params.NoProofFac(),
+	}
+	if len(fullBytesLen) > 0 {
+		in.FullBytesLen = fullBytesLen[0]
+	}
+	if msg != nil {
+		in.Message = msg.Bytes()
+	}
+	var err error
+	if data != nil {
+		if in.Data, err = json.Marshal(data); err != nil {
+			return err
+		}
+	}
+	if in.Seed, err = common.GetRandomBytes(rand.Reader, seedLen); err != nil {
+		return err
+	}
+	if in.PartialKeySeed, err = common.GetRandomBytes(rand.Reader, seedLen); err != nil {
+		return err
+	}
+	if err = setSeededRandom(params, in); err != nil {
+		return err
+	}
+	r.t.Inputs = append(r.t.Inputs, in)
+	return nil
+}
+
+func (r *Recorder) recordMessage(msg tss.Message) {
+	bz, err := proto.Marshal(msg.WireMsg())
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if err != nil {
+		r.err = err
+		return
+	}
+	r.t.Messages = append(r.t.Messages, &Message{Wire: bz})
+}
+
+func (r *Recorder) recordOutput(output interface{}) {
+	bz, err := json.Marshal(output)
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if err != nil {
+		r.err = err
+		return
+	}
+	r.t.Outputs = append(r.t.Outputs, bz)
+}
@@ -0,0 +1,422 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package transcript
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	ecdsakeygen "github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	ecdsaresharing "github.com/bnb-chain/tss-lib/v2/ecdsa/resharing"
+	ecdsasigning "github.com/bnb-chain/tss-lib/v2/ecdsa/signing"
+	eddsakeygen "github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	eddsaresharing "github.com/bnb-chain/tss-lib/v2/eddsa/resharing"
+	eddsasigning "github.com/bnb-chain/tss-lib/v2/eddsa/signing"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// Divergence is the first point where a replay did not reproduce its transcript
+type Divergence struct {
+	// Message is the index of the recorded message that was not sent again, or was sent differently. It is -1 when
+	// every message was reproduced but a party failed, stalled or had a different output.
+	Message int
+	Reason  string
+}
+
+type replay struct {
+	t           *Transcript
+	old, new    tss.SortedPartyIDs
+	parties     []tss.Party
+	out         chan tss.Message
+	ecdsaEnd    chan *ecdsakeygen.LocalPartySaveData
+	eddsaEnd    chan *eddsakeygen.LocalPartySaveData
+	signatureCh chan *common.SignatureData
+
+	mtx  sync.Mutex
+	sent map[string][]*tss.MessageWrapper
+	err  *tss.Error
+}
+
+// Replay runs the recorded parties of a transcript again, with their inputs and seeded randomness, and returns the
+// first point where the run differs from the transcript, or nil when it does not. The messages of the replayed parties
+// are delivered to each other, and the recorded messages that they received from other parties are delivered to
+// them again. The replay gives up on parties that have not finished after timeout.
+func Replay(t *Transcript, timeout time.Duration) (*Divergence, error) {
+	rp, err := newReplay(t)
+	if err != nil {
+		return nil, err
+	}
+	stop, stopped := make(chan struct{}), make(chan struct{})
+	go rp.route(stop, stopped)
+	// a party only stores the messages it receives before it has started, so they are injected after
+	var started sync.WaitGroup
+	for _, P := range rp.parties {
+		started.Add(1)
+		go func(P tss.Party) {
+			defer started.Done()
+			if err := P.Start(); err != nil {
+				rp.fail(err)
+			}
+		}(P)
+	}
+	started.Wait()
+	for _, rec := range t.Messages {
+		if rec.ReceivedBy != nil {
+			if err := rp.inject(rec); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	deadline := time.After(timeout)
+	var stalled []string
+	for _, P := range rp.parties {
+		select {
+		case <-P.(tss.Lifecycle).Done():
+		case <-deadline:
+			stalled = append(stalled, P.PartyID().String())
+		}
+	}
+	close(stop)
+	<-stopped
+
+	rp.mtx.Lock()
+	defer rp.mtx.Unlock()
+	if d, err := rp.compareMessages(); d != nil || err != nil {
+		return d, err
+	}
+	if rp.err != nil {
+		return &Divergence{Message: -1, Reason: rp.err.Error()}, nil
+	}
+	if len(stalled) > 0 {
+		return &Divergence{Message: -1, Reason: fmt.Sprintf("parties %s did not finish", strings.Join(stalled, ", "))}, nil
+	}
+	return rp.compareOutputs()
+}
+
+func (d *Divergence) String() string {
+	if d.Message < 0 {
+		return d.Reason
+	}
+	return fmt.Sprintf("message %d: %s", d.Message, d.Reason)
+}
+
+// ----- //
+
+func newReplay(t *Transcript) (*replay, error) {
+	if t.Version != Version {
+		return nil, fmt.Errorf("transcript: unsupported version %d", t.Version)
+	}
+	ec, ok := tss.GetCurveByName(t.Curve)
+	if !ok {
+		return nil, fmt.Errorf("transcript: unknown curve %q", t.Curve)
+	}
+	rp := &replay{
+		t:           t,
+		out:         make(chan tss.Message, len(t.Inputs)),
+		ecdsaEnd:    make(chan *ecdsakeygen.LocalPartySaveData, len(t.Inputs)),
+		eddsaEnd:    make(chan *eddsakeygen.LocalPartySaveData, len(t.Inputs)),
+		signatureCh: make(chan *common.SignatureData, len(t.Inputs)),
+		sent:        make(map[string][]*tss.MessageWrapper),
+	}
+	var err error
+	if rp.old, err = sortedPartyIDs(t.Parties); err != nil {
+		return nil, err
+	}
+	isReSharing := t.Protocol == ECDSAReSharing || t.Protocol == EdDSAReSharing
+	if isReSharing {
+		if rp.new, err = sortedPartyIDs(t.NewParties); err != nil {
+			return nil, err
+		}
+	}
+	oldCtx, newCtx := tss.NewPeerContext(rp.old), tss.NewPeerContext(rp.new)
+
+	for _, in := range t.Inputs {
+		committee := rp.old
+		if in.NewCommittee {
+			committee = rp.new
+		}
+		pID := committee.FindByKey(new(big.Int).SetBytes(in.Party))
+		if pID == nil {
+			return nil, errors.New("transcript: an input is of a party that is not in the committee")
+		}
+		var params *tss.Parameters
+		var rgParams *tss.ReSharingParameters
+		if isReSharing {
+			rgParams = tss.NewReSharingParameters(ec, oldCtx, newCtx, pID, len(rp.old), t.Threshold, len(rp.new), t.NewThreshold)
+			params = rgParams.Parameters
+		} else {
+			params = tss.NewParameters(ec, oldCtx, pID, len(rp.old), t.Threshold)
+		}
+		if err := setSeededRandom(params, in); err != nil {
+			return nil, err
+		}
+		if in.NoProofMod {
+			params.SetNoProofMod()
+		}
+		if in.NoProofFac {
+			params.SetNoProofFac()
+		}
+		P, err := rp.newParty(in, params, rgParams)
+		if err != nil {
+			return nil, err
+		}
+		rp.parties = append(rp.parties, P)
+	}
+	return rp, nil
+}
+
+func (rp *replay) newParty(in *Input, params *tss.Parameters, rgParams *tss.ReSharingParameters) (tss.Party, error) {
+	msg := new(big.Int).SetBytes(in.Message)
+	var fullBytesLen []int
+	if in.FullBytesLen > 0 {
+		fullBytesLen = append(fullBytesLen, in.FullBytesLen)
+	}
+	switch rp.t.Protocol {
+	case ECDSAKeygen:
+		var preParams ecdsakeygen.LocalPreParams
+		if err := json.Unmarshal(in.Data, &preParams); err != nil {
+			return nil, err
+		}
+		return ecdsakeygen.NewLocalParty(params, rp.out, rp.ecdsaEnd, preParams), nil
+	case ECDSASigning:
+		var key ecdsakeygen.LocalPartySaveData
+		if err := json.Unmarshal(in.Data, &key); err != nil {
+			return nil, err
+		}
+		return ecdsasigning.NewLocalParty(msg, params, key, rp.out, rp.signatureCh, fullBytesLen...), nil
+	case ECDSAReSharing:
+		var key ecdsakeygen.LocalPartySaveData
+		if err := json.Unmarshal(in.Data, &key); err != nil {
+			return nil, err
+		}
+		return ecdsaresharing.NewLocalParty(rgParams, key, rp.out, rp.ecdsaEnd), nil
+	case EdDSAKeygen:
+		return eddsakeygen.NewLocalParty(params, rp.out, rp.eddsaEnd), nil
+	case EdDSASigning:
+		var key eddsakeygen.LocalPartySaveData
+		if err := json.Unmarshal(in.Data, &key); err != nil {
+			return nil, err
+		}
+		return eddsasigning.NewLocalParty(msg, params, key, rp.out, rp.signatureCh, fullBytesLen...), nil
+	case EdDSAReSharing:
+		var key eddsakeygen.LocalPartySaveData
+		if err := json.Unmarshal(in.Data, &key); err != nil {
+			return nil, err
+		}
+		return eddsaresharing.NewLocalParty(rgParams, key, rp.out, rp.eddsaEnd), nil
+	}
+	return nil, fmt.Errorf("transcript: unknown protocol %q", rp.t.Protocol)
+}
+
+// route delivers the messages of the replayed parties to each other and keeps them for the comparison. Once stopped, it
+// keeps the messages that are still queued, such as the last ones of the parties that finished.
+func (rp *replay) route(stop <-chan struct{}, stopped chan<- struct{}) {
+	defer close(stopped)
+	for {
+		select {
+		case msg := <-rp.out:
+			rp.keep(msg)
+			for _, P := range rp.parties {
+				if P.PartyID() != msg.GetFrom() && rp.isTo(P, msg) {
+					go rp.deliver(P, msg, msg.GetFrom())
+				}
+			}
+		case <-stop:
+			for len(rp.out) > 0 {
+				rp.keep(<-rp.out)
+			}
+			return
+		}
+	}
+}
+
+func (rp *replay) keep(msg tss.Message) {
+	wire := msg.WireMsg()
+	rp.mtx.Lock()
+	defer rp.mtx.Unlock()
+	key := messageKey(wire)
+	rp.sent[key] = append(rp.sent[key], wire)
+}
+
+// isTo follows the routing of a message, including to the committees of a re-sharing
+func (rp *replay) isTo(P tss.Party, msg tss.Message) bool {
+	to := msg.GetTo()
+	if to == nil {
+		return true
+	}
+	if rp.new != nil && !msg.IsToOldAndNewCommittees() {
+		isOld := rp.old.FindByKey(P.PartyID().KeyInt()) == P.PartyID()
+		if msg.IsToOldCommittee() != isOld {
+			return false
+		}
+	}
+	for _, pID := range to {
+		if bytes.Equal(pID.GetKey(), P.PartyID().GetKey()) {
+			return true
+		}
+	}
+	return false
+}
+
+// inject delivers a recorded message from a party that was not replayed
+func (rp *replay) inject(rec *Message) error {
+	wire := new(tss.MessageWrapper)
+	if err := proto.Unmarshal(rec.Wire, wire); err != nil {
+		return err
+	}
+	key := new(big.Int).SetBytes(wire.GetFrom().GetKey())
+	from := rp.old.FindByKey(key)
+	if from == nil && rp.new != nil {
+		from = rp.new.FindByKey(key)
+	}
+	if from == nil {
+		return errors.New("transcript: a recorded message is from a party that is not in the committees")
+	}
+	for _, P := range rp.parties {
+		if bytes.Equal(P.PartyID().GetKey(), rec.ReceivedBy) {
+			msg, err := tss.ParseWireMessage(mustMarshal(wire.GetMessage()), from, wire.GetIsBroadcast())
+			if err != nil {
+				return err
+			}
+			go rp.deliver(P, msg, from)
+		}
+	}
+	return nil
+}
+
+func (rp *replay) deliver(P tss.Party, msg tss.Message, from *tss.PartyID) {
+	bz, _, err := msg.WireBytes()
+	if err != nil {
+		rp.fail(P.WrapError(err))
+		return
+	}
+	if _, err := P.UpdateFromBytes(bz, from, msg.IsBroadcast()); err != nil {
+		rp.fail(err)
+	}
+}
+
+func (rp *replay) fail(err *tss.Error) {
+	rp.mtx.Lock()
+	defer rp.mtx.Unlock()
+	if rp.err == nil {
+		rp.err = err
+	}
+}
+
+// compareMessages finds the first recorded message that the replayed parties did not send again in the same way.
+// Messages are matched by their sender, recipients and type, as a party may send the messages of a round in any order.
+func (rp *replay) compareMessages() (*Divergence, error) {
+	expected := make(map[string][]int)
+	wires := make([]*tss.MessageWrapper, len(rp.t.Messages))
+	for i, rec := range rp.t.Messages {
+		if rec.ReceivedBy != nil {
+			continue
+		}
+		wires[i] = new(tss.MessageWrapper)
+		if err := proto.Unmarshal(rec.Wire, wires[i]); err != nil {
+			return nil, err
+		}
+		key := messageKey(wires[i])
+		expected[key] = append(expected[key], i)
+	}
+	var first *Divergence
+	diverge := func(i int, reason string) {
+		if first == nil || i < first.Message {
+			first = &Divergence{Message: i, Reason: reason}
+		}
+	}
+	for key, indices := range expected {
+		sent := rp.sent[key]
+		for n, i := range indices {
+			switch {
+			case n >= len(sent):
+				diverge(i, fmt.Sprintf("%s from %s was not sent", wires[i].GetMessage().GetTypeUrl(), wires[i].GetFrom().GetMoniker()))
+			case !proto.Equal(wires[i], sent[n]):
+				diverge(i, fmt.Sprintf("%s from %s has different content", wires[i].GetMessage().GetTypeUrl(), wires[i].GetFrom().GetMoniker()))
+			}
+		}
+	}
+	for key, sent := range rp.sent {
+		if extra := len(sent) - len(expected[key]); extra > 0 {
+			wire := sent[len(expected[key])]
+			diverge(len(rp.t.Messages), fmt.Sprintf("%s from %s was sent but not recorded", wire.GetMessage().GetTypeUrl(), wire.GetFrom().GetMoniker()))
+		}
+	}
+	return first, nil
+}
+
+func (rp *replay) compareOutputs() (*Divergence, error) {
+	var outputs []interface{}
+	for len(rp.ecdsaEnd) > 0 {
+		outputs = append(outputs, <-rp.ecdsaEnd)
+	}
+	for len(rp.eddsaEnd) > 0 {
+		outputs = append(outputs, <-rp.eddsaEnd)
+	}
+	for len(rp.signatureCh) > 0 {
+		outputs = append(outputs, <-rp.signatureCh)
+	}
+	got := make([]string, len(outputs))
+	for o, output := range outputs {
+		bz, err := json.Marshal(output)
+		if err != nil {
+			return nil, err
+		}
+		got[o] = string(bz)
+	}
+	expected := make([]string, len(rp.t.Outputs))
+	for o, output := range rp.t.Outputs {
+		// the outputs are indented when the transcript is written
+		var buf bytes.Buffer
+		if err := json.Compact(&buf, output); err != nil {
+			return nil, err
+		}
+		expected[o] = buf.String()
+	}
+	// the parties finish in any order
+	sort.Strings(got)
+	sort.Strings(expected)
+	if len(got) != len(expected) {
+		return &Divergence{Message: -1, Reason: fmt.Sprintf("%d outputs were recorded but %d were replayed", len(expected), len(got))}, nil
+	}
+	for o := range got {
+		if got[o] != expected[o] {
+			return &Divergence{Message: -1, Reason: "the outputs differ"}, nil
+		}
+	}
+	return nil, nil
+}
+
+// messageKey identifies a message by its sender, its recipients and its type
+func messageKey(wire *tss.MessageWrapper) string {
+	to := make([]string, len(wire.GetTo()))
+	for j, pID := range wire.GetTo() {
+		to[j] = hex.EncodeToString(pID.GetKey())
+	}
+	return fmt.Sprintf("%x|%s|%s|%t|%t|%t", wire.GetFrom().GetKey(), strings.Join(to, ","), wire.GetMessage().GetTypeUrl(),
+		wire.GetIsBroadcast(), wire.GetIsToOldCommittee(), wire.GetIsToOldAndNewCommittees())
+}
+
+func mustMarshal(m proto.Message) []byte {
+	bz, err := proto.Marshal(m)
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
@@ -0,0 +1,132 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Package transcript records a run of a protocol, with the inputs and the seeded randomness of its parties and every
+// message that they sent, so that the run can be replayed and checked for the first point where it diverges.
+//
+// A transcript holds the secret inputs of the parties, such as their key shares, and their randomness. Record only
+// test keys or keys that are about to be discarded.
+package transcript
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// Version is the version of the encoding of a transcript
+const Version = 1
+
+// Protocol names a protocol that can be recorded and replayed
+type Protocol string
+
+const (
+	ECDSAKeygen    Protocol = "ecdsa-keygen"
+	ECDSASigning   Protocol = "ecdsa-signing"
+	ECDSAReSharing Protocol = "ecdsa-resharing"
+	EdDSAKeygen    Protocol = "eddsa-keygen"
+	EdDSASigning   Protocol = "eddsa-signing"
+	EdDSAReSharing Protocol = "eddsa-resharing"
+)
+
+type (
+	// Transcript is a recorded run of a protocol
+	Transcript struct {
+		Version   int           `json:"version"`
+		Protocol  Protocol      `json:"protocol"`
+		Curve     tss.CurveName `json:"curve"`
+		Parties   []*PartyID    `json:"parties"` // the old committee in a re-sharing
+		Threshold int           `json:"threshold"`
+		// the new committee in a re-sharing
+		NewParties   []*PartyID `json:"newParties,omitempty"`
+		NewThreshold int        `json:"newThreshold,omitempty"`
+		// the inputs of the recorded parties, which may be only some of the parties of the run
+		Inputs []*Input `json:"inputs"`
+		// the messages sent by the recorded parties, and those that they received from the others, in order
+		Messages []*Message `json:"messages"`
+		// the outputs of the recorded parties as JSON, in the order they were sent
+		Outputs []json.RawMessage `json:"outputs"`
+	}
+
+	PartyID struct {
+		ID      string `json:"id"`
+		Moniker string `json:"moniker"`
+		Key     []byte `json:"key"`
+		Index   int    `json:"index"`
+	}
+
+	Message struct {
+		Wire []byte `json:"wire"` // the MessageWrapper
+		// the key of the recorded party that received the message, when it was sent by a party that was not recorded
+		ReceivedBy []byte `json:"receivedBy,omitempty"`
+	}
+
+	// Input is what a recorded party was created with
+	Input struct {
+		Party        []byte `json:"party"` // the key of the party
+		NewCommittee bool   `json:"newCommittee,omitempty"`
+		// the seeds of the random readers set on its parameters
+		Seed           []byte `json:"seed"`
+		PartialKeySeed []byte `json:"partialKeySeed"`
+		NoProofMod     bool   `json:"noProofMod,omitempty"`
+		NoProofFac     bool   `json:"noProofFac,omitempty"`
+		// the pre-params of an ECDSA keygen party, or the key data of a signing or re-sharing party, as JSON
+		Data         json.RawMessage `json:"data,omitempty"`
+		Message      []byte          `json:"message,omitempty"` // the message to sign
+		FullBytesLen int             `json:"fullBytesLen,omitempty"`
+	}
+)
+
+// ReadFile reads a transcript written by WriteFile
+func ReadFile(path string) (*Transcript, error) {
+	bz, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	t := new(Transcript)
+	if err = json.Unmarshal(bz, t); err != nil {
+		return nil, err
+	}
+	if t.Version != Version {
+		return nil, fmt.Errorf("transcript: unsupported version %d", t.Version)
+	}
+	return t, nil
+}
+
+// WriteFile writes the transcript as JSON
+func (t *Transcript) WriteFile(path string) error {
+	bz, err := json.MarshalIndent(t, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, bz, 0o600)
+}
+
+// ----- //
+
+func newPartyIDs(pIDs tss.SortedPartyIDs) []*PartyID {
+	ids := make([]*PartyID, len(pIDs))
+	for j, pID := range pIDs {
+		ids[j] = &PartyID{ID: pID.Id, Moniker: pID.Moniker, Key: pID.Key, Index: pID.Index}
+	}
+	return ids
+}
+
+func sortedPartyIDs(ids []*PartyID) (tss.SortedPartyIDs, error) {
+	if len(ids) == 0 {
+		return nil, errors.New("transcript: a committee has no parties")
+	}
+	pIDs := make(tss.SortedPartyIDs, len(ids))
+	for j, id := range ids {
+		pIDs[j] = tss.NewPartyID(id.ID, id.Moniker, new(big.Int).SetBytes(id.Key))
+		pIDs[j].Index = id.Index
+	}
+	return pIDs, nil
+}
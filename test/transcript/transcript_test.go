@@ -0,0 +1,194 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package transcript
+
+import (
+	"bytes"
+	"math/big"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/signing"
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+
+	testFixtureFileName = "signing.json"
+
+	replayTimeout = 2 * time.Minute
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+func TestReplaySigning(t *testing.T) {
+	setUp("info")
+
+	tr := recordSigning(t, testParticipants)
+	path := filepath.Join(t.TempDir(), "transcript.json")
+	assert.NoError(t, tr.WriteFile(path))
+	tr, err := ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, testThreshold+1, len(tr.Inputs))
+	assert.Equal(t, testThreshold+1, len(tr.Outputs))
+
+	d, err := Replay(tr, replayTimeout)
+	assert.NoError(t, err)
+	assert.Nil(t, d)
+}
+
+func TestReplayOneOfTheParties(t *testing.T) {
+	setUp("info")
+
+	tr := recordSigning(t, 1)
+	assert.Equal(t, 1, len(tr.Inputs))
+	received := 0
+	for _, msg := range tr.Messages {
+		if msg.ReceivedBy != nil {
+			received++
+		}
+	}
+	assert.NotZero(t, received)
+
+	d, err := Replay(tr, replayTimeout)
+	assert.NoError(t, err)
+	assert.Nil(t, d)
+}
+
+func TestReplayDiverges(t *testing.T) {
+	setUp("info")
+
+	tr := recordSigning(t, testParticipants)
+	// the first party now draws different randomness, so its first message differs
+	tr.Inputs[0].Seed[0] ^= 1
+	first := -1
+	for i, msg := range tr.Messages {
+		wire := new(tss.MessageWrapper)
+		assert.NoError(t, proto.Unmarshal(msg.Wire, wire))
+		if bytes.Equal(wire.GetFrom().GetKey(), tr.Inputs[0].Party) {
+			first = i
+			break
+		}
+	}
+
+	d, err := Replay(tr, replayTimeout)
+	assert.NoError(t, err)
+	if assert.NotNil(t, d) {
+		assert.Equal(t, first, d.Message, d.String())
+	}
+}
+
+// TestReplayFixture replays a transcript that was recorded by an earlier version, so that a change to the messages or
+// to the way the parties draw their randomness is noticed. The transcript is recorded when it does not exist.
+func TestReplayFixture(t *testing.T) {
+	setUp("info")
+
+	_, callerFileName, _, _ := runtime.Caller(0)
+	path := filepath.Join(filepath.Dir(callerFileName), "..", "_ecdsa_transcript_fixtures", testFixtureFileName)
+	tr, err := ReadFile(path)
+	if os.IsNotExist(err) {
+		tr = recordSigning(t, testParticipants)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+		assert.NoError(t, tr.WriteFile(path))
+		t.Logf("Saved a transcript fixture file: %s", path)
+		return
+	}
+	assert.NoError(t, err)
+
+	d, err := Replay(tr, replayTimeout)
+	assert.NoError(t, err)
+	assert.Nil(t, d)
+}
+
+// recordSigning signs with keygen fixtures and records the first `recorded` of the parties
+func recordSigning(t *testing.T, recorded int) *Transcript {
+	keys, signPIDs, err := keygen.LoadKeygenTestFixtures(testThreshold + 1)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	rec := NewRecorder(ECDSASigning)
+	msg := big.NewInt(42)
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]tss.Party, 0, len(signPIDs))
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		var P tss.Party
+		if i < recorded {
+			assert.NoError(t, rec.ECDSASigningParty(params, keys[i], msg))
+			P = signing.NewLocalParty(msg, params, keys[i], rec.Out(outCh), rec.SignatureEnd(endCh))
+		} else {
+			P = signing.NewLocalParty(msg, params, keys[i], outCh, endCh)
+		}
+		parties = append(parties, P)
+		go func(P tss.Party) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	update := func(P tss.Party, msg tss.Message) {
+		if P.PartyID().Index >= recorded {
+			test.SharedPartyUpdater(P, msg, errCh)
+			return
+		}
+		// the message must be recorded before the party can answer it
+		bz, _, err := msg.WireBytes()
+		if err != nil {
+			errCh <- P.WrapError(err)
+			return
+		}
+		pMsg, err := tss.ParseWireMessage(bz, msg.GetFrom(), msg.IsBroadcast())
+		if err != nil {
+			errCh <- P.WrapError(err)
+			return
+		}
+		rec.Received(P, pMsg)
+		if _, err := P.Update(pMsg); err != nil {
+			errCh <- err
+		}
+	}
+	for ended := 0; ended < len(signPIDs); {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+		case msg := <-outCh:
+			if dest := msg.GetTo(); dest != nil {
+				go update(parties[dest[0].Index], msg)
+				continue
+			}
+			for _, P := range parties {
+				if P.PartyID().Index != msg.GetFrom().Index {
+					go update(P, msg)
+				}
+			}
+		case <-endCh:
+			ended++
+		}
+	}
+	tr, err := rec.Transcript()
+	assert.NoError(t, err)
+	return tr
+}
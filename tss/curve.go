@@ -20,6 +20,7 @@ type CurveName string
 const (
 	Secp256k1 CurveName = "secp256k1"
 	Ed25519   CurveName = "ed25519"
+	Secp256r1 CurveName = "secp256r1" // NIST P-256
 )
 
 var (
@@ -34,6 +35,7 @@ func init() {
 	registry = make(map[CurveName]elliptic.Curve)
 	registry[Secp256k1] = s256k1.S256()
 	registry[Ed25519] = edwards.Edwards()
+	registry[Secp256r1] = elliptic.P256()
 }
 
 func RegisterCurve(name CurveName, curve elliptic.Curve) {
@@ -93,3 +95,8 @@ func S256() elliptic.Curve {
 func Edwards() elliptic.Curve {
 	return edwards.Edwards()
 }
+
+// NIST P-256 (secp256r1)
+func P256() elliptic.Curve {
+	return elliptic.P256()
+}
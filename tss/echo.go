@@ -0,0 +1,312 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package tss
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"sort"
+)
+
+type (
+	// EchoRound is implemented by the rounds of the protocols that support echo broadcast. Out returns the channel
+	// that the party sends its messages to.
+	EchoRound interface {
+		Round
+		Out() chan<- Message
+	}
+
+	// echoState tracks the consistency rounds of a party with echo broadcast enabled
+	echoState struct {
+		// the broadcast messages received that have not been echoed yet, by sender key and type, and those that have
+		pending map[string]ParsedMessage
+		echoed  map[string]struct{}
+
+		// the round whose consistency round has begun, and the last one that was checked
+		round, checked int
+		digests        map[string][]byte   // by the key of the sender
+		senders        map[string]*PartyID // by key, the senders of the broadcast messages
+		group          map[string]*PartyID // by key, the parties that the echoes are exchanged with
+
+		// the echoes received, by round and by the key of their sender
+		echoes map[int]map[string]*EchoMessage
+
+		// the envelopes that carried the broadcast messages of the round, by the key of their sender, and the witness
+		// that provides and checks them when the messages are received in signed envelopes
+		envelopes map[string][][]byte
+		witness   BroadcastWitness
+	}
+
+	// BroadcastWitness provides the signed envelopes that carried the broadcast messages of a party, which its echoes
+	// attach, and checks those attached to the echoes of other parties. envelope.NewGuard sets its Guard as the
+	// witness of its party (see SetBroadcastWitness).
+	BroadcastWitness interface {
+		// Witness returns the signed envelope that carried a broadcast message received by the party, or nil
+		Witness(msg ParsedMessage) []byte
+		// ProvesEquivocation returns whether a and b are envelopes that from signed for two different versions of a
+		// broadcast message of round
+		ProvesEquivocation(from *PartyID, round int, a, b []byte) bool
+	}
+
+	// BroadcastMismatch is the cause of the error of a party whose echoes show that the broadcast messages of Sender
+	// in Round were received in different versions. The echoers are never named as culprits, and Sender is named only
+	// when the party holds two versions of the broadcast that Sender signed, which are then kept in Proof: the
+	// envelope it received and one attached to an echo (see BroadcastWitness). A digest alone could have been made up
+	// by the echoer to frame an honest sender, so without such proof the error has no culprits.
+	BroadcastMismatch struct {
+		Sender *PartyID
+		Round  int
+		Proof  [][]byte
+	}
+)
+
+func (e *BroadcastMismatch) Error() string {
+	return fmt.Sprintf("echo broadcast: the parties received different broadcast messages of %s in round %d", e.Sender, e.Round)
+}
+
+func (m *EchoMessage) ValidateBasic() bool {
+	if m == nil || m.GetRound() < 1 {
+		return false
+	}
+	for _, d := range m.GetDigests() {
+		if len(d.GetPartyKey()) == 0 || len(d.GetHash()) != sha256.Size {
+			return false
+		}
+	}
+	return true
+}
+
+// SetBroadcastWitness makes the echoes of a party attach the signed envelopes of the broadcast messages they echo, as
+// provided by w, so that a sender whose broadcast was received in two versions that it signed is named as the culprit
+// of the BroadcastMismatch. envelope.NewGuard calls it.
+func SetBroadcastWitness(p Party, w BroadcastWitness) {
+	p.lock()
+	defer p.unlock()
+	p.echoState().witness = w
+}
+
+// ----- //
+
+func newEchoState() *echoState {
+	return &echoState{
+		pending: make(map[string]ParsedMessage),
+		echoed:  make(map[string]struct{}),
+		echoes:  make(map[int]map[string]*EchoMessage),
+	}
+}
+
+// record keeps a broadcast message until it is echoed in the consistency round of the round that accepts it
+func (st *echoState) record(msg ParsedMessage) {
+	wire := msg.WireMsg()
+	if wire == nil || wire.GetMessage() == nil {
+		return
+	}
+	id := string(msg.GetFrom().GetKey()) + "/" + wire.GetMessage().GetTypeUrl()
+	if _, ok := st.echoed[id]; !ok {
+		st.pending[id] = msg
+	}
+}
+
+// receive keeps an echo until its round is checked
+func (st *echoState) receive(msg ParsedMessage) {
+	echo := msg.Content().(*EchoMessage)
+	round := int(echo.GetRound())
+	if round <= st.checked {
+		return
+	}
+	if st.echoes[round] == nil {
+		st.echoes[round] = make(map[string]*EchoMessage)
+	}
+	st.echoes[round][string(msg.GetFrom().GetKey())] = echo
+}
+
+// waitingFor returns the parties whose echoes have not been received yet in the consistency round of round
+func (st *echoState) waitingFor(round int) []*PartyID {
+	waiting := make([]*PartyID, 0)
+	if st.round != round || len(st.digests) == 0 {
+		return waiting
+	}
+	for key, pID := range st.group {
+		if _, ok := st.echoes[st.round][key]; !ok {
+			waiting = append(waiting, pID)
+		}
+	}
+	return waiting
+}
+
+// echoBroadcast runs the consistency round after the current round of the party, which can proceed. It returns
+// whether the party must wait for more echoes before it advances. The caller holds the lock.
+func echoBroadcast(p Party) (waiting bool, err *Error) {
+	rnd := p.round()
+	if !rnd.Params().EchoBroadcast() {
+		return false, nil
+	}
+	st := p.echoState()
+	if st.round != rnd.RoundNumber() {
+		if err := st.begin(rnd); err != nil {
+			return false, err
+		}
+	}
+	if len(st.digests) == 0 {
+		st.checked = st.round // no broadcast was received in this round
+		return false, nil
+	}
+	echoes := st.echoes[st.round]
+	for key := range st.group {
+		if _, ok := echoes[key]; !ok {
+			return true, nil
+		}
+	}
+	// a broadcast received in different versions is found by comparing the digests. its sender is named only if it
+	// signed both versions, as a digest could be made up by the echoer to frame it.
+	if mismatch := st.mismatch(echoes); mismatch != nil {
+		culprits := make([]*PartyID, 0, 1)
+		if mismatch.Proof != nil {
+			culprits = append(culprits, mismatch.Sender)
+		}
+		return false, rnd.WrapError(mismatch, culprits...)
+	}
+	delete(st.echoes, st.round)
+	st.checked, st.digests, st.envelopes = st.round, nil, nil
+	return false, nil
+}
+
+// mismatch returns the first sender, by key, of a broadcast whose digest in the echoes differs from this party's. The
+// envelopes attached to the echoes that differ are checked against those this party received, for proof that the
+// sender signed both versions.
+func (st *echoState) mismatch(echoes map[string]*EchoMessage) *BroadcastMismatch {
+	senderKeys := make([]string, 0, len(st.digests))
+	for key := range st.digests {
+		senderKeys = append(senderKeys, key)
+	}
+	sort.Strings(senderKeys)
+	echoerKeys := make([]string, 0, len(st.group))
+	for key := range st.group {
+		echoerKeys = append(echoerKeys, key)
+	}
+	sort.Strings(echoerKeys)
+	for _, senderKey := range senderKeys {
+		var found *BroadcastMismatch
+		for _, key := range echoerKeys {
+			for _, d := range echoes[key].GetDigests() {
+				if string(d.GetPartyKey()) != senderKey || bytes.Equal(st.digests[senderKey], d.GetHash()) {
+					continue
+				}
+				if found == nil {
+					found = &BroadcastMismatch{Sender: st.senders[senderKey], Round: st.round}
+				}
+				if proof := st.prove(found.Sender, d.GetEnvelopes()); proof != nil {
+					found.Proof = proof
+					return found
+				}
+			}
+		}
+		if found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// prove returns an envelope this party received from sender and one of envelopes that sender signed for two different
+// versions of a broadcast, or nil if there are none
+func (st *echoState) prove(sender *PartyID, envelopes [][]byte) [][]byte {
+	if st.witness == nil {
+		return nil
+	}
+	for _, ours := range st.envelopes[string(sender.GetKey())] {
+		for _, theirs := range envelopes {
+			if st.witness.ProvesEquivocation(sender, st.round, ours, theirs) {
+				return [][]byte{ours, theirs}
+			}
+		}
+	}
+	return nil
+}
+
+// begin starts the consistency round of rnd by sending the digests of the broadcast messages that rnd accepted
+func (st *echoState) begin(rnd Round) *Error {
+	st.round = rnd.RoundNumber()
+	st.digests, st.senders, st.group = nil, make(map[string]*PartyID), make(map[string]*PartyID)
+	st.envelopes = make(map[string][][]byte)
+
+	received := make(map[string][]ParsedMessage)
+	for id, msg := range st.pending {
+		if !rnd.CanAccept(msg) {
+			continue
+		}
+		delete(st.pending, id)
+		st.echoed[id] = struct{}{}
+		key := string(msg.GetFrom().GetKey())
+		received[key] = append(received[key], msg)
+		st.senders[key] = msg.GetFrom()
+	}
+	if len(received) == 0 {
+		return nil
+	}
+	out, ok := rnd.(EchoRound)
+	if !ok {
+		return rnd.WrapError(fmt.Errorf("echo broadcast is not supported by %T", rnd))
+	}
+
+	// the echoes are exchanged within the committee of the party. a party ignores its own echo.
+	self := rnd.Params().PartyID()
+	routing := MessageRouting{From: self, IsBroadcast: true}
+	committee := rnd.Params().Parties().IDs()
+	if reSharing, ok := rnd.(interface{ ReSharingParams() *ReSharingParameters }); ok {
+		if params := reSharing.ReSharingParams(); params.IsOldCommittee() {
+			committee, routing.To, routing.IsToOldCommittee = params.OldParties().IDs(), params.OldParties().IDs(), true
+		} else {
+			committee, routing.To = params.NewParties().IDs(), params.NewParties().IDs()
+		}
+	}
+	for _, pID := range committee {
+		if !bytes.Equal(pID.GetKey(), self.GetKey()) {
+			st.group[string(pID.GetKey())] = pID
+		}
+	}
+
+	st.digests = make(map[string][]byte, len(received))
+	echo := &EchoMessage{Round: int32(st.round)}
+	for key, msgs := range received {
+		st.digests[key] = broadcastDigest(msgs)
+		if st.witness != nil {
+			for _, msg := range msgs {
+				if env := st.witness.Witness(msg); env != nil {
+					st.envelopes[key] = append(st.envelopes[key], env)
+				}
+			}
+		}
+		echo.Digests = append(echo.Digests, &EchoMessage_Digest{PartyKey: []byte(key), Hash: st.digests[key],
+			Envelopes: st.envelopes[key]})
+	}
+	sort.Slice(echo.Digests, func(i, j int) bool {
+		return bytes.Compare(echo.Digests[i].GetPartyKey(), echo.Digests[j].GetPartyKey()) < 0
+	})
+	out.Out() <- WithRound(NewMessage(routing, echo, NewMessageWrapper(routing, echo)), st.round)
+	return nil
+}
+
+// broadcastDigest hashes the broadcast messages of a party in a round as they were received
+func broadcastDigest(msgs []ParsedMessage) []byte {
+	sort.Slice(msgs, func(i, j int) bool {
+		return msgs[i].WireMsg().GetMessage().GetTypeUrl() < msgs[j].WireMsg().GetMessage().GetTypeUrl()
+	})
+	h := sha256.New()
+	for _, msg := range msgs {
+		any := msg.WireMsg().GetMessage()
+		for _, bz := range [][]byte{[]byte(any.GetTypeUrl()), any.GetValue()} {
+			var length [8]byte
+			binary.BigEndian.PutUint64(length[:], uint64(len(bz)))
+			h.Write(length[:])
+			h.Write(bz)
+		}
+	}
+	return h.Sum(nil)
+}
@@ -0,0 +1,190 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package tss
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/curve25519"
+	"golang.org/x/crypto/hkdf"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// p2pEncryptionInfo separates the keys of point-to-point messages from other uses of the X25519 keys
+const p2pEncryptionInfo = "tss-lib/p2p-encryption/v1"
+
+// DecryptionKey is the X25519 key a party decrypts its point-to-point messages with. Its public key goes in the
+// EncryptionKey of the party's PartyID, as known to the other parties.
+type DecryptionKey struct {
+	private, public []byte
+}
+
+// GenerateDecryptionKey returns a new decryption key
+func GenerateDecryptionKey(rand io.Reader) (*DecryptionKey, error) {
+	private := make([]byte, curve25519.ScalarSize)
+	if _, err := io.ReadFull(rand, private); err != nil {
+		return nil, err
+	}
+	return NewDecryptionKey(private)
+}
+
+// NewDecryptionKey returns the decryption key with the given X25519 private key, as returned by Bytes
+func NewDecryptionKey(private []byte) (*DecryptionKey, error) {
+	if len(private) != curve25519.ScalarSize {
+		return nil, errors.New("NewDecryptionKey: the private key must be 32 bytes")
+	}
+	public, err := curve25519.X25519(private, curve25519.Basepoint)
+	if err != nil {
+		return nil, err
+	}
+	return &DecryptionKey{private: append([]byte(nil), private...), public: public}, nil
+}
+
+// PublicKey returns the X25519 public key that the other parties encrypt to
+func (k *DecryptionKey) PublicKey() []byte {
+	return append([]byte(nil), k.public...)
+}
+
+// Bytes returns the X25519 private key
+func (k *DecryptionKey) Bytes() []byte {
+	return append([]byte(nil), k.private...)
+}
+
+func (m *EncryptedMessage) ValidateBasic() bool {
+	return m != nil && len(m.GetEphemeralKey()) == curve25519.PointSize && len(m.GetCiphertext()) > 0
+}
+
+// EncryptMessage returns a point-to-point message with its wire encrypted to the EncryptionKey of its recipient, and
+// its content left as it is for the sender. The ephemeral key is drawn from the randomness of params. Broadcasts, and
+// messages to several parties or to a party without an EncryptionKey, are returned as they are. The rounds pass each
+// point-to-point message through it before sending it.
+func EncryptMessage(params *Parameters, msg ParsedMessage) (ParsedMessage, error) {
+	to := msg.GetTo()
+	if msg.IsBroadcast() || len(to) != 1 || len(to[0].EncryptionKey) == 0 {
+		return msg, nil
+	}
+	enc, err := encryptContent(msg.WireMsg().GetMessage(), msg.GetFrom(), to[0], params.Rand())
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt a message to %s: %v", to[0], err)
+	}
+	wire := proto.Clone(msg.WireMsg()).(*MessageWrapper)
+	wire.Message = enc
+	meta := MessageRouting{
+		From:                    msg.GetFrom(),
+		To:                      to,
+		IsBroadcast:             msg.IsBroadcast(),
+		IsToOldCommittee:        msg.IsToOldCommittee(),
+		IsToOldAndNewCommittees: msg.IsToOldAndNewCommittees(),
+	}
+	return WithRound(NewMessage(meta, msg.Content(), wire), RoundOf(msg)), nil
+}
+
+// ----- //
+
+// encryptContent encrypts the content of a point-to-point message to the encryption key of its recipient, with an
+// ephemeral X25519 key. The keys of the sender and the recipient are authenticated along with it.
+func encryptContent(content *anypb.Any, from, to *PartyID, rand io.Reader) (*anypb.Any, error) {
+	if content == nil {
+		return nil, errors.New("the message has no content")
+	}
+	plaintext, err := proto.Marshal(content)
+	if err != nil {
+		return nil, err
+	}
+	ephemeral, err := GenerateDecryptionKey(rand)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := p2pCipher(ephemeral.private, to.EncryptionKey, ephemeral.public, to.EncryptionKey)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize()) // the key is used only once
+	enc := &EncryptedMessage{
+		EphemeralKey: ephemeral.public,
+		Ciphertext:   aead.Seal(nil, nonce, plaintext, p2pAdditionalData(from, to)),
+	}
+	return anypb.New(enc)
+}
+
+// decryptMessage decrypts a message received by the party self, keeping its routing and wire
+func decryptMessage(msg ParsedMessage, key *DecryptionKey, self *PartyID) (ParsedMessage, error) {
+	enc, ok := msg.Content().(*EncryptedMessage)
+	if !ok || !enc.ValidateBasic() {
+		return nil, errors.New("the message is not an encrypted message")
+	}
+	aead, err := p2pCipher(key.private, enc.GetEphemeralKey(), enc.GetEphemeralKey(), key.public)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	plaintext, err := aead.Open(nil, nonce, enc.GetCiphertext(), p2pAdditionalData(msg.GetFrom(), self))
+	if err != nil {
+		return nil, errors.New("failed to decrypt the message")
+	}
+	inner := new(anypb.Any)
+	if err := proto.Unmarshal(plaintext, inner); err != nil {
+		return nil, err
+	}
+	m, err := inner.UnmarshalNew()
+	if err != nil {
+		return nil, err
+	}
+	content, ok := m.(MessageContent)
+	if !ok {
+		return nil, fmt.Errorf("the encrypted message contained unknown content")
+	}
+	if _, ok := content.(*EncryptedMessage); ok {
+		return nil, errors.New("the encrypted message contained another encrypted message")
+	}
+	meta := MessageRouting{
+		From:        msg.GetFrom(),
+		To:          msg.GetTo(),
+		IsBroadcast: msg.IsBroadcast(),
+	}
+	return NewMessage(meta, content, msg.WireMsg()), nil
+}
+
+// wasEncrypted returns whether the message arrived encrypted, which is seen on its wire
+func wasEncrypted(msg ParsedMessage) bool {
+	wire := msg.WireMsg()
+	return wire != nil && wire.GetMessage() != nil && wire.GetMessage().MessageIs((*EncryptedMessage)(nil))
+}
+
+func p2pCipher(private, peer, ephemeral, recipient []byte) (cipher.AEAD, error) {
+	shared, err := curve25519.X25519(private, peer)
+	if err != nil {
+		return nil, err
+	}
+	salt := append(append([]byte(nil), ephemeral...), recipient...)
+	key := make([]byte, 32)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, shared, salt, []byte(p2pEncryptionInfo)), key); err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func p2pAdditionalData(from, to *PartyID) []byte {
+	ad := make([]byte, 0, 8+len(from.GetKey())+len(to.GetKey()))
+	for _, key := range [][]byte{from.GetKey(), to.GetKey()} {
+		var length [4]byte
+		binary.BigEndian.PutUint32(length[:], uint32(len(key)))
+		ad = append(append(ad, length[:]...), key...)
+	}
+	return ad
+}
@@ -0,0 +1,398 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Package envelope protects the messages of a party against spoofing and replays. Each party holds a long-term ed25519
+// identity key and uses the identity public key as the key of its PartyID. A Sealer signs the messages of a party in
+// SignedEnvelopes, which carry a session ID, a round and a sequence number, and a Guard checks them before they reach
+// the receiving party.
+package envelope
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// signingDomain separates the signatures of envelopes from other uses of the identity keys
+const signingDomain = "tss-lib/envelope/v1"
+
+type (
+	// Sealer signs the messages of a party
+	Sealer struct {
+		sessionID string
+		key       ed25519.PrivateKey
+		self      *tss.PartyID
+
+		mtx      sync.Mutex
+		sequence uint64
+	}
+
+	// Guard checks the envelopes received by a party and passes on the messages in those that are valid
+	Guard struct {
+		party     tss.Party
+		sessionID string
+		// the IDs of the parties that the party exchanges messages with, as known to the party
+		peers []*tss.PartyID
+
+		mtx sync.Mutex
+		// the sequence numbers received from each peer, with the rounds they are labelled with
+		seen map[string]map[uint64]uint32
+		// the envelopes of the broadcast messages received from each peer, as proof of what it sent
+		broadcasts map[string][]*SignedEnvelope
+		// the last round that the party got to, when seen was last pruned
+		pruned int
+	}
+)
+
+// NewPartyID returns a PartyID whose key is the identity public key of the party
+func NewPartyID(id, moniker string, identity ed25519.PublicKey) *tss.PartyID {
+	return tss.NewPartyID(id, moniker, new(big.Int).SetBytes(identity))
+}
+
+// IdentityOf returns the identity public key that the key of a PartyID is bound to
+func IdentityOf(pID *tss.PartyID) (ed25519.PublicKey, error) {
+	return identityOfKey(pID.GetKey())
+}
+
+// NewSealer returns the Sealer of a party, whose PartyID must be bound to the public key of key
+func NewSealer(sessionID string, key ed25519.PrivateKey, self *tss.PartyID) (*Sealer, error) {
+	if len(key) != ed25519.PrivateKeySize {
+		return nil, errors.New("envelope: invalid identity key")
+	}
+	identity, err := IdentityOf(self)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(identity, key.Public().(ed25519.PublicKey)) {
+		return nil, errors.New("envelope: the key of the PartyID is not bound to the identity key")
+	}
+	return &Sealer{sessionID: sessionID, key: key, self: self}, nil
+}
+
+// Seal signs a message of the party in a SignedEnvelope and returns its encoding, which is sent to the recipients of
+// the message in place of its wire bytes. The envelope is labelled with the round that sent the message (see
+// tss.RoundOf).
+func (s *Sealer) Seal(msg tss.Message) ([]byte, error) {
+	wireBytes, routing, err := msg.WireBytes()
+	if err != nil {
+		return nil, err
+	}
+	if routing.From == nil || routing.From.KeyInt().Cmp(s.self.KeyInt()) != 0 {
+		return nil, errors.New("envelope: the message is not from the party of the sealer")
+	}
+	s.mtx.Lock()
+	s.sequence++
+	sequence := s.sequence
+	s.mtx.Unlock()
+
+	env := &SignedEnvelope{
+		SessionId:               s.sessionID,
+		Round:                   uint32(tss.RoundOf(msg)),
+		Sequence:                sequence,
+		From:                    s.self.GetKey(),
+		IsBroadcast:             routing.IsBroadcast,
+		IsToOldCommittee:        routing.IsToOldCommittee,
+		IsToOldAndNewCommittees: routing.IsToOldAndNewCommittees,
+		Message:                 wireBytes,
+	}
+	for _, to := range routing.To {
+		env.To = append(env.To, to.GetKey())
+	}
+	env.Signature = ed25519.Sign(s.key, signingPayload(env))
+	return proto.Marshal(env)
+}
+
+// NewGuard returns the Guard of a party. peers are the parties it exchanges messages with, e.g.
+// params.Parties().IDs(), or params.OldAndNewParties() in a re-sharing. All of the messages of the party must then
+// be received through the Guard, as it marks the messages of the party as authenticated (see tss.SetAuthenticated)
+// and is the witness of its echoes (see tss.SetBroadcastWitness).
+func NewGuard(party tss.Party, sessionID string, peers []*tss.PartyID) *Guard {
+	g := &Guard{
+		party:      party,
+		sessionID:  sessionID,
+		peers:      peers,
+		seen:       make(map[string]map[uint64]uint32, len(peers)),
+		broadcasts: make(map[string][]*SignedEnvelope, len(peers)),
+	}
+	tss.SetAuthenticated(party)
+	tss.SetBroadcastWitness(party, g)
+	return g
+}
+
+// Broadcasts returns the envelopes of the broadcast messages labelled with round that the party received from a peer,
+// which can be checked with Equivocation against those that other parties received. The envelopes of the rounds
+// before the current round of the party are dropped.
+func (g *Guard) Broadcasts(from *tss.PartyID, round int) [][]byte {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+	envs := make([][]byte, 0)
+	for _, env := range g.broadcasts[string(from.GetKey())] {
+		if int(env.GetRound()) != round {
+			continue
+		}
+		if bz, err := proto.Marshal(env); err == nil {
+			envs = append(envs, bz)
+		}
+	}
+	return envs
+}
+
+// Witness returns the envelope that carried a broadcast message received by the party, or nil if it was not kept.
+// The echoes of the party attach it, as the proof of what the sender sent.
+func (g *Guard) Witness(msg tss.ParsedMessage) []byte {
+	wire := msg.WireMsg().GetMessage()
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+	for _, env := range g.broadcasts[string(msg.GetFrom().GetKey())] {
+		any := new(anypb.Any)
+		if err := proto.Unmarshal(env.GetMessage(), any); err != nil {
+			continue
+		}
+		if any.GetTypeUrl() == wire.GetTypeUrl() && bytes.Equal(any.GetValue(), wire.GetValue()) {
+			if bz, err := proto.Marshal(env); err == nil {
+				return bz
+			}
+		}
+	}
+	return nil
+}
+
+// ProvesEquivocation returns whether a and b are envelopes of the session that from signed for two different versions
+// of a broadcast message of round, as checked by Equivocation
+func (g *Guard) ProvesEquivocation(from *tss.PartyID, round int, a, b []byte) bool {
+	key, err := Equivocation(g.sessionID, a, b)
+	if err != nil || !bytes.Equal(key, from.GetKey()) {
+		return false
+	}
+	for _, bz := range [][]byte{a, b} {
+		env := new(SignedEnvelope)
+		if err := proto.Unmarshal(bz, env); err != nil || int64(env.GetRound()) != int64(round) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equivocation checks two envelopes of broadcast messages of the same type that were signed for a session, and
+// returns the key of their sender if it signed two different messages: proof that it sent different versions of its
+// broadcast to different parties. It fails unless both envelopes are signed by the same sender and are labelled with
+// the same round.
+func Equivocation(sessionID string, a, b []byte) ([]byte, error) {
+	envs, anys := make([]*SignedEnvelope, 2), make([]*anypb.Any, 2)
+	for i, bz := range [][]byte{a, b} {
+		env := new(SignedEnvelope)
+		if err := proto.Unmarshal(bz, env); err != nil {
+			return nil, fmt.Errorf("envelope: malformed envelope: %v", err)
+		}
+		identity, err := identityOfKey(env.GetFrom())
+		if err != nil {
+			return nil, err
+		}
+		if !ed25519.Verify(identity, signingPayload(env), env.GetSignature()) {
+			return nil, errors.New("envelope: invalid signature")
+		}
+		if env.GetSessionId() != sessionID || !env.GetIsBroadcast() {
+			return nil, errors.New("envelope: not a broadcast of the session")
+		}
+		any := new(anypb.Any)
+		if err := proto.Unmarshal(env.GetMessage(), any); err != nil {
+			return nil, fmt.Errorf("envelope: malformed message: %v", err)
+		}
+		envs[i], anys[i] = env, any
+	}
+	switch {
+	case !bytes.Equal(envs[0].GetFrom(), envs[1].GetFrom()):
+		return nil, errors.New("envelope: the envelopes are from different parties")
+	case envs[0].GetRound() != envs[1].GetRound():
+		return nil, errors.New("envelope: the envelopes are labelled with different rounds")
+	case anys[0].GetTypeUrl() != anys[1].GetTypeUrl():
+		return nil, errors.New("envelope: the envelopes hold messages of different types")
+	case bytes.Equal(anys[0].GetValue(), anys[1].GetValue()):
+		return nil, errors.New("envelope: the envelopes hold the same message")
+	}
+	return envs[0].GetFrom(), nil
+}
+
+// Update checks an envelope sealed by another party and updates the party with its message. Envelopes that are not
+// signed by their sender, were sealed for another session or another party, were already received, or are labelled
+// with a round before the current round of the party are rejected with the sender as the culprit.
+func (g *Guard) Update(bz []byte) (ok bool, err *tss.Error) {
+	env := new(SignedEnvelope)
+	if err := proto.Unmarshal(bz, env); err != nil {
+		return false, g.party.WrapError(fmt.Errorf("envelope: malformed envelope: %v", err))
+	}
+	from := g.findPeer(env.GetFrom())
+	if from == nil {
+		return false, g.party.WrapError(errors.New("envelope: the sender is not a party of the protocol"))
+	}
+	if err := g.check(env); err != nil {
+		return false, g.party.WrapError(err, from)
+	}
+	return g.party.UpdateFromBytes(env.GetMessage(), from, env.GetIsBroadcast())
+}
+
+// ----- //
+
+func (g *Guard) check(env *SignedEnvelope) error {
+	identity, err := identityOfKey(env.GetFrom())
+	if err != nil {
+		return err
+	}
+	if !ed25519.Verify(identity, signingPayload(env), env.GetSignature()) {
+		return errors.New("envelope: invalid signature")
+	}
+	if env.GetSessionId() != g.sessionID {
+		return fmt.Errorf("envelope: the message is from session %q", env.GetSessionId())
+	}
+	if err := proto.Unmarshal(env.GetMessage(), new(anypb.Any)); err != nil {
+		return fmt.Errorf("envelope: malformed message: %v", err)
+	}
+	if len(env.GetTo()) > 0 && !containsKey(env.GetTo(), g.party.PartyID().GetKey()) {
+		return errors.New("envelope: the message is addressed to other parties")
+	}
+	current := tss.CurrentRound(g.party)
+
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+	g.prune(current)
+	// once the party has finished, the last round it was in is kept as the floor, as the sequence numbers of the
+	// rounds before it have been forgotten
+	if err := checkRound(env.GetRound(), g.pruned); err != nil {
+		return err
+	}
+	seen, ok := g.seen[string(env.GetFrom())]
+	if !ok {
+		seen = make(map[uint64]uint32)
+		g.seen[string(env.GetFrom())] = seen
+	}
+	if _, dup := seen[env.GetSequence()]; dup {
+		return fmt.Errorf("envelope: message %d was already received", env.GetSequence())
+	}
+	seen[env.GetSequence()] = env.GetRound()
+	if env.GetIsBroadcast() && 0 < env.GetRound() {
+		g.broadcasts[string(env.GetFrom())] = append(g.broadcasts[string(env.GetFrom())], env)
+	}
+	return nil
+}
+
+// checkRound checks the round that a message is labelled with against the last round that the party receiving it got
+// to. A party only moves past a round once it has the messages of that round, so an earlier round is a replay or a
+// deviation. A peer may be several rounds ahead, e.g. a party of the new committee in a re-sharing, whose messages are
+// kept by the party until it gets to their round. Messages labelled without a round, and those received before the
+// party has started, are not checked.
+func checkRound(label uint32, reached int) error {
+	if label == 0 || reached <= 0 {
+		return nil
+	}
+	if int64(label) < int64(reached) {
+		return fmt.Errorf("envelope: a message of round %d was received in round %d", label, reached)
+	}
+	return nil
+}
+
+// prune forgets the sequence numbers, and the broadcasts, of the messages labelled with rounds before the current
+// round of the party, as checkRound rejects those messages. The round is kept in pruned, which stays at the last round
+// of the party once it has finished; the caller holds the lock
+func (g *Guard) prune(current int) {
+	if current <= g.pruned {
+		return
+	}
+	g.pruned = current
+	for _, seen := range g.seen {
+		for sequence, round := range seen {
+			if 0 < round && int(round) < current {
+				delete(seen, sequence)
+			}
+		}
+	}
+	for key, envs := range g.broadcasts {
+		kept := envs[:0]
+		for _, env := range envs {
+			if current <= int(env.GetRound()) {
+				kept = append(kept, env)
+			}
+		}
+		g.broadcasts[key] = kept
+	}
+}
+
+func (g *Guard) findPeer(key []byte) *tss.PartyID {
+	if len(key) == 0 {
+		return nil
+	}
+	keyInt := new(big.Int).SetBytes(key)
+	for _, pID := range g.peers {
+		if pID.KeyInt().Cmp(keyInt) == 0 {
+			return pID
+		}
+	}
+	return nil
+}
+
+// signingPayload encodes the fields of an envelope that are signed, each with its length
+func signingPayload(env *SignedEnvelope) []byte {
+	var buf bytes.Buffer
+	writeField := func(bz []byte) {
+		var length [8]byte
+		binary.BigEndian.PutUint64(length[:], uint64(len(bz)))
+		buf.Write(length[:])
+		buf.Write(bz)
+	}
+	writeUint := func(i uint64) {
+		var bz [8]byte
+		binary.BigEndian.PutUint64(bz[:], i)
+		buf.Write(bz[:])
+	}
+	writeBool := func(b bool) {
+		if b {
+			buf.WriteByte(1)
+		} else {
+			buf.WriteByte(0)
+		}
+	}
+	writeField([]byte(signingDomain))
+	writeField([]byte(env.GetSessionId()))
+	writeUint(uint64(env.GetRound()))
+	writeUint(env.GetSequence())
+	writeField(env.GetFrom())
+	writeUint(uint64(len(env.GetTo())))
+	for _, to := range env.GetTo() {
+		writeField(to)
+	}
+	writeBool(env.GetIsBroadcast())
+	writeBool(env.GetIsToOldCommittee())
+	writeBool(env.GetIsToOldAndNewCommittees())
+	writeField(env.GetMessage())
+	return buf.Bytes()
+}
+
+func identityOfKey(key []byte) (ed25519.PublicKey, error) {
+	keyInt := new(big.Int).SetBytes(key)
+	if keyInt.Sign() == 0 || keyInt.BitLen() > 8*ed25519.PublicKeySize {
+		return nil, errors.New("envelope: the key of the PartyID is not an identity public key")
+	}
+	identity := make([]byte, ed25519.PublicKeySize)
+	return keyInt.FillBytes(identity), nil
+}
+
+func containsKey(keys [][]byte, key []byte) bool {
+	keyInt := new(big.Int).SetBytes(key)
+	for _, k := range keys {
+		if new(big.Int).SetBytes(k).Cmp(keyInt) == 0 {
+			return true
+		}
+	}
+	return false
+}
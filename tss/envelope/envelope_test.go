@@ -0,0 +1,387 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package envelope
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+
+	cmt "github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+type testParty struct {
+	party  tss.Party
+	sealer *Sealer
+	guard  *Guard
+}
+
+// newTestParties creates keygen parties whose PartyIDs are bound to fresh identity keys
+func newTestParties(t *testing.T, sessionID string, outCh chan tss.Message, endCh chan *keygen.LocalPartySaveData, echoBroadcast ...bool) []*testParty {
+	keys := make(map[string]ed25519.PrivateKey, testParticipants)
+	unsorted := make(tss.UnSortedPartyIDs, 0, testParticipants)
+	for i := 0; i < testParticipants; i++ {
+		pub, priv, err := ed25519.GenerateKey(rand.Reader)
+		assert.NoError(t, err)
+		pID := NewPartyID(fmt.Sprintf("%d", i+1), fmt.Sprintf("P[%d]", i+1), pub)
+		keys[string(pID.Key)] = priv
+		unsorted = append(unsorted, pID)
+	}
+	pIDs := tss.SortPartyIDs(unsorted)
+	p2pCtx := tss.NewPeerContext(pIDs)
+	parties := make([]*testParty, len(pIDs))
+	for i, pID := range pIDs {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, pID, len(pIDs), testThreshold)
+		params.SetEchoBroadcast(len(echoBroadcast) > 0 && echoBroadcast[0])
+		party := keygen.NewLocalParty(params, outCh, endCh)
+		sealer, err := NewSealer(sessionID, keys[string(pID.Key)], pID)
+		assert.NoError(t, err)
+		parties[i] = &testParty{party: party, sealer: sealer, guard: NewGuard(party, sessionID, pIDs)}
+	}
+	return parties
+}
+
+func TestE2EKeygenWithEnvelopes(t *testing.T) {
+	setUp("info")
+
+	outCh := make(chan tss.Message, testParticipants*testParticipants)
+	endCh := make(chan *keygen.LocalPartySaveData, testParticipants)
+	errCh := make(chan *tss.Error, testParticipants)
+	parties := newTestParties(t, "keygen-1", outCh, endCh)
+	for _, P := range parties {
+		go func(P tss.Party) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P.party)
+	}
+
+	ended := 0
+	for ended < len(parties) {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+		case msg := <-outCh:
+			var sender *testParty
+			for _, P := range parties {
+				if P.party.PartyID() == msg.GetFrom() {
+					sender = P
+				}
+			}
+			bz, err := sender.sealer.Seal(msg)
+			assert.NoError(t, err)
+			for _, P := range parties {
+				if P == sender || (msg.GetTo() != nil && P.party.PartyID() != msg.GetTo()[0]) {
+					continue
+				}
+				go func(P *testParty) {
+					if _, err := P.guard.Update(bz); err != nil {
+						errCh <- err
+					}
+				}(P)
+			}
+		case <-endCh:
+			ended++
+		case <-time.After(time.Minute):
+			assert.FailNow(t, "timed out waiting for the keygen")
+		}
+	}
+}
+
+func TestGuardRejectsForgedAndReplayedEnvelopes(t *testing.T) {
+	setUp("info")
+
+	outCh := make(chan tss.Message, testParticipants*testParticipants)
+	endCh := make(chan *keygen.LocalPartySaveData, testParticipants)
+	parties := newTestParties(t, "keygen-1", outCh, endCh)
+	sender, receiver := parties[0], parties[1]
+	assert.Nil(t, sender.party.Start())
+	msg := <-outCh
+	assert.True(t, msg.IsBroadcast())
+	sealed, err := sender.sealer.Seal(msg)
+	assert.NoError(t, err)
+
+	assertRejected := func(bz []byte, reason string) {
+		_, err := receiver.guard.Update(bz)
+		if assert.NotNil(t, err, reason) {
+			assert.Equal(t, []*tss.PartyID{sender.party.PartyID()}, err.Culprits(), reason)
+		}
+	}
+	tamper := func(change func(env *SignedEnvelope)) []byte {
+		env := new(SignedEnvelope)
+		assert.NoError(t, proto.Unmarshal(sealed, env))
+		change(env)
+		bz, err := proto.Marshal(env)
+		assert.NoError(t, err)
+		return bz
+	}
+
+	assertRejected(tamper(func(env *SignedEnvelope) { env.Sequence++ }), "a tampered envelope must be rejected")
+	assertRejected(tamper(func(env *SignedEnvelope) { env.Signature[0] ^= 1 }), "a forged signature must be rejected")
+
+	otherSession, err := NewSealer("keygen-2", sender.sealer.key, sender.party.PartyID())
+	assert.NoError(t, err)
+	bz, err := otherSession.Seal(msg)
+	assert.NoError(t, err)
+	assertRejected(bz, "a message of another session must be rejected")
+
+	_, tssErr := receiver.guard.Update(sealed)
+	assert.Nil(t, tssErr, "the first copy of a message must be accepted")
+	assertRejected(sealed, "a replayed message must be rejected")
+
+	_, otherKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	_, err = NewSealer("keygen-1", otherKey, sender.party.PartyID())
+	assert.Error(t, err, "a sealer needs the identity key bound to its PartyID")
+}
+
+func TestSealLabelsRoundOfSender(t *testing.T) {
+	outCh := make(chan tss.Message, testParticipants*testParticipants)
+	parties := newTestParties(t, "keygen-1", outCh, nil)
+	sender := parties[0]
+	assert.Nil(t, sender.party.Start())
+	labelOf := func(msg tss.Message) uint32 {
+		bz, err := sender.sealer.Seal(msg)
+		assert.NoError(t, err)
+		env := new(SignedEnvelope)
+		assert.NoError(t, proto.Unmarshal(bz, env))
+		return env.GetRound()
+	}
+	assert.Equal(t, uint32(1), labelOf(<-outCh))
+
+	// the round is taken from the round that sent the message, not from the name of its type
+	routing := tss.MessageRouting{From: sender.party.PartyID(), IsBroadcast: true}
+	echo := &tss.EchoMessage{Round: 2}
+	msg := tss.NewMessage(routing, echo, tss.NewMessageWrapper(routing, echo))
+	assert.Equal(t, uint32(0), labelOf(msg), "a message whose round was not recorded is not labelled")
+	assert.Equal(t, uint32(2), labelOf(tss.WithRound(msg, 2)))
+}
+
+func TestCheckRound(t *testing.T) {
+	assert.NoError(t, checkRound(1, 0), "the peers may start first")
+	assert.NoError(t, checkRound(3, 0))
+	assert.NoError(t, checkRound(2, 2))
+	assert.NoError(t, checkRound(3, 2), "the peers may be a round ahead")
+	assert.NoError(t, checkRound(4, 2), "the new committee may be two rounds ahead in a re-sharing")
+	assert.Error(t, checkRound(1, 2), "a message of a past round must be rejected")
+	assert.NoError(t, checkRound(0, 2), "messages without a round are not checked")
+}
+
+func TestFinishedPartyKeepsLastRoundAsFloor(t *testing.T) {
+	parties := newTestParties(t, "keygen-1", make(chan tss.Message, testParticipants), nil)
+	g := parties[0].guard
+	g.seen["a"] = map[uint64]uint32{1: 1, 2: 3}
+	g.prune(3)
+	g.prune(-1)
+	assert.Equal(t, 3, g.pruned, "the last round is kept once the party has finished")
+	assert.Error(t, checkRound(1, g.pruned), "a replay of a pruned round must be rejected once the party has finished")
+	assert.NoError(t, checkRound(3, g.pruned))
+}
+
+func TestGuardPrunesPastRounds(t *testing.T) {
+	parties := newTestParties(t, "keygen-1", make(chan tss.Message, testParticipants), nil)
+	g := parties[0].guard
+	g.seen["a"] = map[uint64]uint32{1: 1, 2: 0, 3: 2, 4: 3}
+	g.prune(3)
+	assert.Equal(t, map[uint64]uint32{2: 0, 4: 3}, g.seen["a"])
+	g.seen["a"][5] = 1
+	g.prune(3)
+	assert.Len(t, g.seen["a"], 3, "pruned once per round")
+}
+
+func TestEquivocationNamesSignerOfBothVersions(t *testing.T) {
+	setUp("info")
+
+	outCh := make(chan tss.Message, testParticipants*testParticipants)
+	parties := newTestParties(t, "keygen-1", outCh, nil)
+	sender, other := parties[0], parties[1]
+	assert.Nil(t, sender.party.Start())
+	assert.Nil(t, other.party.Start())
+	var msg, otherMsg tss.Message
+	for len(outCh) > 0 {
+		m := <-outCh
+		switch {
+		case !m.IsBroadcast():
+		case m.GetFrom() == sender.party.PartyID():
+			msg = m
+		case m.GetFrom() == other.party.PartyID():
+			otherMsg = m
+		}
+	}
+	// the sender signs a second version of its broadcast for the third party
+	forged := tss.WithRound(keygen.NewKGRound1Message(sender.party.PartyID(), cmt.NewHashCommitment(rand.Reader, big.NewInt(1)).C), 1)
+	sealed, err := sender.sealer.Seal(msg)
+	assert.NoError(t, err)
+	sealedForged, err := sender.sealer.Seal(forged)
+	assert.NoError(t, err)
+	_, tssErr := parties[2].guard.Update(sealed)
+	assert.Nil(t, tssErr)
+	_, tssErr = parties[3].guard.Update(sealedForged)
+	assert.Nil(t, tssErr)
+
+	a, b := parties[2].guard.Broadcasts(sender.party.PartyID(), 1), parties[3].guard.Broadcasts(sender.party.PartyID(), 1)
+	if assert.Len(t, a, 1) && assert.Len(t, b, 1) {
+		key, err := Equivocation("keygen-1", a[0], b[0])
+		assert.NoError(t, err)
+		assert.Equal(t, sender.party.PartyID().GetKey(), key)
+		_, err = Equivocation("keygen-2", a[0], b[0])
+		assert.Error(t, err, "the envelopes of another session are no proof")
+		_, err = Equivocation("keygen-1", a[0], a[0])
+		assert.Error(t, err, "the same message twice is no proof")
+	}
+
+	sealedOther, err := other.sealer.Seal(otherMsg)
+	assert.NoError(t, err)
+	_, err = Equivocation("keygen-1", sealed, sealedOther)
+	assert.Error(t, err, "the broadcasts of two parties are no proof")
+
+	// a message of the same type sent in another round, as in a batch, is no second version
+	later, err := sender.sealer.Seal(tss.WithRound(forged, 2))
+	assert.NoError(t, err)
+	_, err = Equivocation("keygen-1", sealed, later)
+	assert.Error(t, err, "the broadcasts of two rounds are no proof")
+	assert.False(t, parties[2].guard.ProvesEquivocation(sender.party.PartyID(), 1, sealed, later))
+	assert.True(t, parties[2].guard.ProvesEquivocation(sender.party.PartyID(), 1, sealed, sealedForged))
+	assert.False(t, parties[2].guard.ProvesEquivocation(other.party.PartyID(), 1, sealed, sealedForged),
+		"the proof must be signed by the sender it names")
+}
+
+// runEchoKeygen runs a keygen with echo broadcast in which reseal may change the envelope of a message for a
+// recipient, and returns the first error of a party
+func runEchoKeygen(t *testing.T, parties []*testParty, outCh chan tss.Message, endCh chan *keygen.LocalPartySaveData,
+	reseal func(sender, P *testParty, msg tss.ParsedMessage, bz []byte) []byte) *tss.Error {
+	errCh := make(chan *tss.Error, testParticipants*testParticipants)
+	for _, P := range parties {
+		go func(P tss.Party) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P.party)
+	}
+	for {
+		select {
+		case err := <-errCh:
+			return err
+		case msg := <-outCh:
+			var sender *testParty
+			for _, P := range parties {
+				if P.party.PartyID() == msg.GetFrom() {
+					sender = P
+				}
+			}
+			bz, err := sender.sealer.Seal(msg)
+			assert.NoError(t, err)
+			for _, P := range parties {
+				if P == sender || (msg.GetTo() != nil && P.party.PartyID() != msg.GetTo()[0]) {
+					continue
+				}
+				go func(P *testParty, envelope []byte) {
+					if _, err := P.guard.Update(envelope); err != nil {
+						errCh <- err
+					}
+				}(P, reseal(sender, P, msg.(tss.ParsedMessage), bz))
+			}
+		case <-endCh:
+			assert.FailNow(t, "keygen must not finish")
+		case <-time.After(time.Minute):
+			assert.FailNow(t, "timed out waiting for the honest parties to abort")
+		}
+	}
+}
+
+// the echoes carry the envelopes of the broadcasts, so the honest parties hold both versions of the equivocated
+// broadcast signed by its sender, and name it
+func TestEquivocatingBroadcastNamesSenderWithSignedEchoes(t *testing.T) {
+	setUp("info")
+
+	outCh := make(chan tss.Message, testParticipants*testParticipants)
+	endCh := make(chan *keygen.LocalPartySaveData, testParticipants)
+	parties := newTestParties(t, "keygen-1", outCh, endCh, true)
+	cheater, victim := parties[0], parties[1]
+	err := runEchoKeygen(t, parties, outCh, endCh, func(sender, P *testParty, msg tss.ParsedMessage, bz []byte) []byte {
+		// the cheater signs a different commitment for the victim than for the others
+		if _, ok := msg.Content().(*keygen.KGRound1Message); ok && sender == cheater && P == victim {
+			forged := tss.WithRound(keygen.NewKGRound1Message(cheater.party.PartyID(), cmt.NewHashCommitment(rand.Reader, big.NewInt(1)).C), 1)
+			sealed, err := sender.sealer.Seal(forged)
+			assert.NoError(t, err)
+			return sealed
+		}
+		return bz
+	})
+
+	var mismatch *tss.BroadcastMismatch
+	if assert.True(t, errors.As(err, &mismatch), err.Error()) {
+		assert.Equal(t, cheater.party.PartyID(), mismatch.Sender)
+		assert.Equal(t, []*tss.PartyID{cheater.party.PartyID()}, err.Culprits())
+		if assert.Len(t, mismatch.Proof, 2) {
+			key, err := Equivocation("keygen-1", mismatch.Proof[0], mismatch.Proof[1])
+			assert.NoError(t, err, "the proof must convince others")
+			assert.Equal(t, cheater.party.PartyID().GetKey(), key)
+		}
+	}
+}
+
+// an echoer whose echoes are signed can still make up the digest of an honest sender, but not its signature, so the
+// mismatch names nobody
+func TestLyingSignedEchoerCannotFrameSender(t *testing.T) {
+	setUp("info")
+
+	outCh := make(chan tss.Message, testParticipants*testParticipants)
+	endCh := make(chan *keygen.LocalPartySaveData, testParticipants)
+	parties := newTestParties(t, "keygen-1", outCh, endCh, true)
+	liar, honest := parties[0], parties[1]
+	err := runEchoKeygen(t, parties, outCh, endCh, func(sender, P *testParty, msg tss.ParsedMessage, bz []byte) []byte {
+		echo, ok := msg.Content().(*tss.EchoMessage)
+		if !ok || sender != liar {
+			return bz
+		}
+		lie := proto.Clone(echo).(*tss.EchoMessage)
+		for _, d := range lie.GetDigests() {
+			if bytes.Equal(d.GetPartyKey(), honest.party.PartyID().GetKey()) {
+				d.Hash = make([]byte, len(d.GetHash()))
+				// the liar can only attach a commitment that it signed itself
+				forged, err := liar.sealer.Seal(tss.WithRound(keygen.NewKGRound1Message(liar.party.PartyID(), cmt.NewHashCommitment(rand.Reader, big.NewInt(1)).C), 1))
+				assert.NoError(t, err)
+				d.Envelopes = append(d.Envelopes, forged)
+			}
+		}
+		routing := tss.MessageRouting{From: liar.party.PartyID(), IsBroadcast: true}
+		sealed, err := liar.sealer.Seal(tss.WithRound(tss.NewMessage(routing, lie, tss.NewMessageWrapper(routing, lie)), int(lie.GetRound())))
+		assert.NoError(t, err)
+		return sealed
+	})
+
+	var mismatch *tss.BroadcastMismatch
+	if assert.True(t, errors.As(err, &mismatch), err.Error()) {
+		assert.Equal(t, honest.party.PartyID(), mismatch.Sender)
+		assert.Empty(t, err.Culprits(), "the honest sender must not be blamed")
+		assert.Nil(t, mismatch.Proof)
+	}
+}
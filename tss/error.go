@@ -17,12 +17,19 @@ type Error struct {
 	round    int
 	victim   *PartyID
 	culprits []*PartyID
+	evidence []byte
 }
 
 func NewError(err error, task string, round int, victim *PartyID, culprits ...*PartyID) *Error {
 	return &Error{cause: err, task: task, round: round, victim: victim, culprits: culprits}
 }
 
+// NewErrorWithEvidence returns an Error whose culprits are backed by a protocol-specific evidence blob
+// that a third party may check independently of the victim.
+func NewErrorWithEvidence(err error, task string, round int, victim *PartyID, evidence []byte, culprits ...*PartyID) *Error {
+	return &Error{cause: err, task: task, round: round, victim: victim, culprits: culprits, evidence: evidence}
+}
+
 func (err *Error) Unwrap() error { return err.cause }
 
 func (err *Error) Cause() error { return err.cause }
@@ -35,6 +42,9 @@ func (err *Error) Victim() *PartyID { return err.victim }
 
 func (err *Error) Culprits() []*PartyID { return err.culprits }
 
+// Evidence returns the evidence backing the culprits, or nil if the protocol did not provide any
+func (err *Error) Evidence() []byte { return err.evidence }
+
 func (err *Error) Error() string {
 	if err == nil || err.cause == nil {
 		return "Error is nil"
@@ -68,6 +68,8 @@ type (
 		MessageRouting
 		content MessageContent
 		wire    *MessageWrapper
+		// the number of the round that sent the message, or 0 if it was not recorded
+		round int
 	}
 )
 
@@ -110,6 +112,23 @@ func NewMessage(meta MessageRouting, content MessageContent, wire *MessageWrappe
 	}
 }
 
+// WithRound records the number of the round that sends a message on it and returns the message. The rounds of the
+// protocols call it on every message they send, so that the transport can label the message with its round.
+func WithRound(msg ParsedMessage, round int) ParsedMessage {
+	if mm, ok := msg.(*MessageImpl); ok {
+		mm.round = round
+	}
+	return msg
+}
+
+// RoundOf returns the number of the round that sent a message, as recorded by WithRound, or 0 if it is not known
+func RoundOf(msg Message) int {
+	if mm, ok := msg.(*MessageImpl); ok {
+		return mm.round
+	}
+	return 0
+}
+
 func (mm *MessageImpl) Type() string {
 	return string(proto.MessageName(mm.content))
 }
@@ -124,6 +124,123 @@ func (x *MessageWrapper) GetMessage() *anypb.Any {
 	return nil
 }
 
+//
+// The content of a point-to-point message encrypted to the X25519 encryption key of its recipient.
+type EncryptedMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the ephemeral X25519 public key of the sender
+	EphemeralKey []byte `protobuf:"bytes,1,opt,name=ephemeral_key,json=ephemeralKey,proto3" json:"ephemeral_key,omitempty"`
+	// the AES-256-GCM encryption of the protobuf Any holding the content
+	Ciphertext []byte `protobuf:"bytes,2,opt,name=ciphertext,proto3" json:"ciphertext,omitempty"`
+}
+
+func (x *EncryptedMessage) Reset() {
+	*x = EncryptedMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_message_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EncryptedMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EncryptedMessage) ProtoMessage() {}
+
+func (x *EncryptedMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_message_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EncryptedMessage.ProtoReflect.Descriptor instead.
+func (*EncryptedMessage) Descriptor() ([]byte, []int) {
+	return file_protob_message_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *EncryptedMessage) GetEphemeralKey() []byte {
+	if x != nil {
+		return x.EphemeralKey
+	}
+	return nil
+}
+
+func (x *EncryptedMessage) GetCiphertext() []byte {
+	if x != nil {
+		return x.Ciphertext
+	}
+	return nil
+}
+
+//
+// Sent in the consistency round that follows a broadcast round when echo broadcast is enabled. It holds a hash of the
+// broadcast messages that the sender received from each party in that round.
+type EchoMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Round   int32                 `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`
+	Digests []*EchoMessage_Digest `protobuf:"bytes,2,rep,name=digests,proto3" json:"digests,omitempty"`
+}
+
+func (x *EchoMessage) Reset() {
+	*x = EchoMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_message_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EchoMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EchoMessage) ProtoMessage() {}
+
+func (x *EchoMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_message_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EchoMessage.ProtoReflect.Descriptor instead.
+func (*EchoMessage) Descriptor() ([]byte, []int) {
+	return file_protob_message_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *EchoMessage) GetRound() int32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *EchoMessage) GetDigests() []*EchoMessage_Digest {
+	if x != nil {
+		return x.Digests
+	}
+	return nil
+}
+
 // PartyID represents a participant in the TSS protocol rounds.
 // Note: The `id` and `moniker` are provided for convenience to allow you to track participants easier.
 // The `id` is intended to be a unique string representation of `key` and `moniker` can be anything (even left blank).
@@ -140,7 +257,7 @@ type MessageWrapper_PartyID struct {
 func (x *MessageWrapper_PartyID) Reset() {
 	*x = MessageWrapper_PartyID{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protob_message_proto_msgTypes[1]
+		mi := &file_protob_message_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -153,7 +270,7 @@ func (x *MessageWrapper_PartyID) String() string {
 func (*MessageWrapper_PartyID) ProtoMessage() {}
 
 func (x *MessageWrapper_PartyID) ProtoReflect() protoreflect.Message {
-	mi := &file_protob_message_proto_msgTypes[1]
+	mi := &file_protob_message_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -190,6 +307,71 @@ func (x *MessageWrapper_PartyID) GetKey() []byte {
 	return nil
 }
 
+type EchoMessage_Digest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the key of the party that broadcast the messages
+	PartyKey []byte `protobuf:"bytes,1,opt,name=party_key,json=partyKey,proto3" json:"party_key,omitempty"`
+	Hash     []byte `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	// the signed envelopes that carried the messages, when they were received through signed envelopes
+	Envelopes [][]byte `protobuf:"bytes,3,rep,name=envelopes,proto3" json:"envelopes,omitempty"`
+}
+
+func (x *EchoMessage_Digest) Reset() {
+	*x = EchoMessage_Digest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_message_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EchoMessage_Digest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EchoMessage_Digest) ProtoMessage() {}
+
+func (x *EchoMessage_Digest) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_message_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EchoMessage_Digest.ProtoReflect.Descriptor instead.
+func (*EchoMessage_Digest) Descriptor() ([]byte, []int) {
+	return file_protob_message_proto_rawDescGZIP(), []int{2, 0}
+}
+
+func (x *EchoMessage_Digest) GetPartyKey() []byte {
+	if x != nil {
+		return x.PartyKey
+	}
+	return nil
+}
+
+func (x *EchoMessage_Digest) GetHash() []byte {
+	if x != nil {
+		return x.Hash
+	}
+	return nil
+}
+
+func (x *EchoMessage_Digest) GetEnvelopes() [][]byte {
+	if x != nil {
+		return x.Envelopes
+	}
+	return nil
+}
+
 var File_protob_message_proto protoreflect.FileDescriptor
 
 var file_protob_message_proto_rawDesc = []byte{
@@ -222,8 +404,25 @@ var file_protob_message_proto_rawDesc = []byte{
 	0x52, 0x02, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x6f, 0x6e, 0x69, 0x6b, 0x65, 0x72, 0x18,
 	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x6f, 0x6e, 0x69, 0x6b, 0x65, 0x72, 0x12, 0x10,
 	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79,
-	0x42, 0x07, 0x5a, 0x05, 0x2e, 0x2f, 0x74, 0x73, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x33,
+	0x22, 0x57, 0x0a, 0x10, 0x45, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x65, 0x64, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x70, 0x68, 0x65, 0x6d, 0x65, 0x72, 0x61,
+	0x6c, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x65, 0x70, 0x68,
+	0x65, 0x6d, 0x65, 0x72, 0x61, 0x6c, 0x4b, 0x65, 0x79, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x69, 0x70,
+	0x68, 0x65, 0x72, 0x74, 0x65, 0x78, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x63,
+	0x69, 0x70, 0x68, 0x65, 0x72, 0x74, 0x65, 0x78, 0x74, 0x22, 0xba, 0x01, 0x0a, 0x0b, 0x45, 0x63,
+	0x68, 0x6f, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75,
+	0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x12,
+	0x3c, 0x0a, 0x07, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x22, 0x2e, 0x62, 0x69, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x74, 0x73, 0x73, 0x6c, 0x69,
+	0x62, 0x2e, 0x45, 0x63, 0x68, 0x6f, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x44, 0x69,
+	0x67, 0x65, 0x73, 0x74, 0x52, 0x07, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x1a, 0x57, 0x0a,
+	0x06, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x72, 0x74, 0x79,
+	0x5f, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x70, 0x61, 0x72, 0x74,
+	0x79, 0x4b, 0x65, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x1c, 0x0a, 0x09, 0x65, 0x6e, 0x76, 0x65,
+	0x6c, 0x6f, 0x70, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x09, 0x65, 0x6e, 0x76,
+	0x65, 0x6c, 0x6f, 0x70, 0x65, 0x73, 0x42, 0x07, 0x5a, 0x05, 0x2e, 0x2f, 0x74, 0x73, 0x73, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -238,21 +437,25 @@ func file_protob_message_proto_rawDescGZIP() []byte {
 	return file_protob_message_proto_rawDescData
 }
 
-var file_protob_message_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_protob_message_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
 var file_protob_message_proto_goTypes = []interface{}{
 	(*MessageWrapper)(nil),         // 0: binance.tsslib.MessageWrapper
-	(*MessageWrapper_PartyID)(nil), // 1: binance.tsslib.MessageWrapper.PartyID
-	(*anypb.Any)(nil),              // 2: google.protobuf.Any
+	(*EncryptedMessage)(nil),       // 1: binance.tsslib.EncryptedMessage
+	(*EchoMessage)(nil),            // 2: binance.tsslib.EchoMessage
+	(*MessageWrapper_PartyID)(nil), // 3: binance.tsslib.MessageWrapper.PartyID
+	(*EchoMessage_Digest)(nil),     // 4: binance.tsslib.EchoMessage.Digest
+	(*anypb.Any)(nil),              // 5: google.protobuf.Any
 }
 var file_protob_message_proto_depIdxs = []int32{
-	1, // 0: binance.tsslib.MessageWrapper.from:type_name -> binance.tsslib.MessageWrapper.PartyID
-	1, // 1: binance.tsslib.MessageWrapper.to:type_name -> binance.tsslib.MessageWrapper.PartyID
-	2, // 2: binance.tsslib.MessageWrapper.message:type_name -> google.protobuf.Any
-	3, // [3:3] is the sub-list for method output_type
-	3, // [3:3] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	3, // 0: binance.tsslib.MessageWrapper.from:type_name -> binance.tsslib.MessageWrapper.PartyID
+	3, // 1: binance.tsslib.MessageWrapper.to:type_name -> binance.tsslib.MessageWrapper.PartyID
+	5, // 2: binance.tsslib.MessageWrapper.message:type_name -> google.protobuf.Any
+	4, // 3: binance.tsslib.EchoMessage.digests:type_name -> binance.tsslib.EchoMessage.Digest
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_protob_message_proto_init() }
@@ -274,6 +477,30 @@ func file_protob_message_proto_init() {
 			}
 		}
 		file_protob_message_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EncryptedMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_message_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EchoMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_message_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*MessageWrapper_PartyID); i {
 			case 0:
 				return &v.state
@@ -285,6 +512,18 @@ func file_protob_message_proto_init() {
 				return nil
 			}
 		}
+		file_protob_message_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EchoMessage_Digest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -292,7 +531,7 @@ func file_protob_message_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_protob_message_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   5,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,67 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package tss
+
+import (
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+)
+
+var ErrNonceUsed = errors.New("the nonce was already used")
+
+type (
+	// NonceLedger durably records the IDs of the single-use nonces that a party signed with, such as ECDSA
+	// presignatures and FROST nonces. Signing two messages with one nonce reveals the key share, so a party records
+	// the ID of its nonce before it sends anything computed with it, and refuses a nonce recorded before. A copy of
+	// the nonce restored from a backup or held by another process is refused as well, as long as it uses the same
+	// ledger.
+	NonceLedger interface {
+		// Use records that the nonce with the ID is being used, or fails with ErrNonceUsed if it was recorded
+		// before. The check and the record are atomic, and the record is durable once Use returns.
+		Use(id []byte) error
+	}
+
+	// FileNonceLedger is a NonceLedger that keeps a file for each nonce in a directory. A directory must not be shared
+	// by parties that use the same nonce IDs, e.g. the parties of one presigning session.
+	FileNonceLedger struct {
+		dir string
+	}
+)
+
+// NewFileNonceLedger returns a ledger that keeps its records in dir, which it creates if needed
+func NewFileNonceLedger(dir string) (*FileNonceLedger, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &FileNonceLedger{dir: dir}, nil
+}
+
+func (l *FileNonceLedger) Use(id []byte) error {
+	if len(id) == 0 {
+		return errors.New("the nonce has no ID")
+	}
+	// O_EXCL makes the check and the record one step, even across processes
+	f, err := os.OpenFile(filepath.Join(l.dir, hex.EncodeToString(id)+".used"), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if os.IsExist(err) {
+		return ErrNonceUsed
+	}
+	if err != nil {
+		return err
+	}
+	err = f.Sync()
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	return common.SyncDir(l.dir)
+}
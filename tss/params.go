@@ -30,6 +30,12 @@ type (
 		noProofFac bool
 		// random sources
 		partialKeyRand, rand io.Reader
+		// for point-to-point encryption
+		decryptionKey *DecryptionKey
+		// for broadcasts over unreliable channels
+		echoBroadcast bool
+		// for aborting parties that stall
+		roundTimeout time.Duration
 	}
 
 	ReSharingParameters struct {
@@ -128,6 +134,38 @@ func (params *Parameters) SetRand(rand io.Reader) {
 	params.rand = rand
 }
 
+func (params *Parameters) DecryptionKey() *DecryptionKey {
+	return params.decryptionKey
+}
+
+// SetDecryptionKey makes the party decrypt the point-to-point messages sent to it and reject those sent in the clear.
+// The other parties must know its public key as the EncryptionKey of its PartyID.
+func (params *Parameters) SetDecryptionKey(key *DecryptionKey) {
+	params.decryptionKey = key
+}
+
+func (params *Parameters) EchoBroadcast() bool {
+	return params.echoBroadcast
+}
+
+// SetEchoBroadcast makes the party run a consistency round after each round with broadcast messages, in which the
+// parties exchange the hashes of the messages they received, so the broadcast channel need not be reliable. A sender
+// that sent different messages to different parties is then found, and named as the culprit only when the echoes carry
+// two versions of its broadcast that it signed (see tss.BroadcastMismatch). All parties must enable it.
+func (params *Parameters) SetEchoBroadcast(enabled bool) {
+	params.echoBroadcast = enabled
+}
+
+func (params *Parameters) RoundTimeout() time.Duration {
+	return params.roundTimeout
+}
+
+// SetRoundTimeout aborts the party when one of its rounds does not finish within timeout. The error of the aborted
+// party names the parties it was still waiting for as culprits. 0, the default, disables the timeout.
+func (params *Parameters) SetRoundTimeout(timeout time.Duration) {
+	params.roundTimeout = timeout
+}
+
 // ----- //
 
 // Exported, used in `tss` client
@@ -17,7 +17,8 @@ import (
 type Party interface {
 	Start() *Error
 	// The main entry point when updating a party's state from the wire.
-	// isBroadcast should represent whether the message was received via a reliable broadcast
+	// isBroadcast should represent whether the message was received via a reliable broadcast, or via a plain broadcast
+	// with echo broadcast enabled in the parameters
 	UpdateFromBytes(wireBytes []byte, from *PartyID, isBroadcast bool) (ok bool, err *Error)
 	// You may use this entry point to update a party's state when running locally or in tests
 	Update(msg ParsedMessage) (ok bool, err *Error)
@@ -36,24 +37,82 @@ type Party interface {
 	advance()
 	lock()
 	unlock()
+	waitingFor() []*PartyID
+	echoState() *echoState
+	runState() *runState
+}
+
+// Lifecycle is implemented by the parties that embed BaseParty, which is all the parties of this library. It is kept
+// apart from Party so that adding it did not change the methods that Party requires.
+type Lifecycle interface {
+	// Done is closed when the party has finished, failed to start a round, or was aborted by its round timeout or its
+	// context
+	Done() <-chan struct{}
+	// Err returns the error that the party failed or was aborted with, or nil
+	Err() *Error
 }
 
 type BaseParty struct {
 	mtx        sync.Mutex
 	rnd        Round
+	echo       *echoState
+	run        *runState
 	FirstRound Round
 }
 
 func (p *BaseParty) Running() bool {
-	return p.rnd != nil
+	return p.rnd != nil && (p.run == nil || p.run.err == nil)
 }
 
 func (p *BaseParty) WaitingFor() []*PartyID {
 	p.lock()
 	defer p.unlock()
+	return p.waitingFor()
+}
+
+func (p *BaseParty) Done() <-chan struct{} {
+	p.lock()
+	defer p.unlock()
+	return p.runState().done
+}
+
+func (p *BaseParty) Err() *Error {
+	p.lock()
+	defer p.unlock()
+	return p.runState().err
+}
+
+// CurrentRound returns the number of the round that a party is in, 0 if it has not started, or -1 if it has finished
+// or was aborted
+func CurrentRound(p Party) int {
+	p.lock()
+	defer p.unlock()
+	if p.runState().finished {
+		return -1
+	}
+	if rnd := p.round(); rnd != nil {
+		return rnd.RoundNumber()
+	}
+	return 0
+}
+
+// SetAuthenticated records that the messages of a party are received in envelopes signed by their senders, so that a
+// point-to-point message that fails to decrypt names its sender as the culprit. NewGuard in tss/envelope calls it.
+func SetAuthenticated(p Party) {
+	p.lock()
+	defer p.unlock()
+	p.runState().authenticated = true
+}
+
+func (p *BaseParty) waitingFor() []*PartyID {
 	if p.rnd == nil {
 		return []*PartyID{}
 	}
+	if p.echo != nil {
+		if waiting := p.echo.waitingFor(p.rnd.RoundNumber()); len(waiting) > 0 {
+			return waiting
+		}
+	}
 	return p.rnd.WaitingFor()
 }
 
@@ -113,6 +172,20 @@ func (p *BaseParty) unlock() {
 	p.mtx.Unlock()
 }
 
+func (p *BaseParty) runState() *runState {
+	if p.run == nil {
+		p.run = newRunState()
+	}
+	return p.run
+}
+
+func (p *BaseParty) echoState() *echoState {
+	if p.echo == nil {
+		p.echo = newEchoState()
+	}
+	return p.echo
+}
+
 // ----- //
 
 func BaseStart(p Party, task string, prepare ...func(Round) *Error) *Error {
@@ -140,13 +213,26 @@ func BaseStart(p Party, task string, prepare ...func(Round) *Error) *Error {
 	defer func() {
 		common.Logger.Debugf("party %s: %s round %d finished", p.round().Params().PartyID(), task, 1)
 	}()
-	return p.round().Start()
+	startRoundTimer(p, task)
+	if err := p.round().Start(); err != nil {
+		fail(p, err)
+		return err
+	}
+	return nil
 }
 
 // an implementation of Update that is shared across the different types of parties (keygen, signing, dynamic groups)
 func BaseUpdate(p Party, msg ParsedMessage, task string) (ok bool, err *Error) {
 	// fast-fail on an invalid message; do not lock the mutex yet
-	if _, err := p.ValidateMessage(msg); err != nil {
+	isEcho := false
+	if msg != nil {
+		_, isEcho = msg.Content().(*EchoMessage)
+	}
+	if isEcho {
+		if _, err := validateEcho(p, msg); err != nil {
+			return false, err
+		}
+	} else if _, err := p.ValidateMessage(msg); err != nil {
 		return false, err
 	}
 	// lock the mutex. need this mtx unlock hook; L108 is recursive so cannot use defer
@@ -155,12 +241,25 @@ func BaseUpdate(p Party, msg ParsedMessage, task string) (ok bool, err *Error) {
 		return ok, err
 	}
 	p.lock() // data is written to P state below
+	if err := p.runState().err; err != nil {
+		return r(false, err)
+	}
+	if msg, err = decryptP2P(p, msg); err != nil || msg == nil {
+		return r(false, err)
+	}
 	common.Logger.Debugf("party %s received message: %s", p.PartyID(), msg.String())
 	if p.round() != nil {
 		common.Logger.Debugf("party %s round %d update: %s", p.PartyID(), p.round().RoundNumber(), msg.String())
 	}
-	if ok, err := p.StoreMessage(msg); err != nil || !ok {
-		return r(false, err)
+	if isEcho {
+		p.echoState().receive(msg)
+	} else {
+		if ok, err := p.StoreMessage(msg); err != nil || !ok {
+			return r(false, err)
+		}
+		if msg.IsBroadcast() && params(p).EchoBroadcast() {
+			p.echoState().record(msg)
+		}
 	}
 	if p.round() != nil {
 		common.Logger.Debugf("party %s: %s round %d update", p.round().Params().PartyID(), task, p.round().RoundNumber())
@@ -168,13 +267,25 @@ func BaseUpdate(p Party, msg ParsedMessage, task string) (ok bool, err *Error) {
 			return r(false, err)
 		}
 		if p.round().CanProceed() {
+			if waiting, err := echoBroadcast(p); err != nil {
+				return r(false, err)
+			} else if waiting {
+				return r(true, nil)
+			}
 			if p.advance(); p.round() != nil {
+				if err := advanced(p); err != nil {
+					fail(p, err)
+					return r(false, err)
+				}
 				if err := p.round().Start(); err != nil {
+					fail(p, err)
 					return r(false, err)
 				}
 				rndNum := p.round().RoundNumber()
 				common.Logger.Infof("party %s: %s round %d started", p.round().Params().PartyID(), task, rndNum)
+				startRoundTimer(p, task)
 			} else {
+				finish(p)
 				// finished! the round implementation will have sent the data through the `end` channel.
 				common.Logger.Infof("party %s: %s finished!", p.PartyID(), task)
 			}
@@ -185,3 +296,62 @@ func BaseUpdate(p Party, msg ParsedMessage, task string) (ok bool, err *Error) {
 	}
 	return r(true, nil)
 }
+
+// validateEcho checks an echo, which is handled by BaseUpdate rather than by the rounds of the party
+func validateEcho(p Party, msg ParsedMessage) (bool, *Error) {
+	if msg.GetFrom() == nil || !msg.GetFrom().ValidateBasic() {
+		return false, p.WrapError(fmt.Errorf("received msg with an invalid sender: %s", msg))
+	}
+	if !msg.IsBroadcast() || !msg.ValidateBasic() {
+		return false, p.WrapError(fmt.Errorf("message failed ValidateBasic: %s", msg), msg.GetFrom())
+	}
+	return true, nil
+}
+
+// decryptP2P decrypts a point-to-point message sent to a party that has a decryption key, and rejects those sent to it
+// in the clear. A nil message is returned for a message that was dropped; the caller holds the lock
+func decryptP2P(p Party, msg ParsedMessage) (ParsedMessage, *Error) {
+	key := params(p).DecryptionKey()
+	_, encrypted := msg.Content().(*EncryptedMessage)
+	switch {
+	case encrypted && key == nil:
+		return nil, p.WrapError(errors.New("received an encrypted message but this party has no decryption key"))
+	case encrypted:
+		decrypted, err := decryptMessage(msg, key, p.PartyID())
+		if err != nil {
+			return rejectP2P(p, msg, err)
+		}
+		if _, err := p.ValidateMessage(decrypted); err != nil {
+			return nil, err
+		}
+		return decrypted, nil
+	case key != nil && !msg.IsBroadcast() && !wasEncrypted(msg):
+		return rejectP2P(p, msg, errors.New("received a point-to-point message in the clear"))
+	}
+	return msg, nil
+}
+
+// rejectP2P rejects a point-to-point message that fails to decrypt or came in the clear. Its sender is named as the
+// culprit only when the messages of the party are authenticated (see SetAuthenticated), as anyone can encrypt a
+// message to the party in the name of another. Otherwise the message is dropped with a warning, and a nil message
+// is returned with no error; the caller holds the lock
+func rejectP2P(p Party, msg ParsedMessage, err error) (ParsedMessage, *Error) {
+	if p.runState().authenticated {
+		return nil, p.WrapError(err, msg.GetFrom())
+	}
+	common.Logger.Warningf("party %s: dropped a point-to-point message from %s: %v", p.PartyID(), msg.GetFrom(), err)
+	return nil, nil
+}
+
+// params returns the parameters of a party, which may not have started yet; the caller holds the lock
+func params(p Party) *Parameters {
+	if rnd := p.round(); rnd != nil {
+		return rnd.Params()
+	}
+	st := p.runState()
+	if st.params == nil {
+		// FirstRound makes a new round on each call, so it is called once
+		st.params = p.FirstRound().Params()
+	}
+	return st.params
+}
@@ -22,6 +22,9 @@ type (
 	PartyID struct {
 		*MessageWrapper_PartyID
 		Index int `json:"index"`
+		// EncryptionKey is the X25519 public key of the party's DecryptionKey. Point-to-point messages to a party
+		// with one are encrypted to it.
+		EncryptionKey []byte `json:"encryptionKey,omitempty"`
 	}
 
 	UnSortedPartyIDs []*PartyID
@@ -0,0 +1,376 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Package session runs many protocol instances side by side on one node. A Manager creates the parties of each run
+// under a session ID, wraps their outgoing messages with it and routes the messages received from the transport to
+// the right party.
+package session
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	// limits on the messages held for sessions that have not been created yet on this node
+	maxPendingSessions = 1024
+	maxPendingMessages = 1024
+
+	// the capacity of the channel a party sends its messages to, which must hold what it sends in a round
+	outBufferSize = 256
+)
+
+var (
+	ErrSessionExists  = errors.New("session: a session with this ID already exists")
+	ErrUnknownSession = errors.New("session: unknown session")
+	ErrTooManyPending = errors.New("session: too many messages for sessions that have not been created")
+)
+
+type (
+	// PartyFactory creates a party of a session, which sends its messages to out. It returns the party along with the
+	// IDs of the parties it exchanges messages with. Once the party has sent its result to its end channel,
+	// s.PartyFinished must be called.
+	PartyFactory func(s *Session, out chan<- tss.Message) (tss.Party, []*tss.PartyID)
+
+	// Outgoing is a message of a session for the transport to deliver
+	Outgoing struct {
+		SessionID string
+		From      *tss.PartyID
+		// To lists the recipients. Unlike tss.MessageRouting.To it is never nil, so a broadcast lists every other party.
+		To          []*tss.PartyID
+		IsBroadcast bool
+		// WireBytes are the bytes to pass to Manager.UpdateFromBytes on the recipients' nodes
+		WireBytes []byte
+	}
+
+	Manager struct {
+		out chan<- *Outgoing
+		ttl time.Duration
+
+		mtx      sync.Mutex
+		sessions map[string]*Session
+		pending  map[string]*pendingSession
+
+		stop     chan struct{}
+		stopOnce sync.Once
+	}
+
+	pendingSession struct {
+		created  time.Time
+		messages []*pendingMessage
+	}
+
+	pendingMessage struct {
+		wire        *SessionMessage
+		from        *big.Int
+		isBroadcast bool
+	}
+)
+
+// NewManager returns a Manager that sends the messages of its sessions to out. A session that has not finished within
+// ttl of its creation is stopped with ErrExpired. Finished and expired sessions are reaped in the background until
+// Close is called.
+func NewManager(out chan<- *Outgoing, ttl time.Duration) *Manager {
+	m := &Manager{
+		out:      out,
+		ttl:      ttl,
+		sessions: make(map[string]*Session),
+		pending:  make(map[string]*pendingSession),
+		stop:     make(chan struct{}),
+	}
+	go m.reapLoop()
+	return m
+}
+
+// Close stops the background reaping and every running session
+func (m *Manager) Close() {
+	m.stopOnce.Do(func() {
+		close(m.stop)
+	})
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	for id, s := range m.sessions {
+		if s != nil {
+			s.fail(errors.New("session: the manager was closed"))
+		}
+		delete(m.sessions, id)
+	}
+}
+
+// NewSession creates and starts the parties of a session. Messages for the session that arrived before it was
+// created are delivered once the parties have started.
+func (m *Manager) NewSession(id string, factories ...PartyFactory) (*Session, error) {
+	if len(factories) == 0 {
+		return nil, errors.New("session: a session needs at least one party")
+	}
+	s := newSession(id, time.Now().Add(m.ttl))
+	m.mtx.Lock()
+	if _, ok := m.sessions[id]; ok {
+		m.mtx.Unlock()
+		return nil, ErrSessionExists
+	}
+	// the ID is reserved without a session until its parties have started, so that its messages keep being held
+	m.sessions[id] = nil
+	m.mtx.Unlock()
+
+	unregister := func() {
+		m.mtx.Lock()
+		delete(m.sessions, id)
+		m.mtx.Unlock()
+	}
+	for _, factory := range factories {
+		out := make(chan tss.Message, outBufferSize)
+		party, peers := factory(s, out)
+		if party == nil {
+			unregister()
+			return nil, errors.New("session: the factory returned a nil party")
+		}
+		s.members = append(s.members, &member{party: party, out: out, peers: peers})
+	}
+	s.remaining = len(s.members)
+	for _, mb := range s.members {
+		go m.forward(s, mb)
+	}
+	for _, mb := range s.members {
+		if err := mb.party.Start(); err != nil {
+			s.fail(err)
+			unregister()
+			return nil, err
+		}
+	}
+	close(s.started)
+
+	m.mtx.Lock()
+	m.sessions[id] = s
+	var held []*pendingMessage
+	if p, ok := m.pending[id]; ok {
+		held = p.messages
+		delete(m.pending, id)
+	}
+	m.mtx.Unlock()
+	for _, msg := range held {
+		if err := m.deliver(s, msg.wire, msg.from, msg.isBroadcast); err != nil {
+			common.Logger.Warningf("session %s: failed to deliver a held message: %v", id, err)
+		}
+	}
+	return s, nil
+}
+
+// Session returns the session with the given ID, or nil
+func (m *Manager) Session(id string) *Session {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	return m.sessions[id]
+}
+
+// WaitingFor returns the parties that the session is waiting for in its current round
+func (m *Manager) WaitingFor(id string) ([]*tss.PartyID, error) {
+	s := m.Session(id)
+	if s == nil {
+		return nil, ErrUnknownSession
+	}
+	return s.WaitingFor(), nil
+}
+
+// UpdateFromBytes routes the wire bytes of an Outgoing message to the parties of its session. from and isBroadcast
+// must come from the authenticated transport, as for tss.Party.UpdateFromBytes; only the key of from is used, so it
+// does not need to be the PartyID object of the session. A party error stops the session and is returned.
+func (m *Manager) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) error {
+	if from == nil || from.MessageWrapper_PartyID == nil || len(from.Key) == 0 {
+		return errors.New("session: the sender is required")
+	}
+	wire := new(SessionMessage)
+	if err := proto.Unmarshal(wireBytes, wire); err != nil {
+		return err
+	}
+	fromKey := from.KeyInt()
+
+	m.mtx.Lock()
+	s, ok := m.sessions[wire.GetSessionId()]
+	if !ok || s == nil {
+		defer m.mtx.Unlock()
+		return m.hold(wire, fromKey, isBroadcast)
+	}
+	m.mtx.Unlock()
+	return m.deliver(s, wire, fromKey, isBroadcast)
+}
+
+// Reap removes the sessions that have finished and stops and removes those that have expired. It is called
+// periodically by the manager and returns the IDs of the sessions it removed.
+func (m *Manager) Reap() []string {
+	now := time.Now()
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	reaped := make([]string, 0)
+	for id, s := range m.sessions {
+		if s == nil {
+			continue // starting
+		}
+		if !s.isDone() && now.After(s.deadline) {
+			s.fail(ErrExpired)
+		}
+		if s.isDone() {
+			delete(m.sessions, id)
+			reaped = append(reaped, id)
+		}
+	}
+	for id, p := range m.pending {
+		if now.Sub(p.created) > m.ttl {
+			delete(m.pending, id)
+		}
+	}
+	return reaped
+}
+
+// ----- //
+
+func (m *Manager) reapLoop() {
+	interval := m.ttl / 4
+	if interval <= 0 {
+		interval = time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			m.Reap()
+		case <-m.stop:
+			return
+		}
+	}
+}
+
+// hold keeps a message for a session that has not been created on this node yet; the caller holds the lock
+func (m *Manager) hold(wire *SessionMessage, from *big.Int, isBroadcast bool) error {
+	p, ok := m.pending[wire.GetSessionId()]
+	if !ok {
+		if len(m.pending) >= maxPendingSessions {
+			return ErrTooManyPending
+		}
+		p = &pendingSession{created: time.Now()}
+		m.pending[wire.GetSessionId()] = p
+	}
+	if len(p.messages) >= maxPendingMessages {
+		return ErrTooManyPending
+	}
+	p.messages = append(p.messages, &pendingMessage{wire: wire, from: from, isBroadcast: isBroadcast})
+	return nil
+}
+
+func (m *Manager) deliver(s *Session, wire *SessionMessage, from *big.Int, isBroadcast bool) error {
+	if s.isDone() {
+		return nil // late messages of a finished session are dropped
+	}
+	recipients := s.recipients(from, wire.GetTo())
+	if len(recipients) == 0 {
+		return fmt.Errorf("session %s: no party of this node is a recipient of the message", s.ID)
+	}
+	for _, mb := range recipients {
+		fromPID := mb.findPeer(from)
+		if fromPID == nil {
+			return fmt.Errorf("session %s: the sender is not a party of the session", s.ID)
+		}
+		if _, err := mb.party.UpdateFromBytes(wire.GetMessage(), fromPID, isBroadcast); err != nil {
+			s.fail(err)
+			return err
+		}
+	}
+	return nil
+}
+
+// forward wraps the messages of a party with the session ID, delivers those addressed to the other party of this
+// node in a re-sharing and hands the rest to the transport
+func (m *Manager) forward(s *Session, mb *member) {
+	self := mb.party.PartyID().KeyInt()
+	send := func(msg tss.Message) {
+		bz, routing, err := msg.WireBytes()
+		if err != nil {
+			s.fail(err)
+			return
+		}
+		to := routing.To
+		if to == nil {
+			to = make([]*tss.PartyID, 0, len(mb.peers))
+			for _, pID := range mb.peers {
+				if pID.KeyInt().Cmp(self) != 0 {
+					to = append(to, pID)
+				}
+			}
+		}
+		wire := &SessionMessage{SessionId: s.ID, Message: bz}
+		if routing.To != nil {
+			wire.To = make([][]byte, len(routing.To))
+			for i, pID := range routing.To {
+				wire.To[i] = pID.Key
+			}
+		}
+		remote := make([]*tss.PartyID, 0, len(to))
+		local := false
+		for _, pID := range to {
+			if s.isLocal(pID.KeyInt()) {
+				local = true
+			} else {
+				remote = append(remote, pID)
+			}
+		}
+		if local {
+			go func() {
+				// the other party may not have started yet
+				select {
+				case <-s.started:
+				case <-s.done:
+					return
+				}
+				if err := m.deliver(s, wire, self, routing.IsBroadcast); err != nil {
+					common.Logger.Errorf("session %s: failed to deliver a local message: %v", s.ID, err)
+				}
+			}()
+		}
+		if len(remote) == 0 {
+			return
+		}
+		wireBytes, err := proto.Marshal(wire)
+		if err != nil {
+			s.fail(err)
+			return
+		}
+		select {
+		case m.out <- &Outgoing{
+			SessionID:   s.ID,
+			From:        routing.From,
+			To:          remote,
+			IsBroadcast: routing.IsBroadcast,
+			WireBytes:   wireBytes,
+		}:
+		case <-m.stop:
+		}
+	}
+	for {
+		select {
+		case msg := <-mb.out:
+			send(msg)
+		case <-s.done:
+			// the messages a party sends before finishing must still go out
+			for {
+				select {
+				case msg := <-mb.out:
+					send(msg)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
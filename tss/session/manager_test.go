@@ -0,0 +1,221 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package session_test
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+	. "github.com/bnb-chain/tss-lib/v2/tss/session"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+// network delivers the messages of the managers of every node, which are keyed by the keys of their parties
+type network struct {
+	mtx      sync.Mutex
+	managers map[string]*Manager
+	out      chan *Outgoing
+	errCh    chan error
+}
+
+func newNetwork() *network {
+	n := &network{
+		managers: make(map[string]*Manager),
+		out:      make(chan *Outgoing, 1024),
+		errCh:    make(chan error, 1024),
+	}
+	go func() {
+		for o := range n.out {
+			for _, to := range o.To {
+				n.mtx.Lock()
+				m := n.managers[string(to.Key)]
+				n.mtx.Unlock()
+				go func(o *Outgoing, m *Manager) {
+					if err := m.UpdateFromBytes(o.WireBytes, o.From, o.IsBroadcast); err != nil {
+						n.errCh <- err
+					}
+				}(o, m)
+			}
+		}
+	}()
+	return n
+}
+
+// node returns the manager of the node hosting the parties with the given keys
+func (n *network) node(ttl time.Duration, keys ...*big.Int) *Manager {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	m, ok := n.managers[string(keys[0].Bytes())]
+	if !ok {
+		m = NewManager(n.out, ttl)
+	}
+	for _, key := range keys {
+		n.managers[string(key.Bytes())] = m
+	}
+	return m
+}
+
+func TestConcurrentEdDSASigningSessions(t *testing.T) {
+	setUp("info")
+
+	keys, _, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	net := newNetwork()
+	for _, key := range keys {
+		defer net.node(time.Minute, key.ShareID).Close()
+	}
+
+	const sessionCount = 8
+	ends := make([]chan *common.SignatureData, sessionCount)
+	msgs := make([]*big.Int, sessionCount)
+	var wg sync.WaitGroup
+	for s := 0; s < sessionCount; s++ {
+		// every session is signed by a different set of parties, each with its own PartyIDs
+		signers := rand.Perm(testParticipants)[:testThreshold+1]
+		pIDs := make(tss.UnSortedPartyIDs, 0, len(signers))
+		for _, j := range signers {
+			pIDs = append(pIDs, tss.NewPartyID(fmt.Sprintf("%d", j+1), fmt.Sprintf("P[%d]", j+1), keys[j].ShareID))
+		}
+		sortedPIDs := tss.SortPartyIDs(pIDs)
+		p2pCtx := tss.NewPeerContext(sortedPIDs)
+		ends[s] = make(chan *common.SignatureData, len(signers))
+		msgs[s] = big.NewInt(int64(1000 + s))
+
+		for _, pID := range sortedPIDs {
+			var key keygen.LocalPartySaveData
+			for _, k := range keys {
+				if k.ShareID.Cmp(pID.KeyInt()) == 0 {
+					key = k
+				}
+			}
+			params := tss.NewParameters(tss.Edwards(), p2pCtx, pID, len(sortedPIDs), testThreshold)
+			m := net.node(time.Minute, key.ShareID)
+			wg.Add(1)
+			go func(id string, s int) {
+				defer wg.Done()
+				// some nodes create their session after the others have sent them messages
+				time.Sleep(time.Duration(rand.Intn(20)) * time.Millisecond)
+				if _, err := m.NewSession(id, EdDSASigning(msgs[s], params, key, ends[s])); err != nil {
+					net.errCh <- err
+				}
+			}(fmt.Sprintf("signing-%d", s), s)
+		}
+	}
+	wg.Wait()
+
+	pk := edwards.PublicKey{Curve: tss.Edwards(), X: keys[0].EDDSAPub.X(), Y: keys[0].EDDSAPub.Y()}
+	for s := 0; s < sessionCount; s++ {
+		for i := 0; i < testThreshold+1; i++ {
+			select {
+			case err := <-net.errCh:
+				assert.FailNow(t, err.Error())
+			case sig := <-ends[s]:
+				newSig, err := edwards.ParseSignature(sig.Signature)
+				assert.NoError(t, err)
+				assert.True(t, edwards.Verify(&pk, msgs[s].Bytes(), newSig.R, newSig.S), "eddsa verify must pass")
+			case <-time.After(time.Minute):
+				assert.FailNow(t, "timed out waiting for the signatures")
+			}
+		}
+	}
+}
+
+func TestEdDSAResharingSessionInBothCommittees(t *testing.T) {
+	setUp("info")
+
+	oldKeys, oldPIDs, err := keygen.LoadKeygenTestFixtures(testThreshold + 1)
+	assert.NoError(t, err, "should load keygen fixtures")
+	newPIDs := tss.GenerateTestPartyIDs(testThreshold + 1)
+	oldP2PCtx, newP2PCtx := tss.NewPeerContext(oldPIDs), tss.NewPeerContext(newPIDs)
+	net := newNetwork()
+
+	// node j hosts both the old party j and the new party j, so their messages to each other stay on the node
+	end := make(chan *keygen.LocalPartySaveData, len(oldPIDs)+len(newPIDs))
+	for j := range oldPIDs {
+		oldParams := tss.NewReSharingParameters(tss.Edwards(), oldP2PCtx, newP2PCtx, oldPIDs[j], testParticipants, testThreshold, len(newPIDs), testThreshold)
+		newParams := tss.NewReSharingParameters(tss.Edwards(), oldP2PCtx, newP2PCtx, newPIDs[j], testParticipants, testThreshold, len(newPIDs), testThreshold)
+		m := net.node(time.Minute, oldPIDs[j].KeyInt(), newPIDs[j].KeyInt())
+		defer m.Close()
+		go func(oldKey keygen.LocalPartySaveData) {
+			_, err := m.NewSession("resharing",
+				EdDSAResharing(oldParams, oldKey, end),
+				EdDSAResharing(newParams, keygen.NewLocalPartySaveData(len(newPIDs)), end))
+			if err != nil {
+				net.errCh <- err
+			}
+		}(oldKeys[j])
+	}
+
+	newShares := 0
+	for i := 0; i < len(oldPIDs)+len(newPIDs); i++ {
+		select {
+		case err := <-net.errCh:
+			assert.FailNow(t, err.Error())
+		case save := <-end:
+			if save.Xi != nil && save.Xi.Sign() != 0 {
+				newShares++
+				assert.True(t, oldKeys[0].EDDSAPub.Equals(save.EDDSAPub), "the public key must not change")
+			}
+		case <-time.After(time.Minute):
+			assert.FailNow(t, "timed out waiting for the re-sharing")
+		}
+	}
+	assert.Equal(t, len(newPIDs), newShares)
+}
+
+func TestSessionExpiry(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	ttl := 200 * time.Millisecond
+	m := NewManager(make(chan *Outgoing, 1024), ttl)
+	defer m.Close()
+
+	// only one of the signers starts the session, so it waits for the others until it expires
+	params := tss.NewParameters(tss.Edwards(), tss.NewPeerContext(signPIDs), signPIDs[0], len(signPIDs), testThreshold)
+	s, err := m.NewSession("lonely", EdDSASigning(big.NewInt(42), params, keys[0], make(chan *common.SignatureData, 1)))
+	assert.NoError(t, err)
+	_, err = m.NewSession("lonely", EdDSASigning(big.NewInt(42), params, keys[0], make(chan *common.SignatureData, 1)))
+	assert.Equal(t, ErrSessionExists, err)
+
+	waitingFor, err := m.WaitingFor("lonely")
+	assert.NoError(t, err)
+	assert.Len(t, waitingFor, testThreshold)
+
+	select {
+	case <-s.Done():
+		assert.Equal(t, ErrExpired, s.Err())
+	case <-time.After(10 * ttl):
+		assert.FailNow(t, "the session did not expire")
+	}
+	assert.Eventually(t, func() bool { return m.Session("lonely") == nil }, 10*ttl, ttl/4, "the session must be reaped")
+	_, err = m.WaitingFor("lonely")
+	assert.Equal(t, ErrUnknownSession, err)
+}
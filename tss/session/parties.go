@@ -0,0 +1,109 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package session
+
+import (
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	ecdsaKeygen "github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	ecdsaResharing "github.com/bnb-chain/tss-lib/v2/ecdsa/resharing"
+	ecdsaSigning "github.com/bnb-chain/tss-lib/v2/ecdsa/signing"
+	eddsaKeygen "github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	eddsaResharing "github.com/bnb-chain/tss-lib/v2/eddsa/resharing"
+	eddsaSigning "github.com/bnb-chain/tss-lib/v2/eddsa/signing"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// The factories below create the parties of the ecdsa and eddsa packages. Each party sends its result to end, which
+// should be buffered, and the session counts it as finished once the result has been received.
+
+func ECDSAKeygen(params *tss.Parameters, end chan<- *ecdsaKeygen.LocalPartySaveData, optionalPreParams ...ecdsaKeygen.LocalPreParams) PartyFactory {
+	return func(s *Session, out chan<- tss.Message) (tss.Party, []*tss.PartyID) {
+		partyEnd := make(chan *ecdsaKeygen.LocalPartySaveData, 1)
+		go forwardECDSASaveData(s, partyEnd, end)
+		return ecdsaKeygen.NewLocalParty(params, out, partyEnd, optionalPreParams...), params.Parties().IDs()
+	}
+}
+
+func ECDSASigning(msg *big.Int, params *tss.Parameters, key ecdsaKeygen.LocalPartySaveData, end chan<- *common.SignatureData, fullBytesLen ...int) PartyFactory {
+	return func(s *Session, out chan<- tss.Message) (tss.Party, []*tss.PartyID) {
+		partyEnd := make(chan *common.SignatureData, 1)
+		go forwardSignature(s, partyEnd, end)
+		return ecdsaSigning.NewLocalParty(msg, params, key, out, partyEnd, fullBytesLen...), params.Parties().IDs()
+	}
+}
+
+// ECDSAResharing creates a party of a re-sharing. A node in both committees passes one factory for each of its two
+// parties to the same Manager.NewSession.
+func ECDSAResharing(params *tss.ReSharingParameters, key ecdsaKeygen.LocalPartySaveData, end chan<- *ecdsaKeygen.LocalPartySaveData) PartyFactory {
+	return func(s *Session, out chan<- tss.Message) (tss.Party, []*tss.PartyID) {
+		partyEnd := make(chan *ecdsaKeygen.LocalPartySaveData, 1)
+		go forwardECDSASaveData(s, partyEnd, end)
+		return ecdsaResharing.NewLocalParty(params, key, out, partyEnd), oldAndNewParties(params)
+	}
+}
+
+func EdDSAKeygen(params *tss.Parameters, end chan<- *eddsaKeygen.LocalPartySaveData) PartyFactory {
+	return func(s *Session, out chan<- tss.Message) (tss.Party, []*tss.PartyID) {
+		partyEnd := make(chan *eddsaKeygen.LocalPartySaveData, 1)
+		go forwardEdDSASaveData(s, partyEnd, end)
+		return eddsaKeygen.NewLocalParty(params, out, partyEnd), params.Parties().IDs()
+	}
+}
+
+func EdDSASigning(msg *big.Int, params *tss.Parameters, key eddsaKeygen.LocalPartySaveData, end chan<- *common.SignatureData, fullBytesLen ...int) PartyFactory {
+	return func(s *Session, out chan<- tss.Message) (tss.Party, []*tss.PartyID) {
+		partyEnd := make(chan *common.SignatureData, 1)
+		go forwardSignature(s, partyEnd, end)
+		return eddsaSigning.NewLocalParty(msg, params, key, out, partyEnd, fullBytesLen...), params.Parties().IDs()
+	}
+}
+
+// EdDSAResharing creates a party of a re-sharing, as ECDSAResharing
+func EdDSAResharing(params *tss.ReSharingParameters, key eddsaKeygen.LocalPartySaveData, end chan<- *eddsaKeygen.LocalPartySaveData) PartyFactory {
+	return func(s *Session, out chan<- tss.Message) (tss.Party, []*tss.PartyID) {
+		partyEnd := make(chan *eddsaKeygen.LocalPartySaveData, 1)
+		go forwardEdDSASaveData(s, partyEnd, end)
+		return eddsaResharing.NewLocalParty(params, key, out, partyEnd), oldAndNewParties(params)
+	}
+}
+
+// ----- //
+
+func forwardSignature(s *Session, partyEnd <-chan *common.SignatureData, end chan<- *common.SignatureData) {
+	select {
+	case sig := <-partyEnd:
+		end <- sig
+		s.PartyFinished()
+	case <-s.Done():
+	}
+}
+
+func forwardECDSASaveData(s *Session, partyEnd <-chan *ecdsaKeygen.LocalPartySaveData, end chan<- *ecdsaKeygen.LocalPartySaveData) {
+	select {
+	case save := <-partyEnd:
+		end <- save
+		s.PartyFinished()
+	case <-s.Done():
+	}
+}
+
+func forwardEdDSASaveData(s *Session, partyEnd <-chan *eddsaKeygen.LocalPartySaveData, end chan<- *eddsaKeygen.LocalPartySaveData) {
+	select {
+	case save := <-partyEnd:
+		end <- save
+		s.PartyFinished()
+	case <-s.Done():
+	}
+}
+
+func oldAndNewParties(params *tss.ReSharingParameters) []*tss.PartyID {
+	peers := make([]*tss.PartyID, 0, params.OldAndNewPartyCount())
+	peers = append(peers, params.OldParties().IDs()...)
+	return append(peers, params.NewParties().IDs()...)
+}
@@ -0,0 +1,167 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package session
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+var (
+	ErrExpired = errors.New("session: the session expired before it finished")
+)
+
+type (
+	// Session is one protocol run under a session ID. It normally holds a single party; a node in both committees of
+	// a re-sharing holds one party for each.
+	Session struct {
+		ID string
+
+		members  []*member
+		deadline time.Time
+
+		mtx       sync.Mutex
+		remaining int
+		err       error
+		started   chan struct{}
+		done      chan struct{}
+	}
+
+	member struct {
+		party tss.Party
+		out   chan tss.Message
+		// the IDs of the parties that this party may receive messages from and send messages to
+		peers []*tss.PartyID
+	}
+)
+
+func newSession(id string, deadline time.Time) *Session {
+	return &Session{
+		ID:       id,
+		deadline: deadline,
+		started:  make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Done is closed when every party of the session has finished, or when the session failed or expired
+func (s *Session) Done() <-chan struct{} {
+	return s.done
+}
+
+// Err returns nil while the session is running and once it has finished, or the reason it was stopped
+func (s *Session) Err() error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.err
+}
+
+// Parties returns the parties of the session
+func (s *Session) Parties() []tss.Party {
+	parties := make([]tss.Party, len(s.members))
+	for i, m := range s.members {
+		parties[i] = m.party
+	}
+	return parties
+}
+
+// WaitingFor returns the parties that the parties of the session are waiting for in their current round
+func (s *Session) WaitingFor() []*tss.PartyID {
+	waitingFor := make([]*tss.PartyID, 0)
+	seen := make(map[string]bool)
+	for _, m := range s.members {
+		for _, pID := range m.party.WaitingFor() {
+			if key := string(pID.Key); !seen[key] {
+				seen[key] = true
+				waitingFor = append(waitingFor, pID)
+			}
+		}
+	}
+	return waitingFor
+}
+
+// PartyFinished is called by a PartyFactory once its party has sent its result to its end channel. The session is
+// done once all of its parties have finished.
+func (s *Session) PartyFinished() {
+	s.stop(nil, true)
+}
+
+func (s *Session) fail(err error) {
+	s.stop(err, false)
+}
+
+func (s *Session) stop(err error, partyFinished bool) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	select {
+	case <-s.done:
+		return
+	default:
+	}
+	if partyFinished {
+		if s.remaining--; s.remaining > 0 {
+			return
+		}
+	}
+	s.err = err
+	close(s.done)
+}
+
+func (s *Session) isDone() bool {
+	select {
+	case <-s.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// recipients returns the parties of the session addressed by a message; a nil to addresses all but the sender
+func (s *Session) recipients(from *big.Int, to [][]byte) []*member {
+	recipients := make([]*member, 0, len(s.members))
+	for _, m := range s.members {
+		key := m.party.PartyID().KeyInt()
+		if key.Cmp(from) == 0 {
+			continue
+		}
+		if len(to) == 0 {
+			recipients = append(recipients, m)
+			continue
+		}
+		for _, k := range to {
+			if key.Cmp(new(big.Int).SetBytes(k)) == 0 {
+				recipients = append(recipients, m)
+				break
+			}
+		}
+	}
+	return recipients
+}
+
+// isLocal returns whether the key is that of a party of the session on this node
+func (s *Session) isLocal(key *big.Int) bool {
+	for _, mb := range s.members {
+		if mb.party.PartyID().KeyInt().Cmp(key) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// findPeer returns the party's own PartyID of the sender with the given key, which holds the right index
+func (m *member) findPeer(key *big.Int) *tss.PartyID {
+	for _, pID := range m.peers {
+		if pID.KeyInt().Cmp(key) == 0 {
+			return pID
+		}
+	}
+	return nil
+}
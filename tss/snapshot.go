@@ -0,0 +1,350 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package tss
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+)
+
+var (
+	ErrSnapshotResumed = errors.New("the snapshot was already resumed")
+	ErrSnapshotStale   = errors.New("the snapshot is stale; the party took a later snapshot or started a later round")
+)
+
+type (
+	// Snapshot is the state of a running party between two updates, from which it can be resumed after a crash. A
+	// party takes a new snapshot each time; every snapshot of one protocol run shares its RunID, and the Sequence
+	// grows with each one.
+	Snapshot struct {
+		RunID    []byte             `json:"runID"`
+		Sequence uint64             `json:"sequence"`
+		Task     string             `json:"task"`
+		Round    int                `json:"round"`
+		Messages []*SnapshotMessage `json:"messages"`
+		// State is the protocol-specific state of the party, such as its temp data
+		State json.RawMessage `json:"state"`
+	}
+
+	// SnapshotMessage is a message that the party had received, decrypted if it was sent encrypted
+	SnapshotMessage struct {
+		From        []byte `json:"from"` // the key of the sender
+		IsBroadcast bool   `json:"isBroadcast"`
+		Content     []byte `json:"content"` // the content as a protobuf Any
+	}
+
+	// SnapshotLedger durably records the snapshots of the parties, so that a snapshot is resumed at most once and
+	// never after a later snapshot of the same run. Resuming a snapshot twice could make a party send different
+	// messages in the same round, or use its secret randomness twice.
+	SnapshotLedger interface {
+		// Snapshotted records that a snapshot was taken. It fails unless sequence is greater than that of every
+		// snapshot of the run recorded before.
+		Snapshotted(runID []byte, sequence uint64) error
+		// Advanced records that the party is starting a round after the snapshot was taken, before the round sends
+		// any message. The snapshot may not be resumed after that, since the round would be started again with fresh
+		// randomness.
+		Advanced(runID []byte, sequence uint64) error
+		// Resumed records that a snapshot is being resumed. It fails with ErrSnapshotStale unless it is the last
+		// snapshot recorded for the run and the party has not started a round since, and with ErrSnapshotResumed if
+		// it was resumed before.
+		Resumed(runID []byte, sequence uint64) error
+	}
+
+	// FileSnapshotLedger is a SnapshotLedger that keeps a file for each run in a directory
+	FileSnapshotLedger struct {
+		dir string
+		mtx sync.Mutex
+	}
+
+	ledgerRecord struct {
+		Sequence uint64 `json:"sequence"`
+		Advanced bool   `json:"advanced"`
+		Resumed  bool   `json:"resumed"`
+	}
+)
+
+// BaseSnapshot takes a snapshot of a running party and records it in the ledger, in which the party then records
+// each round that it starts before its next snapshot. capture is called with the current
+// round under the lock of the party and returns its protocol-specific state, which is encoded as JSON, and the
+// messages it has stored, or an error if the party cannot be snapshotted in that round.
+func BaseSnapshot(p Party, task string, ledger SnapshotLedger, capture func(Round) (state interface{}, stored []ParsedMessage, err error)) (*Snapshot, *Error) {
+	p.lock()
+	defer p.unlock()
+	rnd, st := p.round(), p.runState()
+	if rnd == nil || st.err != nil {
+		return nil, p.WrapError(errors.New("snapshot: the party is not running"))
+	}
+	if rnd.Params().EchoBroadcast() {
+		return nil, p.WrapError(errors.New("snapshot: a party with echo broadcast enabled cannot be snapshotted"))
+	}
+	if st.runID == nil {
+		st.runID = make([]byte, 16)
+		if _, err := rand.Read(st.runID); err != nil {
+			st.runID = nil
+			return nil, p.WrapError(err)
+		}
+	}
+	state, stored, err := capture(rnd)
+	if err != nil {
+		return nil, p.WrapError(fmt.Errorf("snapshot: %v", err))
+	}
+	stateBz, err := json.Marshal(state)
+	if err != nil {
+		return nil, p.WrapError(fmt.Errorf("snapshot: %v", err))
+	}
+	snap := &Snapshot{
+		RunID:    st.runID,
+		Sequence: st.sequence + 1,
+		Task:     task,
+		Round:    rnd.RoundNumber(),
+		Messages: make([]*SnapshotMessage, 0, len(stored)),
+		State:    stateBz,
+	}
+	for _, msg := range stored {
+		any, err := anypb.New(msg.Content())
+		if err != nil {
+			return nil, p.WrapError(fmt.Errorf("snapshot: %v", err))
+		}
+		content, err := proto.Marshal(any)
+		if err != nil {
+			return nil, p.WrapError(fmt.Errorf("snapshot: %v", err))
+		}
+		snap.Messages = append(snap.Messages, &SnapshotMessage{
+			From:        msg.GetFrom().GetKey(),
+			IsBroadcast: msg.IsBroadcast(),
+			Content:     content,
+		})
+	}
+	if err := ledger.Snapshotted(snap.RunID, snap.Sequence); err != nil {
+		return nil, p.WrapError(fmt.Errorf("snapshot: %v", err))
+	}
+	st.sequence, st.ledger, st.advanced = snap.Sequence, ledger, false
+	return snap, nil
+}
+
+// BaseResume resumes a new party from a snapshot, once the protocol has restored its state into the party and built
+// round, the round that the snapshot was taken in. The snapshot is consumed in the ledger before the party resumes.
+// The messages in the snapshot are stored again, with their senders found by key among parties, and the party
+// proceeds through any rounds that they complete.
+func BaseResume(p Party, snap *Snapshot, ledger SnapshotLedger, parties []*PartyID, round Round, task string) *Error {
+	p.lock()
+	defer p.unlock()
+	if p.round() != nil {
+		return p.WrapError(errors.New("could not resume. this party was already started"))
+	}
+	if snap.Task != task || round.RoundNumber() != snap.Round {
+		return p.WrapError(fmt.Errorf("could not resume. the snapshot is of %s round %d", snap.Task, snap.Round))
+	}
+	msgs := make([]ParsedMessage, 0, len(snap.Messages))
+	for _, m := range snap.Messages {
+		msg, err := parseSnapshotMessage(m, parties)
+		if err != nil {
+			return p.WrapError(fmt.Errorf("could not resume: %v", err))
+		}
+		msgs = append(msgs, msg)
+	}
+	if err := ledger.Resumed(snap.RunID, snap.Sequence); err != nil {
+		return p.WrapError(fmt.Errorf("could not resume: %w", err))
+	}
+
+	st := p.runState()
+	st.runID, st.sequence, st.ledger, st.advanced = snap.RunID, snap.Sequence, ledger, false
+	for _, msg := range msgs {
+		if _, err := p.StoreMessage(msg); err != nil {
+			return err
+		}
+	}
+	if err := p.setRound(round); err != nil {
+		return err
+	}
+	common.Logger.Infof("party %s: %s resumed in round %d", p.PartyID(), task, snap.Round)
+	startRoundTimer(p, task)
+	for {
+		if _, err := p.round().Update(); err != nil {
+			fail(p, err)
+			return err
+		}
+		if !p.round().CanProceed() {
+			return nil
+		}
+		if p.advance(); p.round() == nil {
+			common.Logger.Infof("party %s: %s finished!", p.PartyID(), task)
+			finish(p)
+			return nil
+		}
+		if err := advanced(p); err != nil {
+			fail(p, err)
+			return err
+		}
+		if err := p.round().Start(); err != nil {
+			fail(p, err)
+			return err
+		}
+		common.Logger.Infof("party %s: %s round %d started", p.PartyID(), task, p.round().RoundNumber())
+		startRoundTimer(p, task)
+	}
+}
+
+// NewFileSnapshotLedger returns a ledger that keeps its records in dir, which it creates if needed
+func NewFileSnapshotLedger(dir string) (*FileSnapshotLedger, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &FileSnapshotLedger{dir: dir}, nil
+}
+
+func (l *FileSnapshotLedger) Snapshotted(runID []byte, sequence uint64) error {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	rec, err := l.read(runID)
+	if err != nil {
+		return err
+	}
+	if rec != nil && sequence <= rec.Sequence {
+		return fmt.Errorf("snapshot %d is not later than snapshot %d", sequence, rec.Sequence)
+	}
+	return l.write(runID, &ledgerRecord{Sequence: sequence})
+}
+
+func (l *FileSnapshotLedger) Advanced(runID []byte, sequence uint64) error {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	rec, err := l.read(runID)
+	if err != nil {
+		return err
+	}
+	switch {
+	case rec == nil:
+		return errors.New("the snapshot was not recorded in the ledger")
+	case sequence != rec.Sequence:
+		return ErrSnapshotStale
+	case rec.Advanced:
+		return nil
+	}
+	rec.Advanced = true
+	return l.write(runID, rec)
+}
+
+func (l *FileSnapshotLedger) Resumed(runID []byte, sequence uint64) error {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	rec, err := l.read(runID)
+	if err != nil {
+		return err
+	}
+	switch {
+	case rec == nil:
+		return errors.New("the snapshot was not recorded in the ledger")
+	case sequence != rec.Sequence, rec.Advanced:
+		return ErrSnapshotStale
+	case rec.Resumed:
+		return ErrSnapshotResumed
+	}
+	rec.Resumed = true
+	return l.write(runID, rec)
+}
+
+// ----- //
+
+// advanced records in the ledger of the last snapshot of a party that it is starting a round, before the round sends
+// any message; the caller holds the lock
+func advanced(p Party) *Error {
+	st := p.runState()
+	if st.ledger == nil || st.advanced {
+		return nil
+	}
+	if err := st.ledger.Advanced(st.runID, st.sequence); err != nil {
+		return p.WrapError(fmt.Errorf("snapshot: %v", err))
+	}
+	st.advanced = true
+	return nil
+}
+
+func parseSnapshotMessage(m *SnapshotMessage, parties []*PartyID) (ParsedMessage, error) {
+	var from *PartyID
+	for _, pID := range parties {
+		if bytes.Equal(pID.GetKey(), m.From) {
+			from = pID
+			break
+		}
+	}
+	if from == nil {
+		return nil, errors.New("a message in the snapshot is from an unknown party")
+	}
+	any := new(anypb.Any)
+	if err := proto.Unmarshal(m.Content, any); err != nil {
+		return nil, err
+	}
+	pb, err := any.UnmarshalNew()
+	if err != nil {
+		return nil, err
+	}
+	content, ok := pb.(MessageContent)
+	if !ok {
+		return nil, errors.New("a message in the snapshot has unknown content")
+	}
+	routing := MessageRouting{From: from, IsBroadcast: m.IsBroadcast}
+	return NewMessage(routing, content, NewMessageWrapper(routing, content)), nil
+}
+
+func (l *FileSnapshotLedger) path(runID []byte) string {
+	return filepath.Join(l.dir, hex.EncodeToString(runID)+".json")
+}
+
+func (l *FileSnapshotLedger) read(runID []byte) (*ledgerRecord, error) {
+	bz, err := ioutil.ReadFile(l.path(runID))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	rec := new(ledgerRecord)
+	if err := json.Unmarshal(bz, rec); err != nil {
+		return nil, err
+	}
+	return rec, nil
+}
+
+// write replaces the record of a run atomically and durably, syncing it and then its directory to disk
+func (l *FileSnapshotLedger) write(runID []byte, rec *ledgerRecord) error {
+	bz, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	f, err := ioutil.TempFile(l.dir, ".ledger-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err = f.Write(bz); err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	if err = os.Rename(f.Name(), l.path(runID)); err != nil {
+		return err
+	}
+	return common.SyncDir(l.dir)
+}
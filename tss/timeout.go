@@ -0,0 +1,138 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package tss
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+)
+
+// ErrRoundTimeout is the cause of the error of a party that was aborted because a round did not finish within the
+// round timeout in its parameters
+var ErrRoundTimeout = errors.New("the round timed out")
+
+// runState tracks whether a party has finished or was aborted, the timer of its current round and its snapshots
+type runState struct {
+	task     string
+	timer    *time.Timer
+	err      *Error
+	done     chan struct{}
+	finished bool
+	// whether the messages of the party are signed by their senders, see SetAuthenticated
+	authenticated bool
+	// the parameters of the party before it has started
+	params *Parameters
+
+	// the run, the sequence number and the ledger of the last snapshot of the party, and whether the party has
+	// started a round since
+	runID    []byte
+	sequence uint64
+	ledger   SnapshotLedger
+	advanced bool
+}
+
+// StartWithContext starts a party that is aborted if ctx is done before it finishes. The error of an aborted party,
+// returned by Err and by any later Update, has the cause ctx.Err() and the parties it was waiting for as culprits.
+func StartWithContext(ctx context.Context, p Party) *Error {
+	if err := ctx.Err(); err != nil {
+		return p.WrapError(err)
+	}
+	if err := p.Start(); err != nil {
+		return err
+	}
+	go func() {
+		select {
+		case <-ctx.Done():
+			p.lock()
+			defer p.unlock()
+			abort(p, ctx.Err())
+		case <-done(p):
+		}
+	}()
+	return nil
+}
+
+// ----- //
+
+func newRunState() *runState {
+	return &runState{done: make(chan struct{})}
+}
+
+// startRoundTimer arms the timeout of the current round of the party, if it has one; the caller holds the lock
+func startRoundTimer(p Party, task string) {
+	st := p.runState()
+	st.task = task
+	if st.timer != nil {
+		st.timer.Stop()
+	}
+	rnd := p.round()
+	timeout := rnd.Params().RoundTimeout()
+	if timeout <= 0 {
+		return
+	}
+	st.timer = time.AfterFunc(timeout, func() {
+		p.lock()
+		defer p.unlock()
+		if p.round() == rnd {
+			abort(p, ErrRoundTimeout)
+		}
+	})
+}
+
+// finish records that the party has finished; the caller holds the lock
+func finish(p Party) {
+	st := p.runState()
+	if st.timer != nil {
+		st.timer.Stop()
+	}
+	if !st.finished {
+		st.finished = true
+		close(st.done)
+	}
+}
+
+// fail stops a party with the error that a round failed to start with, so that Err reports it rather than the timeout
+// of the round; the caller holds the lock
+func fail(p Party, err *Error) {
+	st := p.runState()
+	if st.finished {
+		return
+	}
+	st.err = err
+	common.Logger.Warningf("party %s: %s failed: %s", p.PartyID(), st.task, err)
+	finish(p)
+}
+
+// done returns the channel closed when the party has finished
+func done(p Party) <-chan struct{} {
+	p.lock()
+	defer p.unlock()
+	return p.runState().done
+}
+
+// abort stops a party that has not finished yet. The other parties that it is still waiting for are the culprits.
+// The caller holds the lock.
+func abort(p Party, cause error) {
+	st := p.runState()
+	if st.finished || p.round() == nil {
+		return
+	}
+	self, waiting := p.PartyID().KeyInt(), p.waitingFor()
+	culprits := make([]*PartyID, 0, len(waiting))
+	for _, pID := range waiting {
+		// some rounds list the party itself until they finish
+		if pID.KeyInt().Cmp(self) != 0 {
+			culprits = append(culprits, pID)
+		}
+	}
+	st.err = p.round().WrapError(cause, culprits...)
+	common.Logger.Warningf("party %s: %s aborted: %s", p.PartyID(), st.task, st.err)
+	finish(p)
+}
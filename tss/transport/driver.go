@@ -0,0 +1,164 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package transport
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+type (
+	// Driver connects a party to a Transport: it sends what the party puts on its out channel and updates the party
+	// with what the transport receives
+	Driver struct {
+		party     tss.Party
+		out       <-chan tss.Message
+		transport Transport
+		// the IDs of the parties that the party exchanges messages with, as known to the party
+		peers     []*tss.PartyID
+		committee Committee
+
+		errCh    chan *tss.Error
+		stop     chan struct{}
+		stopOnce sync.Once
+	}
+)
+
+// NewDriver connects a keygen or signing party to a transport. out must be the channel the party was created with.
+func NewDriver(party tss.Party, params *tss.Parameters, out <-chan tss.Message, transport Transport) *Driver {
+	return newDriver(party, params.Parties().IDs(), AnyCommittee, out, transport)
+}
+
+// NewReSharingDriver connects a re-sharing party to a transport. The party only receives the messages addressed to
+// its committee.
+func NewReSharingDriver(party tss.Party, params *tss.ReSharingParameters, out <-chan tss.Message, transport Transport) *Driver {
+	committee := NewCommittee
+	if params.IsOldCommittee() {
+		committee = OldCommittee
+	}
+	return newDriver(party, params.OldAndNewParties(), committee, out, transport)
+}
+
+func newDriver(party tss.Party, peers []*tss.PartyID, committee Committee, out <-chan tss.Message, transport Transport) *Driver {
+	return &Driver{
+		party:     party,
+		out:       out,
+		transport: transport,
+		peers:     peers,
+		committee: committee,
+		errCh:     make(chan *tss.Error, len(peers)),
+		stop:      make(chan struct{}),
+	}
+}
+
+// Start starts the party and relays its messages. What the transport receives before the party has started waits in
+// the transport.
+func (d *Driver) Start() *tss.Error {
+	go d.sendLoop()
+	if err := d.party.Start(); err != nil {
+		d.Stop()
+		return err
+	}
+	go d.receiveLoop()
+	return nil
+}
+
+// Errors returns the errors of the party and of sending its messages. The party may not recover from them.
+func (d *Driver) Errors() <-chan *tss.Error {
+	return d.errCh
+}
+
+// Stop stops relaying messages; it does not close the transport
+func (d *Driver) Stop() {
+	d.stopOnce.Do(func() {
+		close(d.stop)
+	})
+}
+
+// ----- //
+
+func (d *Driver) sendLoop() {
+	for {
+		select {
+		case <-d.stop:
+			return
+		case msg := <-d.out:
+			bz, routing, err := msg.WireBytes()
+			if err == nil {
+				if routing.To == nil {
+					err = d.transport.Broadcast(bz, routing)
+				} else {
+					err = d.transport.Send(bz, routing)
+				}
+			}
+			if err != nil {
+				d.report(d.party.WrapError(err))
+			}
+		}
+	}
+}
+
+func (d *Driver) receiveLoop() {
+	incoming := d.transport.Receive()
+	for {
+		select {
+		case <-d.stop:
+			return
+		case msg, ok := <-incoming:
+			if !ok {
+				return
+			}
+			d.handle(msg)
+		}
+	}
+}
+
+func (d *Driver) handle(msg *Incoming) {
+	if !d.committee.accepts(msg.Routing) {
+		return
+	}
+	self := d.party.PartyID().KeyInt()
+	if msg.Routing.To != nil && !containsKey(msg.Routing.To, self) {
+		common.Logger.Warningf("transport: %s received a message addressed to others from %s", d.party.PartyID(), msg.Routing.From)
+		return
+	}
+	// the sender must be known by the PartyID the party holds for it, whose index is that in its committee
+	var from *tss.PartyID
+	for _, pID := range d.peers {
+		if pID.KeyInt().Cmp(msg.Routing.From.KeyInt()) == 0 {
+			from = pID
+			break
+		}
+	}
+	if from == nil {
+		d.report(d.party.WrapError(fmt.Errorf("received a message from %s, who is not a party of the protocol", msg.Routing.From)))
+		return
+	}
+	if _, err := d.party.UpdateFromBytes(msg.WireBytes, from, msg.Routing.IsBroadcast); err != nil {
+		d.report(err)
+	}
+}
+
+func (d *Driver) report(err *tss.Error) {
+	select {
+	case d.errCh <- err:
+	case <-d.stop:
+	}
+}
+
+func containsKey(pIDs []*tss.PartyID, key *big.Int) bool {
+	for _, pID := range pIDs {
+		if key.Cmp(pID.KeyInt()) == 0 {
+			return true
+		}
+	}
+	return false
+}
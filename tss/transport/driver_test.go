@@ -0,0 +1,232 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package transport_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/eddsa/resharing"
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+	. "github.com/bnb-chain/tss-lib/v2/tss/transport"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+// runEdDSAKeygen runs a keygen of the parties over the given transports and checks the result
+func runEdDSAKeygen(t *testing.T, pIDs tss.SortedPartyIDs, transports []Transport) {
+	p2pCtx := tss.NewPeerContext(pIDs)
+	endCh := make(chan *keygen.LocalPartySaveData, len(pIDs))
+	errCh := make(chan *tss.Error, len(pIDs))
+	for i, pID := range pIDs {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, pID, len(pIDs), testThreshold)
+		outCh := make(chan tss.Message, len(pIDs))
+		driver := NewDriver(keygen.NewLocalParty(params, outCh, endCh), params, outCh, transports[i])
+		defer driver.Stop()
+		go func() {
+			if err := driver.Start(); err != nil {
+				errCh <- err
+				return
+			}
+			for err := range driver.Errors() {
+				errCh <- err
+			}
+		}()
+	}
+
+	saves := make([]*keygen.LocalPartySaveData, 0, len(pIDs))
+	for len(saves) < len(pIDs) {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+		case save := <-endCh:
+			saves = append(saves, save)
+		case <-time.After(time.Minute):
+			assert.FailNow(t, "timed out waiting for the keygen")
+		}
+	}
+	for _, save := range saves {
+		assert.True(t, saves[0].EDDSAPub.Equals(save.EDDSAPub), "all parties must agree on the public key")
+	}
+}
+
+func TestMemoryNetworkKeygen(t *testing.T) {
+	setUp("info")
+
+	pIDs := tss.GenerateTestPartyIDs(testParticipants)
+	network := NewMemoryNetwork()
+	transports := make([]Transport, len(pIDs))
+	for i, pID := range pIDs {
+		var err error
+		transports[i], err = network.Transport(pID)
+		assert.NoError(t, err)
+		defer transports[i].Close()
+	}
+	_, err := network.Transport(pIDs[0])
+	assert.Error(t, err, "a party may only join the network once")
+
+	runEdDSAKeygen(t, pIDs, transports)
+}
+
+func TestMemoryNetworkReSharing(t *testing.T) {
+	setUp("info")
+
+	oldKeys, oldPIDs, err := keygen.LoadKeygenTestFixtures(testThreshold + 1)
+	assert.NoError(t, err, "should load keygen fixtures")
+	newPIDs := tss.GenerateTestPartyIDs(testParticipants)
+	oldP2PCtx, newP2PCtx := tss.NewPeerContext(oldPIDs), tss.NewPeerContext(newPIDs)
+	network := NewMemoryNetwork()
+
+	endCh := make(chan *keygen.LocalPartySaveData, len(oldPIDs)+len(newPIDs))
+	errCh := make(chan *tss.Error, len(oldPIDs)+len(newPIDs))
+	drivers := make([]*Driver, 0, len(oldPIDs)+len(newPIDs))
+	for _, pID := range append(oldPIDs.ToUnSorted(), newPIDs...) {
+		params := tss.NewReSharingParameters(tss.Edwards(), oldP2PCtx, newP2PCtx, pID, testThreshold+1, testThreshold, len(newPIDs), testThreshold)
+		committee := NewCommittee
+		if params.IsOldCommittee() {
+			committee = OldCommittee
+		}
+		transport, err := network.ReSharingTransport(pID, committee)
+		assert.NoError(t, err)
+		defer transport.Close()
+		save := keygen.NewLocalPartySaveData(len(newPIDs))
+		if params.IsOldCommittee() {
+			save = oldKeys[pID.Index]
+		}
+		outCh := make(chan tss.Message, len(oldPIDs)+len(newPIDs))
+		driver := NewReSharingDriver(resharing.NewLocalParty(params, save, outCh, endCh), params, outCh, transport)
+		defer driver.Stop()
+		drivers = append(drivers, driver)
+	}
+	// the new committee starts first and waits for the messages of the old committee
+	for i := len(drivers) - 1; i >= 0; i-- {
+		go func(driver *Driver) {
+			if err := driver.Start(); err != nil {
+				errCh <- err
+				return
+			}
+			for err := range driver.Errors() {
+				errCh <- err
+			}
+		}(drivers[i])
+	}
+
+	newKeys := make([]*keygen.LocalPartySaveData, 0, len(newPIDs))
+	for ended := 0; ended < len(oldPIDs)+len(newPIDs); ended++ {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+		case save := <-endCh:
+			if save.Xi != nil {
+				newKeys = append(newKeys, save)
+			}
+		case <-time.After(time.Minute):
+			assert.FailNow(t, "timed out waiting for the re-sharing")
+		}
+	}
+	assert.Len(t, newKeys, len(newPIDs))
+	for _, save := range newKeys {
+		assert.True(t, oldKeys[0].EDDSAPub.Equals(save.EDDSAPub), "the public key must not change")
+		index, err := save.OriginalIndex()
+		assert.NoError(t, err)
+		assert.True(t, save.BigXj[index].Equals(crypto.ScalarBaseMult(tss.Edwards(), save.Xi)), "ensure BigX_j == g^x_j")
+	}
+}
+
+func TestMemoryNetworkBroadcastReachesCommittee(t *testing.T) {
+	pIDs := tss.GenerateTestPartyIDs(3)
+	network := NewMemoryNetwork()
+	sender, err := network.Transport(pIDs[0])
+	assert.NoError(t, err)
+	defer sender.Close()
+	old, err := network.ReSharingTransport(pIDs[1], OldCommittee)
+	assert.NoError(t, err)
+	defer old.Close()
+	new, err := network.ReSharingTransport(pIDs[2], NewCommittee)
+	assert.NoError(t, err)
+	defer new.Close()
+
+	received := func(tr Transport) bool {
+		select {
+		case <-tr.Receive():
+			return true
+		case <-time.After(100 * time.Millisecond):
+			return false
+		}
+	}
+	assert.NoError(t, sender.Broadcast([]byte("old"), &tss.MessageRouting{From: pIDs[0], IsToOldCommittee: true}))
+	assert.True(t, received(old), "the old committee must receive a broadcast to it")
+	assert.False(t, received(new), "the new committee must not receive a broadcast to the old committee")
+
+	assert.NoError(t, sender.Broadcast([]byte("new"), &tss.MessageRouting{From: pIDs[0]}))
+	assert.False(t, received(old), "the old committee must not receive a broadcast to the new committee")
+	assert.True(t, received(new), "the new committee must receive a broadcast to it")
+
+	assert.NoError(t, sender.Broadcast([]byte("both"), &tss.MessageRouting{From: pIDs[0], IsToOldAndNewCommittees: true}))
+	assert.True(t, received(old), "the old committee must receive a broadcast to both committees")
+	assert.True(t, received(new), "the new committee must receive a broadcast to both committees")
+}
+
+func TestMemoryNetworkDeliversToAllOrNone(t *testing.T) {
+	pIDs := tss.GenerateTestPartyIDs(3)
+	network := NewMemoryNetwork()
+	transports := make([]Transport, len(pIDs))
+	for i, pID := range pIDs {
+		var err error
+		transports[i], err = network.Transport(pID)
+		assert.NoError(t, err)
+		defer transports[i].Close()
+	}
+
+	// fill the inbox of the last party, which does not read it
+	var err error
+	for sent := 0; err == nil; sent++ {
+		if sent > 10000 {
+			assert.FailNow(t, "the inbox must be bounded")
+		}
+		err = transports[0].Send([]byte("p2p"), &tss.MessageRouting{From: pIDs[0], To: pIDs[2:]})
+	}
+	assert.True(t, errors.Is(err, ErrInboxFull))
+
+	err = transports[0].Broadcast([]byte("broadcast"), &tss.MessageRouting{From: pIDs[0], IsBroadcast: true})
+	assert.True(t, errors.Is(err, ErrInboxFull))
+	assert.Contains(t, err.Error(), pIDs[2].String(), "the error must name the party whose inbox is full")
+	assert.NotContains(t, err.Error(), pIDs[1].String())
+	select {
+	case <-transports[1].Receive():
+		assert.FailNow(t, "a broadcast that could not reach every party must reach none")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// the inbox makes room once the party reads from it
+	<-transports[2].Receive()
+	assert.Eventually(t, func() bool {
+		return transports[0].Broadcast([]byte("broadcast"), &tss.MessageRouting{From: pIDs[0], IsBroadcast: true}) == nil
+	}, time.Second, 10*time.Millisecond)
+	select {
+	case in := <-transports[1].Receive():
+		assert.Equal(t, []byte("broadcast"), in.WireBytes)
+	case <-time.After(time.Second):
+		assert.FailNow(t, "the broadcast must be received once there is room for it")
+	}
+}
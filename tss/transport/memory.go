@@ -0,0 +1,127 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package transport
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+type (
+	// MemoryNetwork connects the transports of parties that run in the same process. A message is delivered to all of
+	// its recipients or, if the inbox of one of them is full, to none of them.
+	MemoryNetwork struct {
+		// held for writing while a message is delivered, so that no other message takes the room in the inboxes
+		mtx       sync.Mutex
+		endpoints map[string]*memoryTransport
+	}
+
+	memoryTransport struct {
+		network   *MemoryNetwork
+		self      *tss.PartyID
+		committee Committee
+		inbox     *inbox
+	}
+)
+
+var _ Transport = (*memoryTransport)(nil)
+
+func NewMemoryNetwork() *MemoryNetwork {
+	return &MemoryNetwork{endpoints: make(map[string]*memoryTransport)}
+}
+
+// Transport returns the endpoint of a party on the network. Every party must have its endpoint before the first
+// message is sent to it.
+func (n *MemoryNetwork) Transport(self *tss.PartyID) (Transport, error) {
+	return n.ReSharingTransport(self, AnyCommittee)
+}
+
+// ReSharingTransport returns the endpoint of a re-sharing party of the given committee, which only receives the
+// broadcasts addressed to its committee
+func (n *MemoryNetwork) ReSharingTransport(self *tss.PartyID, committee Committee) (Transport, error) {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	key := string(self.Key)
+	if _, ok := n.endpoints[key]; ok {
+		return nil, fmt.Errorf("transport: party %s is already on the network", self)
+	}
+	t := &memoryTransport{network: n, self: self, committee: committee, inbox: newInbox()}
+	n.endpoints[key] = t
+	return t, nil
+}
+
+func (t *memoryTransport) Send(wireBytes []byte, routing *tss.MessageRouting) error {
+	if len(routing.To) == 0 {
+		return ErrNoRecipients
+	}
+	t.network.mtx.Lock()
+	defer t.network.mtx.Unlock()
+	if t.network.endpoints[string(t.self.Key)] != t {
+		return ErrClosed
+	}
+	dests := make([]*memoryTransport, 0, len(routing.To))
+	for _, to := range routing.To {
+		dest, ok := t.network.endpoints[string(to.Key)]
+		if !ok {
+			return fmt.Errorf("transport: party %s is not on the network", to)
+		}
+		dests = append(dests, dest)
+	}
+	return t.deliver(dests, wireBytes, routing)
+}
+
+func (t *memoryTransport) Broadcast(wireBytes []byte, routing *tss.MessageRouting) error {
+	t.network.mtx.Lock()
+	defer t.network.mtx.Unlock()
+	if t.network.endpoints[string(t.self.Key)] != t {
+		return ErrClosed
+	}
+	dests := make([]*memoryTransport, 0, len(t.network.endpoints))
+	for _, dest := range t.network.endpoints {
+		if dest != t && dest.committee.accepts(routing) {
+			dests = append(dests, dest)
+		}
+	}
+	return t.deliver(dests, wireBytes, routing)
+}
+
+func (t *memoryTransport) Receive() <-chan *Incoming {
+	return t.inbox.out
+}
+
+func (t *memoryTransport) Close() error {
+	t.network.mtx.Lock()
+	if t.network.endpoints[string(t.self.Key)] == t {
+		delete(t.network.endpoints, string(t.self.Key))
+	}
+	t.network.mtx.Unlock()
+	t.inbox.close()
+	return nil
+}
+
+// deliver queues the message in the inboxes of the recipients if none of them is full. The caller must hold the lock
+// of the network for writing.
+func (t *memoryTransport) deliver(dests []*memoryTransport, wireBytes []byte, routing *tss.MessageRouting) error {
+	full := make([]string, 0)
+	for _, dest := range dests {
+		if dest.inbox.full() {
+			full = append(full, dest.self.String())
+		}
+	}
+	if len(full) > 0 {
+		return fmt.Errorf("%w: %s", ErrInboxFull, strings.Join(full, ", "))
+	}
+	for _, dest := range dests {
+		received := *routing
+		received.From = t.self
+		dest.inbox.push(&Incoming{WireBytes: wireBytes, Routing: &received})
+	}
+	return nil
+}
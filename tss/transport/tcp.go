@@ -0,0 +1,464 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package transport
+
+import (
+	"bufio"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	// the largest frame accepted from a peer, which leaves room for the proofs of the ECDSA keygen
+	maxFrameSize = 32 << 20
+
+	defaultDialTimeout = 30 * time.Second
+	dialRetryInterval  = 100 * time.Millisecond
+	connectTimeout     = 5 * time.Second
+	handshakeTimeout   = 10 * time.Second
+	sendQueueSize      = 1024
+)
+
+var ErrSendQueueFull = errors.New("transport: the send queue of the peer is full")
+
+type (
+	// TCPPeer is a party reachable over TCP
+	TCPPeer struct {
+		ID   *tss.PartyID
+		Addr string
+		// Committee is the committee of the peer in a re-sharing, so that broadcasts reach only the committee they
+		// are addressed to; AnyCommittee if zero
+		Committee Committee
+	}
+
+	TCPConfig struct {
+		Self       *tss.PartyID
+		ListenAddr string
+		Peers      []TCPPeer
+		// TLS holds the certificate of this party along with RootCAs and ClientCAs, the pools that the certificates of
+		// the peers are verified against. The certificate of each party must list the Id of its PartyID as a DNS name;
+		// that is how the sender of a connection is authenticated.
+		TLS *tls.Config
+		// DialTimeout is how long to keep trying to connect to a peer that is not listening yet; 30s if zero
+		DialTimeout time.Duration
+	}
+
+	// TCPTransport sends each message over a TLS connection that it dials to the recipient, and receives messages on
+	// the connections that its peers dial to it. A message is queued for all of its recipients or, if the send queue
+	// of one of them is full or one of them cannot be connected to, for none of them. A message that is dropped after
+	// it was queued, as its connection broke and could not be made again, fails the next message sent to that peer.
+	TCPTransport struct {
+		config   TCPConfig
+		listener net.Listener
+		links    map[string]*tcpLink
+		inbox    *inbox
+		// held while a message is queued, so that no other message takes the room in the send queues
+		sendMtx sync.Mutex
+
+		mtx     sync.Mutex
+		inbound map[net.Conn]struct{}
+
+		closed    chan struct{}
+		closeOnce sync.Once
+		wg        sync.WaitGroup
+	}
+
+	// tcpLink holds the outgoing connection to a peer
+	tcpLink struct {
+		peer  TCPPeer
+		queue chan []byte
+
+		mtx  sync.Mutex
+		conn net.Conn
+		// the error of the last frame that was dropped, returned by the next Send or Broadcast to the peer
+		err error
+	}
+)
+
+var _ Transport = (*TCPTransport)(nil)
+
+// NewTCPTransport starts listening on config.ListenAddr. Connections to the peers are made when the first message is
+// sent to them, by the Send or Broadcast that sends it.
+func NewTCPTransport(config TCPConfig) (*TCPTransport, error) {
+	if config.Self == nil || !config.Self.ValidateBasic() {
+		return nil, errors.New("transport: Self must be a valid PartyID")
+	}
+	if config.TLS == nil || len(config.TLS.Certificates) == 0 || config.TLS.RootCAs == nil || config.TLS.ClientCAs == nil {
+		return nil, errors.New("transport: TLS must hold a certificate, RootCAs and ClientCAs")
+	}
+	if config.DialTimeout <= 0 {
+		config.DialTimeout = defaultDialTimeout
+	}
+	t := &TCPTransport{
+		config:  config,
+		links:   make(map[string]*tcpLink, len(config.Peers)),
+		inbox:   newInbox(),
+		inbound: make(map[net.Conn]struct{}),
+		closed:  make(chan struct{}),
+	}
+	for _, peer := range config.Peers {
+		if peer.ID == nil || !peer.ID.ValidateBasic() {
+			return nil, errors.New("transport: every peer must have a valid PartyID")
+		}
+		key := string(peer.ID.Key)
+		if _, ok := t.links[key]; ok || key == string(config.Self.Key) {
+			return nil, fmt.Errorf("transport: peer %s is listed twice", peer.ID)
+		}
+		t.links[key] = &tcpLink{peer: peer, queue: make(chan []byte, sendQueueSize)}
+	}
+
+	serverConfig := config.TLS.Clone()
+	serverConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	if serverConfig.MinVersion < tls.VersionTLS12 {
+		serverConfig.MinVersion = tls.VersionTLS12
+	}
+	listener, err := tls.Listen("tcp", config.ListenAddr, serverConfig)
+	if err != nil {
+		return nil, err
+	}
+	t.listener = listener
+
+	t.wg.Add(1 + len(t.links))
+	go t.accept()
+	for _, link := range t.links {
+		go t.run(link)
+	}
+	return t, nil
+}
+
+// Addr returns the address the transport listens on
+func (t *TCPTransport) Addr() net.Addr {
+	return t.listener.Addr()
+}
+
+func (t *TCPTransport) Send(wireBytes []byte, routing *tss.MessageRouting) error {
+	if len(routing.To) == 0 {
+		return ErrNoRecipients
+	}
+	links := make([]*tcpLink, 0, len(routing.To))
+	for _, to := range routing.To {
+		link, ok := t.links[string(to.Key)]
+		if !ok {
+			return fmt.Errorf("transport: party %s is not a peer", to)
+		}
+		links = append(links, link)
+	}
+	return t.enqueue(links, wireBytes, routing)
+}
+
+func (t *TCPTransport) Broadcast(wireBytes []byte, routing *tss.MessageRouting) error {
+	links := make([]*tcpLink, 0, len(t.links))
+	for _, link := range t.links {
+		if link.peer.Committee.accepts(routing) {
+			links = append(links, link)
+		}
+	}
+	return t.enqueue(links, wireBytes, routing)
+}
+
+func (t *TCPTransport) Receive() <-chan *Incoming {
+	return t.inbox.out
+}
+
+// Close stops the transport; messages that have not been written yet are dropped
+func (t *TCPTransport) Close() error {
+	t.closeOnce.Do(func() {
+		close(t.closed)
+		_ = t.listener.Close()
+		t.mtx.Lock()
+		for conn := range t.inbound {
+			_ = conn.Close()
+		}
+		t.mtx.Unlock()
+		// a connection whose reading waits on a full inbox ends once the inbox is closed
+		t.inbox.close()
+		t.wg.Wait()
+	})
+	return nil
+}
+
+// ----- //
+
+func (t *TCPTransport) enqueue(links []*tcpLink, wireBytes []byte, routing *tss.MessageRouting) error {
+	frame, err := encodeFrame(wireBytes, routing)
+	if err != nil {
+		return err
+	}
+	if err := t.connect(links); err != nil {
+		return err
+	}
+	t.sendMtx.Lock()
+	defer t.sendMtx.Unlock()
+	select {
+	case <-t.closed:
+		return ErrClosed
+	default:
+	}
+	full := make([]string, 0)
+	for _, link := range links {
+		if len(link.queue) == cap(link.queue) {
+			full = append(full, link.peer.ID.String())
+		}
+	}
+	if len(full) > 0 {
+		return fmt.Errorf("%w: %s", ErrSendQueueFull, strings.Join(full, ", "))
+	}
+	for _, link := range links {
+		link.queue <- frame
+	}
+	return nil
+}
+
+// connect makes the connections to the peers of links that are not connected, and returns the errors of those that
+// cannot be connected to along with those of the frames that were dropped since the last message to them
+func (t *TCPTransport) connect(links []*tcpLink) error {
+	errs := make([]error, len(links))
+	var wg sync.WaitGroup
+	wg.Add(len(links))
+	for i, link := range links {
+		go func(i int, link *tcpLink) {
+			defer wg.Done()
+			link.mtx.Lock()
+			defer link.mtx.Unlock()
+			if link.err != nil {
+				errs[i], link.err = link.err, nil
+				return
+			}
+			if link.conn != nil {
+				return
+			}
+			conn, err := t.dial(link.peer)
+			if err != nil {
+				errs[i] = fmt.Errorf("transport: failed to connect to %s: %w", link.peer.ID, err)
+				return
+			}
+			select {
+			case <-t.closed:
+				// run may have returned, and would not close the connection
+				_ = conn.Close()
+				errs[i] = ErrClosed
+				return
+			default:
+			}
+			link.conn = conn
+		}(i, link)
+	}
+	wg.Wait()
+	failed := make([]string, 0)
+	for _, err := range errs {
+		if err != nil {
+			failed = append(failed, err.Error())
+		}
+	}
+	if len(failed) > 0 {
+		return errors.New(strings.Join(failed, "; "))
+	}
+	return nil
+}
+
+// run writes the frames queued for a peer, reconnecting to it when its connection breaks
+func (t *TCPTransport) run(link *tcpLink) {
+	defer t.wg.Done()
+	defer func() {
+		link.mtx.Lock()
+		if link.conn != nil {
+			_ = link.conn.Close()
+		}
+		link.mtx.Unlock()
+	}()
+	for {
+		select {
+		case <-t.closed:
+			return
+		case frame := <-link.queue:
+			if err := t.write(link, frame); err != nil {
+				link.mtx.Lock()
+				link.err = fmt.Errorf("transport: a message to %s was dropped: %w", link.peer.ID, err)
+				link.mtx.Unlock()
+			}
+		}
+	}
+}
+
+// write writes a frame to the connection of a link. A connection that broke since the last frame is only noticed on
+// write, so the frame gets a second try on a new connection.
+func (t *TCPTransport) write(link *tcpLink, frame []byte) error {
+	var err error
+	for attempt := 0; attempt < 2; attempt++ {
+		link.mtx.Lock()
+		conn := link.conn
+		if conn == nil {
+			if conn, err = t.dial(link.peer); err != nil {
+				link.mtx.Unlock()
+				return err
+			}
+			link.conn = conn
+		}
+		link.mtx.Unlock()
+		// the write is not made under the lock, so that a peer that stops reading does not hold up Send
+		if err = writeFrame(conn, frame); err == nil {
+			return nil
+		}
+		_ = conn.Close()
+		link.mtx.Lock()
+		if link.conn == conn {
+			link.conn = nil
+		}
+		link.mtx.Unlock()
+	}
+	return err
+}
+
+func (t *TCPTransport) dial(peer TCPPeer) (net.Conn, error) {
+	clientConfig := t.config.TLS.Clone()
+	clientConfig.ServerName = peer.ID.Id
+	if clientConfig.MinVersion < tls.VersionTLS12 {
+		clientConfig.MinVersion = tls.VersionTLS12
+	}
+	dialer := &net.Dialer{Timeout: connectTimeout}
+	deadline := time.Now().Add(t.config.DialTimeout)
+	for {
+		conn, err := tls.DialWithDialer(dialer, "tcp", peer.Addr, clientConfig)
+		if err == nil {
+			return conn, nil
+		}
+		if time.Now().After(deadline) {
+			return nil, err
+		}
+		select {
+		case <-t.closed:
+			return nil, ErrClosed
+		case <-time.After(dialRetryInterval):
+		}
+	}
+}
+
+func (t *TCPTransport) accept() {
+	defer t.wg.Done()
+	for {
+		conn, err := t.listener.Accept()
+		if err != nil {
+			select {
+			case <-t.closed:
+			default:
+				common.Logger.Errorf("transport: failed to accept a connection: %v", err)
+			}
+			return
+		}
+		t.mtx.Lock()
+		select {
+		case <-t.closed:
+			t.mtx.Unlock()
+			_ = conn.Close()
+			return
+		default:
+		}
+		t.inbound[conn] = struct{}{}
+		t.wg.Add(1)
+		t.mtx.Unlock()
+		go t.serve(conn.(*tls.Conn))
+	}
+}
+
+// serve reads the frames sent by the peer authenticated on an inbound connection
+func (t *TCPTransport) serve(conn *tls.Conn) {
+	defer t.wg.Done()
+	defer func() {
+		t.mtx.Lock()
+		delete(t.inbound, conn)
+		t.mtx.Unlock()
+		_ = conn.Close()
+	}()
+	// a peer that does not finish the handshake in time is dropped, so that it cannot hold the connection open
+	if err := conn.SetDeadline(time.Now().Add(handshakeTimeout)); err != nil {
+		return
+	}
+	if err := conn.Handshake(); err != nil {
+		common.Logger.Warningf("transport: TLS handshake with %s failed: %v", conn.RemoteAddr(), err)
+		return
+	}
+	from := t.authenticate(conn.ConnectionState())
+	if from == nil {
+		common.Logger.Warningf("transport: rejected a connection from %s, whose certificate is not that of a peer", conn.RemoteAddr())
+		return
+	}
+	if err := conn.SetDeadline(time.Time{}); err != nil {
+		return
+	}
+	r := bufio.NewReader(conn)
+	for {
+		frame, err := readFrame(r)
+		if err != nil {
+			if err != io.EOF {
+				select {
+				case <-t.closed:
+				default:
+					common.Logger.Warningf("transport: failed to read from %s: %v", from, err)
+				}
+			}
+			return
+		}
+		msg, err := decodeFrame(frame, from)
+		if err != nil {
+			common.Logger.Warningf("transport: dropping a malformed message from %s: %v", from, err)
+			return
+		}
+		// a full inbox stops the reading, so that the peer finds its send queue full rather than this party running
+		// out of memory
+		if !t.inbox.push(msg) {
+			return
+		}
+	}
+}
+
+// authenticate returns the peer whose Id is a name of the certificate of the connection
+func (t *TCPTransport) authenticate(state tls.ConnectionState) *tss.PartyID {
+	if len(state.PeerCertificates) == 0 {
+		return nil
+	}
+	leaf := state.PeerCertificates[0]
+	for _, link := range t.links {
+		if leaf.VerifyHostname(link.peer.ID.Id) == nil {
+			return link.peer.ID
+		}
+	}
+	return nil
+}
+
+func writeFrame(w io.Writer, frame []byte) error {
+	bz := make([]byte, 4+len(frame))
+	binary.BigEndian.PutUint32(bz, uint32(len(frame)))
+	copy(bz[4:], frame)
+	_, err := w.Write(bz)
+	return err
+}
+
+func readFrame(r io.Reader) ([]byte, error) {
+	var header [4]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, err
+	}
+	size := binary.BigEndian.Uint32(header[:])
+	if size > maxFrameSize {
+		return nil, fmt.Errorf("frame of %d bytes is too large", size)
+	}
+	frame := make([]byte, size)
+	if _, err := io.ReadFull(r, frame); err != nil {
+		return nil, err
+	}
+	return frame, nil
+}
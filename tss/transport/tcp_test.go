@@ -0,0 +1,183 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package transport_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/bnb-chain/tss-lib/v2/tss"
+	. "github.com/bnb-chain/tss-lib/v2/tss/transport"
+)
+
+// testCA issues the certificates of the parties in the tests
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pool *x509.CertPool
+}
+
+func newTestCA(t *testing.T) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "tss test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+	return &testCA{cert: cert, key: key, pool: pool}
+}
+
+// tlsConfig returns the TLS configuration of a party whose certificate is issued for the given name
+func (ca *testCA) tlsConfig(t *testing.T, name string, serial int64) *tls.Config {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	assert.NoError(t, err)
+	return &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}},
+		RootCAs:      ca.pool,
+		ClientCAs:    ca.pool,
+	}
+}
+
+// freeAddrs returns addresses on localhost with ports that are free at the time of the call
+func freeAddrs(t *testing.T, count int) []string {
+	addrs := make([]string, count)
+	for i := range addrs {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		assert.NoError(t, err)
+		addrs[i] = l.Addr().String()
+		assert.NoError(t, l.Close())
+	}
+	return addrs
+}
+
+func TestTCPTransportKeygen(t *testing.T) {
+	setUp("info")
+
+	pIDs := tss.GenerateTestPartyIDs(testParticipants)
+	ca := newTestCA(t)
+	addrs := freeAddrs(t, len(pIDs))
+	transports := make([]Transport, len(pIDs))
+	for i, pID := range pIDs {
+		peers := make([]TCPPeer, 0, len(pIDs)-1)
+		for j, peerID := range pIDs {
+			if j != i {
+				peers = append(peers, TCPPeer{ID: peerID, Addr: addrs[j]})
+			}
+		}
+		tr, err := NewTCPTransport(TCPConfig{
+			Self:       pID,
+			ListenAddr: addrs[i],
+			Peers:      peers,
+			TLS:        ca.tlsConfig(t, pID.Id, int64(i+2)),
+		})
+		assert.NoError(t, err)
+		defer tr.Close()
+		transports[i] = tr
+	}
+
+	runEdDSAKeygen(t, pIDs, transports)
+}
+
+func TestTCPTransportRejectsStrangers(t *testing.T) {
+	setUp("info")
+
+	pIDs := tss.GenerateTestPartyIDs(2)
+	ca := newTestCA(t)
+	receiver, err := NewTCPTransport(TCPConfig{
+		Self:       pIDs[0],
+		ListenAddr: "127.0.0.1:0",
+		Peers:      []TCPPeer{{ID: pIDs[1], Addr: "127.0.0.1:1"}},
+		TLS:        ca.tlsConfig(t, pIDs[0].Id, 2),
+	})
+	assert.NoError(t, err)
+	defer receiver.Close()
+
+	// the stranger knows the receiver but holds a certificate for a name that is not a peer of the receiver
+	stranger, err := NewTCPTransport(TCPConfig{
+		Self:        pIDs[1],
+		ListenAddr:  "127.0.0.1:0",
+		Peers:       []TCPPeer{{ID: pIDs[0], Addr: receiver.Addr().String()}},
+		TLS:         ca.tlsConfig(t, "stranger", 3),
+		DialTimeout: time.Second,
+	})
+	assert.NoError(t, err)
+	defer stranger.Close()
+
+	any, err := anypb.New(&tss.MessageWrapper_PartyID{Id: "hello"})
+	assert.NoError(t, err)
+	bz, err := proto.Marshal(any)
+	assert.NoError(t, err)
+	assert.NoError(t, stranger.Send(bz, &tss.MessageRouting{From: pIDs[1], To: pIDs[:1]}))
+
+	select {
+	case in := <-receiver.Receive():
+		assert.FailNow(t, "a message from a stranger was received", "%v", in.Routing)
+	case <-time.After(500 * time.Millisecond):
+	}
+}
+
+func TestTCPTransportSendReturnsDialFailure(t *testing.T) {
+	setUp("info")
+
+	pIDs := tss.GenerateTestPartyIDs(2)
+	ca := newTestCA(t)
+	// nothing listens on the address of the peer
+	addrs := freeAddrs(t, 1)
+	sender, err := NewTCPTransport(TCPConfig{
+		Self:        pIDs[0],
+		ListenAddr:  "127.0.0.1:0",
+		Peers:       []TCPPeer{{ID: pIDs[1], Addr: addrs[0]}},
+		TLS:         ca.tlsConfig(t, pIDs[0].Id, 2),
+		DialTimeout: 200 * time.Millisecond,
+	})
+	assert.NoError(t, err)
+	defer sender.Close()
+
+	any, err := anypb.New(&tss.MessageWrapper_PartyID{Id: "hello"})
+	assert.NoError(t, err)
+	bz, err := proto.Marshal(any)
+	assert.NoError(t, err)
+	err = sender.Send(bz, &tss.MessageRouting{From: pIDs[0], To: pIDs[1:]})
+	if assert.Error(t, err, "a message that cannot be delivered must fail its Send") {
+		assert.Contains(t, err.Error(), pIDs[1].String())
+	}
+	assert.Error(t, sender.Broadcast(bz, &tss.MessageRouting{From: pIDs[0], IsBroadcast: true}))
+}
@@ -0,0 +1,190 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Package transport carries the messages of tss parties between nodes. A Transport is the endpoint of one party and
+// a Driver connects a party to it. MemoryNetwork connects parties in the same process and TCPTransport connects
+// parties over TCP with mutually authenticated TLS.
+package transport
+
+import (
+	"errors"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	// AnyCommittee is the committee of a keygen or signing party, which receives every message
+	AnyCommittee Committee = iota
+	// OldCommittee is the committee of a re-sharing party that holds the key being re-shared
+	OldCommittee
+	// NewCommittee is the committee of a re-sharing party that receives the new shares
+	NewCommittee
+
+	// the number of received messages that a transport holds for its party; a memory transport refuses messages
+	// beyond it and a TCP transport stops reading from its peers until the party catches up
+	inboxSize = 1024
+)
+
+var (
+	ErrClosed       = errors.New("transport: the transport is closed")
+	ErrNoRecipients = errors.New("transport: a point-to-point message needs at least one recipient")
+	ErrInboxFull    = errors.New("transport: the inbox of the party is full")
+)
+
+type (
+	// Committee is the committee of a re-sharing that a party belongs to. A broadcast whose routing names a committee
+	// is only delivered to the parties of that committee.
+	Committee int
+
+	// Transport is the endpoint of a party. Parties are addressed by their keys, so the parties of both committees of
+	// a re-sharing must have distinct keys, as tss.ReSharingParameters expects.
+	Transport interface {
+		// Send delivers a message to the parties in routing.To
+		Send(wireBytes []byte, routing *tss.MessageRouting) error
+		// Broadcast delivers a message to every other party of the transport
+		Broadcast(wireBytes []byte, routing *tss.MessageRouting) error
+		// Receive returns the messages sent to this party; it is closed once the transport is closed
+		Receive() <-chan *Incoming
+		Close() error
+	}
+
+	// Incoming is a message received by a Transport
+	Incoming struct {
+		// WireBytes are the bytes to pass to tss.Party.UpdateFromBytes
+		WireBytes []byte
+		// Routing is the routing of the message as sent. Its From is the sender as authenticated by the transport,
+		// so its Index may not be that of the receiving party's PartyID for the sender.
+		Routing *tss.MessageRouting
+	}
+
+	// inbox queues up to inboxSize messages received by a transport
+	inbox struct {
+		queue chan *Incoming
+		out   chan *Incoming
+
+		closed    chan struct{}
+		closeOnce sync.Once
+	}
+)
+
+// ----- //
+
+// accepts returns whether a party of the committee is a recipient of a message with the routing, following the
+// routing of the re-sharing tests
+func (c Committee) accepts(routing *tss.MessageRouting) bool {
+	switch c {
+	case OldCommittee:
+		return routing.IsToOldCommittee || routing.IsToOldAndNewCommittees
+	case NewCommittee:
+		return !routing.IsToOldCommittee || routing.IsToOldAndNewCommittees
+	default:
+		return true
+	}
+}
+
+func newInbox() *inbox {
+	in := &inbox{
+		queue:  make(chan *Incoming, inboxSize),
+		out:    make(chan *Incoming),
+		closed: make(chan struct{}),
+	}
+	go in.pump()
+	return in
+}
+
+// push queues a message, waiting for room in the inbox; it returns false if the inbox was closed first
+func (in *inbox) push(msg *Incoming) bool {
+	select {
+	case in.queue <- msg:
+		return true
+	case <-in.closed:
+		return false
+	}
+}
+
+// full returns whether the next push would wait. Only a caller that excludes the other pushers may rely on it.
+func (in *inbox) full() bool {
+	return len(in.queue) == cap(in.queue)
+}
+
+func (in *inbox) close() {
+	in.closeOnce.Do(func() {
+		close(in.closed)
+	})
+}
+
+func (in *inbox) pump() {
+	defer close(in.out)
+	for {
+		select {
+		case msg := <-in.queue:
+			select {
+			case in.out <- msg:
+			case <-in.closed:
+				return
+			}
+		case <-in.closed:
+			return
+		}
+	}
+}
+
+// encodeFrame wraps the wire bytes of a message with its routing for transports that send it over a connection
+func encodeFrame(wireBytes []byte, routing *tss.MessageRouting) ([]byte, error) {
+	any := new(anypb.Any)
+	if err := proto.Unmarshal(wireBytes, any); err != nil {
+		return nil, err
+	}
+	wrapper := &tss.MessageWrapper{
+		IsBroadcast:             routing.IsBroadcast,
+		IsToOldCommittee:        routing.IsToOldCommittee,
+		IsToOldAndNewCommittees: routing.IsToOldAndNewCommittees,
+		Message:                 any,
+	}
+	if routing.From != nil {
+		wrapper.From = routing.From.MessageWrapper_PartyID
+	}
+	if routing.To != nil {
+		wrapper.To = make([]*tss.MessageWrapper_PartyID, len(routing.To))
+		for i, pID := range routing.To {
+			wrapper.To[i] = pID.MessageWrapper_PartyID
+		}
+	}
+	return proto.Marshal(wrapper)
+}
+
+// decodeFrame reverses encodeFrame. The sender named in the frame is ignored in favour of from, which the transport
+// has authenticated.
+func decodeFrame(bz []byte, from *tss.PartyID) (*Incoming, error) {
+	wrapper := new(tss.MessageWrapper)
+	if err := proto.Unmarshal(bz, wrapper); err != nil {
+		return nil, err
+	}
+	if wrapper.GetMessage() == nil {
+		return nil, errors.New("transport: the frame holds no message")
+	}
+	wireBytes, err := proto.Marshal(wrapper.GetMessage())
+	if err != nil {
+		return nil, err
+	}
+	routing := &tss.MessageRouting{
+		From:                    from,
+		IsBroadcast:             wrapper.GetIsBroadcast(),
+		IsToOldCommittee:        wrapper.GetIsToOldCommittee(),
+		IsToOldAndNewCommittees: wrapper.GetIsToOldAndNewCommittees(),
+	}
+	if wrapper.GetTo() != nil {
+		routing.To = make([]*tss.PartyID, len(wrapper.GetTo()))
+		for i, pID := range wrapper.GetTo() {
+			routing.To[i] = &tss.PartyID{MessageWrapper_PartyID: pID, Index: -1}
+		}
+	}
+	return &Incoming{WireBytes: wireBytes, Routing: routing}, nil
+}